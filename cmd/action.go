@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/actions"
+	"github.com/naag/gh-project-report/pkg/hooks"
+	"github.com/naag/gh-project-report/pkg/redact"
+	"github.com/spf13/cobra"
+)
+
+// actionRun receives the report while the action command runs, nil otherwise
+var actionRun *actionEnv
+
+// actionDefaults are the diff flags set by the action command unless given as inputs
+var actionDefaults = map[string]string{
+	"capture": "true",
+	"output":  "markdown",
+}
+
+// actionDefaultRange is the range of the report unless the range or from input is given
+const actionDefaultRange = "last 1 week"
+
+// actionTokenInputs are inputs holding the GitHub token used by the run
+var actionTokenInputs = []string{"token", "github_token"}
+
+var actionCmd = &cobra.Command{
+	Use:   "action",
+	Short: "Capture, compare and publish in one step of a GitHub Actions workflow",
+	Long: `Action command runs diff configured entirely from the inputs of a GitHub Actions step,
+passed as INPUT_* environment variables, and writes the results as step outputs.
+
+Every input is a flag of diff with underscores instead of dashes, e.g. INPUT_PROJECT_NUMBER
+sets --project-number and INPUT_POST_TO_ISSUE sets --post-to-issue. Unknown inputs fail the
+run. Unless given, the current state is captured, the range is "last 1 week" and the
+output is markdown. The token (or github_token) input is used as GITHUB_TOKEN. Tokens
+and webhook URLs are masked in all output.
+
+The report is written to the "report" output and, for markdown, to the job summary. The
+outputs added, removed, changed, delayed and items hold the counts of the report, and
+snapshot and from_snapshot the compared snapshots.
+
+Examples:
+  INPUT_PROJECT_NUMBER=42 INPUT_RANGE="last 2 weeks" gh-project-report action`,
+	Args: cobra.NoArgs,
+	RunE: runAction,
+}
+
+func init() {
+	rootCmd.AddCommand(actionCmd)
+}
+
+func runAction(cmd *cobra.Command, args []string) error {
+	inputs := actions.Inputs(os.Environ())
+	for name, value := range inputs {
+		if strings.Contains(name, "token") || strings.Contains(name, "webhook") || strings.Contains(name, "secret") {
+			redact.Add(value)
+		}
+	}
+	for _, name := range actionTokenInputs {
+		if token, ok := inputs[name]; ok {
+			if err := os.Setenv("GITHUB_TOKEN", token); err != nil {
+				return fmt.Errorf("failed to set GITHUB_TOKEN: %w", err)
+			}
+			delete(inputs, name)
+		}
+	}
+
+	if err := applyActionInputs(diffCmd, inputs); err != nil {
+		return err
+	}
+	if err := applyPreset(diffCmd); err != nil {
+		return err
+	}
+	if err := applyActionDefaults(diffCmd); err != nil {
+		return err
+	}
+	if err := applyConfigDefaults(diffCmd); err != nil {
+		return err
+	}
+	if projectNumber == 0 {
+		return fmt.Errorf(`required input "project_number" not set`)
+	}
+
+	actionRun = &actionEnv{
+		outputPath:  os.Getenv("GITHUB_OUTPUT"),
+		summaryPath: os.Getenv("GITHUB_STEP_SUMMARY"),
+	}
+	defer func() { actionRun = nil }()
+
+	diffCmd.SetContext(cmd.Context())
+	if err := diffCmd.PreRunE(diffCmd, nil); err != nil {
+		return err
+	}
+	return runDiff(diffCmd, nil)
+}
+
+// applyActionInputs sets the flags of the command from the inputs
+func applyActionInputs(cmd *cobra.Command, inputs map[string]string) error {
+	// Merge the persistent flags of the parents, such as --project-number
+	cmd.InheritedFlags()
+
+	// Apply in a stable order for deterministic errors
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := strings.ReplaceAll(name, "_", "-")
+		if cmd.Flags().Lookup(flag) == nil {
+			return fmt.Errorf("unknown input %q", name)
+		}
+		if err := cmd.Flags().Set(flag, inputs[name]); err != nil {
+			return fmt.Errorf("invalid value of input %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// applyActionDefaults sets the defaults of the action for flags set neither by inputs
+// nor by the preset
+func applyActionDefaults(cmd *cobra.Command) error {
+	for flag, value := range actionDefaults {
+		if cmd.Flags().Changed(flag) {
+			continue
+		}
+		if err := cmd.Flags().Set(flag, value); err != nil {
+			return err
+		}
+	}
+	if !cmd.Flags().Changed("range") && !cmd.Flags().Changed("from") {
+		if err := cmd.Flags().Set("range", actionDefaultRange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// actionEnv writes the results of the action command to the files provided by GitHub
+// Actions. Files that are not set, e.g. when running outside of Actions, are skipped.
+type actionEnv struct {
+	outputPath  string // $GITHUB_OUTPUT
+	summaryPath string // $GITHUB_STEP_SUMMARY
+}
+
+// write writes the report and its counts as step outputs, and Markdown reports to the
+// job summary
+func (a *actionEnv) write(report string, ctx hooks.Context) error {
+	if a.outputPath != "" {
+		outputs := map[string]string{
+			"report":        report,
+			"snapshot":      ctx.Snapshot,
+			"from_snapshot": ctx.FromSnapshot,
+			"items":         strconv.Itoa(ctx.Items),
+			"added":         strconv.Itoa(ctx.Added),
+			"removed":       strconv.Itoa(ctx.Removed),
+			"changed":       strconv.Itoa(ctx.Changed),
+			"delayed":       strconv.Itoa(ctx.Delayed),
+		}
+		if err := actions.WriteOutputs(a.outputPath, outputs); err != nil {
+			return err
+		}
+	}
+	// The github-summary output already wrote the job summary
+	if a.summaryPath != "" && output == "markdown" {
+		if err := actions.AppendSummary(a.summaryPath, report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJobSummary appends the report of the github-summary output to the job summary and
+// prints the annotations as workflow commands. Outside of GitHub Actions the report is
+// printed instead.
+func writeJobSummary(report string, annotations []actions.Annotation) error {
+	if path := os.Getenv("GITHUB_STEP_SUMMARY"); path != "" {
+		if err := actions.AppendSummary(path, report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Print(report)
+	}
+	for _, annotation := range annotations {
+		fmt.Println(annotation)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	burndownRange  string
+	burndownStatus string
+	burndownDone   []string
+	burndownWeight string
+	burndownOutput string
+)
+
+var burndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Chart open versus done work across snapshots",
+	Long: `Burndown command reads all snapshots in a time range and counts, for each of them,
+the open and done items. Items are done if their status field has one of the --done
+values. Use --weight-field to sum a numeric field such as story points instead of
+counting items.
+
+The chart is rendered as a table with bars, as markdown, or as a Mermaid line chart
+that GitHub renders natively.
+
+Examples:
+  gh-project-report report burndown -p 123 --range "last 1 month"
+  gh-project-report report burndown -p 123 --weight-field Points --done Done,Released
+  gh-project-report report burndown -p 123 --range "last 2 weeks" --output mermaid`,
+	RunE: runBurndown,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	diffCmd.AddCommand(burndownCmd)
+	burndownCmd.Flags().StringVarP(&burndownRange, "range", "r", "", "Only include snapshots in this time range (e.g. \"last 1 month\")")
+	burndownCmd.Flags().StringVar(&burndownStatus, "status-field", "Status", "Field containing the status of items")
+	burndownCmd.Flags().StringSliceVar(&burndownDone, "done", []string{"Done"}, "Status values of done items")
+	addWeightFieldFlag(burndownCmd, &burndownWeight, "Numeric field to sum instead of counting items (e.g. Points)")
+	burndownCmd.Flags().StringVarP(&burndownOutput, "output", "o", "table", "Output format (table, markdown, mermaid)")
+
+	burndownCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"table", "markdown", "mermaid"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runBurndown(cmd *cobra.Command, args []string) error {
+	if burndownOutput != "table" && burndownOutput != "markdown" && burndownOutput != "mermaid" {
+		return fmt.Errorf("invalid output format: %s (must be one of: table, markdown, mermaid)", burndownOutput)
+	}
+
+	var from, to time.Time
+	if burndownRange != "" {
+		var err error
+		from, to, err = format.ParseHumanRange(burndownRange)
+		if err != nil {
+			return fmt.Errorf("error parsing time range: %w", err)
+		}
+	}
+
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	points, err := collectBurndown(store, from, to)
+	if err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return fmt.Errorf("no snapshots found for project %d", projectNumber)
+	}
+
+	dateLocale, err := reportLocale()
+	if err != nil {
+		return err
+	}
+	options := format.DefaultOptions()
+	options.Locale = dateLocale
+	switch burndownOutput {
+	case "mermaid":
+		fmt.Print(format.FormatBurndownMermaid(points, burndownWeight, options))
+	case "markdown":
+		doc := format.BuildBurndownDocument(points, burndownWeight, options)
+		fmt.Print((&format.MarkdownRenderer{}).RenderDocument(&doc))
+	default:
+		doc := format.BuildBurndownDocument(points, burndownWeight, options)
+		fmt.Print(format.NewCLITableRenderer().RenderDocument(&doc))
+	}
+	return nil
+}
+
+// collectBurndown counts the open and done work of every snapshot between from and to,
+// loading one snapshot at a time. Zero times leave the range open.
+func collectBurndown(store storage.StateStore, from, to time.Time) ([]types.BurndownPoint, error) {
+	timestamps, err := store.ListTimestamps(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []types.BurndownPoint
+	for _, ts := range timestamps {
+		if (!from.IsZero() && ts.Before(from)) || (!to.IsZero() && ts.After(to)) {
+			continue
+		}
+		state, err := store.LoadState(projectNumber, ts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load state: %w", err)
+		}
+		points = append(points, state.Burndown(burndownStatus, burndownDone, burndownWeight))
+	}
+	return points, nil
+}
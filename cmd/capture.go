@@ -7,7 +7,10 @@ import (
 	"os"
 
 	"github.com/naag/gh-project-report/pkg/github"
+	"github.com/naag/gh-project-report/pkg/hooks"
+	"github.com/naag/gh-project-report/pkg/redact"
 	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
 	"github.com/spf13/cobra"
 	"golang.org/x/oauth2"
 )
@@ -16,6 +19,11 @@ var (
 	startField   string
 	endField     string
 	organization string
+	timezone     string
+	compress     bool
+
+	skipUnchanged   bool
+	recordHeartbeat bool
 )
 
 var captureCmd = &cobra.Command{
@@ -24,58 +32,180 @@ var captureCmd = &cobra.Command{
 	Long: `Capture command fetches the current state of a GitHub Project and saves it locally.
 The state includes all metadata such as custom fields, priorities, and dates.`,
 	RunE: runCapture,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
 }
 
 func init() {
 	rootCmd.AddCommand(captureCmd)
-	captureCmd.Flags().StringVar(&startField, "start-field", "Start", "Field name containing start date")
-	captureCmd.Flags().StringVar(&endField, "end-field", "End", "Field name containing end date")
+	captureCmd.Flags().StringVar(&startField, "start-field", "Start", "Field name containing start date, or @iteration to schedule items by their iteration")
+	captureCmd.Flags().StringVar(&endField, "end-field", "End", "Field name containing end date, or @milestone to end items on the due date of their milestone")
 	captureCmd.Flags().StringVarP(&organization, "organization", "o", "", "GitHub organization name (optional)")
+	captureCmd.Flags().StringVar(&timezone, "timezone", "", "IANA timezone of the project used to interpret date fields (e.g. Europe/Berlin)")
+	captureCmd.Flags().BoolVar(&compress, "compress", false, "Write the state gzip compressed (*.json.gz)")
+	captureCmd.Flags().BoolVar(&skipUnchanged, "skip-unchanged", false, "Don't save the state if it has the same content as the latest snapshot (default: the config file)")
+	captureCmd.Flags().BoolVar(&recordHeartbeat, "heartbeat", false, "Record the time of captures skipped by --skip-unchanged (default: the config file)")
 }
 
 func runCapture(cmd *cobra.Command, args []string) error {
-	// Get GitHub token from environment
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		return fmt.Errorf("GITHUB_TOKEN environment variable is required")
+	store, err := newStore(storage.WithCompression(compress))
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
 	}
 
-	// Get verbose flag from root command
-	verbose, err := cmd.Flags().GetBool("verbose")
+	_, filename, err := captureState(cmd, store)
 	if err != nil {
-		return fmt.Errorf("failed to get verbose flag: %w", err)
+		return err
 	}
 
-	// Setup GitHub client
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	httpClient := oauth2.NewClient(context.Background(), src)
+	log.Printf("State captured and saved to %s\n", filename)
+	return nil
+}
 
-	if verbose {
-		log.Printf("Using GitHub token: %s...\n", token[:10])
+// captureState fetches the current state of the project and saves it to the store
+func captureState(cmd *cobra.Command, store storage.StateStore) (*types.ProjectState, string, error) {
+	client, err := newGitHubClient(cmd)
+	if err != nil {
+		return nil, "", err
 	}
 
-	client := github.NewClient(httpClient, verbose)
+	runner := newHookRunner()
+	if err := runner.Run(cmd.Context(), hooks.PreCapture, hooks.Context{ProjectNumber: projectNumber}); err != nil {
+		return nil, "", err
+	}
 
 	// Fetch project state
 	state, err := client.FetchProjectState(projectNumber, organization, startField, endField)
 	if err != nil {
-		return fmt.Errorf("failed to fetch project state: %w", err)
+		return nil, "", fmt.Errorf("failed to fetch project state: %w", err)
 	}
 
-	// Create storage
-	store, err := storage.NewStore("")
+	// Record the project timezone so reports interpret dates consistently
+	state.Timezone = timezone
+	if _, err := state.Location(); err != nil {
+		return nil, "", err
+	}
+
+	// Identical snapshots only fill the store, the latest one stands in for them
+	latest, err := unchangedState(cmd, store, state)
 	if err != nil {
-		return fmt.Errorf("failed to create storage: %w", err)
+		return nil, "", err
+	}
+	if latest != nil {
+		return latest, latest.Filename, nil
 	}
 
 	// Save state
 	filename, err := store.SaveState(state)
+	if err = warnPushFailed(err); err != nil {
+		return nil, "", fmt.Errorf("failed to save state: %w", err)
+	}
+	updatePalette(store, state)
+
+	for _, warning := range state.Warnings {
+		log.Printf("Warning: %s\n", warning)
+	}
+
+	hookCtx := hooks.Context{ProjectNumber: projectNumber, Snapshot: filename, Items: len(state.Items)}
+	if err := runner.Run(cmd.Context(), hooks.PostCapture, hookCtx); err != nil {
+		return nil, "", err
+	}
+
+	return state, filename, nil
+}
+
+// unchangedState returns the latest snapshot if skipping unchanged snapshots is enabled
+// and it has the same content as the fetched state, recording a heartbeat if enabled.
+// The flags take precedence over the config file.
+func unchangedState(cmd *cobra.Command, store storage.StateStore, state *types.ProjectState) (*types.ProjectState, error) {
+	skip, heartbeat := cfg.Capture.SkipUnchanged, cfg.Capture.Heartbeat
+	if cmd.Flags().Changed("skip-unchanged") {
+		skip = skipUnchanged
+	}
+	if cmd.Flags().Changed("heartbeat") {
+		heartbeat = recordHeartbeat
+	}
+	if !skip {
+		return nil, nil
+	}
+
+	states, err := store.ListStates(projectNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list states: %w", err)
+	}
+	if len(states) == 0 {
+		return nil, nil
+	}
+	latest, err := store.LoadStateFile(states[len(states)-1].Filename)
 	if err != nil {
-		return fmt.Errorf("failed to save state: %w", err)
+		return nil, fmt.Errorf("failed to load latest state: %w", err)
+	}
+	if !latest.SameContent(state) {
+		return nil, nil
 	}
 
-	log.Printf("State captured and saved to %s\n", filename)
-	return nil
+	if heartbeat {
+		if err := store.SaveHeartbeat(projectNumber, &types.Heartbeat{Timestamp: state.Timestamp, Snapshot: latest.Filename}); err != nil {
+			return nil, err
+		}
+	}
+	log.Printf("State unchanged since %s, not saved\n", latest.Filename)
+	return latest, nil
+}
+
+// newGitHubClient creates a GitHub client authenticated with the token from
+// --token-file, GITHUB_TOKEN, GH_TOKEN or the gh CLI
+func newGitHubClient(cmd *cobra.Command) (*github.Client, error) {
+	// The flag and GH_HOST take precedence over the config file
+	host := githubHost
+	if host == "" {
+		host = cfg.GitHub.Host
+	}
+
+	token, source, err := github.ResolveToken(tokenFile, host, os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+	kind := github.DetectTokenKind(token)
+
+	// Get verbose flag from root command
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verbose flag: %w", err)
+	}
+
+	// Setup GitHub client
+	src := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+	httpClient := oauth2.NewClient(context.Background(), src)
+
+	baseURL, err := github.GraphQLURL(host)
+	if err != nil {
+		return nil, err
+	}
+
+	// Long captures of big projects outlast rate limits and transient errors
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("invalid --max-attempts: %d (must be at least 1)", maxAttempts)
+	}
+	retry := github.DefaultRetryPolicy()
+	retry.MaxAttempts = maxAttempts
+
+	opts := []github.ClientOption{
+		github.WithBaseURL(baseURL),
+		github.WithRetryPolicy(retry),
+		github.WithUserAgent("gh-project-report/" + version),
+		github.WithTokenKind(kind),
+	}
+	// Tokens read from files or environment variables are masked in all output
+	redact.Add(token)
+	if verbose {
+		log.Printf("Using GitHub %s from %s: %s\n", kind, source, redact.Preview(token))
+		log.Printf("Using GitHub API at %s\n", baseURL)
+		opts = append(opts, github.WithLogger(log.Default()))
+	}
+
+	return github.NewClient(httpClient, opts...), nil
 }
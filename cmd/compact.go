@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var distributionFields []string
+
+var compactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Create monthly aggregates of captured states",
+	Long: `Compact command creates a monthly aggregate for every completed month with captured
+states. Aggregates record item counts, totals and the value distribution of selected
+fields, and are stored next to the raw states in states/project=<number>/monthly/.
+
+Months that already have an aggregate are skipped, so raw states of compacted months
+can be deleted without losing long-term trends.
+
+Examples:
+  gh-project-report compact -p 123
+  gh-project-report compact -p 123 --distribution Status,Team`,
+	RunE: runCompact,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compactCmd)
+	compactCmd.Flags().StringSliceVar(&distributionFields, "distribution", []string{"Status"}, "Fields whose value distribution is recorded")
+}
+
+func runCompact(cmd *cobra.Command, args []string) error {
+	key, err := loadStoreKey()
+	if err != nil {
+		return err
+	}
+	store, err := storage.NewStore(storeLocation, storage.WithEncryption(key))
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+	fileStore, ok := store.(*storage.Store)
+	if !ok {
+		return fmt.Errorf("compact only supports local storage, not %s", storeLocation)
+	}
+
+	aggregates, err := fileStore.Compact(projectNumber, distributionFields, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to compact states: %w", err)
+	}
+
+	if len(aggregates) == 0 {
+		log.Printf("No months to compact for project %d\n", projectNumber)
+		return nil
+	}
+
+	for _, aggregate := range aggregates {
+		log.Printf("Compacted %d states of %s (items: %d-%d, mean %.1f)\n",
+			aggregate.Snapshots, aggregate.Month,
+			aggregate.Items.Min, aggregate.Items.Max, aggregate.Items.Mean)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `Completion generates a shell completion script for gh-project-report.
+
+Besides commands and flags, the scripts complete project numbers and snapshot
+timestamps from the local state store and output formats from the formatters
+that are available.
+
+To load completions:
+
+Bash:
+  source <(gh-project-report completion bash)
+
+Zsh:
+  gh-project-report completion zsh > "${fpath[1]}/_gh-project-report"
+
+Fish:
+  gh-project-report completion fish > ~/.config/fish/completions/gh-project-report.fish
+
+PowerShell:
+  gh-project-report completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		return fmt.Errorf("unsupported shell: %s", args[0])
+	},
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+
+}
+
+// completeProjectNumbers completes the numbers of projects with stored states
+func completeProjectNumbers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	store, err := newStore()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	projects, err := store.ListProjects()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	completions := make([]string, len(projects))
+	for i, project := range projects {
+		completions[i] = strconv.Itoa(project)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeOutputFormats completes the names of the registered formatters
+func completeOutputFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return format.Names(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSnapshotTimestamps completes the timestamps of the stored states of the selected project
+func completeSnapshotTimestamps(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if projectNumber == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	store, err := newStore()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	timestamps, err := store.ListTimestamps(projectNumber)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Most recent snapshots first
+	completions := make([]string, 0, len(timestamps))
+	for i := len(timestamps) - 1; i >= 0; i-- {
+		completions = append(completions, timestamps[i].UTC().Format(time.RFC3339))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
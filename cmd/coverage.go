@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	coverageRange    string
+	estimateField    string
+	ownerField       string
+	coverageMarkdown bool
+)
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Show how planning attribute coverage developed over time",
+	Long: `Coverage command reports, for every captured snapshot, the share of items that had
+start and end dates, an estimate and an owner set. The trend shows whether planning
+discipline is improving.
+
+Owners are taken from the assignees unless --owner-field names a user field.
+
+Examples:
+  gh-project-report coverage -p 123
+  gh-project-report coverage -p 123 --range "last 3 months" --estimate-field Points
+  gh-project-report coverage -p 123 --owner-field Owner --markdown`,
+	RunE: runCoverage,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(coverageCmd)
+	coverageCmd.Flags().StringVarP(&coverageRange, "range", "r", "", "Only include snapshots in this time range (e.g. \"last 3 months\")")
+	coverageCmd.Flags().StringVar(&estimateField, "estimate-field", "Estimate", "Numeric field containing the estimate")
+	coverageCmd.Flags().StringVar(&ownerField, "owner-field", "", "Field containing the owner (default: assignees)")
+	coverageCmd.Flags().BoolVar(&coverageMarkdown, "markdown", false, "Render the report as markdown")
+}
+
+func runCoverage(cmd *cobra.Command, args []string) error {
+	var from, to time.Time
+	if coverageRange != "" {
+		var err error
+		from, to, err = format.ParseHumanRange(coverageRange)
+		if err != nil {
+			return fmt.Errorf("error parsing time range: %w", err)
+		}
+	}
+
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	coverages, err := collectCoverage(store, from, to)
+	if err != nil {
+		return err
+	}
+	if len(coverages) == 0 {
+		return fmt.Errorf("no snapshots found for project %d", projectNumber)
+	}
+
+	doc := format.BuildCoverageDocument(coverages, estimateField, ownerField, format.DefaultOptions())
+	if coverageMarkdown {
+		fmt.Print((&format.MarkdownRenderer{}).RenderDocument(&doc))
+	} else {
+		fmt.Print(format.NewCLITableRenderer().RenderDocument(&doc))
+	}
+	return nil
+}
+
+// collectCoverage computes the attribute coverage of every snapshot between from and to,
+// loading one snapshot at a time. Zero times leave the range open.
+func collectCoverage(store storage.StateStore, from, to time.Time) ([]types.Coverage, error) {
+	timestamps, err := store.ListTimestamps(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var coverages []types.Coverage
+	for _, ts := range timestamps {
+		if (!from.IsZero() && ts.Before(from)) || (!to.IsZero() && ts.After(to)) {
+			continue
+		}
+		state, err := store.LoadState(projectNumber, ts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load state: %w", err)
+		}
+		coverages = append(coverages, state.Coverage(estimateField, ownerField))
+	}
+	return coverages, nil
+}
@@ -2,10 +2,18 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/github"
+	"github.com/naag/gh-project-report/pkg/hooks"
+	"github.com/naag/gh-project-report/pkg/notify"
+	"github.com/naag/gh-project-report/pkg/redact"
 	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
 	"github.com/spf13/cobra"
 )
 
@@ -17,27 +25,95 @@ var (
 	highRisk     int
 	extremeRisk  int
 	output       string
+	outputFile   string
 	filter       string
+	weightField  string
+	limit        int
+	compareWith  string
+	saveReport   string
+	staleDraft   int
+	mine         bool
+	comments     bool
+	snap         string
+	iterationFld string
+	publish      bool
+	notifyTarget string
+	slackWebhook string
+	teamsWebhook string
+	postToIssue  string
+	releaseRepo  string
+	durUnits     string
+	durMaxUnits  int
+	exactDays    bool
+	showAttrs    string
+	workWeeks    int
+	maxItems     int
+	autoExpand   bool
+	preset       string
+	captureNow   bool
+	doneStatuses []string
+	locale       string
+	lang         string
+	noEmoji      bool
+	noColor      bool
+	changesFrom  []string
+	sortBy       string
+	sortDesc     bool
+	columns      []string
+	groupBy      string
+	only         []string
+	steps        string
+	failOn       []string
+	orgTodo      bool
 )
 
 var diffCmd = &cobra.Command{
-	Use:   "diff",
-	Short: "Compare project states between two timestamps",
-	Long: `Diff command compares two project states and shows what has changed.
-It will find the closest state files to the specified dates and compare them.
+	Use:     "diff",
+	Aliases: []string{"report"},
+	Short:   "Compare project states between two timestamps",
+	Long: `Diff command, also available as report, compares two project states and shows what
+has changed. It will find the closest state files to the specified dates and compare them.
+The burndown, velocity and matrix subcommands chart and compare several snapshots instead.
 
 You can specify the time range in two ways:
 1. Using --from and --to flags with ISO8601 timestamps (e.g., 2024-01-01T15:04:05Z)
 2. Using --range flag with human-readable format like "last 30 minutes" or "last 2 hours"
 
-The output format can be specified using the --format flag:
-- text: Plain text output (default)
-- markdown: Markdown table output
-- tableplain: Plain table output
-
+The output format can be specified using the --output flag:
+` + format.Help() + `
 You can filter items using the --filter flag with attribute=value format:
 - gh-project-report diff --range "last 1 week" --filter "Team=UI"
 - gh-project-report diff --range "last 1 week" --filter "Priority=High"
+- gh-project-report diff --range "last 1 week" --filter "field:Status=Done" (project fields only)
+
+Use --mine to only include items assigned to you or where you are set in a user
+field (e.g. Owner). The login is resolved from the GitHub token.
+
+Use --snap to move the resolved from/to timestamps back to the start of their day,
+week (Monday 00:00), month or iteration in --timezone, the timezone of the config file
+or local time, so reports cover the same periods no matter when the command runs.
+Iterations are those of --iteration-field captured in the latest snapshot:
+- gh-project-report diff --range "last 1 week" --snap week
+- gh-project-report diff --range "last 2 weeks" --snap iteration --iteration-field Sprint
+
+Use --capture to capture the current state and compare with it in one run. The
+fetched state is saved and used directly as the "to" side, and the baseline is
+loaded while the project is fetched:
+- gh-project-report report --range "last 1 week" --capture
+
+Use --preset to apply a named set of flags from the presets of the config file.
+Flags given on the command line override the preset:
+- gh-project-report report --preset exec-weekly
+
+Use --group-by to list items with the same value of a field together, and --only to
+select the report sections instead of report.sections of the config file:
+- gh-project-report report --range "last 1 week" --group-by Team --only summary,timeline
+
+Use --weight-field to add a summary weighted by a numeric field (e.g. story points)
+instead of plain item counts. The burndown, velocity and forecast commands take the
+same flag to chart and forecast the field instead of items:
+- gh-project-report diff --range "last 1 week" --weight-field Estimate
+- gh-project-report report burndown --range "last 1 month" --weight-field Estimate
 
 Examples:
   gh-project-report diff --from 2024-01-01T15:04:05Z --to 2024-01-02T15:04:05Z
@@ -46,14 +122,36 @@ Examples:
   gh-project-report diff --range "last 1 day"
   gh-project-report diff --range "last 1 week"
   gh-project-report diff --range "last 1 month"
-  gh-project-report diff --range "last 1 week" --format markdown
-  gh-project-report diff --range "last 1 week" --filter "Team=UI"`,
+  gh-project-report diff --range "last 1 week" --output markdown
+  gh-project-report diff --range "last 1 week" --filter "Team=UI"
+  gh-project-report diff --range "last 1 week" --output markdown --limit 25
+  gh-project-report diff --range "last 1 week" --output dot | dot -Tsvg > slip.svg
+  gh-project-report diff --range "last 1 week" --output json | jq '.changed[] | select(.delay_level == "extreme")'
+  gh-project-report diff --range "last 1 week" --compare-with last-week.json --save-report this-week.json
+  gh-project-report diff --range "last 1 week" --publish-status
+  gh-project-report diff --range "last 1 week" --post-to-issue octo-org/planning#42
+  gh-project-report diff --range "last 1 week" --publish-release octo-org/reports
+  gh-project-report diff --range "last 1 week" --notify slack --slack-webhook https://hooks.slack.com/services/...`,
 	RunE: runDiff,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// Check that either timeRange or both fromDate and toDate are provided
 		hasTimeRange := cmd.Flags().Changed("range")
 		hasFromTo := cmd.Flags().Changed("from") && cmd.Flags().Changed("to")
 
+		// A fresh capture is the "to" side
+		if captureNow {
+			if cmd.Flags().Changed("to") {
+				return fmt.Errorf("--to cannot be combined with --capture, which compares with the current state")
+			}
+			if hasTimeRange == cmd.Flags().Changed("from") {
+				return fmt.Errorf("must specify either --range or --from with --capture")
+			}
+			return nil
+		}
+
 		if hasTimeRange == hasFromTo {
 			return fmt.Errorf("must specify either --range or both --from and --to flags")
 		}
@@ -71,38 +169,273 @@ func init() {
 	diffCmd.Flags().IntVar(&moderateRisk, "moderate-risk", 7, "Days of delay to consider moderate risk (default: 7)")
 	diffCmd.Flags().IntVar(&highRisk, "high-risk", 14, "Days of delay to consider high risk (default: 14)")
 	diffCmd.Flags().IntVar(&extremeRisk, "extreme-risk", 30, "Days of delay to consider extreme risk (default: 30)")
-	diffCmd.Flags().StringVarP(&output, "output", "o", "text", "Output format (text, markdown, or tableplain)")
+	diffCmd.Flags().BoolVar(&captureNow, "capture", false, "Capture the current state and use it as the \"to\" side")
+	diffCmd.Flags().StringVar(&organization, "organization", "", "GitHub organization name, with --capture (optional)")
+	diffCmd.Flags().StringVar(&startField, "start-field", "Start", "Field name containing start date, or @iteration to schedule items by their iteration, with --capture")
+	diffCmd.Flags().StringVar(&endField, "end-field", "End", "Field name containing end date, or @milestone to end items on the due date of their milestone, with --capture")
+	diffCmd.Flags().StringVar(&timezone, "timezone", "", "IANA timezone of the team (e.g. Europe/Berlin) for dates of --range, --snap, due dates and dates of the report, and date fields with --capture (default: the config file, the timezone of the snapshot or local time)")
+	diffCmd.Flags().BoolVar(&compress, "compress", false, "Write the captured state gzip compressed (*.json.gz), with --capture")
+	diffCmd.Flags().BoolVar(&skipUnchanged, "skip-unchanged", false, "Don't save the captured state if it has the same content as the latest snapshot, with --capture (default: the config file)")
+	diffCmd.Flags().BoolVar(&recordHeartbeat, "heartbeat", false, "Record the time of captures skipped by --skip-unchanged, with --capture (default: the config file)")
+	diffCmd.Flags().StringVar(&preset, "preset", "", "Apply the flags of a preset defined in the config file")
+	diffCmd.Flags().BoolVar(&autoExpand, "auto-expand", false, "Compare the nearest two snapshots when both ends of the range resolve to the same snapshot")
+	diffCmd.Flags().StringVar(&snap, "snap", "", "Snap from/to back to cadence boundaries in --timezone (default: the config file or local time): day, week, month or iteration")
+	diffCmd.Flags().StringVar(&iterationFld, "iteration-field", "Iteration", "Iteration field whose iterations --snap iteration snaps to, as captured in the latest snapshot")
+	diffCmd.Flags().StringVar(&durUnits, "duration-units", string(format.DurationUnitsMonths), "Phrase durations in months (years, months, weeks, days) or weeks (weeks, days)")
+	diffCmd.Flags().IntVar(&durMaxUnits, "duration-max-units", 2, "Number of units shown in durations, 1 or 2 (e.g. \"1 month\" or \"1 month 1 week\")")
+	diffCmd.Flags().BoolVar(&exactDays, "exact-days", false, "Append the exact number of days to durations that drop a remainder, e.g. \"3 months (95 days)\"")
+	diffCmd.Flags().StringVar(&showAttrs, "show-attributes", string(format.AttributesAddedRemoved), "Attributes listed under items in text output: added-removed (attributes of added and removed items, field changes of changed items), all, changed (field changes only) or none")
+	diffCmd.PersistentFlags().StringVar(&locale, "locale", "", "Language of month and weekday names in dates: en, de, fr or ja (default: the config file or en)")
+	diffCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "Render delay levels as tags, e.g. [HIGH], and section titles without emoji")
+	diffCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colors of text and tableplain output in terminals")
+	diffCmd.PersistentFlags().StringVar(&lang, "lang", "", "Language of durations, delay levels and section titles: en, de or fr (default: the config file or en)")
+	diffCmd.Flags().StringVarP(&output, "output", "o", "text", fmt.Sprintf("Output format (%s)", strings.Join(format.Names(), ", ")))
+	diffCmd.Flags().StringVar(&outputFile, "output-file", "", "Write the report to this file instead of stdout, required for xlsx output")
 	diffCmd.Flags().StringVarP(&filter, "filter", "f", "", "Filter items using attribute=value format")
+	diffCmd.Flags().StringSliceVar(&changesFrom, "changes-from", nil, "Only report field changes of attributes from these sources: field, content or derived (default: all)")
+	diffCmd.Flags().StringVar(&sortBy, "sort", "", "Order items by delay, start, end, title or status, ascending unless --desc (default: project order)")
+	diffCmd.Flags().BoolVar(&sortDesc, "desc", false, "Sort in descending order, e.g. --sort delay --desc for the most delayed items first")
+	diffCmd.Flags().StringSliceVar(&columns, "columns", nil, fmt.Sprintf("Columns of the timeline table in markdown, tableplain and html output: %s or item attributes (default: all built-in columns)", strings.Join(format.DefaultColumns, ", ")))
+	diffCmd.Flags().StringVar(&groupBy, "group-by", "", "Group items by the value of a field, e.g. Team: items are ordered by it, keeping the --sort order within groups, and the timeline table shows it after the task")
+	diffCmd.Flags().StringSliceVar(&only, "only", nil, fmt.Sprintf("Only include these report sections, in this order: %s (default: report.sections of the config file or all)", strings.Join(format.SectionIDs(), ", ")))
+	diffCmd.Flags().StringVar(&steps, "steps", "", "Also compare the snapshots of each step of the range (daily, weekly, monthly) and report when dates moved")
+	diffCmd.Flags().StringSliceVar(&failOn, "fail-on", nil, "Exit with code 2 after the report when an item reaches a delay level (moderate, high, extreme) or the scope changed (scope)")
+	diffCmd.Flags().BoolVar(&orgTodo, "org-todo", false, "List the added and changed items as TODO or DONE headings with their dates in org output")
+	diffCmd.Flags().BoolVar(&mine, "mine", false, "Only include items where the authenticated user is an assignee or set in a user field")
+	diffCmd.Flags().BoolVar(&comments, "comments", false, "Fetch the latest comment of items with a high or extreme delay as context")
+	diffCmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of items per section, keeping the most delayed, omitted items are summarized (0 = unlimited)")
+	diffCmd.Flags().StringVar(&weightField, "weight-field", "", "Numeric field to sum instead of counting items in a weighted summary (e.g. Estimate)")
+	diffCmd.Flags().StringVar(&compareWith, "compare-with", "", "Report file of a previous run to highlight changes of the delayed list")
+	diffCmd.Flags().IntVar(&staleDraft, "stale-draft-days", 30, "Report draft issues older than this many days that were never converted (0 = disabled)")
+	diffCmd.Flags().StringSliceVar(&doneStatuses, "done", []string{"Done"}, "Status values of done items, to report items completed before their end date")
+	diffCmd.Flags().IntVar(&workWeeks, "workload-weeks", 0, "Add a workload section with the items scheduled per assignee in this many upcoming weeks (0 = disabled)")
+	diffCmd.Flags().IntVar(&maxItems, "max-concurrent", 3, "Flag assignees in the workload section with more items than this scheduled at the same time")
+	diffCmd.Flags().StringVar(&saveReport, "save-report", "", "Write a report file for comparison with future runs")
+	diffCmd.Flags().StringVar(&notifyTarget, "notify", "", fmt.Sprintf("Send the report to a notification target: a compact summary to a chat tool or the full report by email (%s)", strings.Join(notify.Names(), ", ")))
+	diffCmd.Flags().StringVar(&slackWebhook, "slack-webhook", "", "Slack incoming webhook URL for --notify slack (default: $SLACK_WEBHOOK_URL)")
+	diffCmd.Flags().StringVar(&teamsWebhook, "teams-webhook", "", "Teams channel webhook URL for --notify teams (default: $TEAMS_WEBHOOK_URL)")
+	diffCmd.Flags().StringVar(&postToIssue, "post-to-issue", "", "Post the report in Markdown as a comment on an issue (owner/repo#123), updating the previous report comment")
+	diffCmd.Flags().StringVar(&releaseRepo, "publish-release", "", "Publish the report as a GitHub release of the week in a repository (owner/repo), with the Markdown report and CSV export attached")
+	diffCmd.Flags().BoolVar(&publish, "publish-status", false, "Post a condensed report as a status update of the project, with on track/at risk/off track from the worst delay")
+
+	diffCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
+	diffCmd.RegisterFlagCompletionFunc("from", completeSnapshotTimestamps)
+	diffCmd.RegisterFlagCompletionFunc("to", completeSnapshotTimestamps)
+	diffCmd.RegisterFlagCompletionFunc("duration-units", cobra.FixedCompletions(
+		[]string{string(format.DurationUnitsMonths), string(format.DurationUnitsWeeks)}, cobra.ShellCompDirectiveNoFileComp))
+	diffCmd.RegisterFlagCompletionFunc("show-attributes", cobra.FixedCompletions(
+		[]string{string(format.AttributesAddedRemoved), string(format.AttributesAll), string(format.AttributesChanged), string(format.AttributesNone)}, cobra.ShellCompDirectiveNoFileComp))
+	diffCmd.RegisterFlagCompletionFunc("changes-from", cobra.FixedCompletions(
+		[]string{string(types.ProvenanceField), string(types.ProvenanceContent), string(types.ProvenanceDerived)}, cobra.ShellCompDirectiveNoFileComp))
+	diffCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(
+		[]string{string(types.SortDelay), string(types.SortStart), string(types.SortEnd), string(types.SortTitle), string(types.SortStatus)}, cobra.ShellCompDirectiveNoFileComp))
+	diffCmd.RegisterFlagCompletionFunc("steps", cobra.FixedCompletions(
+		[]string{"daily", "weekly", "monthly"}, cobra.ShellCompDirectiveNoFileComp))
+	diffCmd.RegisterFlagCompletionFunc("fail-on", cobra.FixedCompletions([]string{
+		string(format.FailOnModerate), string(format.FailOnHigh), string(format.FailOnExtreme), string(format.FailOnScope)}, cobra.ShellCompDirectiveNoFileComp))
+	diffCmd.RegisterFlagCompletionFunc("notify", cobra.FixedCompletions(notify.Names(), cobra.ShellCompDirectiveNoFileComp))
+	diffCmd.RegisterFlagCompletionFunc("snap", cobra.FixedCompletions(
+		[]string{format.CadenceDay, format.CadenceWeek, format.CadenceMonth, format.CadenceIteration}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// addWeightFieldFlag adds --weight-field, summing a numeric field such as story points
+// instead of counting items, with its deprecated former name --estimate-field
+func addWeightFieldFlag(cmd *cobra.Command, p *string, usage string) {
+	cmd.Flags().StringVar(p, "weight-field", "", usage)
+	cmd.Flags().StringVar(p, "estimate-field", "", usage)
+	cmd.Flags().MarkDeprecated("estimate-field", "use --weight-field instead")
 }
 
 func runDiff(cmd *cobra.Command, args []string) error {
-	// Validate output format
-	if output != "text" && output != "markdown" && output != "tableplain" {
-		return fmt.Errorf("invalid output format: %s (must be 'text', 'markdown', or 'tableplain')", output)
+	// Validate output format before loading any state
+	outputFormatter, err := format.New(output)
+	if err != nil {
+		return err
+	}
+	if _, ok := outputFormatter.(*format.XLSXFormatter); ok && outputFile == "" {
+		return fmt.Errorf("--output xlsx requires --output-file")
 	}
 
-	// Create formatter with custom options
-	var formatter format.Formatter
+	// Validate the report sections, selected by --only or the config file
+	sections := cfg.Report.Sections
+	if len(only) > 0 {
+		sections = make([]string, len(only))
+		for i, id := range only {
+			sections[i] = strings.TrimSpace(id)
+		}
+		if err := format.ValidateSections(sections); err != nil {
+			return fmt.Errorf("invalid --only: %w", err)
+		}
+	} else if err := format.ValidateSections(sections); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if err := validateStatusSLAs(cfg.Report.StatusSLAs); err != nil {
+		return err
+	}
+
+	// Validate the issue to post to before loading any state
+	var reportIssue github.RepoIssue
+	if postToIssue != "" {
+		var err error
+		if reportIssue, err = github.ParseRepoIssue(postToIssue); err != nil {
+			return err
+		}
+	}
+
+	// Validate the release repository before loading any state
+	var reportRepo github.Repository
+	if releaseRepo != "" {
+		var err error
+		if reportRepo, err = github.ParseRepository(releaseRepo); err != nil {
+			return err
+		}
+	}
+
+	// Validate the notification target before loading any state
+	var notifier notify.Notifier
+	if notifyTarget != "" {
+		if slackWebhook == "" {
+			slackWebhook = os.Getenv("SLACK_WEBHOOK_URL")
+		}
+		if teamsWebhook == "" {
+			teamsWebhook = os.Getenv("TEAMS_WEBHOOK_URL")
+		}
+		redact.Add(slackWebhook)
+		redact.Add(teamsWebhook)
+		email := cfg.Notify.Email
+		if password := os.Getenv("SMTP_PASSWORD"); password != "" {
+			email.Password = password
+		}
+		redact.Add(email.Password)
+		var err error
+		notifier, err = notify.New(notifyTarget, notify.Config{
+			SlackWebhook: slackWebhook,
+			TeamsWebhook: teamsWebhook,
+			Email: notify.EmailConfig{
+				Host:     email.Host,
+				Port:     email.Port,
+				Username: email.Username,
+				Password: email.Password,
+				From:     email.From,
+				To:       email.To,
+				Format:   email.Format,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Validate duration rendering
+	units, err := format.ParseDurationUnits(durUnits)
+	if err != nil {
+		return err
+	}
+	if durMaxUnits < 1 || durMaxUnits > 2 {
+		return fmt.Errorf("invalid --duration-max-units: %d (must be 1 or 2)", durMaxUnits)
+	}
+	listing, err := format.ParseAttributeListing(showAttrs)
+	if err != nil {
+		return err
+	}
+	dateLocale, err := reportLocale()
+	if err != nil {
+		return err
+	}
+	reportLang, err := reportLanguage()
+	if err != nil {
+		return err
+	}
+	provenances := make([]types.Provenance, len(changesFrom))
+	for i, kind := range changesFrom {
+		if provenances[i], err = types.ParseProvenance(kind); err != nil {
+			return fmt.Errorf("invalid --changes-from: %w", err)
+		}
+	}
+	var sortKey types.SortKey
+	if sortBy != "" {
+		if sortKey, err = types.ParseSortKey(sortBy); err != nil {
+			return fmt.Errorf("invalid --sort: %w", err)
+		}
+	} else if sortDesc {
+		return fmt.Errorf("--desc requires --sort")
+	}
+	failConditions, err := format.ParseFailConditions(failOn)
+	if err != nil {
+		return fmt.Errorf("invalid --fail-on: %w", err)
+	}
+	var step [3]int
+	if steps != "" {
+		if step, err = parseSteps(steps); err != nil {
+			return err
+		}
+	}
+
+	// Collect formatter options
 	opts := []func(*format.FormatterOptions){
+		format.WithDurationStyle(format.DurationStyle{Units: units, MaxUnits: durMaxUnits, ExactDays: exactDays}),
 		format.WithModerateDelayThreshold(moderateRisk),
 		format.WithHighDelayThreshold(highRisk),
 		format.WithExtremeDelayThreshold(extremeRisk),
+		format.WithWeightField(weightField),
+		format.WithLimit(limit),
+		format.WithColumns(groupColumns(trimColumns(columns), groupBy)),
+		format.WithSections(sections),
+		format.WithWorkload(workWeeks, maxItems),
+		format.WithCauseLabels(cfg.Report.CauseLabels),
+		format.WithShowAttributes(listing),
+		format.WithDoneStatuses(statusField, doneStatuses),
+		format.WithLocale(dateLocale),
+		format.WithLanguage(reportLang),
+		format.WithEmojiStyle(emojiStyle()),
+		format.WithColor(colorOutput()),
+		format.WithOrgTodo(orgTodo),
 	}
 
-	if output == "text" {
-		formatter = format.NewTextFormatter(opts...)
-	} else if output == "tableplain" {
-		formatter = format.NewPlainTableFormatter(opts...)
-	} else {
-		formatter = format.NewTableFormatter(opts...)
+	// Validate the options before loading any state
+	if _, err := format.NewOptions(opts...); err != nil {
+		return err
+	}
+
+	// Stale draft detection, the flag takes precedence over the config file
+	if !cmd.Flags().Changed("stale-draft-days") && cfg.Report.StaleDraftDays != nil {
+		staleDraft = *cfg.Report.StaleDraftDays
+	}
+	opts = append(opts, format.WithStaleDraftAge(time.Duration(staleDraft)*24*time.Hour))
+
+	// Freeze windows don't count as slip or towards staleness
+	freezes, err := cfg.Report.Freezes()
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	opts = append(opts, format.WithFreezeWindows(freezes))
+
+	// Load the report of a previous run
+	if compareWith != "" {
+		previous, err := loadReport(compareWith)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, format.WithPreviousReport(previous))
+	}
+
+	// Timezone of the report, nil to use the one recorded with the snapshot
+	var reportLoc *time.Location
+	if timezone != "" {
+		if reportLoc, err = time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("invalid --timezone: %w", err)
+		}
 	}
 
 	// Get from and to times based on input flags
 	var fromTime, toTime time.Time
-	var err error
 
 	if cmd.Flags().Changed("range") {
-		fromTime, toTime, err = format.ParseHumanRange(timeRange)
+		rangeLoc := time.UTC
+		if reportLoc != nil {
+			rangeLoc = reportLoc
+		}
+		fromTime, toTime, err = format.ParseHumanRangeIn(timeRange, rangeLoc)
 		if err != nil {
 			return fmt.Errorf("error parsing time range: %w", err)
 		}
@@ -112,28 +445,107 @@ func runDiff(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid 'from' date format (must be ISO8601): %w", err)
 		}
 
-		toTime, err = time.Parse(time.RFC3339, toDate)
-		if err != nil {
-			return fmt.Errorf("invalid 'to' date format (must be ISO8601): %w", err)
+		if !captureNow {
+			toTime, err = time.Parse(time.RFC3339, toDate)
+			if err != nil {
+				return fmt.Errorf("invalid 'to' date format (must be ISO8601): %w", err)
+			}
 		}
 	}
 
 	// Create storage and load states
-	store, err := storage.NewStore("")
+	store, err := newStore(storage.WithCompression(compress))
 	if err != nil {
 		return fmt.Errorf("failed to create storage: %w", err)
 	}
 
+	// Snap to cadence boundaries for consistent period-over-period reports
+	if snap != "" {
+		snapLoc := time.Local
+		if reportLoc != nil {
+			snapLoc = reportLoc
+		}
+		snapTo := func(t time.Time) (time.Time, error) {
+			return format.SnapToCadence(t, snap, snapLoc)
+		}
+		if snap == format.CadenceIteration {
+			starts, err := iterationStarts(store, iterationFld)
+			if err != nil {
+				return fmt.Errorf("failed to snap to iterations: %w", err)
+			}
+			snapTo = func(t time.Time) (time.Time, error) {
+				return format.SnapToIteration(t, starts, snapLoc)
+			}
+		}
+		if fromTime, err = snapTo(fromTime); err != nil {
+			return err
+		}
+		if toTime, err = snapTo(toTime); err != nil {
+			return err
+		}
+	}
+
+	// Fetch the current state while the baseline is loaded
+	var captured chan capturedState
+	if captureNow {
+		toTime = time.Now()
+		captured = make(chan capturedState, 1)
+		go func() {
+			state, filename, err := captureState(cmd, store)
+			captured <- capturedState{state: state, filename: filename, err: err}
+		}()
+	}
+
 	fromState, err := store.LoadState(projectNumber, fromTime)
 	if err != nil {
 		return fmt.Errorf("failed to load from state: %w", err)
 	}
 
-	toState, err := store.LoadState(projectNumber, toTime)
-	if err != nil {
+	var toState *types.ProjectState
+	if captured != nil {
+		// Use the fetched state instead of reading it back from the store
+		result := <-captured
+		if result.err != nil {
+			return result.err
+		}
+		toState = result.state
+		toState.Filename = result.filename
+	} else if toState, err = store.LoadState(projectNumber, toTime); err != nil {
 		return fmt.Errorf("failed to load to state: %w", err)
 	}
 
+	// Caveats of the report for machine-readable output
+	var diagnostics []format.Diagnostic
+
+	// Both ends resolving to the same snapshot would produce an empty diff
+	if fromState.Filename == toState.Filename && !fromTime.Equal(toTime) {
+		fromTime, toTime, err = storage.ExpandRange(store, projectNumber, fromTime, toTime)
+		if err != nil {
+			return fmt.Errorf("no distinct snapshots in the requested range: %w", err)
+		}
+		if !autoExpand {
+			return fmt.Errorf("both ends of the requested range resolve to the snapshot %s; compare the nearest snapshots with --from %s --to %s or pass --auto-expand",
+				fromState.Filename, fromTime.Format(time.RFC3339), toTime.Format(time.RFC3339))
+		}
+		notice := fmt.Sprintf("No distinct snapshots in the requested range, comparing %s to %s instead",
+			fromTime.Format(time.RFC3339), toTime.Format(time.RFC3339))
+		fmt.Fprintln(os.Stderr, notice)
+		diagnostics = append(diagnostics, format.Diagnostic{Level: format.DiagnosticInfo, Code: format.DiagnosticRangeExpanded, Message: notice})
+
+		if fromState, err = store.LoadState(projectNumber, fromTime); err != nil {
+			return fmt.Errorf("failed to load from state: %w", err)
+		}
+		if toState, err = store.LoadState(projectNumber, toTime); err != nil {
+			return fmt.Errorf("failed to load to state: %w", err)
+		}
+	}
+
+	// Keep the order and colors of statuses and assignees stable between reports,
+	// recording values of the unfiltered states
+	if palette := updatePalette(store, fromState, toState); palette != nil {
+		opts = append(opts, format.WithPalette(palette))
+	}
+
 	// Apply filter if specified
 	if filter != "" {
 		fromState, err = fromState.FilterState(filter)
@@ -147,11 +559,382 @@ func runDiff(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("From: %s\n", fromState.Filename)
-	fmt.Printf("To: %s\n", toState.Filename)
+	// Restrict to items of the authenticated user
+	var login string
+	if mine {
+		client, err := newGitHubClient(cmd)
+		if err != nil {
+			return err
+		}
+		login, err = client.ViewerLogin()
+		if err != nil {
+			return fmt.Errorf("failed to resolve --mine: %w", err)
+		}
+		fromState = fromState.FilterByUser(login)
+		toState = toState.FilterByUser(login)
+	}
 
-	// Compare states and format output
+	// Use the timezone of the report, or the project timezone recorded at capture time,
+	// for due dates and the dates of snapshots and comments
+	if reportLoc != nil {
+		opts = append(opts, format.WithLocation(reportLoc))
+	} else if toState.Timezone != "" {
+		loc, err := toState.Location()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, format.WithLocation(loc))
+	}
+
+	// Items exceeding the time allowed in their status
+	if len(cfg.Report.StatusSLAs) > 0 {
+		violations, err := collectSLAViolations(store, toState, cfg.Report.StatusSLAs)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, format.WithSLAViolations(violations))
+	}
+
+	// When dates moved across the snapshots between the compared ones
+	if steps != "" {
+		trajectories, err := collectTrajectories(store, fromState, toState, step, login)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, format.WithTrajectories(trajectories))
+	}
+
+	// Compare states
 	diff := fromState.CompareTo(toState)
-	fmt.Print(formatter.Format(*diff))
+	diff.DiscountFreezes(freezes)
+	diff.KeepProvenance(provenances...)
+	if sortKey != "" {
+		diff.Sort(sortKey, statusField, sortDesc)
+	}
+	if groupBy != "" {
+		diff.GroupBy(groupBy)
+	}
+
+	// Fetch the latest comment of slipped items as context
+	if comments {
+		latest, err := fetchSlipComments(cmd, *diff, opts)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, format.WithComments(latest))
+	}
+
+	for _, warning := range append(fromState.Warnings, toState.Warnings...) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		diagnostics = append(diagnostics, format.Diagnostic{Level: format.DiagnosticWarning, Code: format.DiagnosticStateWarning, Message: warning})
+	}
+	if drift, ok := format.SnapshotDrift("from", fromTime, fromState.Timestamp, format.DefaultDriftTolerance); ok {
+		diagnostics = append(diagnostics, drift)
+	}
+	if drift, ok := format.SnapshotDrift("to", toTime, toState.Timestamp, format.DefaultDriftTolerance); ok {
+		diagnostics = append(diagnostics, drift)
+	}
+	opts = append(opts, format.WithDiagnostics(diagnostics))
+
+	formatter, err := format.New(output, opts...)
+	if err != nil {
+		return err
+	}
+
+	// Keep stdout clean for piping machine-readable formats
+	fmt.Fprintf(os.Stderr, "From: %s\n", fromState.Filename)
+	fmt.Fprintf(os.Stderr, "To: %s\n", toState.Filename)
+
+	// Format output
+	formatted := formatter.Format(*diff)
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, []byte(formatted), 0o644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	} else if summary, ok := formatter.(*format.GitHubSummaryFormatter); ok {
+		if err := writeJobSummary(formatted, summary.Annotations(*diff)); err != nil {
+			return err
+		}
+	} else {
+		fmt.Print(formatted)
+	}
+
+	// Save the report for comparison with future runs
+	if saveReport != "" {
+		report := format.NewReport(*diff, buildOptions(opts))
+		report.ProjectNumber = projectNumber
+		if err := writeReport(saveReport, report); err != nil {
+			return err
+		}
+	}
+
+	// Post the report as an issue comment
+	if postToIssue != "" {
+		if err := postReportComment(cmd, reportIssue, *diff, opts); err != nil {
+			return err
+		}
+	}
+
+	// Publish the report as the release of the week
+	if releaseRepo != "" {
+		if err := publishReportRelease(cmd, reportRepo, toState, *diff, opts); err != nil {
+			return err
+		}
+	}
+
+	// Send the report to the notification target
+	if notifier != nil {
+		report := notify.Report{ProjectNumber: projectNumber, Diff: *diff, Options: buildOptions(opts)}
+		if err := notifier.Notify(cmd.Context(), report); err != nil {
+			return err
+		}
+	}
+
+	// Post the report as a project status update
+	if publish {
+		if err := publishStatusUpdate(cmd, toState, *diff, buildOptions(opts)); err != nil {
+			return err
+		}
+	}
+
+	hookCtx := hooks.Context{
+		ProjectNumber: projectNumber,
+		Snapshot:      toState.Filename,
+		FromSnapshot:  fromState.Filename,
+		Items:         len(toState.Items),
+		Added:         len(diff.AddedItems),
+		Removed:       len(diff.RemovedItems),
+		Changed:       len(diff.ChangedItems),
+		Delayed:       len(format.NewReport(*diff, buildOptions(opts)).Delayed),
+	}
+
+	// Write the results as outputs of the GitHub Actions step
+	if actionRun != nil {
+		if err := actionRun.write(formatted, hookCtx); err != nil {
+			return err
+		}
+	}
+	if err := newHookRunner().Run(cmd.Context(), hooks.PostReport, hookCtx); err != nil {
+		return err
+	}
+
+	// Fail CI gates only after the report was written and delivered
+	if failures := format.Failures(*diff, buildOptions(opts), failConditions); len(failures) > 0 {
+		return &exitError{code: exitCodeFailOn, err: fmt.Errorf("report fails --fail-on: %s", strings.Join(failures, ", "))}
+	}
 	return nil
 }
+
+// trimColumns trims the spaces around column names, e.g. from "task, end"
+func trimColumns(columns []string) []string {
+	trimmed := make([]string, len(columns))
+	for i, column := range columns {
+		trimmed[i] = strings.TrimSpace(column)
+	}
+	return trimmed
+}
+
+// iterationStarts returns the start dates of the iterations of an iteration field, as
+// captured with the items of the latest snapshot of the project
+func iterationStarts(store storage.StateStore, field string) ([]time.Time, error) {
+	states, err := store.ListStates(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return nil, fmt.Errorf("no state files found for project %d", projectNumber)
+	}
+	state, err := store.LoadStateFile(states[len(states)-1].Filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load latest state: %w", err)
+	}
+
+	var starts []time.Time
+	for _, item := range state.Items {
+		value, _ := item.Attributes[field+github.IterationStartSuffix].(string)
+		if start, err := time.Parse("2006-01-02", value); err == nil {
+			starts = append(starts, start)
+		}
+	}
+	if len(starts) == 0 {
+		return nil, fmt.Errorf("no items of the latest snapshot are in an iteration of the field %s", field)
+	}
+	return starts, nil
+}
+
+// groupColumns adds the field items are grouped by after the task to the default
+// timeline columns. Columns given with --columns are kept as they are.
+func groupColumns(columns []string, groupBy string) []string {
+	if groupBy == "" || len(columns) > 0 {
+		return columns
+	}
+	return append([]string{format.DefaultColumns[0], groupBy}, format.DefaultColumns[1:]...)
+}
+
+// capturedState is the result of capturing the "to" side of a diff
+type capturedState struct {
+	state    *types.ProjectState
+	filename string
+	err      error
+}
+
+// postReportComment posts the diff formatted as Markdown as a comment on the issue
+func postReportComment(cmd *cobra.Command, issue github.RepoIssue, diff types.ProjectDiff, opts []func(*format.FormatterOptions)) error {
+	client, err := newGitHubClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	url, err := client.PostReportComment(issue, format.NewTableFormatter(opts...).Format(diff))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Posted report to %s\n", url)
+	return nil
+}
+
+// publishReportRelease creates or updates the release of the week of the "to" state,
+// e.g. project-123-2024-W03, with the condensed report as body and the full report
+// in Markdown and the CSV export as assets
+func publishReportRelease(cmd *cobra.Command, repo github.Repository, state *types.ProjectState, diff types.ProjectDiff, opts []func(*format.FormatterOptions)) error {
+	client, err := newGitHubClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	year, week := state.Timestamp.ISOWeek()
+	tag := fmt.Sprintf("project-%d-%d-W%02d", projectNumber, year, week)
+	name := fmt.Sprintf("Project %d: week %d, %d", projectNumber, week, year)
+	csv, err := format.New("csv", opts...)
+	if err != nil {
+		return err
+	}
+	assets := []github.ReleaseAsset{
+		{Name: tag + ".md", ContentType: "text/markdown; charset=utf-8", Data: []byte(format.NewTableFormatter(opts...).Format(diff))},
+		{Name: tag + ".csv", ContentType: "text/csv; charset=utf-8", Data: []byte(csv.Format(diff))},
+	}
+
+	body := format.FormatStatusUpdate(diff, buildOptions(opts)) + "\n\nThe full report and a CSV export are attached.\n"
+	url, err := client.PublishRelease(repo, tag, name, body, assets)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Published release %s\n", url)
+	return nil
+}
+
+// publishStatusUpdate posts a condensed report of the diff as a status update of the project
+func publishStatusUpdate(cmd *cobra.Command, state *types.ProjectState, diff types.ProjectDiff, options format.FormatterOptions) error {
+	client, err := newGitHubClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Snapshots record the node ID of the project, older ones may lack it
+	projectID := state.ProjectID
+	if projectID == "" {
+		projectID, err = client.LookupProjectNodeID(state.ProjectNumber, state.Organization)
+		if err != nil {
+			return fmt.Errorf("failed to lookup project ID: %w", err)
+		}
+	}
+
+	health := format.ClassifyHealth(diff, options)
+	if _, err := client.CreateStatusUpdate(projectID, string(health), format.FormatStatusUpdate(diff, options)); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Published status update: %s\n", health)
+	return nil
+}
+
+// buildOptions applies formatter options to the defaults
+func buildOptions(opts []func(*format.FormatterOptions)) format.FormatterOptions {
+	options := format.DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// fetchSlipComments fetches the latest comment of each item with a high or extreme delay
+func fetchSlipComments(cmd *cobra.Command, diff types.ProjectDiff, opts []func(*format.FormatterOptions)) (map[string]types.Comment, error) {
+	slipped := format.SlippedItems(diff, buildOptions(opts))
+	if len(slipped) == 0 {
+		return nil, nil
+	}
+
+	client, err := newGitHubClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]types.Comment)
+	for _, change := range slipped {
+		comment, err := client.LatestComment(change.ItemID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch comments: %w", err)
+		}
+		if comment != nil {
+			latest[change.ItemID] = *comment
+		}
+	}
+	return latest, nil
+}
+
+// loadReport loads a report file written by --save-report
+func loadReport(path string) (*format.Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open report: %w", err)
+	}
+	defer f.Close()
+
+	return format.ReadReport(f)
+}
+
+// writeReport writes a report file
+func writeReport(path string, report format.Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer f.Close()
+
+	if err := report.Write(f); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// reportLocale returns the locale of dates in reports, the --locale flag takes
+// precedence over the config file
+func reportLocale() (format.Locale, error) {
+	if locale == "" {
+		return format.ParseLocale(cfg.Report.Locale)
+	}
+	return format.ParseLocale(locale)
+}
+
+// emojiStyle returns the emoji style of reports, ASCII with --no-emoji or no_emoji in
+// the config file
+func emojiStyle() format.EmojiStyle {
+	if noEmoji || cfg.Report.NoEmoji {
+		return format.EmojiStyleASCII
+	}
+	return format.EmojiStyleGlyphs
+}
+
+// colorOutput returns true if the report is colored: printed to a terminal, unless
+// disabled by --no-color or the NO_COLOR environment variable
+func colorOutput() bool {
+	return !noColor && !color.NoColor && outputFile == ""
+}
+
+// reportLanguage returns the language of labels in reports, the --lang flag takes
+// precedence over the config file
+func reportLanguage() (format.Language, error) {
+	if lang == "" {
+		return format.ParseLanguage(cfg.Report.Lang)
+	}
+	return format.ParseLanguage(lang)
+}
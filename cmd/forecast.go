@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/forecast"
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	forecastRange       string
+	forecastStatus      string
+	forecastDone        []string
+	forecastWeight      string
+	forecastPeriodWeeks int
+	forecastTrials      int
+	forecastSeed        uint64
+	forecastOutput      string
+)
+
+var forecastCmd = &cobra.Command{
+	Use:   "forecast",
+	Short: "Forecast when the remaining work will be done",
+	Long: `Forecast command measures the throughput of every full period in the snapshot history,
+like report velocity, and simulates the future many times by drawing the throughput of each
+period at random from that history until the open work of the latest snapshot is done.
+It prints the dates by which 50%, 85% and 95% of the simulated futures were done.
+
+Use --weight-field to forecast a numeric field such as story points instead of items.
+
+If the config file lists the people of the team under capacity, with their working days
+per week and holidays, the forecast also schedules the remaining working days of the open
+dated items on the days each assignee is available, and prints when each person and the
+whole team are done.
+
+Examples:
+  gh-project-report forecast -p 123
+  gh-project-report forecast -p 123 --range "last 3 months" --weight-field Points
+  gh-project-report forecast -p 123 --period-weeks 2 --output json`,
+	RunE: runForecast,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(forecastCmd)
+	forecastCmd.Flags().StringVarP(&forecastRange, "range", "r", "", "Only use snapshots in this time range as history (e.g. \"last 3 months\")")
+	forecastCmd.Flags().StringVar(&forecastStatus, "status-field", "Status", "Field containing the status of items")
+	forecastCmd.Flags().StringSliceVar(&forecastDone, "done", []string{"Done"}, "Status values of done items")
+	addWeightFieldFlag(forecastCmd, &forecastWeight, "Numeric field to forecast instead of counting items (e.g. Points)")
+	forecastCmd.Flags().IntVar(&forecastPeriodWeeks, "period-weeks", 1, "Length of a sampled period in weeks")
+	forecastCmd.Flags().IntVar(&forecastTrials, "trials", forecast.DefaultTrials, "Number of simulated futures")
+	forecastCmd.Flags().Uint64Var(&forecastSeed, "seed", 0, "Seed for reproducible forecasts (default: random)")
+	forecastCmd.Flags().StringVarP(&forecastOutput, "output", "o", "table", "Output format (table, markdown, json)")
+
+	forecastCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"table", "markdown", "json"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runForecast(cmd *cobra.Command, args []string) error {
+	if forecastOutput != "table" && forecastOutput != "markdown" && forecastOutput != "json" {
+		return fmt.Errorf("invalid output format: %s (must be one of: table, markdown, json)", forecastOutput)
+	}
+	if forecastPeriodWeeks < 1 {
+		return fmt.Errorf("invalid period: --period-weeks must be at least 1")
+	}
+
+	var from, to time.Time
+	if forecastRange != "" {
+		var err error
+		from, to, err = format.ParseHumanRange(forecastRange)
+		if err != nil {
+			return fmt.Errorf("error parsing time range: %w", err)
+		}
+	}
+
+	capacity, err := cfg.Capacity.Capacity()
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	samples, err := collectVelocity(store, from, to, forecastStatus, forecastDone, forecastWeight)
+	if err != nil {
+		return err
+	}
+	if len(samples) == 0 {
+		return fmt.Errorf("at least two snapshots are needed for project %d", projectNumber)
+	}
+
+	latest, err := store.LoadState(projectNumber, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	period := time.Duration(forecastPeriodWeeks) * 7 * 24 * time.Hour
+	throughput := fullPeriodThroughput(samples, period)
+	if len(throughput) == 0 {
+		return fmt.Errorf("not enough history: the snapshots must span at least one full period of %d week(s)", forecastPeriodWeeks)
+	}
+
+	opts := []forecast.Option{forecast.WithTrials(forecastTrials)}
+	if cmd.Flags().Changed("seed") {
+		opts = append(opts, forecast.WithSeed(forecastSeed))
+	}
+	remaining := latest.Burndown(forecastStatus, forecastDone, forecastWeight).Remaining
+	result, err := forecast.Simulate(throughput, remaining, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to forecast: %w", err)
+	}
+
+	input := format.ForecastInput{
+		Result:         result,
+		Start:          latest.Timestamp,
+		Period:         period,
+		HistoryPeriods: len(throughput),
+		WeightField:    forecastWeight,
+		Percentiles:    forecast.DefaultPercentiles,
+	}
+	if len(capacity.People) > 0 {
+		work := forecast.ScheduledWork(latest, forecastStatus, forecastDone)
+		projection := capacity.Project(work, latest.Timestamp)
+		input.Capacity = &projection
+	}
+	switch forecastOutput {
+	case "json":
+		output, err := format.FormatForecastJSON(input)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+	case "markdown":
+		doc := format.BuildForecastDocument(input, format.DefaultOptions())
+		fmt.Print((&format.MarkdownRenderer{}).RenderDocument(&doc))
+	default:
+		doc := format.BuildForecastDocument(input, format.DefaultOptions())
+		fmt.Print(format.NewCLITableRenderer().RenderDocument(&doc))
+	}
+	return nil
+}
+
+// fullPeriodThroughput returns the completed work of each full period covered by the
+// samples, counting periods back from the last sample. A partial period at the start
+// of the history would understate the throughput.
+func fullPeriodThroughput(samples []types.VelocitySample, period time.Duration) []float64 {
+	first := samples[0].Since
+	last := samples[len(samples)-1].Timestamp
+
+	throughput := make([]float64, int(last.Sub(first)/period))
+	for _, sample := range samples {
+		// Period 0 ends with the last sample, each period includes its end
+		index := int(last.Sub(sample.Timestamp) / period)
+		if index < len(throughput) {
+			throughput[len(throughput)-1-index] += sample.Completed
+		}
+	}
+	return throughput
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/naag/gh-project-report/pkg/hooks"
+	"github.com/naag/gh-project-report/pkg/redact"
+)
+
+// newHookRunner creates a runner for the hook commands of the configuration file
+func newHookRunner() *hooks.Runner {
+	return hooks.NewRunner(map[hooks.Event][]string{
+		hooks.PreCapture:  cfg.Hooks.PreCapture,
+		hooks.PostCapture: cfg.Hooks.PostCapture,
+		hooks.PostReport:  cfg.Hooks.PostReport,
+	}, hooks.WithOutput(redact.Writer(os.Stderr)))
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/github"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var importTimestamp string
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a snapshot from a gh project item-list export",
+	Long: `Import command converts the JSON written by "gh project item-list --format json" into a
+snapshot of the project at the given timestamp, so exports made with the GitHub CLI can seed
+or supplement the captured history. Use "-" to read the export from stdin.
+
+gh exports 30 items unless --limit is given; the import warns if the export is incomplete.
+Exports have no creation and update times of items.
+
+Examples:
+  gh project item-list 123 --owner my-org --format json --limit 1000 > export.json
+  gh-project-report import -p 123 export.json --timestamp 2024-01-08T09:00:00Z
+  gh project item-list 123 --owner my-org --format json --limit 1000 | gh-project-report import -p 123 -`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringVar(&importTimestamp, "timestamp", "", "Time the export was taken (RFC3339 or YYYY-MM-DD, default: now)")
+	importCmd.Flags().StringVar(&startField, "start-field", "Start", "Field name containing start date, or @iteration to schedule items by their iteration")
+	importCmd.Flags().StringVar(&endField, "end-field", "End", "Field name containing end date, or @milestone to end items on the due date of their milestone")
+	importCmd.Flags().StringVar(&timezone, "timezone", "", "IANA timezone of the project used to interpret date fields (e.g. Europe/Berlin)")
+	importCmd.Flags().BoolVar(&compress, "compress", false, "Write the state gzip compressed (*.json.gz)")
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	timestamp := time.Now()
+	if importTimestamp != "" {
+		var err error
+		if timestamp, err = parseImportTimestamp(importTimestamp); err != nil {
+			return err
+		}
+	}
+
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read export: %w", err)
+	}
+
+	state, err := github.ParseItemListExport(data, startField, endField)
+	if err != nil {
+		return err
+	}
+	state.ProjectNumber = projectNumber
+	state.Timestamp = timestamp
+	state.Timezone = timezone
+	if _, err := state.Location(); err != nil {
+		return err
+	}
+
+	store, err := newStore(storage.WithCompression(compress))
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+	filename, err := store.SaveState(state)
+	if err = warnPushFailed(err); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	updatePalette(store, state)
+
+	for _, warning := range state.Warnings {
+		log.Printf("Warning: %s\n", warning)
+	}
+	log.Printf("Imported %d items and saved to %s\n", len(state.Items), filename)
+	return nil
+}
+
+// parseImportTimestamp parses an RFC3339 timestamp or a date in local time
+func parseImportTimestamp(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %s (must be RFC3339 or YYYY-MM-DD)", s)
+	}
+	return t, nil
+}
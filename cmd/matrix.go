@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// baselineFirst selects the first snapshot of a project as baseline
+const baselineFirst = "first"
+
+var (
+	matrixBaselines []string
+	matrixTo        string
+	matrixOutput    string
+)
+
+var matrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Compare the current state against several baselines at once",
+	Long: `Matrix command compares the latest snapshot, or the one closest to --to, against several
+baselines at once and prints one column per baseline, so a single report shows the changes
+at several time horizons. The baselines are loaded in parallel.
+
+A baseline is a relative range such as "last 1 week", a date or RFC3339 timestamp, or
+"first" for the first snapshot of the project. Prefix it with a label and "=" to name its
+column, e.g. "Kickoff=2024-01-08".
+
+Examples:
+  gh-project-report report matrix -p 123
+  gh-project-report report matrix -p 123 --baseline "last 1 day" --baseline "Sprint start=2024-03-04"
+  gh-project-report report matrix -p 123 --filter "Team=UI" --output html > matrix.html`,
+	RunE: runMatrix,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	diffCmd.AddCommand(matrixCmd)
+	matrixCmd.Flags().StringArrayVar(&matrixBaselines, "baseline", []string{"Yesterday=last 1 day", "Last week=last 1 week", "Last month=last 4 weeks", baselineFirst},
+		"Baseline to compare with, repeatable: a relative range, date, RFC3339 timestamp or \"first\", optionally prefixed with \"label=\"")
+	matrixCmd.Flags().StringVar(&matrixTo, "to", "", "Current state to compare (ISO8601 format, default: latest snapshot)")
+	matrixCmd.Flags().StringVarP(&filter, "filter", "f", "", "Filter items using attribute=value format")
+	matrixCmd.Flags().IntVar(&moderateRisk, "moderate-risk", 7, "Days of delay to consider moderate risk (default: 7)")
+	matrixCmd.Flags().IntVar(&highRisk, "high-risk", 14, "Days of delay to consider high risk (default: 14)")
+	matrixCmd.Flags().IntVar(&extremeRisk, "extreme-risk", 30, "Days of delay to consider extreme risk (default: 30)")
+	matrixCmd.Flags().StringVarP(&matrixOutput, "output", "o", "table", "Output format (table, markdown, html)")
+
+	matrixCmd.RegisterFlagCompletionFunc("to", completeSnapshotTimestamps)
+	matrixCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"table", "markdown", "html"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// matrixBaseline is a parsed --baseline
+type matrixBaseline struct {
+	label     string
+	timestamp time.Time
+}
+
+func runMatrix(cmd *cobra.Command, args []string) error {
+	if matrixOutput != "table" && matrixOutput != "markdown" && matrixOutput != "html" {
+		return fmt.Errorf("invalid output format: %s (must be one of: table, markdown, html)", matrixOutput)
+	}
+	if len(matrixBaselines) == 0 {
+		return fmt.Errorf("at least one --baseline is required")
+	}
+
+	freezes, err := cfg.Report.Freezes()
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	dateLocale, err := reportLocale()
+	if err != nil {
+		return err
+	}
+	reportLang, err := reportLanguage()
+	if err != nil {
+		return err
+	}
+	options, err := format.NewOptions(
+		format.WithLocale(dateLocale),
+		format.WithLanguage(reportLang),
+		format.WithEmojiStyle(emojiStyle()),
+		format.WithModerateDelayThreshold(moderateRisk),
+		format.WithHighDelayThreshold(highRisk),
+		format.WithExtremeDelayThreshold(extremeRisk),
+	)
+	if err != nil {
+		return err
+	}
+
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+	timestamps, err := store.ListTimestamps(projectNumber)
+	if err != nil {
+		return err
+	}
+	if len(timestamps) == 0 {
+		return fmt.Errorf("no snapshots found for project %d", projectNumber)
+	}
+
+	toTime := timestamps[len(timestamps)-1]
+	if matrixTo != "" {
+		if toTime, err = time.Parse(time.RFC3339, matrixTo); err != nil {
+			return fmt.Errorf("invalid 'to' date format (must be ISO8601): %w", err)
+		}
+	}
+
+	baselines := make([]matrixBaseline, len(matrixBaselines))
+	for i, spec := range matrixBaselines {
+		if baselines[i], err = parseMatrixBaseline(spec, timestamps[0], toTime); err != nil {
+			return err
+		}
+	}
+
+	toState, err := store.LoadState(projectNumber, toTime)
+	if err != nil {
+		return fmt.Errorf("failed to load to state: %w", err)
+	}
+	fromStates, err := loadBaselines(store, baselines)
+	if err != nil {
+		return err
+	}
+
+	if filter != "" {
+		if toState, err = toState.FilterState(filter); err != nil {
+			return fmt.Errorf("failed to apply filter to to state: %w", err)
+		}
+	}
+
+	columns := make([]format.MatrixColumn, len(baselines))
+	for i, fromState := range fromStates {
+		if filter != "" {
+			if fromState, err = fromState.FilterState(filter); err != nil {
+				return fmt.Errorf("failed to apply filter to baseline %q: %w", baselines[i].label, err)
+			}
+		}
+		diff := fromState.CompareTo(toState)
+		diff.DiscountFreezes(freezes)
+		columns[i] = format.MatrixColumn{Label: baselines[i].label, Baseline: fromState.Timestamp, Diff: *diff}
+	}
+
+	doc := format.BuildMatrixDocument(columns, options)
+	switch matrixOutput {
+	case "html":
+		fmt.Print((&format.HTMLRenderer{}).RenderDocument(&doc))
+	case "markdown":
+		fmt.Print((&format.MarkdownRenderer{}).RenderDocument(&doc))
+	default:
+		fmt.Print(format.NewCLITableRenderer().RenderDocument(&doc))
+	}
+	return nil
+}
+
+// parseMatrixBaseline parses a baseline given as [label=]spec. The first snapshot
+// resolves "first", relative ranges reach back from the compared state at to.
+func parseMatrixBaseline(spec string, first, to time.Time) (matrixBaseline, error) {
+	label, value, ok := strings.Cut(spec, "=")
+	if !ok {
+		label, value = "", spec
+	}
+	label, value = strings.TrimSpace(label), strings.TrimSpace(value)
+
+	var baseline matrixBaseline
+	switch {
+	case value == baselineFirst:
+		baseline = matrixBaseline{label: "First snapshot", timestamp: first}
+	case strings.HasPrefix(value, "last "):
+		from, now, err := format.ParseHumanRange(value)
+		if err != nil {
+			return matrixBaseline{}, fmt.Errorf("invalid baseline %q: %w", spec, err)
+		}
+		baseline = matrixBaseline{label: strings.TrimPrefix(value, "last "), timestamp: to.Add(from.Sub(now))}
+	default:
+		ts, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if ts, err = time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+				return matrixBaseline{}, fmt.Errorf("invalid baseline %q (must be a relative range, date, RFC3339 timestamp or %q)", spec, baselineFirst)
+			}
+		}
+		baseline = matrixBaseline{label: value, timestamp: ts}
+	}
+
+	if label != "" {
+		baseline.label = label
+	}
+	return baseline, nil
+}
+
+// loadBaselines loads the snapshots closest to the baselines in parallel
+func loadBaselines(store storage.StateStore, baselines []matrixBaseline) ([]*types.ProjectState, error) {
+	states := make([]*types.ProjectState, len(baselines))
+	errs := make([]error, len(baselines))
+
+	var wg sync.WaitGroup
+	for i, baseline := range baselines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			states[i], errs[i] = store.LoadState(projectNumber, baseline.timestamp)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to load baseline %q: %w", baselines[i].label, err)
+		}
+	}
+	return states, nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// statusField is the field holding the status of items, recorded in the palette
+// and checked against status SLAs
+const statusField = "Status"
+
+// updatePalette records the statuses, assignees and labels of the states in the
+// palette of the project, so that later reports keep their order and colors.
+// Failing to read or write the palette only degrades the report, so it is a warning.
+func updatePalette(store storage.StateStore, states ...*types.ProjectState) *types.Palette {
+	palette, err := store.LoadPalette(projectNumber)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return nil
+	}
+
+	changed := false
+	for _, state := range states {
+		changed = palette.ObserveState(state, statusField) || changed
+	}
+	if changed {
+		if err := store.SavePalette(projectNumber, palette); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	return palette
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// applyPreset sets the flags of the preset selected with --preset from the
+// config file. Flags set on the command line take precedence over the preset.
+func applyPreset(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("preset")
+	if flag == nil || flag.Value.String() == "" {
+		return nil
+	}
+	name := flag.Value.String()
+
+	values, err := cfg.Preset(name)
+	if err != nil {
+		return err
+	}
+
+	// Apply in a stable order for deterministic errors
+	flags := make([]string, 0, len(values))
+	for f := range values {
+		flags = append(flags, f)
+	}
+	sort.Strings(flags)
+
+	for _, f := range flags {
+		target := cmd.Flags().Lookup(f)
+		if target == nil || f == "preset" {
+			return fmt.Errorf("invalid flag %q in preset %q", f, name)
+		}
+		if target.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(f, values[f]); err != nil {
+			return fmt.Errorf("invalid value of %q in preset %q: %w", f, name, err)
+		}
+	}
+	return nil
+}
+
+// applyConfigDefaults sets the flags of the command that have defaults in the config
+// file and were set neither on the command line nor by the preset. The output default
+// only applies to diff, as other commands have other output formats.
+func applyConfigDefaults(cmd *cobra.Command) error {
+	values := cfg.Defaults.Flags()
+	if cmd != diffCmd {
+		delete(values, "output")
+	}
+
+	flags := make([]string, 0, len(values))
+	for f := range values {
+		flags = append(flags, f)
+	}
+	sort.Strings(flags)
+
+	for _, f := range flags {
+		target := cmd.Flags().Lookup(f)
+		if target == nil || target.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(f, values[f]); err != nil {
+			return fmt.Errorf("invalid value of %q in the defaults of the config file: %w", strings.ReplaceAll(f, "-", "_"), err)
+		}
+	}
+	return nil
+}
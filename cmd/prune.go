@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	keepDaily   int
+	keepWeekly  int
+	keepMonthly int
+	pruneDryRun bool
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete old snapshots according to a retention policy",
+	Long: `Prune command thins out old snapshots of a project. For each of the most recent
+days, weeks and months with snapshots, the latest snapshot of the period is kept, up to
+the given number of periods. The latest snapshot is always kept, all others are deleted.
+
+Periods are determined in local time and weeks start on Monday. Run compact first to
+keep monthly aggregates of the deleted snapshots.
+
+Examples:
+  gh-project-report prune -p 123 --keep-daily 30 --keep-weekly 12 --keep-monthly 24 --dry-run
+  gh-project-report prune -p 123 --keep-daily 7`,
+	RunE: runPrune,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+	pruneCmd.Flags().IntVar(&keepDaily, "keep-daily", 0, "Number of most recent days to keep the latest snapshot of")
+	pruneCmd.Flags().IntVar(&keepWeekly, "keep-weekly", 0, "Number of most recent weeks to keep the latest snapshot of")
+	pruneCmd.Flags().IntVar(&keepMonthly, "keep-monthly", 0, "Number of most recent months to keep the latest snapshot of")
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Only list the snapshots that would be deleted")
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	policy := storage.RetentionPolicy{Daily: keepDaily, Weekly: keepWeekly, Monthly: keepMonthly}
+	if policy.IsZero() {
+		return fmt.Errorf("at least one of --keep-daily, --keep-weekly or --keep-monthly is required")
+	}
+
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	timestamps, err := store.ListTimestamps(projectNumber)
+	if err != nil {
+		return fmt.Errorf("failed to list states: %w", err)
+	}
+
+	keep, prune := policy.Apply(timestamps, time.Local)
+	for _, ts := range prune {
+		filename, err := store.FindClosestState(projectNumber, ts)
+		if err != nil {
+			return err
+		}
+
+		if pruneDryRun {
+			log.Printf("Would delete %s\n", filename)
+			continue
+		}
+		if err := warnPushFailed(store.DeleteState(filename)); err != nil {
+			return err
+		}
+		log.Printf("Deleted %s\n", filename)
+	}
+
+	verb := "Deleted"
+	if pruneDryRun {
+		verb = "Would delete"
+	}
+	log.Printf("%s %d of %d snapshots of project %d, keeping %d\n", verb, len(prune), len(timestamps), projectNumber, len(keep))
+	return nil
+}
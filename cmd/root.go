@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 
+	"github.com/naag/gh-project-report/pkg/config"
+	"github.com/naag/gh-project-report/pkg/github"
+	"github.com/naag/gh-project-report/pkg/redact"
+	"github.com/naag/gh-project-report/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -15,28 +22,189 @@ var (
 It captures the state of project items periodically and allows you to compare states between different timestamps.`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Secrets from the environment never show up in logs, even if echoed back in errors
+			for _, name := range secretEnvVars {
+				redact.Add(os.Getenv(name))
+			}
+
+			// A missing file given with --config is an error, a missing default file is not
+			var err error
+			path := configPath
+			if path == "" {
+				if path, err = config.DefaultPath(); err != nil {
+					return err
+				}
+			} else if _, err = os.Stat(path); err != nil {
+				return fmt.Errorf("failed to read config file: %w", err)
+			}
+			cfg, err = config.Load(path)
+			if err != nil {
+				return err
+			}
+
+			if err := applyPreset(cmd); err != nil {
+				return err
+			}
+			if err := applyConfigDefaults(cmd); err != nil {
+				return err
+			}
+			// The flag and GH_PROJECT_REPORT_STORE take precedence over the config file
+			if storeLocation == "" {
+				storeLocation = cfg.Defaults.Store
+			}
+			gitCommit = gitCommit || cfg.Defaults.GitCommit
+			if gitPush == "" {
+				gitPush = cfg.Defaults.GitPush
+			}
+
+			if cmd.Annotations[annotationRequiresProject] == "true" && projectNumber == 0 {
+				return fmt.Errorf(`required flag(s) "project-number" not set`)
+			}
+			return nil
+		},
 	}
 
 	// Shared flags
 	verbose       bool
+	configPath    string
 	projectNumber int
+	tokenFile     string
+	storeLocation string
+	gitCommit     bool
+	gitPush       string
+	keyFile       string
+	githubHost    string
+	maxAttempts   int
+
+	// Configuration loaded from the config file
+	cfg = &config.Config{}
+
+	// Operations of all stores opened by the command, logged with --verbose
+	storeMetrics = storage.NewMetrics()
+
+	// Build information, set from main
+	version = "dev"
 )
 
+// secretEnvVars are environment variables holding secrets masked in all output
+var secretEnvVars = []string{"GITHUB_TOKEN", "GH_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "GCS_SECRET_ACCESS_KEY", "SLACK_WEBHOOK_URL", "TEAMS_WEBHOOK_URL", "SMTP_PASSWORD"}
+
+// annotationRequiresProject marks commands that operate on a project and need --project-number
+const annotationRequiresProject = "requires-project"
+
+// exitCodeFailOn is the exit code of reports meeting a condition of --fail-on, to tell
+// them apart from errors
+const exitCodeFailOn = 2
+
+// exitError is an error that exits with a specific code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// SetVersion sets the version reported by the CLI
+func SetVersion(v string) {
+	if v != "" {
+		version = v
+		rootCmd.Version = v
+	}
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	err := rootCmd.Execute()
+	if verbose && len(storeMetrics.Operations()) > 0 {
+		log.Print(storeMetrics.Summary())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, redact.String(err.Error()))
+		var exit *exitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.code)
+		}
 		os.Exit(1)
 	}
 }
 
+// newStore opens the store of --store, recording its operations in storeMetrics,
+// encrypting states with the key of loadStoreKey and committing saved states to git
+// with --git-commit or --git-push. With --verbose, failures that don't fail operations
+// are logged.
+func newStore(opts ...storage.StoreOption) (storage.StateStore, error) {
+	key, err := loadStoreKey()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, storage.WithMetrics(storeMetrics), storage.WithEncryption(key), storage.WithGit(gitCommit, gitPush))
+	if verbose {
+		opts = append(opts, storage.WithLogger(log.Default()))
+	}
+	return storage.NewStore(storeLocation, opts...)
+}
+
+// warnPushFailed logs a *storage.PushError as a warning and returns nil for it, as the
+// snapshot was saved or deleted and retrying would duplicate it. Other errors are
+// returned unchanged.
+func warnPushFailed(err error) error {
+	var pushErr *storage.PushError
+	if errors.As(err, &pushErr) {
+		log.Printf("Warning: %v; the commit is pushed with the next one\n", err)
+		return nil
+	}
+	return err
+}
+
+// loadStoreKey loads the key encrypting states from --key-file, GH_PROJECT_REPORT_KEY
+// or the key file of the config file, in this order. It returns nil without a key.
+// The key is loaded when a store is opened, so commands without a store work with a
+// broken key, and it is masked in all output.
+func loadStoreKey() ([]byte, error) {
+	var key []byte
+	var err error
+	switch encoded := os.Getenv("GH_PROJECT_REPORT_KEY"); {
+	case keyFile != "":
+		key, err = storage.ReadKeyFile(keyFile)
+	case encoded != "":
+		if key, err = storage.ParseKey(encoded); err != nil {
+			err = fmt.Errorf("invalid GH_PROJECT_REPORT_KEY: %w", err)
+		}
+	case cfg.Defaults.KeyFile != "":
+		key, err = storage.ReadKeyFile(cfg.Defaults.KeyFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		redact.Add(base64.StdEncoding.EncodeToString(key))
+	}
+	return key, nil
+}
+
 func init() {
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
+	log.SetOutput(redact.Writer(os.Stderr))
 
-	rootCmd.PersistentFlags().IntVarP(&projectNumber, "project-number", "p", 0, "GitHub Project number (required)")
-	rootCmd.MarkPersistentFlagRequired("project-number")
+	rootCmd.PersistentFlags().IntVarP(&projectNumber, "project-number", "p", 0, "GitHub Project number (required for project commands)")
+	rootCmd.RegisterFlagCompletionFunc("project-number", completeProjectNumbers)
 
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file (default: $XDG_CONFIG_HOME/gh-project-report/config.yaml or ~/.config/gh-project-report/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&storeLocation, "store", os.Getenv("GH_PROJECT_REPORT_STORE"), "Where states are stored: a directory, s3://bucket/prefix or gs://bucket/prefix (default: $GH_PROJECT_REPORT_STORE, the config file or current directory)")
+	rootCmd.PersistentFlags().BoolVar(&gitCommit, "git-commit", false, "Commit each saved and deleted state to the git repository of a local store, initializing it if needed")
+	rootCmd.PersistentFlags().StringVar(&gitPush, "git-push", "", "Push each commit of a state to this git remote, implies --git-commit")
+	rootCmd.PersistentFlags().StringVar(&keyFile, "key-file", "", "Encrypt new states with the base64 encoded AES-256 key in this file and decrypt encrypted states (default: $GH_PROJECT_REPORT_KEY or the config file)")
+	rootCmd.PersistentFlags().StringVar(&githubHost, "github-host", os.Getenv("GH_HOST"), "GitHub Enterprise host or GraphQL endpoint, e.g. github.mycorp.com (default: $GH_HOST, the config file or github.com)")
+	rootCmd.PersistentFlags().IntVar(&maxAttempts, "max-attempts", github.DefaultRetryPolicy().MaxAttempts, "Attempts of GitHub API requests failing with rate limits, 5xx responses or network errors (1 disables retries)")
+	rootCmd.PersistentFlags().StringVar(&tokenFile, "token-file", "", "Read the GitHub token from a file instead of GITHUB_TOKEN or GH_TOKEN")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose debug output")
 }
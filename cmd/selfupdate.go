@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/naag/gh-project-report/pkg/update"
+	"github.com/spf13/cobra"
+)
+
+const releaseRepository = "naag/gh-project-report"
+
+var (
+	checkOnly   bool
+	forceUpdate bool
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update gh-project-report to the latest release",
+	Long: `Self-update checks the latest release of gh-project-report on GitHub and
+replaces the running binary with the release binary for this platform, found by the
+<os>-<arch> suffix of its name like gh finds precompiled extensions, e.g.
+gh-project-report-linux-amd64 as built by "make release". Requests time out after
+5 minutes.
+
+The downloaded binary is verified against the SHA-256 checksums published with
+the release before it is installed. This detects corrupted or incomplete downloads,
+but releases aren't signed: the checksums come from the same release as the binary,
+so they don't protect against a compromised release or repository.
+
+Examples:
+  gh-project-report self-update
+  gh-project-report self-update --check`,
+	RunE: runSelfUpdate,
+}
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+
+	selfUpdateCmd.Flags().BoolVar(&checkOnly, "check", false, "Only check whether a newer release is available")
+	selfUpdateCmd.Flags().BoolVar(&forceUpdate, "force", false, "Install the latest release even if it matches the current version")
+}
+
+func runSelfUpdate(cmd *cobra.Command, args []string) error {
+	updater := update.NewUpdater(nil, releaseRepository)
+
+	release, err := updater.LatestRelease()
+	if err != nil {
+		return err
+	}
+
+	if release.TagName == version && !forceUpdate {
+		fmt.Printf("Already up to date (%s)\n", version)
+		return nil
+	}
+
+	if checkOnly {
+		fmt.Printf("New release available: %s (current: %s)\n", release.TagName, version)
+		if release.HTMLURL != "" {
+			fmt.Println(release.HTMLURL)
+		}
+		return nil
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate running binary: %w", err)
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return fmt.Errorf("failed to resolve running binary: %w", err)
+	}
+
+	data, err := updater.DownloadVerified(release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+
+	err = update.ReplaceExecutable(executable, data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated %s from %s to %s\n", executable, version, release.TagName)
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr     string
+	metricsWindow time.Duration
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a web dashboard of the stored snapshots",
+	Long: `Serve command starts an HTTP server to browse the history of all projects in the store:
+the snapshots of each project, all items of a single snapshot and HTML reports of the
+changes between two selected snapshots. Metrics of the store (calls, errors, latency
+and bytes read) and the health of each project (items per status, items per delay level
+and slip days within --metrics-window) are served at /metrics in the Prometheus text format.
+
+Report sections, cause labels and freeze windows of the config file apply to the reports.
+The server has no authentication, so it listens on localhost by default.
+
+Examples:
+  gh-project-report serve
+  gh-project-report serve --addr :8080 --store s3://my-bucket/reports`,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Address to listen on")
+	serveCmd.Flags().DurationVar(&metricsWindow, "metrics-window", 7*24*time.Hour, "Delays in /metrics are measured against the latest snapshot at least this much older")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	freezes, err := cfg.Report.Freezes()
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	dateLocale, err := format.ParseLocale(cfg.Report.Locale)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	reportLang, err := format.ParseLanguage(cfg.Report.Lang)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	handler := server.New(store,
+		format.WithLocale(dateLocale),
+		format.WithLanguage(reportLang),
+		format.WithEmojiStyle(emojiStyle()),
+		format.WithSections(cfg.Report.Sections),
+		format.WithCauseLabels(cfg.Report.CauseLabels),
+		format.WithFreezeWindows(freezes),
+	)
+	handler.HandleMetrics(storeMetrics, &server.ProjectMetrics{Store: store, Window: metricsWindow, StatusField: statusField})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	srv := &http.Server{Addr: serveAddr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+	log.Printf("Serving snapshots on http://%s\n", serveAddr)
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var showOutput string
+
+var showCmd = &cobra.Command{
+	Use:   "show [latest|TIMESTAMP|FILE]",
+	Short: "Show all items of a single snapshot",
+	Long: `Show command renders one captured snapshot with the timeline and attributes of
+all its items. The snapshot is chosen by "latest" (default), by an RFC3339 timestamp,
+which selects the closest snapshot, or by the file name listed by "states list".
+
+Examples:
+  gh-project-report show -p 123
+  gh-project-report show -p 123 2024-01-01T00:00:00Z --output markdown
+  gh-project-report show -p 123 states/project=123/1704067200.json --output json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runShow,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+	showCmd.Flags().StringVarP(&showOutput, "output", "o", "table", "Output format (table, markdown, json)")
+
+	showCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"table", "markdown", "json"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	if showOutput != "table" && showOutput != "markdown" && showOutput != "json" {
+		return fmt.Errorf("invalid output format: %s (must be one of: table, markdown, json)", showOutput)
+	}
+
+	selector := "latest"
+	if len(args) > 0 {
+		selector = args[0]
+	}
+
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	state, err := loadSelectedState(store, selector)
+	if err != nil {
+		return err
+	}
+
+	switch showOutput {
+	case "json":
+		output, err := format.FormatStateJSON(state)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+	case "markdown":
+		doc := format.BuildStateDocument(state, format.DefaultDurationStyle())
+		fmt.Print((&format.MarkdownRenderer{}).RenderDocument(&doc))
+	default:
+		doc := format.BuildStateDocument(state, format.DefaultDurationStyle())
+		fmt.Print(format.NewCLITableRenderer().RenderDocument(&doc))
+	}
+	return nil
+}
+
+// loadSelectedState loads the snapshot selected by "latest", an RFC3339 timestamp or a file name
+func loadSelectedState(store storage.StateStore, selector string) (*types.ProjectState, error) {
+	if selector == "latest" {
+		timestamps, err := store.ListTimestamps(projectNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list states: %w", err)
+		}
+		if len(timestamps) == 0 {
+			return nil, fmt.Errorf("no state files found for project %d", projectNumber)
+		}
+		selector = timestamps[len(timestamps)-1].Format(time.RFC3339)
+	}
+
+	if timestamp, err := time.Parse(time.RFC3339, selector); err == nil {
+		state, err := store.LoadState(projectNumber, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load state: %w", err)
+		}
+		return state, nil
+	}
+
+	state, err := store.LoadStateFile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+	return state, nil
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"syscall"
+)
+
+// captureSignals are the signals that trigger an immediate capture in watch mode
+var captureSignals = []os.Signal{syscall.SIGUSR1}
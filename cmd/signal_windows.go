@@ -0,0 +1,9 @@
+//go:build windows
+
+package cmd
+
+import "os"
+
+// captureSignals are the signals that trigger an immediate capture in watch mode.
+// Windows has no user-defined signals.
+var captureSignals []os.Signal
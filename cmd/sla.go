@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// validateStatusSLAs checks the configured status SLAs
+func validateStatusSLAs(slas map[string]int) error {
+	for status, days := range slas {
+		if days < 0 {
+			return fmt.Errorf("invalid config: status SLA of %q must not be negative", status)
+		}
+	}
+	return nil
+}
+
+// collectSLAViolations replays the status history of all snapshots before the
+// state, loading one at a time, and returns the items of the state that have been
+// in a status for longer than its SLA at the time of the state
+func collectSLAViolations(store storage.StateStore, state *types.ProjectState, slas map[string]int) ([]types.SLAViolation, error) {
+	timestamps, err := store.ListTimestamps(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := types.NewStatusTracker(statusField)
+	for _, ts := range timestamps {
+		if !ts.Before(state.Timestamp) {
+			break
+		}
+		previous, err := store.LoadState(projectNumber, ts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load state: %w", err)
+		}
+		tracker.Observe(previous)
+	}
+	tracker.Observe(state)
+
+	return tracker.Violations(state, slas, state.Timestamp), nil
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var statesOutput string
+
+var statesCmd = &cobra.Command{
+	Use:   "states",
+	Short: "Inspect captured snapshots",
+}
+
+var statesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List captured snapshots of a project",
+	Long: `List command enumerates the stored snapshots of a project with their timestamp,
+number of items and stored size, oldest first.
+
+Examples:
+  gh-project-report states list -p 123
+  gh-project-report states list -p 123 --output json | jq '.[-1].filename'`,
+	RunE: runStatesList,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+var statesReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuild the index of the snapshots of a project",
+	Long: `Reindex command rebuilds meta/index.json of a project, which lists its snapshots with
+their timestamp, number of items, size and checksum so that they are looked up
+without reading every snapshot. The index is updated when snapshots are captured or
+pruned, and refreshed automatically when snapshot files were added or deleted by other
+means. Lookups trust it otherwise, so run reindex after editing snapshot files in
+place. Unlike the automatic refresh, which only logs failures to save the index with
+--verbose, reindex reloads every snapshot and fails if the index can't be written.
+
+Examples:
+  gh-project-report states reindex -p 123`,
+	RunE: runStatesReindex,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statesCmd)
+	statesCmd.AddCommand(statesListCmd)
+	statesCmd.AddCommand(statesReindexCmd)
+	statesListCmd.Flags().StringVarP(&statesOutput, "output", "o", "table", "Output format (table, json)")
+
+	statesListCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"table", "json"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runStatesList(cmd *cobra.Command, args []string) error {
+	if statesOutput != "table" && statesOutput != "json" {
+		return fmt.Errorf("invalid output format: %s (must be one of: table, json)", statesOutput)
+	}
+
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	states, err := store.ListStates(projectNumber)
+	if err != nil {
+		return fmt.Errorf("failed to list states: %w", err)
+	}
+
+	// Item counts are loaded from the snapshots unless the store indexes them
+	snapshots := make([]format.Snapshot, len(states))
+	for i, info := range states {
+		items, err := storage.CountItems(store, info)
+		if err != nil {
+			return err
+		}
+		snapshots[i] = format.Snapshot{
+			Timestamp: info.Timestamp,
+			Filename:  info.Filename,
+			Items:     items,
+			Size:      info.Size,
+		}
+	}
+
+	if statesOutput == "json" {
+		output, err := format.FormatSnapshotsJSON(snapshots)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+		return nil
+	}
+
+	if len(snapshots) == 0 {
+		fmt.Printf("No snapshots found for project %d\n", projectNumber)
+		return nil
+	}
+	doc := format.BuildSnapshotsDocument(projectNumber, snapshots)
+	fmt.Print(format.NewCLITableRenderer().RenderDocument(&doc))
+
+	// Captures skipped as unchanged leave a heartbeat newer than the latest snapshot
+	heartbeat, err := store.LoadHeartbeat(projectNumber)
+	if err != nil {
+		return err
+	}
+	if heartbeat != nil && heartbeat.Timestamp.After(snapshots[len(snapshots)-1].Timestamp) {
+		fmt.Printf("\nLast checked %s, unchanged since the latest snapshot\n", heartbeat.Timestamp.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func runStatesReindex(cmd *cobra.Command, args []string) error {
+	key, err := loadStoreKey()
+	if err != nil {
+		return err
+	}
+	store, err := storage.NewStore(storeLocation, storage.WithEncryption(key))
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+	fileStore, ok := store.(*storage.Store)
+	if !ok {
+		return fmt.Errorf("reindex only supports local storage, not %s", storeLocation)
+	}
+
+	index, err := fileStore.RebuildIndex(projectNumber)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild index: %w", err)
+	}
+	fmt.Printf("Indexed %d snapshots of project %d\n", len(index.States), projectNumber)
+	return nil
+}
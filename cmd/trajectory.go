@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// trajectorySteps are the values of --steps, as the years, months and days between two
+// steps
+var trajectorySteps = map[string][3]int{
+	"daily":   {0, 0, 1},
+	"weekly":  {0, 0, 7},
+	"monthly": {0, 1, 0},
+}
+
+// parseSteps returns the calendar offset between two steps of --steps
+func parseSteps(s string) ([3]int, error) {
+	step, ok := trajectorySteps[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return step, fmt.Errorf("invalid --steps: %s (must be one of: daily, weekly, monthly)", s)
+	}
+	return step, nil
+}
+
+// stepTimestamps picks the latest snapshot at or before each step from the start of
+// the range, strictly between from and to, without repeating a snapshot
+func stepTimestamps(timestamps []time.Time, from, to time.Time, step [3]int) []time.Time {
+	var picked []time.Time
+	for boundary := from.AddDate(step[0], step[1], step[2]); boundary.Before(to); boundary = boundary.AddDate(step[0], step[1], step[2]) {
+		var latest time.Time
+		for _, ts := range timestamps {
+			if ts.After(boundary) {
+				break
+			}
+			latest = ts
+		}
+		if !latest.After(from) || !latest.Before(to) {
+			continue
+		}
+		if len(picked) > 0 && picked[len(picked)-1].Equal(latest) {
+			continue
+		}
+		picked = append(picked, latest)
+	}
+	return picked
+}
+
+// collectTrajectories loads the snapshots of each step between the compared states,
+// filtered like them, and returns when the dates of items moved across the sequence.
+// The login restricts the snapshots to items of the user, unless empty.
+func collectTrajectories(store storage.StateStore, fromState, toState *types.ProjectState, step [3]int, login string) ([]types.ItemTrajectory, error) {
+	timestamps, err := store.ListTimestamps(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	states := []*types.ProjectState{fromState}
+	for _, ts := range stepTimestamps(timestamps, fromState.Timestamp, toState.Timestamp, step) {
+		state, err := store.LoadState(projectNumber, ts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load state: %w", err)
+		}
+		if filter != "" {
+			if state, err = state.FilterState(filter); err != nil {
+				return nil, fmt.Errorf("failed to apply filter to intermediate state: %w", err)
+			}
+		}
+		if login != "" {
+			state = state.FilterByUser(login)
+		}
+		states = append(states, state)
+	}
+	states = append(states, toState)
+
+	return types.Trajectories(states), nil
+}
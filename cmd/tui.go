@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse snapshots and their changes interactively",
+	Long: `TUI command opens an interactive terminal view of the stored snapshots of a project.
+Pick two snapshots to list the added, removed and changed items between them, and
+expand an item to see all its field changes, which tables of large projects truncate
+or scroll off-screen.
+
+Keys: ↑/↓ or j/k move, enter or space picks a snapshot or expands an item, esc goes
+back and q quits. Locale, language, emoji and freeze windows of the config file apply.
+
+Examples:
+  gh-project-report tui -p 123`,
+	RunE: runTUI,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	freezes, err := cfg.Report.Freezes()
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	dateLocale, err := format.ParseLocale(cfg.Report.Locale)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	reportLang, err := format.ParseLanguage(cfg.Report.Lang)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	model, err := tui.New(store, projectNumber,
+		format.WithLocale(dateLocale),
+		format.WithLanguage(reportLang),
+		format.WithEmojiStyle(emojiStyle()),
+		format.WithFreezeWindows(freezes),
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("failed to run tui: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	velocityRange       string
+	velocityStatus      string
+	velocityDone        []string
+	velocityWeight      string
+	velocityPeriodWeeks int
+	velocityPeriodStart string
+	velocityOutput      string
+)
+
+var velocityCmd = &cobra.Command{
+	Use:   "velocity",
+	Short: "Chart work moved to done per week or iteration",
+	Long: `Velocity command compares consecutive snapshots in a time range and counts the items
+that moved to a done status, or were added as done, between them. Completions are summed
+per week, or per iteration of --period-weeks weeks starting at --period-start. Use
+--weight-field to sum a numeric field such as story points instead of counting items.
+
+Completions are attributed to the snapshot in which they were first seen as done, so
+the resolution of the report is the capture interval.
+
+Examples:
+  gh-project-report report velocity -p 123 --range "last 3 months"
+  gh-project-report report velocity -p 123 --weight-field Points --period-weeks 2 --period-start 2024-01-08
+  gh-project-report report velocity -p 123 --output mermaid`,
+	RunE: runVelocity,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	diffCmd.AddCommand(velocityCmd)
+	velocityCmd.Flags().StringVarP(&velocityRange, "range", "r", "", "Only include snapshots in this time range (e.g. \"last 3 months\")")
+	velocityCmd.Flags().StringVar(&velocityStatus, "status-field", "Status", "Field containing the status of items")
+	velocityCmd.Flags().StringSliceVar(&velocityDone, "done", []string{"Done"}, "Status values of done items")
+	addWeightFieldFlag(velocityCmd, &velocityWeight, "Numeric field to sum instead of counting items (e.g. Points)")
+	velocityCmd.Flags().IntVar(&velocityPeriodWeeks, "period-weeks", 1, "Length of a period in weeks, e.g. 2 for two-week iterations")
+	velocityCmd.Flags().StringVar(&velocityPeriodStart, "period-start", "", "First day of the first period (YYYY-MM-DD, default: Monday of the first snapshot)")
+	velocityCmd.Flags().StringVarP(&velocityOutput, "output", "o", "table", "Output format (table, markdown, mermaid)")
+
+	velocityCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"table", "markdown", "mermaid"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runVelocity(cmd *cobra.Command, args []string) error {
+	if velocityOutput != "table" && velocityOutput != "markdown" && velocityOutput != "mermaid" {
+		return fmt.Errorf("invalid output format: %s (must be one of: table, markdown, mermaid)", velocityOutput)
+	}
+	if velocityPeriodWeeks < 1 {
+		return fmt.Errorf("invalid period: --period-weeks must be at least 1")
+	}
+
+	var periodStart time.Time
+	if velocityPeriodStart != "" {
+		var err error
+		periodStart, err = time.ParseInLocation("2006-01-02", velocityPeriodStart, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid period start: %w", err)
+		}
+	}
+
+	var from, to time.Time
+	if velocityRange != "" {
+		var err error
+		from, to, err = format.ParseHumanRange(velocityRange)
+		if err != nil {
+			return fmt.Errorf("error parsing time range: %w", err)
+		}
+	}
+
+	store, err := newStore()
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	samples, err := collectVelocity(store, from, to, velocityStatus, velocityDone, velocityWeight)
+	if err != nil {
+		return err
+	}
+	if len(samples) == 0 {
+		return fmt.Errorf("at least two snapshots are needed for project %d", projectNumber)
+	}
+
+	if periodStart.IsZero() {
+		periodStart = types.StartOfWeek(samples[0].Timestamp)
+	}
+	periods := types.BucketVelocity(samples, periodStart, time.Duration(velocityPeriodWeeks)*7*24*time.Hour)
+	if len(periods) == 0 {
+		return fmt.Errorf("no snapshots found after the period start %s", velocityPeriodStart)
+	}
+
+	dateLocale, err := reportLocale()
+	if err != nil {
+		return err
+	}
+	options := format.DefaultOptions()
+	options.Locale = dateLocale
+	switch velocityOutput {
+	case "mermaid":
+		fmt.Print(format.FormatVelocityMermaid(periods, velocityWeight, options))
+	case "markdown":
+		doc := format.BuildVelocityDocument(periods, velocityWeight, options)
+		fmt.Print((&format.MarkdownRenderer{}).RenderDocument(&doc))
+	default:
+		doc := format.BuildVelocityDocument(periods, velocityWeight, options)
+		fmt.Print(format.NewCLITableRenderer().RenderDocument(&doc))
+	}
+	return nil
+}
+
+// collectVelocity compares every snapshot between from and to with the one before it,
+// keeping only two snapshots loaded at a time. Zero times leave the range open.
+func collectVelocity(store storage.StateStore, from, to time.Time, statusField string, doneValues []string, weightField string) ([]types.VelocitySample, error) {
+	timestamps, err := store.ListTimestamps(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []types.VelocitySample
+	var prev *types.ProjectState
+	for _, ts := range timestamps {
+		if (!from.IsZero() && ts.Before(from)) || (!to.IsZero() && ts.After(to)) {
+			continue
+		}
+		state, err := store.LoadState(projectNumber, ts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load state: %w", err)
+		}
+		if prev != nil {
+			samples = append(samples, state.Velocity(prev, statusField, doneValues, weightField))
+		}
+		prev = state
+	}
+	return samples, nil
+}
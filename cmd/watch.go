@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/server"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchInterval time.Duration
+	dashboard     bool
+	metricsAddr   string
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Capture the project state periodically",
+	Long: `Watch command captures the state of a GitHub Project at a fixed interval until interrupted.
+
+Send SIGUSR1 to the process to capture immediately without waiting for the next
+interval, e.g. right before and after a planning meeting:
+  kill -USR1 <pid>
+
+Use --dashboard to show a live terminal view with the latest capture time, item counts,
+a gauge of items past their end date and a scrolling log of recent changes.
+
+Use --metrics-addr to serve /metrics in the Prometheus text format while watching: the
+health of the project (items per status, items per delay level and slip days within
+--metrics-window) and the captures (successes, failures and the latest duration).
+
+Examples:
+  gh-project-report watch -p 123 --interval 15m
+  gh-project-report watch -p 123 --interval 5m --dashboard
+  gh-project-report watch -p 123 --interval 15m --metrics-addr :9090`,
+	RunE: runWatch,
+	Annotations: map[string]string{
+		annotationRequiresProject: "true",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 15*time.Minute, "Time between captures")
+	watchCmd.Flags().BoolVar(&dashboard, "dashboard", false, "Show a live terminal dashboard")
+	watchCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics of the project and captures at /metrics, e.g. :9090 (default: disabled)")
+	watchCmd.Flags().DurationVar(&metricsWindow, "metrics-window", 7*24*time.Hour, "Delays in /metrics are measured against the latest snapshot at least this much older")
+	watchCmd.Flags().StringVar(&startField, "start-field", "Start", "Field name containing start date, or @iteration to schedule items by their iteration")
+	watchCmd.Flags().StringVar(&endField, "end-field", "End", "Field name containing end date, or @milestone to end items on the due date of their milestone")
+	watchCmd.Flags().StringVarP(&organization, "organization", "o", "", "GitHub organization name (optional)")
+	watchCmd.Flags().StringVar(&timezone, "timezone", "", "IANA timezone of the project used to interpret date fields (e.g. Europe/Berlin)")
+	watchCmd.Flags().BoolVar(&compress, "compress", false, "Write states gzip compressed (*.json.gz)")
+	watchCmd.Flags().BoolVar(&skipUnchanged, "skip-unchanged", false, "Don't save states with the same content as the latest snapshot (default: the config file)")
+	watchCmd.Flags().BoolVar(&recordHeartbeat, "heartbeat", false, "Record the time of captures skipped by --skip-unchanged (default: the config file)")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if watchInterval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	store, err := newStore(storage.WithCompression(compress))
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// The dashboard owns the terminal, so log output goes to its change log instead
+	var view *format.Dashboard
+	if dashboard {
+		view = format.NewDashboard(projectNumber, watchInterval)
+		log.SetOutput(io.Discard)
+		defer log.SetOutput(os.Stderr)
+		if err := view.Render(os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	captures := &server.CaptureMetrics{}
+	if metricsAddr != "" {
+		shutdown, err := serveWatchMetrics(store, captures)
+		if err != nil {
+			return err
+		}
+		defer shutdown()
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	// Signals trigger an out-of-schedule capture
+	trigger := make(chan os.Signal, 1)
+	if len(captureSignals) > 0 {
+		signal.Notify(trigger, captureSignals...)
+		defer signal.Stop(trigger)
+	}
+
+	var previous *types.ProjectState
+	var previousFilename string
+	for {
+		start := time.Now()
+		state, filename, err := captureState(cmd, store)
+		captures.Record(start, err)
+		switch {
+		case err != nil && view != nil:
+			view.LogError(time.Now(), err)
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Capture failed: %v\n", err)
+		case view != nil:
+			var diff *types.ProjectDiff
+			if previous != nil {
+				diff = previous.CompareTo(state)
+			}
+			if err := view.Update(state, diff); err != nil {
+				view.LogError(time.Now(), err)
+			}
+		case filename == previousFilename:
+			// Skipped as unchanged, already logged
+		default:
+			fmt.Fprintf(os.Stderr, "State captured and saved to %s\n", filename)
+		}
+		if err == nil {
+			previous, previousFilename = state, filename
+		}
+
+		if view != nil {
+			if err := view.Render(os.Stdout); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case sig := <-trigger:
+			if view != nil {
+				view.LogMessage(time.Now(), fmt.Sprintf("Capture triggered by %s", sig))
+			} else {
+				fmt.Fprintf(os.Stderr, "Capture triggered by %s\n", sig)
+			}
+		}
+	}
+}
+
+// serveWatchMetrics serves the metrics of the project, the captures and the store at
+// --metrics-addr in the background. The returned function shuts the server down.
+func serveWatchMetrics(store storage.StateStore, captures *server.CaptureMetrics) (func(), error) {
+	listener, err := net.Listen("tcp", metricsAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serve metrics: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", server.MetricsHandler(
+		&server.ProjectMetrics{Store: store, Projects: []int{projectNumber}, Window: metricsWindow, StatusField: statusField},
+		captures,
+		storeMetrics,
+	))
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Failed to serve metrics: %v\n", err)
+		}
+	}()
+	log.Printf("Serving metrics on http://%s/metrics\n", listener.Addr())
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}, nil
+}
@@ -9,5 +9,6 @@ var (
 )
 
 func main() {
+	cmd.SetVersion(Version)
 	cmd.Execute()
 }
@@ -0,0 +1,142 @@
+// Package actions reads the inputs of a GitHub Actions step and writes its outputs
+// and job summary.
+package actions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// inputPrefix prefixes the environment variables GitHub sets for the inputs of an action
+const inputPrefix = "INPUT_"
+
+// Inputs returns the non-empty inputs of an action from its INPUT_* environment
+// variables, by lower case name. GitHub sets a variable for every declared input, so
+// empty values are inputs that were not given.
+func Inputs(environ []string) map[string]string {
+	inputs := map[string]string{}
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, inputPrefix) || name == inputPrefix {
+			continue
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			inputs[strings.ToLower(strings.TrimPrefix(name, inputPrefix))] = value
+		}
+	}
+	return inputs
+}
+
+// FormatOutputs formats outputs in the syntax of the GITHUB_OUTPUT file, sorted by name.
+// Multiline values are written between delimiters that do not occur in the value.
+func FormatOutputs(outputs map[string]string) (string, error) {
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		if strings.ContainsAny(name, "=\n") {
+			return "", fmt.Errorf("invalid output name: %q", name)
+		}
+		value := outputs[name]
+		if !strings.Contains(value, "\n") {
+			fmt.Fprintf(&b, "%s=%s\n", name, value)
+			continue
+		}
+
+		delimiter, err := newDelimiter(value)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", name, delimiter, strings.TrimSuffix(value, "\n"), delimiter)
+	}
+	return b.String(), nil
+}
+
+// WriteOutputs appends outputs to the GITHUB_OUTPUT file at path
+func WriteOutputs(path string, outputs map[string]string) error {
+	content, err := FormatOutputs(outputs)
+	if err != nil {
+		return err
+	}
+	if err := appendFile(path, content); err != nil {
+		return fmt.Errorf("failed to write outputs: %w", err)
+	}
+	return nil
+}
+
+// AppendSummary appends Markdown to the job summary file at path
+func AppendSummary(path, markdown string) error {
+	if !strings.HasSuffix(markdown, "\n") {
+		markdown += "\n"
+	}
+	if err := appendFile(path, markdown); err != nil {
+		return fmt.Errorf("failed to write job summary: %w", err)
+	}
+	return nil
+}
+
+// AnnotationLevel is the level of an annotation, shown on the summary page of a workflow run
+type AnnotationLevel string
+
+const (
+	AnnotationNotice  AnnotationLevel = "notice"
+	AnnotationWarning AnnotationLevel = "warning"
+	AnnotationError   AnnotationLevel = "error"
+)
+
+// Annotation is a message shown on the summary page of a workflow run
+type Annotation struct {
+	Level   AnnotationLevel
+	Title   string
+	Message string
+}
+
+// String formats the annotation as a workflow command, e.g. "::warning title=Delay::Migrate DB"
+func (a Annotation) String() string {
+	command := "::" + string(a.Level)
+	if a.Title != "" {
+		command += " title=" + propertyEscaper.Replace(a.Title)
+	}
+	return command + "::" + dataEscaper.Replace(a.Message)
+}
+
+// dataEscaper escapes the message of a workflow command
+var dataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+// propertyEscaper escapes the properties of a workflow command
+var propertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+// newDelimiter returns a random delimiter of a multiline value that is not part of it
+func newDelimiter(value string) (string, error) {
+	for {
+		buf := make([]byte, 8)
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("failed to generate delimiter: %w", err)
+		}
+		delimiter := "ghadelimiter_" + hex.EncodeToString(buf)
+		if !strings.Contains(value, delimiter) {
+			return delimiter, nil
+		}
+	}
+}
+
+// appendFile appends content to the file at path, creating it if needed
+func appendFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
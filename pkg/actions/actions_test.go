@@ -0,0 +1,82 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputs(t *testing.T) {
+	environ := []string{
+		"INPUT_PROJECT_NUMBER=42",
+		"INPUT_RANGE= last 1 week ",
+		"INPUT_OUTPUT=",
+		"INPUT_=ignored",
+		"HOME=/root",
+		"MALFORMED",
+	}
+
+	assert.Equal(t, map[string]string{
+		"project_number": "42",
+		"range":          "last 1 week",
+	}, Inputs(environ))
+}
+
+func TestFormatOutputs(t *testing.T) {
+	out, err := FormatOutputs(map[string]string{
+		"report":  "# Report\n\n- item\n",
+		"added":   "3",
+		"removed": "0",
+	})
+	require.NoError(t, err)
+
+	assert.Regexp(t, regexp.MustCompile(`^added=3\nremoved=0\nreport<<(ghadelimiter_[0-9a-f]{16})\n# Report\n\n- item\n(ghadelimiter_[0-9a-f]{16})\n$`), out)
+	m := regexp.MustCompile(`<<(\S+)\n[\s\S]*\n(\S+)\n$`).FindStringSubmatch(out)
+	require.Len(t, m, 3)
+	assert.Equal(t, m[1], m[2])
+}
+
+func TestFormatOutputsInvalidName(t *testing.T) {
+	_, err := FormatOutputs(map[string]string{"a=b": "1"})
+	assert.EqualError(t, err, `invalid output name: "a=b"`)
+}
+
+func TestWriteOutputsAndSummary(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "output")
+	summaryPath := filepath.Join(dir, "summary")
+
+	require.NoError(t, os.WriteFile(outputPath, []byte("previous=1\n"), 0o644))
+	require.NoError(t, WriteOutputs(outputPath, map[string]string{"changed": "2"}))
+	require.NoError(t, AppendSummary(summaryPath, "# Report"))
+	require.NoError(t, AppendSummary(summaryPath, "More\n"))
+
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "previous=1\nchanged=2\n", string(data))
+
+	data, err = os.ReadFile(summaryPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# Report\nMore\n", string(data))
+}
+
+func TestAnnotationString(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation Annotation
+		expected   string
+	}{
+		{"with title", Annotation{Level: AnnotationWarning, Title: "Extreme delay", Message: "Migrate DB"}, "::warning title=Extreme delay::Migrate DB"},
+		{"without title", Annotation{Level: AnnotationNotice, Message: "done"}, "::notice::done"},
+		{"escaped", Annotation{Level: AnnotationError, Title: "a, b: c", Message: "100% done\nnext"}, "::error title=a%2C b%3A c::100%25 done%0Anext"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.annotation.String())
+		})
+	}
+}
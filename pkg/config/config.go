@@ -0,0 +1,324 @@
+// Package config loads the gh-project-report configuration file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/forecast"
+	"github.com/naag/gh-project-report/pkg/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config represents the configuration file
+type Config struct {
+	Report ReportConfig `yaml:"report"`
+	Hooks  HooksConfig  `yaml:"hooks"`
+	GitHub GitHubConfig `yaml:"github"`
+
+	// Defaults contains the values of common flags when not given on the command line
+	Defaults DefaultsConfig `yaml:"defaults"`
+
+	// Capture contains the defaults of capturing snapshots
+	Capture CaptureConfig `yaml:"capture"`
+
+	// Notify contains the settings of notification targets of --notify
+	Notify NotifyConfig `yaml:"notify"`
+
+	// Capacity describes the availability of the team for capacity-aware forecasts
+	Capacity CapacityConfig `yaml:"capacity"`
+
+	// Presets maps preset names to flag values of the diff command, e.g. range: last 1 week
+	Presets map[string]map[string]interface{} `yaml:"presets"`
+}
+
+// GitHubConfig contains the settings of the connection to GitHub
+type GitHubConfig struct {
+	// Host is the GitHub Enterprise host or GraphQL endpoint, e.g. github.mycorp.com. Empty uses github.com.
+	Host string `yaml:"host"`
+}
+
+// DefaultsConfig contains the defaults of flags shared by several commands. Flags given
+// on the command line and presets take precedence.
+type DefaultsConfig struct {
+	ProjectNumber int    `yaml:"project_number"`
+	Organization  string `yaml:"organization"`
+	StartField    string `yaml:"start_field"`
+	EndField      string `yaml:"end_field"`
+
+	// Timezone is the IANA timezone of the team, e.g. Europe/Berlin
+	Timezone string `yaml:"timezone"`
+
+	// Delay thresholds in days of the moderate, high and extreme risk levels
+	ModerateRisk *int `yaml:"moderate_risk"`
+	HighRisk     *int `yaml:"high_risk"`
+	ExtremeRisk  *int `yaml:"extreme_risk"`
+
+	// Output is the output format of diff
+	Output string `yaml:"output"`
+
+	// Store is where states are stored: a directory, s3://bucket/prefix or gs://bucket/prefix
+	Store string `yaml:"store"`
+
+	// GitCommit commits each saved and deleted state to the git repository of a local store
+	GitCommit bool `yaml:"git_commit"`
+
+	// GitPush is the git remote pushed to after each commit, implying GitCommit
+	GitPush string `yaml:"git_push"`
+
+	// KeyFile contains the base64 encoded AES-256 key encrypting states
+	KeyFile string `yaml:"key_file"`
+}
+
+// Flags returns the configured defaults by flag name, without the store and unset values
+func (d DefaultsConfig) Flags() map[string]string {
+	flags := make(map[string]string)
+	if d.ProjectNumber != 0 {
+		flags["project-number"] = strconv.Itoa(d.ProjectNumber)
+	}
+	for flag, value := range map[string]string{
+		"organization": d.Organization,
+		"start-field":  d.StartField,
+		"end-field":    d.EndField,
+		"timezone":     d.Timezone,
+		"output":       d.Output,
+	} {
+		if value != "" {
+			flags[flag] = value
+		}
+	}
+	for flag, value := range map[string]*int{
+		"moderate-risk": d.ModerateRisk,
+		"high-risk":     d.HighRisk,
+		"extreme-risk":  d.ExtremeRisk,
+	} {
+		if value != nil {
+			flags[flag] = strconv.Itoa(*value)
+		}
+	}
+	return flags
+}
+
+// NotifyConfig contains the settings of notification targets
+type NotifyConfig struct {
+	Email EmailConfig `yaml:"email"`
+}
+
+// EmailConfig contains the SMTP server and recipients of email reports
+type EmailConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+
+	// Password of the SMTP user. $SMTP_PASSWORD takes precedence, to keep it out of the file.
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+
+	// Format of the report: html (default) or markdown
+	Format string `yaml:"format"`
+}
+
+// CaptureConfig contains the defaults of commands capturing snapshots
+type CaptureConfig struct {
+	// SkipUnchanged skips saving snapshots with the same content as the latest snapshot
+	SkipUnchanged bool `yaml:"skip_unchanged"`
+
+	// Heartbeat records the time of captures skipped by SkipUnchanged
+	Heartbeat bool `yaml:"heartbeat"`
+}
+
+// HooksConfig lists shell commands run at points of the workflow, with the
+// context passed as GH_PROJECT_REPORT_* environment variables
+type HooksConfig struct {
+	PreCapture  []string `yaml:"pre-capture"`
+	PostCapture []string `yaml:"post-capture"`
+	PostReport  []string `yaml:"post-report"`
+}
+
+// ReportConfig contains the settings that shape generated reports
+type ReportConfig struct {
+	// Sections lists the report sections to include, in order. Empty includes all sections in their default order.
+	Sections []string `yaml:"sections"`
+
+	// StaleDraftDays is the age in days after which unconverted draft issues are reported, 0 disables the check
+	StaleDraftDays *int `yaml:"stale_draft_days"`
+
+	// FreezeWindows lists periods of expected inactivity, e.g. holiday shutdowns
+	FreezeWindows []FreezeWindowConfig `yaml:"freeze_windows"`
+
+	// CauseLabels lists labels naming the cause of a slip, e.g. scope-change. Empty disables the slip causes section.
+	CauseLabels []string `yaml:"cause_labels"`
+
+	// Locale is the language of month and weekday names in dates: en, de, fr or ja. Empty is English.
+	Locale string `yaml:"locale"`
+
+	// Lang is the language of durations, delay levels and section titles: en, de or fr. Empty is English.
+	Lang string `yaml:"lang"`
+
+	// NoEmoji renders delay levels as tags, e.g. [HIGH], and section titles without emoji
+	NoEmoji bool `yaml:"no_emoji"`
+
+	// StatusSLAs maps statuses to the business days items may stay in them, e.g. Blocked: 5.
+	// Empty disables the status SLA section.
+	StatusSLAs map[string]int `yaml:"status_slas"`
+}
+
+// CapacityConfig lists the people working on the project and their days off
+type CapacityConfig struct {
+	People []PersonConfig `yaml:"people"`
+
+	// Holidays lists days off of the whole team, e.g. public holidays
+	Holidays []FreezeWindowConfig `yaml:"holidays"`
+}
+
+// PersonConfig is the availability of one person
+type PersonConfig struct {
+	// Login is the GitHub login of the person, with or without @
+	Login string `yaml:"login"`
+
+	// DaysPerWeek is the number of days per week the person works on the project, 5 if not set
+	DaysPerWeek *float64 `yaml:"days_per_week"`
+
+	// Holidays lists days off of the person, e.g. vacations
+	Holidays []FreezeWindowConfig `yaml:"holidays"`
+}
+
+// Capacity parses the configured capacity. It is empty if no people are configured.
+func (c CapacityConfig) Capacity() (forecast.Capacity, error) {
+	var capacity forecast.Capacity
+	holidays, err := freezeWindows(c.Holidays)
+	if err != nil {
+		return forecast.Capacity{}, err
+	}
+	capacity.Holidays = holidays
+
+	for _, p := range c.People {
+		login := strings.TrimPrefix(p.Login, "@")
+		if login == "" {
+			return forecast.Capacity{}, fmt.Errorf("invalid capacity: a person has no login")
+		}
+		days := 5.0
+		if p.DaysPerWeek != nil {
+			days = *p.DaysPerWeek
+		}
+		if days < 0 || days > 5 {
+			return forecast.Capacity{}, fmt.Errorf("invalid capacity of %s: days_per_week must be between 0 and 5", login)
+		}
+		holidays, err := freezeWindows(p.Holidays)
+		if err != nil {
+			return forecast.Capacity{}, fmt.Errorf("invalid capacity of %s: %w", login, err)
+		}
+		capacity.People = append(capacity.People, forecast.Person{Login: types.Mention(login), DaysPerWeek: days, Holidays: holidays})
+	}
+	return capacity, nil
+}
+
+// FreezeWindowConfig is a freeze window with dates in YYYY-MM-DD format
+type FreezeWindowConfig struct {
+	Name  string `yaml:"name"`
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// Freezes parses the configured freeze windows
+func (c ReportConfig) Freezes() ([]types.FreezeWindow, error) {
+	return freezeWindows(c.FreezeWindows)
+}
+
+// freezeWindows parses freeze windows or holidays
+func freezeWindows(configs []FreezeWindowConfig) ([]types.FreezeWindow, error) {
+	windows := make([]types.FreezeWindow, 0, len(configs))
+	for _, w := range configs {
+		window, err := types.NewFreezeWindow(w.Name, w.Start, w.End)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// Preset returns the flag values of a preset as strings. Lists are joined with commas
+// as expected by flags taking multiple values.
+func (c *Config) Preset(name string) (map[string]string, error) {
+	preset, ok := c.Presets[name]
+	if !ok {
+		names := make([]string, 0, len(c.Presets))
+		for n := range c.Presets {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown preset %q: no presets defined in the config file", name)
+		}
+		return nil, fmt.Errorf("unknown preset %q (must be one of: %s)", name, strings.Join(names, ", "))
+	}
+
+	flags := make(map[string]string, len(preset))
+	for flag, value := range preset {
+		switch v := value.(type) {
+		case []interface{}:
+			values := make([]string, len(v))
+			for i, item := range v {
+				values[i] = presetValue(item)
+			}
+			flags[flag] = strings.Join(values, ",")
+		case map[string]interface{}:
+			return nil, fmt.Errorf("invalid value of %q in preset %q: must be a value or a list", flag, name)
+		default:
+			flags[flag] = presetValue(v)
+		}
+	}
+	return flags, nil
+}
+
+// presetValue formats a scalar preset value as a flag value. Unquoted timestamps
+// are decoded as times by YAML and formatted back in RFC3339 format.
+func presetValue(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
+
+// DefaultPath returns the default location of the configuration file,
+// $XDG_CONFIG_HOME/gh-project-report/config.yaml or ~/.config/gh-project-report/config.yaml
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gh-project-report", "config.yaml"), nil
+}
+
+// Load loads the configuration file. A missing file results in an empty configuration.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	err = yaml.Unmarshal(data, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
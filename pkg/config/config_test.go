@@ -0,0 +1,209 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("missing file", func(t *testing.T) {
+		cfg, err := Load(filepath.Join(dir, "missing.yaml"))
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Report.Sections)
+	})
+
+	t.Run("report sections", func(t *testing.T) {
+		path := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("report:\n  sections: [summary, timeline]\n  stale_draft_days: 14\n  cause_labels: [scope-change, underestimated]\n  status_slas:\n    Blocked: 5\n    In Review: 3\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"summary", "timeline"}, cfg.Report.Sections)
+		require.NotNil(t, cfg.Report.StaleDraftDays)
+		assert.Equal(t, 14, *cfg.Report.StaleDraftDays)
+		assert.Equal(t, []string{"scope-change", "underestimated"}, cfg.Report.CauseLabels)
+		assert.Equal(t, map[string]int{"Blocked": 5, "In Review": 3}, cfg.Report.StatusSLAs)
+	})
+
+	t.Run("capture", func(t *testing.T) {
+		path := filepath.Join(dir, "capture.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("capture:\n  skip_unchanged: true\n  heartbeat: true\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, CaptureConfig{SkipUnchanged: true, Heartbeat: true}, cfg.Capture)
+	})
+
+	t.Run("freeze windows", func(t *testing.T) {
+		path := filepath.Join(dir, "freeze.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("report:\n  freeze_windows:\n    - name: Winter break\n      start: 2024-12-23\n      end: 2025-01-03\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		freezes, err := cfg.Report.Freezes()
+		require.NoError(t, err)
+		require.Len(t, freezes, 1)
+		assert.Equal(t, "Winter break", freezes[0].Name)
+		assert.Equal(t, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), freezes[0].End)
+	})
+
+	t.Run("invalid freeze window", func(t *testing.T) {
+		cfg := ReportConfig{FreezeWindows: []FreezeWindowConfig{{Name: "Broken", Start: "2024-12-23", End: "soon"}}}
+		_, err := cfg.Freezes()
+		assert.ErrorContains(t, err, `invalid freeze window "Broken"`)
+	})
+
+	t.Run("capacity", func(t *testing.T) {
+		path := filepath.Join(dir, "capacity.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("capacity:\n  holidays:\n    - name: New Year\n      start: 2025-01-01\n      end: 2025-01-01\n  people:\n    - login: alice\n    - login: \"@bob\"\n      days_per_week: 2.5\n      holidays:\n        - name: Vacation\n          start: 2025-01-06\n          end: 2025-01-10\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		capacity, err := cfg.Capacity.Capacity()
+		require.NoError(t, err)
+		require.Len(t, capacity.Holidays, 1)
+		require.Len(t, capacity.People, 2)
+		assert.Equal(t, "@alice", capacity.People[0].Login)
+		assert.Equal(t, 5.0, capacity.People[0].DaysPerWeek)
+		assert.Equal(t, "@bob", capacity.People[1].Login)
+		assert.Equal(t, 2.5, capacity.People[1].DaysPerWeek)
+		require.Len(t, capacity.People[1].Holidays, 1)
+		assert.Equal(t, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), capacity.People[1].Holidays[0].End)
+	})
+
+	t.Run("invalid capacity", func(t *testing.T) {
+		days := 6.0
+		_, err := CapacityConfig{People: []PersonConfig{{Login: "alice", DaysPerWeek: &days}}}.Capacity()
+		assert.EqualError(t, err, "invalid capacity of alice: days_per_week must be between 0 and 5")
+
+		_, err = CapacityConfig{People: []PersonConfig{{}}}.Capacity()
+		assert.EqualError(t, err, "invalid capacity: a person has no login")
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		path := filepath.Join(dir, "hooks.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("hooks:\n  pre-capture: [\"git pull\"]\n  post-report:\n    - ./notify.sh\n    - echo done\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"git pull"}, cfg.Hooks.PreCapture)
+		assert.Empty(t, cfg.Hooks.PostCapture)
+		assert.Equal(t, []string{"./notify.sh", "echo done"}, cfg.Hooks.PostReport)
+	})
+
+	t.Run("email", func(t *testing.T) {
+		path := filepath.Join(dir, "email.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("notify:\n  email:\n    host: smtp.example.com\n    port: 465\n    username: bot\n    from: reports@example.com\n    to: [alice@example.com, bob@example.com]\n    format: markdown\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, EmailConfig{
+			Host:     "smtp.example.com",
+			Port:     465,
+			Username: "bot",
+			From:     "reports@example.com",
+			To:       []string{"alice@example.com", "bob@example.com"},
+			Format:   "markdown",
+		}, cfg.Notify.Email)
+	})
+
+	t.Run("github host", func(t *testing.T) {
+		path := filepath.Join(dir, "github.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("github:\n  host: github.mycorp.com\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "github.mycorp.com", cfg.GitHub.Host)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		path := filepath.Join(dir, "defaults.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("defaults:\n  project_number: 42\n  organization: my-org\n  end_field: Target\n  timezone: Europe/Berlin\n  high_risk: 10\n  extreme_risk: 0\n  output: markdown\n  store: s3://bucket/states\n"), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "s3://bucket/states", cfg.Defaults.Store)
+		assert.Equal(t, map[string]string{
+			"project-number": "42",
+			"organization":   "my-org",
+			"end-field":      "Target",
+			"timezone":       "Europe/Berlin",
+			"high-risk":      "10",
+			"extreme-risk":   "0",
+			"output":         "markdown",
+		}, cfg.Defaults.Flags())
+		assert.Empty(t, DefaultsConfig{}.Flags())
+	})
+
+	t.Run("presets", func(t *testing.T) {
+		path := filepath.Join(dir, "presets.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`presets:
+  exec-weekly:
+    range: last 1 week
+    output: markdown
+    limit: 20
+    notify: slack
+  exec-grouped:
+    range: "last 1 week"
+    output: markdown
+    group-by: Team
+    only: timeline
+    limit: 20
+  team-ui:
+    filter: Team=UI
+    weight-field: [Estimate]
+    from: 2024-01-07T12:00:00Z
+`), 0644))
+
+		cfg, err := Load(path)
+		require.NoError(t, err)
+
+		flags, err := cfg.Preset("exec-weekly")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"range": "last 1 week", "output": "markdown", "limit": "20", "notify": "slack"}, flags)
+
+		// The example of grouped executive reports
+		flags, err = cfg.Preset("exec-grouped")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"range": "last 1 week", "output": "markdown", "group-by": "Team", "only": "timeline", "limit": "20"}, flags)
+
+		flags, err = cfg.Preset("team-ui")
+		require.NoError(t, err)
+		assert.Equal(t, "Estimate", flags["weight-field"])
+		assert.Equal(t, "2024-01-07T12:00:00Z", flags["from"])
+
+		_, err = cfg.Preset("missing")
+		assert.ErrorContains(t, err, `unknown preset "missing" (must be one of: exec-grouped, exec-weekly, team-ui)`)
+	})
+
+	t.Run("invalid preset", func(t *testing.T) {
+		cfg := &Config{Presets: map[string]map[string]interface{}{"broken": {"range": map[string]interface{}{"last": "week"}}}}
+		_, err := cfg.Preset("broken")
+		assert.ErrorContains(t, err, `invalid value of "range" in preset "broken"`)
+
+		_, err = (&Config{}).Preset("weekly")
+		assert.ErrorContains(t, err, "no presets defined")
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("report: [unclosed"), 0644))
+
+		_, err := Load(path)
+		assert.ErrorContains(t, err, "failed to parse config file")
+	})
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	path, err := DefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/xdg/gh-project-report/config.yaml", path)
+}
@@ -0,0 +1,175 @@
+package forecast
+
+import (
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// Person is the availability of one assignee
+type Person struct {
+	Login       string               // Mention of the assignee, e.g. "@alice"
+	DaysPerWeek float64              // Working days per week, spread evenly over Monday to Friday
+	Holidays    []types.FreezeWindow // Days off of this person
+}
+
+// Capacity describes who works on the project and when
+type Capacity struct {
+	People   []Person
+	Holidays []types.FreezeWindow // Days off of the whole team, e.g. public holidays
+}
+
+// PersonForecast is the projected completion of the remaining work of one person
+type PersonForecast struct {
+	Login       string
+	DaysPerWeek float64
+	Remaining   float64   // Remaining scheduled working days of the items assigned to the person
+	Done        time.Time // Day the person completes their items, zero if they have none or never do
+}
+
+// CapacityForecast is the projected completion of the remaining scheduled work
+// given the availability of the team
+type CapacityForecast struct {
+	People     []PersonForecast
+	Unassigned float64   // Remaining days of items without a configured assignee, shared by the team
+	Done       time.Time // Day all remaining work is completed, zero if capped
+	Capped     bool      // The work is not completed within MaxPeriods weeks
+	Items      int       // Open items with dates
+	Undated    int       // Open items without dates, which are not included
+}
+
+// RemainingWork is the remaining scheduled work of the open items of a state
+type RemainingWork struct {
+	Days    map[string]float64 // Remaining working days by assignee mention, "" for unassigned items
+	Items   int                // Open items with dates
+	Undated int                // Open items without start or end date, which are not included
+}
+
+// ScheduledWork returns the working days (Monday to Friday) left until the end date of
+// every open item of the state, counted from the day of the state or the start of the
+// item, whichever is later. Open items past their end date count as one day of work.
+// The days of items with several assignees are split evenly among them.
+func ScheduledWork(state *types.ProjectState, statusField string, doneValues []string) RemainingWork {
+	work := RemainingWork{Days: map[string]float64{}}
+	today := truncateDay(state.Timestamp)
+	for _, item := range state.Items {
+		if item.IsDone(statusField, doneValues) {
+			continue
+		}
+		if item.DateSpan.Start.IsZero() || item.DateSpan.End.IsZero() {
+			work.Undated++
+			continue
+		}
+		work.Items++
+
+		from := truncateDay(item.DateSpan.Start)
+		if from.Before(today) {
+			from = today
+		}
+		days := float64(max(workingDays(from, truncateDay(item.DateSpan.End)), 1))
+
+		assignees := item.Assignees()
+		if len(assignees) == 0 {
+			work.Days[""] += days
+			continue
+		}
+		for _, assignee := range assignees {
+			work.Days[strings.ToLower(assignee)] += days / float64(len(assignees))
+		}
+	}
+	return work
+}
+
+// Project projects the completion of the remaining work, starting on the day of start.
+// Every working day, each person spends their availability on their own items first and
+// then on the work shared by the team: unassigned items and items of assignees that are
+// not part of the capacity.
+func (c Capacity) Project(work RemainingWork, start time.Time) CapacityForecast {
+	result := CapacityForecast{People: make([]PersonForecast, len(c.People)), Items: work.Items, Undated: work.Undated}
+	own := make([]float64, len(c.People))
+	known := map[string]bool{}
+	for i, person := range c.People {
+		login := strings.ToLower(person.Login)
+		known[login] = true
+		own[i] = work.Days[login]
+		result.People[i] = PersonForecast{Login: person.Login, DaysPerWeek: person.DaysPerWeek, Remaining: own[i]}
+	}
+	for assignee, days := range work.Days {
+		if !known[assignee] {
+			result.Unassigned += days
+		}
+	}
+
+	const epsilon = 1e-9
+	shared := result.Unassigned
+	day := truncateDay(start)
+	for n := 0; n < MaxPeriods*7; n, day = n+1, day.AddDate(0, 0, 1) {
+		left := shared
+		for _, days := range own {
+			left += days
+		}
+		if left <= epsilon {
+			result.Done = day.AddDate(0, 0, -1)
+			if n == 0 {
+				result.Done = time.Time{}
+			}
+			return result
+		}
+		if !isWorkingDay(day) || onHoliday(c.Holidays, day) {
+			continue
+		}
+
+		for i, person := range c.People {
+			if onHoliday(person.Holidays, day) {
+				continue
+			}
+			available := person.DaysPerWeek / 5
+			if own[i] > epsilon {
+				spent := min(available, own[i])
+				own[i] -= spent
+				available -= spent
+				if own[i] <= epsilon {
+					result.People[i].Done = day
+				}
+			}
+			if shared > epsilon && available > 0 {
+				shared -= min(available, shared)
+			}
+		}
+	}
+
+	result.Capped = true
+	return result
+}
+
+// workingDays returns the number of days from Monday to Friday between from and to, inclusive
+func workingDays(from, to time.Time) int {
+	days := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if isWorkingDay(day) {
+			days++
+		}
+	}
+	return days
+}
+
+// isWorkingDay returns true for Monday to Friday
+func isWorkingDay(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+// onHoliday returns true if the day falls into any of the holidays
+func onHoliday(holidays []types.FreezeWindow, day time.Time) bool {
+	for _, h := range holidays {
+		if !day.Before(truncateDay(h.Start)) && !day.After(truncateDay(h.End)) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateDay returns midnight UTC of the calendar day of t
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
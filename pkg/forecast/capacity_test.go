@@ -0,0 +1,82 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func TestScheduledWork(t *testing.T) {
+	item := func(id, status, assignees string, span types.DateSpan) types.Item {
+		attributes := map[string]interface{}{"Status": status}
+		if assignees != "" {
+			attributes[types.AssigneesAttribute] = assignees
+		}
+		return types.Item{ID: id, DateSpan: span, Attributes: attributes}
+	}
+	state := &types.ProjectState{
+		Timestamp: time.Date(2024, 1, 3, 15, 0, 0, 0, time.UTC), // Wednesday
+		Items: []types.Item{
+			item("started", "In Progress", "@alice", types.MustNewDateSpan("2024-01-01", "2024-01-05")),
+			item("shared", "Todo", "@Alice, @bob", types.MustNewDateSpan("2024-01-06", "2024-01-09")),
+			item("overdue", "Todo", "", types.MustNewDateSpan("2023-12-01", "2023-12-05")),
+			item("done", "Done", "@alice", types.MustNewDateSpan("2024-01-08", "2024-01-12")),
+			item("undated", "Todo", "@bob", types.DateSpan{}),
+		},
+	}
+
+	work := ScheduledWork(state, "Status", []string{"Done"})
+	assert.Equal(t, map[string]float64{"@alice": 4, "@bob": 1, "": 1}, work.Days)
+	assert.Equal(t, 3, work.Items)
+	assert.Equal(t, 1, work.Undated)
+}
+
+func TestCapacityProject(t *testing.T) {
+	capacity := Capacity{People: []Person{
+		{Login: "@alice", DaysPerWeek: 5},
+		{Login: "@Bob", DaysPerWeek: 2.5, Holidays: []types.FreezeWindow{{Start: day("2024-01-02"), End: day("2024-01-03")}}},
+	}}
+	work := RemainingWork{Days: map[string]float64{"@alice": 5, "@bob": 2, "": 2, "@carol": 1}, Items: 6, Undated: 1}
+
+	result := capacity.Project(work, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	require.Len(t, result.People, 2)
+	assert.Equal(t, PersonForecast{Login: "@alice", DaysPerWeek: 5, Remaining: 5, Done: day("2024-01-05")}, result.People[0])
+	// Bob works half days and is off on Tuesday and Wednesday
+	assert.Equal(t, PersonForecast{Login: "@Bob", DaysPerWeek: 2.5, Remaining: 2, Done: day("2024-01-08")}, result.People[1])
+	// Unassigned items and items of others are shared once people are done with their own
+	assert.Equal(t, float64(3), result.Unassigned)
+	assert.Equal(t, day("2024-01-10"), result.Done)
+	assert.False(t, result.Capped)
+	assert.Equal(t, 6, result.Items)
+	assert.Equal(t, 1, result.Undated)
+
+	t.Run("team holidays", func(t *testing.T) {
+		capacity := Capacity{
+			People:   []Person{{Login: "@alice", DaysPerWeek: 5}},
+			Holidays: []types.FreezeWindow{{Name: "New Year", Start: day("2024-01-01"), End: day("2024-01-01")}},
+		}
+		result := capacity.Project(RemainingWork{Days: map[string]float64{"@alice": 1}}, day("2024-01-01"))
+		assert.Equal(t, day("2024-01-02"), result.Done)
+	})
+
+	t.Run("no remaining work", func(t *testing.T) {
+		result := capacity.Project(RemainingWork{}, day("2024-01-01"))
+		assert.True(t, result.Done.IsZero())
+		assert.False(t, result.Capped)
+	})
+
+	t.Run("nobody available", func(t *testing.T) {
+		capacity := Capacity{People: []Person{{Login: "@alice", DaysPerWeek: 0}}}
+		result := capacity.Project(RemainingWork{Days: map[string]float64{"@alice": 1}}, day("2024-01-01"))
+		assert.True(t, result.Capped)
+		assert.True(t, result.Done.IsZero())
+	})
+}
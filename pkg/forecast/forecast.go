@@ -0,0 +1,114 @@
+// Package forecast estimates completion dates of the remaining work of a project
+// by Monte Carlo simulation of its historical throughput.
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+// DefaultTrials is the number of simulated futures used unless WithTrials is given
+const DefaultTrials = 10000
+
+// MaxPeriods caps the length of a single simulated future, so that a history of
+// mostly empty periods cannot make a trial run forever
+const MaxPeriods = 520
+
+// DefaultPercentiles are the confidence levels reported by default
+var DefaultPercentiles = []float64{50, 85, 95}
+
+// ErrNoThroughput is returned if no work was completed in any historical period
+var ErrNoThroughput = errors.New("no work was completed in the history, the throughput is zero")
+
+// Options configures a simulation
+type Options struct {
+	Trials int
+	Rand   *rand.Rand
+}
+
+// Option is a functional option for Simulate
+type Option func(*Options)
+
+// WithTrials sets the number of simulated futures
+func WithTrials(trials int) Option {
+	return func(o *Options) {
+		o.Trials = trials
+	}
+}
+
+// WithSeed makes the simulation reproducible
+func WithSeed(seed uint64) Option {
+	return func(o *Options) {
+		o.Rand = rand.New(rand.NewPCG(seed, seed))
+	}
+}
+
+// Result holds the number of periods each simulated future needed to complete the
+// remaining work, sorted ascending
+type Result struct {
+	Remaining float64
+	Trials    int
+	Periods   []int
+	Capped    int // Trials that did not complete within MaxPeriods
+}
+
+// Simulate draws the throughput of each future period at random from the historical
+// throughput per period until the remaining work is done, repeated for every trial
+func Simulate(throughput []float64, remaining float64, opts ...Option) (Result, error) {
+	options := Options{Trials: DefaultTrials}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.Trials < 1 {
+		return Result{}, fmt.Errorf("invalid number of trials: %d", options.Trials)
+	}
+	if options.Rand == nil {
+		options.Rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+
+	total := 0.0
+	for _, t := range throughput {
+		total += t
+	}
+	if total <= 0 {
+		return Result{}, ErrNoThroughput
+	}
+
+	result := Result{Remaining: remaining, Trials: options.Trials, Periods: make([]int, options.Trials)}
+	for i := range result.Periods {
+		done := 0.0
+		periods := 0
+		for done < remaining && periods < MaxPeriods {
+			done += throughput[options.Rand.IntN(len(throughput))]
+			periods++
+		}
+		if done < remaining {
+			result.Capped++
+		}
+		result.Periods[i] = periods
+	}
+
+	sort.Ints(result.Periods)
+	return result, nil
+}
+
+// Percentile returns the number of periods within which the given percentage of
+// the trials completed, e.g. 85 for the P85 forecast
+func (r Result) Percentile(p float64) int {
+	if len(r.Periods) == 0 {
+		return 0
+	}
+	index := int(math.Ceil(p/100*float64(len(r.Periods)))) - 1
+	index = min(max(index, 0), len(r.Periods)-1)
+	return r.Periods[index]
+}
+
+// Date returns the completion date of a percentile, counting periods of the given
+// length from start
+func (r Result) Date(p float64, start time.Time, period time.Duration) time.Time {
+	return start.Add(time.Duration(r.Percentile(p)) * period)
+}
@@ -0,0 +1,78 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Run("constant throughput", func(t *testing.T) {
+		result, err := Simulate([]float64{5, 5}, 12, WithTrials(100), WithSeed(1))
+		require.NoError(t, err)
+
+		assert.Equal(t, 100, result.Trials)
+		assert.Equal(t, 3, result.Percentile(50))
+		assert.Equal(t, 3, result.Percentile(95))
+		assert.Zero(t, result.Capped)
+	})
+
+	t.Run("variable throughput", func(t *testing.T) {
+		result, err := Simulate([]float64{0, 2, 10}, 20, WithSeed(42))
+		require.NoError(t, err)
+
+		assert.Equal(t, DefaultTrials, result.Trials)
+		assert.LessOrEqual(t, 2, result.Percentile(50))
+		assert.LessOrEqual(t, result.Percentile(50), result.Percentile(85))
+		assert.LessOrEqual(t, result.Percentile(85), result.Percentile(95))
+	})
+
+	t.Run("reproducible with seed", func(t *testing.T) {
+		a, err := Simulate([]float64{1, 3, 8}, 30, WithSeed(7))
+		require.NoError(t, err)
+		b, err := Simulate([]float64{1, 3, 8}, 30, WithSeed(7))
+		require.NoError(t, err)
+		assert.Equal(t, a, b)
+	})
+
+	t.Run("nothing remaining", func(t *testing.T) {
+		result, err := Simulate([]float64{3}, 0, WithTrials(10))
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Percentile(95))
+	})
+
+	t.Run("capped trials", func(t *testing.T) {
+		result, err := Simulate([]float64{1}, MaxPeriods+1, WithTrials(3))
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Capped)
+		assert.Equal(t, MaxPeriods, result.Percentile(50))
+	})
+
+	t.Run("zero throughput", func(t *testing.T) {
+		_, err := Simulate([]float64{0, 0}, 5)
+		assert.ErrorIs(t, err, ErrNoThroughput)
+	})
+
+	t.Run("no history", func(t *testing.T) {
+		_, err := Simulate(nil, 5)
+		assert.ErrorIs(t, err, ErrNoThroughput)
+	})
+
+	t.Run("invalid trials", func(t *testing.T) {
+		_, err := Simulate([]float64{1}, 5, WithTrials(0))
+		assert.Error(t, err)
+	})
+}
+
+func TestResultDate(t *testing.T) {
+	result := Result{Periods: []int{1, 2, 2, 3}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	assert.Equal(t, 2, result.Percentile(50))
+	assert.Equal(t, 3, result.Percentile(95))
+	assert.Equal(t, time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC), result.Date(95, start, week))
+	assert.Equal(t, 0, Result{}.Percentile(50))
+}
@@ -0,0 +1,119 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// aheadSectionTitle is the title of the ahead of schedule section
+const aheadSectionTitle = "🚀 Ahead / Completed early"
+
+// Kinds of items ahead of schedule
+const (
+	aheadCompleted = "Completed early"
+	aheadPulledIn  = "Pulled in"
+)
+
+// aheadItem is an item that finished early or had its end date pulled in
+type aheadItem struct {
+	item       types.Item
+	kind       string
+	plannedEnd time.Time // End date before the change
+	end        time.Time // Day the item was done, or its new end date
+	daysSaved  int
+}
+
+// findAheadItems returns the changed items that moved to a done status before their
+// planned end date, or whose end date moved earlier, most days saved first. Items
+// done today count from the day of now in the project timezone.
+func findAheadItems(diff types.ProjectDiff, options FormatterOptions, now time.Time) []aheadItem {
+	loc := options.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, d := now.In(loc).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	var ahead []aheadItem
+	for _, change := range diff.ChangedItems {
+		plannedEnd := change.Before.DateSpan.End
+		if plannedEnd.IsZero() {
+			continue
+		}
+
+		completed := change.After.IsDone(options.StatusField, options.DoneStatuses) &&
+			!change.Before.IsDone(options.StatusField, options.DoneStatuses)
+		switch {
+		case completed:
+			// Teams often set the end date to the day an item was done
+			end := today
+			if after := change.After.DateSpan.End; !after.IsZero() && after.Before(end) {
+				end = after
+			}
+			if days := daysBetween(end, plannedEnd); days > 0 {
+				ahead = append(ahead, aheadItem{item: change.After, kind: aheadCompleted, plannedEnd: plannedEnd, end: end, daysSaved: days})
+			}
+		case change.DateChange != nil && change.DateChange.EndDaysDelta < 0:
+			ahead = append(ahead, aheadItem{
+				item:       change.After,
+				kind:       aheadPulledIn,
+				plannedEnd: plannedEnd,
+				end:        change.After.DateSpan.End,
+				daysSaved:  -change.DateChange.EndDaysDelta,
+			})
+		}
+	}
+
+	sort.SliceStable(ahead, func(i, j int) bool {
+		if ahead[i].daysSaved != ahead[j].daysSaved {
+			return ahead[i].daysSaved > ahead[j].daysSaved
+		}
+		return ahead[i].item.GetTitle() < ahead[j].item.GetTitle()
+	})
+	return ahead
+}
+
+// daysBetween returns the number of whole days from one date to another
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// buildAheadTable builds the table of items ahead of schedule and a note with the
+// total time saved, or nil if there are none
+func buildAheadTable(diff types.ProjectDiff, options FormatterOptions, now time.Time) (*Table, string) {
+	ahead := findAheadItems(diff, options, now)
+	if len(ahead) == 0 {
+		return nil, ""
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Task", Alignment: AlignLeft},
+			{Header: "Change", Alignment: AlignLeft},
+			{Header: "Planned End", Alignment: AlignRight},
+			{Header: "Done / New End", Alignment: AlignRight},
+			{Header: "Saved", Alignment: AlignRight},
+		},
+		Links: make(map[string]string),
+	}
+	total := 0
+	for _, a := range ahead {
+		total += a.daysSaved
+		title := a.item.GetTitle()
+		if a.item.URL != "" {
+			table.Links[title] = a.item.URL
+		}
+		table.Rows = append(table.Rows, []string{
+			title,
+			a.kind,
+			formatDate(a.plannedEnd, options),
+			formatDate(a.end, options),
+			options.Duration.Format(a.daysSaved),
+		})
+	}
+	note := fmt.Sprintf("%d item%s ahead of schedule, %s saved in total.", len(ahead), pluralize(len(ahead)), options.Duration.Format(total))
+	return table, note
+}
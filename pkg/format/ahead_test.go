@@ -0,0 +1,95 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aheadDiff returns a diff with an item done early, an item pulled in, a slipped
+// item and an item done late
+func aheadDiff() types.ProjectDiff {
+	item := func(id, title, status, start, end string) types.Item {
+		return types.Item{
+			ID:         id,
+			URL:        "https://github.com/org/repo/issues/" + id,
+			DateSpan:   types.MustNewDateSpan(start, end),
+			Attributes: map[string]interface{}{"Title": title, "Status": status},
+		}
+	}
+	return types.ProjectDiff{
+		ChangedItems: []types.ItemDiff{
+			{
+				ItemID: "1",
+				Before: item("1", "Login page", "In Progress", "2024-03-01", "2024-03-29"),
+				After:  item("1", "Login page", "Done", "2024-03-01", "2024-03-29"),
+			},
+			{
+				ItemID:     "2",
+				Before:     item("2", "API", "Todo", "2024-03-01", "2024-03-20"),
+				After:      item("2", "API", "Todo", "2024-03-01", "2024-03-17"),
+				DateChange: &types.DateSpanChange{EndDaysDelta: -3, DurationDelta: -3},
+			},
+			{
+				ItemID:     "3",
+				Before:     item("3", "Docs", "Todo", "2024-03-01", "2024-03-10"),
+				After:      item("3", "Docs", "Todo", "2024-03-01", "2024-03-20"),
+				DateChange: &types.DateSpanChange{EndDaysDelta: 10, DurationDelta: 10},
+			},
+			{
+				ItemID: "4",
+				Before: item("4", "Overdue", "In Progress", "2024-03-01", "2024-03-10"),
+				After:  item("4", "Overdue", "done", "2024-03-01", "2024-03-10"),
+			},
+			{
+				// Teams often set the end date to the day the item was done
+				ItemID:     "5",
+				Before:     item("5", "Search", "In Progress", "2024-03-01", "2024-03-25"),
+				After:      item("5", "Search", "Done", "2024-03-01", "2024-03-12"),
+				DateChange: &types.DateSpanChange{EndDaysDelta: -13, DurationDelta: -13},
+			},
+		},
+	}
+}
+
+func TestBuildAheadTable(t *testing.T) {
+	now := time.Date(2024, 3, 15, 18, 0, 0, 0, time.UTC)
+
+	table, note := buildAheadTable(aheadDiff(), DefaultOptions(), now)
+	require.NotNil(t, table)
+	assert.Equal(t, [][]string{
+		{"Login page", "Completed early", "Mar 29, 2024", "Mar 15, 2024", "2 weeks"},
+		{"Search", "Completed early", "Mar 25, 2024", "Mar 12, 2024", "1 week 6 days"},
+		{"API", "Pulled in", "Mar 20, 2024", "Mar 17, 2024", "3 days"},
+	}, table.Rows)
+	assert.Equal(t, "https://github.com/org/repo/issues/2", table.Links["API"])
+	assert.Equal(t, "3 items ahead of schedule, 1 month saved in total.", note)
+
+	// Other done statuses
+	options := DefaultOptions()
+	WithDoneStatuses("Status", []string{"Shipped"})(&options)
+	table, _ = buildAheadTable(aheadDiff(), options, now)
+	require.NotNil(t, table)
+	assert.Len(t, table.Rows, 2, "only items pulled in")
+
+	table, _ = buildAheadTable(createTestDiff(), DefaultOptions(), now)
+	assert.Nil(t, table)
+}
+
+func TestAheadSection(t *testing.T) {
+	opts := []func(*FormatterOptions){WithSections([]string{SectionAhead})}
+
+	markdown := NewTableFormatter(opts...).Format(aheadDiff())
+	assert.Contains(t, markdown, "## 🚀 Ahead / Completed early")
+	assert.Contains(t, markdown, "| [API](https://github.com/org/repo/issues/2) | Pulled in |")
+
+	plain := NewPlainTableFormatter(opts...).Format(aheadDiff())
+	assert.Contains(t, plain, "Ahead / Completed early")
+
+	text := NewTextFormatter(opts...).Format(aheadDiff())
+	assert.Contains(t, text, "Ahead / Completed early:\n")
+	assert.Contains(t, text, "- API: Pulled in, 3 days saved (planned Mar 20, 2024, now Mar 17, 2024)\n")
+}
@@ -0,0 +1,127 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// asciiDocAlignments maps column alignments to the alignment operators of AsciiDoc
+// column specifiers
+var asciiDocAlignments = map[Alignment]string{
+	AlignLeft:   "<",
+	AlignCenter: "^",
+	AlignRight:  ">",
+}
+
+// asciiDocCellEscaper escapes characters ending a cell of an AsciiDoc table
+var asciiDocCellEscaper = strings.NewReplacer("|", `\|`)
+
+// asciiDocLinkEscaper escapes characters ending the text of an AsciiDoc link
+var asciiDocLinkEscaper = strings.NewReplacer("]", `\]`, "|", `\|`)
+
+// AsciiDocFormatter formats project diffs as an AsciiDoc document, e.g. for Antora or
+// Asciidoctor documentation sites
+type AsciiDocFormatter struct {
+	tables   *TableFormatter
+	renderer *AsciiDocRenderer
+}
+
+// NewAsciiDocFormatter creates a new AsciiDoc formatter with the given options
+func NewAsciiDocFormatter(opts ...func(*FormatterOptions)) *AsciiDocFormatter {
+	return &AsciiDocFormatter{
+		tables:   NewTableFormatter(opts...),
+		renderer: &AsciiDocRenderer{},
+	}
+}
+
+// Format formats the project diff as an AsciiDoc document
+func (f *AsciiDocFormatter) Format(diff types.ProjectDiff) string {
+	if !hasChanges(diff) {
+		return noChangesMessage(diff) + "\n"
+	}
+
+	doc := f.tables.buildDocument(diff)
+	return f.renderer.RenderDocument(&doc)
+}
+
+// AsciiDocRenderer handles rendering generic types into AsciiDoc
+type AsciiDocRenderer struct{}
+
+// RenderTable converts a generic Table to an AsciiDoc table with a header row.
+// First-column values with a link are rendered as links.
+func (r *AsciiDocRenderer) RenderTable(t *Table) string {
+	if len(t.Columns) == 0 {
+		return ""
+	}
+
+	specs := make([]string, len(t.Columns))
+	headers := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		specs[i] = asciiDocAlignments[col.Alignment]
+		headers[i] = "|" + asciiDocCellEscaper.Replace(col.Header)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`[cols="` + strings.Join(specs, ",") + `",options="header"]` + "\n")
+	sb.WriteString("|===\n")
+	sb.WriteString(strings.Join(headers, " ") + "\n\n")
+
+	for _, row := range t.Rows {
+		cells := make([]string, len(t.Columns))
+		for i := range t.Columns {
+			value := "-"
+			if i < len(row) {
+				value = row[i]
+			}
+			if url, ok := t.Links[value]; ok && i == 0 {
+				cells[i] = "|" + url + "[" + asciiDocLinkEscaper.Replace(value) + "]"
+			} else {
+				cells[i] = "|" + asciiDocCellEscaper.Replace(value)
+			}
+		}
+		sb.WriteString(strings.Join(cells, " ") + "\n")
+	}
+
+	sb.WriteString("|===\n")
+	return sb.String()
+}
+
+// RenderSection converts a generic Section to an AsciiDoc section
+func (r *AsciiDocRenderer) RenderSection(s *Section) string {
+	var sb strings.Builder
+
+	if s.Title != "" {
+		sb.WriteString("== " + s.Title + "\n\n")
+	}
+
+	if s.Table != nil {
+		sb.WriteString(r.RenderTable(s.Table))
+	} else if s.Text != "" {
+		sb.WriteString(s.Text + "\n")
+	}
+
+	if s.Note != "" {
+		sb.WriteString("\n_" + s.Note + "_\n")
+	}
+
+	return sb.String()
+}
+
+// RenderDocument converts a generic Document to an AsciiDoc document
+func (r *AsciiDocRenderer) RenderDocument(d *Document) string {
+	var sb strings.Builder
+
+	if d.Title != "" {
+		sb.WriteString("= " + d.Title + "\n\n")
+	}
+
+	for i, section := range d.Sections {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(r.RenderSection(&section))
+	}
+
+	return sb.String()
+}
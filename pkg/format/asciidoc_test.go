@@ -0,0 +1,65 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsciiDocFormatter(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems[0].After.URL = "https://github.com/org/repo/issues/1"
+	diff.AddedItems[0].Attributes["Title"] = "New | Task"
+
+	output := NewAsciiDocFormatter().Format(diff)
+
+	assert.Contains(t, output, "= Project Timeline Analysis\n\n== 📅 Timeline Changes\n\n")
+	assert.Contains(t, output, "[cols=\"<,^,<,>,>,>\",options=\"header\"]\n|===\n|Task |Status |Details |Start Date |End Date |Duration\n\n")
+	assert.Contains(t, output, "|https://github.com/org/repo/issues/1[Changed Task] |🟠 Moderate delay |")
+	assert.Contains(t, output, `|New \| Task |Added |New task |`)
+	assert.Contains(t, output, "\n|===\n")
+}
+
+func TestAsciiDocFormatterNoChanges(t *testing.T) {
+	output := NewAsciiDocFormatter().Format(types.ProjectDiff{})
+	assert.Equal(t, "No changes found in the project timeline.\n", output)
+}
+
+func TestAsciiDocRenderer(t *testing.T) {
+	r := &AsciiDocRenderer{}
+	output := r.RenderDocument(&Document{
+		Title: "Report",
+		Sections: []Section{
+			{
+				Title: "Items",
+				Table: &Table{
+					Columns: []TableColumn{{Header: "Task", Alignment: AlignLeft}, {Header: "Days", Alignment: AlignRight}},
+					Rows:    [][]string{{"API [v2]", "3"}, {"UI"}},
+					Links:   map[string]string{"API [v2]": "https://example.com/1"},
+				},
+				Note: "…and 2 more",
+			},
+			{Title: "Text", Text: "Plain text"},
+		},
+	})
+
+	assert.Equal(t, `= Report
+
+== Items
+
+[cols="<,>",options="header"]
+|===
+|Task |Days
+
+|https://example.com/1[API [v2\]] |3
+|UI |-
+|===
+
+_…and 2 more_
+
+== Text
+
+Plain text
+`, output)
+}
@@ -0,0 +1,92 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// burndownBarWidth is the width of the longest bar in the burndown chart
+const burndownBarWidth = 30
+
+// BuildBurndownDocument builds the burndown report, one row per snapshot with a bar
+// of the remaining work. An empty weightField counts items.
+func BuildBurndownDocument(points []types.BurndownPoint, weightField string, options FormatterOptions) Document {
+	unit := burndownUnit(weightField)
+
+	peak := 0.0
+	missing := 0
+	for _, p := range points {
+		peak = max(peak, p.Remaining)
+		missing = max(missing, p.MissingWeight)
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Snapshot", Alignment: AlignLeft},
+			{Header: "Open", Alignment: AlignRight},
+			{Header: "Done", Alignment: AlignRight},
+			{Header: "Remaining " + unit, Alignment: AlignRight},
+			{Header: "Burndown", Alignment: AlignLeft},
+		},
+	}
+	for _, p := range points {
+		table.Rows = append(table.Rows, []string{
+			formatTimestamp(p.Timestamp, options),
+			strconv.Itoa(p.Open),
+			strconv.Itoa(p.Done),
+			formatWeight(p.Remaining),
+			burndownBar(p.Remaining, peak),
+		})
+	}
+
+	section := Section{Title: "📉 Remaining " + unit + " per snapshot", Table: table}
+	if missing > 0 {
+		section.Note = fmt.Sprintf("Up to %d item%s per snapshot had no %s and counted as 0.", missing, pluralize(missing), weightField)
+	}
+	return Document{Title: "Burndown", Sections: []Section{section}}
+}
+
+// FormatBurndownMermaid formats the remaining work per snapshot as a fenced Mermaid
+// line chart, which GitHub renders natively in Markdown
+func FormatBurndownMermaid(points []types.BurndownPoint, weightField string, options FormatterOptions) string {
+	labels := make([]string, len(points))
+	values := make([]string, len(points))
+	peak := 0.0
+	for i, p := range points {
+		labels[i] = strconv.Quote(formatTimestamp(p.Timestamp, options))
+		values[i] = formatWeight(p.Remaining)
+		peak = max(peak, p.Remaining)
+	}
+
+	// The y-axis needs a non-empty range
+	peak = max(peak, 1)
+
+	var sb strings.Builder
+	sb.WriteString("```mermaid\n")
+	sb.WriteString("xychart-beta\n")
+	sb.WriteString("    title \"Burndown\"\n")
+	sb.WriteString("    x-axis [" + strings.Join(labels, ", ") + "]\n")
+	sb.WriteString(fmt.Sprintf("    y-axis \"Remaining %s\" 0 --> %s\n", burndownUnit(weightField), formatWeight(peak)))
+	sb.WriteString("    line [" + strings.Join(values, ", ") + "]\n")
+	sb.WriteString("```\n")
+	return sb.String()
+}
+
+// burndownUnit names the unit of remaining work, the weight field or items
+func burndownUnit(weightField string) string {
+	if weightField == "" {
+		return "items"
+	}
+	return weightField
+}
+
+// burndownBar renders remaining work as a bar relative to the peak
+func burndownBar(remaining, peak float64) string {
+	if peak <= 0 {
+		return ""
+	}
+	return strings.Repeat("█", int(remaining/peak*burndownBarWidth+0.5))
+}
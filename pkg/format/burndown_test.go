@@ -0,0 +1,43 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func burndownPoints() []types.BurndownPoint {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []types.BurndownPoint{
+		{Timestamp: day, Open: 4, Remaining: 20},
+		{Timestamp: day.AddDate(0, 0, 7), Open: 3, Done: 1, Remaining: 10.5, Completed: 9.5, MissingWeight: 1},
+	}
+}
+
+func TestBuildBurndownDocument(t *testing.T) {
+	doc := BuildBurndownDocument(burndownPoints(), "Points", DefaultOptions())
+
+	require.Len(t, doc.Sections, 1)
+	section := doc.Sections[0]
+	assert.Equal(t, "Remaining Points", section.Table.Columns[3].Header)
+	require.Len(t, section.Table.Rows, 2)
+	assert.Equal(t, []string{"Jan 1, 2024", "4", "0", "20"}, section.Table.Rows[0][:4])
+	assert.Len(t, []rune(section.Table.Rows[0][4]), burndownBarWidth)
+	assert.Len(t, []rune(section.Table.Rows[1][4]), 16)
+	assert.Equal(t, "Up to 1 item per snapshot had no Points and counted as 0.", section.Note)
+}
+
+func TestFormatBurndownMermaid(t *testing.T) {
+	got := FormatBurndownMermaid(burndownPoints(), "", DefaultOptions())
+
+	assert.Equal(t, "```mermaid\n"+
+		"xychart-beta\n"+
+		"    title \"Burndown\"\n"+
+		"    x-axis [\"Jan 1, 2024\", \"Jan 8, 2024\"]\n"+
+		"    y-axis \"Remaining items\" 0 --> 20\n"+
+		"    line [20, 10.5]\n"+
+		"```\n", got)
+}
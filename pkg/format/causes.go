@@ -0,0 +1,51 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// causesSectionTitle is the title of the slip causes section
+const causesSectionTitle = "🏷️ Slip Causes"
+
+// unattributedCause names the slip of items without a cause label
+const unattributedCause = "Unattributed"
+
+// buildCausesTable builds the breakdown of slip days by cause label, or nil if no
+// cause labels are configured or no item slipped
+func buildCausesTable(diff types.ProjectDiff, options FormatterOptions) *Table {
+	slips := diff.SlipByCause(options.CauseLabels)
+	if len(slips) == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, s := range slips {
+		total += s.Days
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Cause", Alignment: AlignLeft},
+			{Header: "Items", Alignment: AlignRight},
+			{Header: "Slip", Alignment: AlignRight},
+			{Header: "Share", Alignment: AlignRight},
+		},
+		Colors: options.Palette.Colors(types.PaletteLabel),
+	}
+	for _, s := range slips {
+		cause := s.Cause
+		if cause == "" {
+			cause = unattributedCause
+		}
+		table.Rows = append(table.Rows, []string{
+			cause,
+			strconv.Itoa(s.Items),
+			options.Duration.Format(s.Days),
+			fmt.Sprintf("%d%%", s.Days*100/total),
+		})
+	}
+	return table
+}
@@ -0,0 +1,52 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func causesDiff() types.ProjectDiff {
+	slipped := func(id string, endDelta int, labels ...string) types.ItemDiff {
+		item := types.Item{ID: id, Labels: labels, Attributes: map[string]interface{}{"Title": "Task " + id}}
+		return types.ItemDiff{ItemID: id, Before: item, After: item, DateChange: &types.DateSpanChange{EndDaysDelta: endDelta}}
+	}
+	return types.ProjectDiff{
+		ChangedItems: []types.ItemDiff{
+			slipped("1", 6, "scope-change"),
+			slipped("2", 2),
+		},
+	}
+}
+
+func TestBuildCausesTable(t *testing.T) {
+	t.Run("disabled without cause labels", func(t *testing.T) {
+		assert.Nil(t, buildCausesTable(causesDiff(), DefaultOptions()))
+	})
+
+	t.Run("breakdown", func(t *testing.T) {
+		options := DefaultOptions()
+		options.CauseLabels = []string{"scope-change"}
+
+		table := buildCausesTable(causesDiff(), options)
+		require.NotNil(t, table)
+		assert.Equal(t, [][]string{
+			{"scope-change", "1", options.Duration.Format(6), "75%"},
+			{unattributedCause, "1", options.Duration.Format(2), "25%"},
+		}, table.Rows)
+	})
+}
+
+func TestCausesSection(t *testing.T) {
+	opts := []func(*FormatterOptions){WithCauseLabels([]string{"scope-change"}), WithSections([]string{SectionCauses})}
+
+	markdown := NewTableFormatter(opts...).Format(causesDiff())
+	assert.Contains(t, markdown, causesSectionTitle)
+	assert.Contains(t, markdown, "| scope-change |")
+
+	text := NewTextFormatter(opts...).Format(causesDiff())
+	assert.Contains(t, text, "Slip causes:")
+	assert.Contains(t, text, "- Unattributed: ")
+}
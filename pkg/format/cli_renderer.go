@@ -91,6 +91,10 @@ func (r *CLITableRenderer) RenderSection(s *Section) string {
 		sb.WriteString(s.Text + "\n")
 	}
 
+	if s.Note != "" {
+		sb.WriteString("\n" + s.Note + "\n")
+	}
+
 	return sb.String()
 }
 
@@ -0,0 +1,51 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// statusColors are the ANSI colors of delay levels and change markers in terminal
+// output, keyed by the English status
+var statusColors = map[string]*color.Color{
+	string(DelayLevelAhead):    ansiColor(color.FgGreen),
+	string(DelayLevelOnTrack):  ansiColor(color.FgBlue),
+	string(DelayLevelModerate): ansiColor(color.FgYellow),
+	string(DelayLevelHigh):     ansiColor(color.FgRed),
+	string(DelayLevelExtreme):  ansiColor(color.FgRed, color.Bold),
+	"Added":                    ansiColor(color.FgGreen),
+	"Removed":                  ansiColor(color.FgRed),
+}
+
+// arrowColor is the ANSI color of the arrows between before and after values
+var arrowColor = ansiColor(color.FgCyan)
+
+// ansiColor returns a color that is always rendered. Colors are enabled by the Color
+// option rather than by detecting the terminal, which is left to the caller.
+func ansiColor(attributes ...color.Attribute) *color.Color {
+	c := color.New(attributes...)
+	c.EnableColor()
+	return c
+}
+
+// colorize colors a status label, such as a delay level or "Added" in any language
+// and emoji style, if colors are enabled. Other values are unchanged.
+func (o FormatterOptions) colorize(label string) string {
+	if !o.Color {
+		return label
+	}
+	if c, ok := statusColors[canonicalStatus(label)]; ok {
+		return c.Sprint(label)
+	}
+	return label
+}
+
+// colorArrows colors the arrows between before and after values, e.g. in
+// "Jan 1, 2024 → Jan 15, 2024", if colors are enabled
+func (o FormatterOptions) colorArrows(s string) string {
+	if !o.Color {
+		return s
+	}
+	return strings.ReplaceAll(s, " → ", " "+arrowColor.Sprint("→")+" ")
+}
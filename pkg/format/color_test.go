@@ -0,0 +1,58 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorize(t *testing.T) {
+	colored := DefaultOptions()
+	WithColor(true)(&colored)
+
+	tests := []struct {
+		name     string
+		options  FormatterOptions
+		label    string
+		expected string
+	}{
+		{"disabled", DefaultOptions(), string(DelayLevelHigh), string(DelayLevelHigh)},
+		{"delay level", colored, string(DelayLevelModerate), "\x1b[33m🟠 Moderate delay\x1b[0m"},
+		{"extreme", colored, string(DelayLevelExtreme), "\x1b[31;1m🚫 Extreme delay\x1b[0;22m"},
+		{"added", colored, "Added", "\x1b[32mAdded\x1b[0m"},
+		{"ascii tag", colored, "[HIGH]", "\x1b[31m[HIGH]\x1b[0m"},
+		{"other values", colored, "Todo", "Todo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.options.colorize(tt.label))
+		})
+	}
+}
+
+func TestColorArrows(t *testing.T) {
+	assert.Equal(t, "Todo → Done", DefaultOptions().colorArrows("Todo → Done"))
+
+	colored := DefaultOptions()
+	WithColor(true)(&colored)
+	assert.Equal(t, "Todo \x1b[36m→\x1b[0m Done", colored.colorArrows("Todo → Done"))
+}
+
+func TestFormattersColor(t *testing.T) {
+	diff := createTestDiff()
+
+	for name, formatter := range map[string]Formatter{
+		"text":       NewTextFormatter(WithColor(true)),
+		"tableplain": NewPlainTableFormatter(WithColor(true)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			output := formatter.Format(diff)
+			assert.Contains(t, output, "\x1b[33m🟠 Moderate delay\x1b[0m")
+			assert.Contains(t, output, "\x1b[32mAdded\x1b[0m")
+			assert.Contains(t, output, " \x1b[36m→\x1b[0m ")
+		})
+	}
+
+	assert.NotContains(t, NewTextFormatter().Format(diff), "\x1b[")
+	assert.NotContains(t, NewPlainTableFormatter().Format(diff), "\x1b[")
+}
@@ -0,0 +1,144 @@
+package format
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// Columns of the timeline table. Any other column name is an item attribute.
+const (
+	ColumnTask     = "task"     // Title of the item
+	ColumnStatus   = "status"   // Added, Removed or the delay level of the item
+	ColumnDetails  = "details"  // What changed about the timeline
+	ColumnStart    = "start"    // Start date, with the previous one if it moved
+	ColumnEnd      = "end"      // End date, with the previous one if it moved
+	ColumnDuration = "duration" // Duration, with the change in days
+)
+
+// DefaultColumns are the columns of the timeline table unless configured otherwise
+var DefaultColumns = []string{ColumnTask, ColumnStatus, ColumnDetails, ColumnStart, ColumnEnd, ColumnDuration}
+
+// timelineColumns are the headers and alignments of the built-in timeline columns
+var timelineColumns = map[string]TableColumn{
+	ColumnTask:     {Header: "Task", Alignment: AlignLeft},
+	ColumnStatus:   {Header: "Status", Alignment: AlignCenter},
+	ColumnDetails:  {Header: "Details", Alignment: AlignLeft},
+	ColumnStart:    {Header: "Start Date", Alignment: AlignRight},
+	ColumnEnd:      {Header: "End Date", Alignment: AlignRight},
+	ColumnDuration: {Header: "Duration", Alignment: AlignRight},
+}
+
+// buildTimelineTable builds the table of added, removed and rescheduled items with the
+// configured columns. It also returns the index of the status column, -1 if not shown.
+func buildTimelineTable(diff types.ProjectDiff, options FormatterOptions) (*Table, int) {
+	columns := options.Columns
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+
+	table := &Table{}
+	if columns[0] == ColumnTask {
+		table.Links = itemLinks(diff)
+	}
+	statusColumn := -1
+	for i, name := range columns {
+		column, ok := timelineColumns[name]
+		if !ok {
+			column = TableColumn{Header: name, Alignment: AlignLeft}
+		}
+		if name == ColumnStatus && statusColumn < 0 {
+			statusColumn = i
+		}
+		table.Columns = append(table.Columns, column)
+	}
+
+	// Cells of built-in columns by name, attribute columns are read from the item
+	addRow := func(item types.Item, cells map[string]string) {
+		row := make([]string, len(columns))
+		for i, name := range columns {
+			if cell, ok := cells[name]; ok {
+				row[i] = cell
+			} else if _, builtIn := timelineColumns[name]; !builtIn {
+				row[i] = formatAttributeCell(item, name)
+			}
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	// Added items
+	for _, item := range diff.AddedItems {
+		addRow(item, map[string]string{
+			ColumnTask:     item.GetTitle(),
+			ColumnStatus:   options.label("Added"),
+			ColumnDetails:  options.Language.Translate("New task"),
+			ColumnStart:    formatDate(item.DateSpan.Start, options),
+			ColumnEnd:      formatDate(item.DateSpan.End, options) + dueSuffix(item, options),
+			ColumnDuration: options.Duration.Format(item.DateSpan.DurationDays()),
+		})
+	}
+
+	// Removed items
+	for _, item := range diff.RemovedItems {
+		addRow(item, map[string]string{
+			ColumnTask:     item.GetTitle(),
+			ColumnStatus:   options.label("Removed"),
+			ColumnDetails:  options.Language.Translate("Task removed"),
+			ColumnStart:    formatDate(item.DateSpan.Start, options),
+			ColumnEnd:      formatDate(item.DateSpan.End, options),
+			ColumnDuration: options.Duration.Format(item.DateSpan.DurationDays()),
+		})
+	}
+
+	// Changed items, handling timeline changes via DateSpan only
+	for _, change := range diff.ChangedItems {
+		if change.DateChange == nil {
+			continue
+		}
+		delay := calculateTimelineDelayLevel(
+			change.DateChange.StartDaysDelta,
+			change.DateChange.DurationDelta,
+			options.ModerateDelayThreshold,
+			options.HighDelayThreshold,
+			options.ExtremeDelayThreshold,
+		)
+		durationDiff := ""
+		if change.DateChange.DurationDelta != 0 {
+			durationDiff = fmt.Sprintf(" (%+d days)", change.DateChange.DurationDelta)
+		}
+
+		addRow(change.After, map[string]string{
+			ColumnTask:     change.After.GetTitle(),
+			ColumnStatus:   options.label(string(delay)),
+			ColumnDetails:  formatTimelineDetails(change.DateChange, change.Before.DateSpan, change.After.DateSpan, options.Duration),
+			ColumnStart:    formatDateWithChange(change.After.DateSpan.Start, change.Before.DateSpan.Start, options),
+			ColumnEnd:      formatDateWithChange(change.After.DateSpan.End, change.Before.DateSpan.End, options) + dueSuffix(change.After, options),
+			ColumnDuration: options.Duration.Format(change.After.DateSpan.DurationDays()) + durationDiff,
+		})
+	}
+
+	return table, statusColumn
+}
+
+// dueSuffix returns the relative due date of an item for its end date cell, e.g.
+// " (overdue by 2 days)", like the due line of text output. It is empty without a
+// project timezone.
+func dueSuffix(item types.Item, options FormatterOptions) string {
+	if options.Location == nil {
+		return ""
+	}
+	if due := formatItemDue(item, time.Now(), options.Location, options.Duration); due != "" {
+		return " (" + due + ")"
+	}
+	return ""
+}
+
+// formatAttributeCell formats the value of an item attribute for a table cell, "-" if unset
+func formatAttributeCell(item types.Item, name string) string {
+	value, ok := item.Attributes[name]
+	if !ok || value == nil || types.FormatValue(value) == "" {
+		return "-"
+	}
+	return types.FormatValue(value)
+}
@@ -0,0 +1,74 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTimelineTableColumns(t *testing.T) {
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	added := types.Item{
+		ID:         "1",
+		URL:        "https://github.com/org/repo/issues/1",
+		DateSpan:   types.DateSpan{Start: start, End: start.AddDate(0, 0, 4)},
+		Attributes: map[string]interface{}{"Title": "New API", "Team": "Backend"},
+	}
+	before := types.Item{ID: "2", DateSpan: types.DateSpan{Start: start, End: start.AddDate(0, 0, 4)}, Attributes: map[string]interface{}{"Title": "Login"}}
+	after := before
+	after.DateSpan.End = start.AddDate(0, 0, 24)
+	diff := types.ProjectDiff{
+		AddedItems:   []types.Item{added},
+		ChangedItems: []types.ItemDiff{before.CompareTo(after)},
+	}
+
+	t.Run("default columns", func(t *testing.T) {
+		table, statusColumn := buildTimelineTable(diff, DefaultOptions())
+		require.Len(t, table.Columns, len(DefaultColumns))
+		assert.Equal(t, "Task", table.Columns[0].Header)
+		assert.Equal(t, 1, statusColumn)
+		assert.Equal(t, "https://github.com/org/repo/issues/1", table.Links["New API"])
+	})
+
+	t.Run("selected columns with attributes", func(t *testing.T) {
+		options := DefaultOptions()
+		options.Columns = []string{ColumnTask, ColumnEnd, "Team", ColumnStatus}
+		table, statusColumn := buildTimelineTable(diff, options)
+
+		headers := make([]string, len(table.Columns))
+		for i, column := range table.Columns {
+			headers[i] = column.Header
+		}
+		assert.Equal(t, []string{"Task", "End Date", "Team", "Status"}, headers)
+		assert.Equal(t, 3, statusColumn)
+		assert.Equal(t, [][]string{
+			{"New API", "Mar 8, 2024", "Backend", "Added"},
+			{"Login", "Mar 8, 2024 → Mar 28, 2024", "-", string(DelayLevelHigh)},
+		}, table.Rows)
+	})
+
+	t.Run("project timezone adds due dates", func(t *testing.T) {
+		options := DefaultOptions()
+		options.Columns = []string{ColumnTask, ColumnEnd}
+		options.Location = time.UTC
+		table, _ := buildTimelineTable(diff, options)
+		assert.Regexp(t, `^Mar 8, 2024 \(overdue by .+\)$`, table.Rows[0][1])
+		assert.Regexp(t, `^Mar 8, 2024 → Mar 28, 2024 \(overdue by .+\)$`, table.Rows[1][1])
+
+		// Markdown and plain tables render the same timeline table as text output
+		assert.Contains(t, NewTableFormatter(WithLocation(time.UTC)).Format(diff), "(overdue by")
+		assert.Contains(t, NewPlainTableFormatter(WithLocation(time.UTC)).Format(diff), "(overdue by")
+		assert.NotContains(t, NewTableFormatter().Format(diff), "overdue")
+	})
+
+	t.Run("no links without the task first", func(t *testing.T) {
+		options := DefaultOptions()
+		options.Columns = []string{"Team", ColumnTask}
+		table, statusColumn := buildTimelineTable(diff, options)
+		assert.Nil(t, table.Links)
+		assert.Equal(t, -1, statusColumn)
+	})
+}
@@ -0,0 +1,73 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// commentsSectionTitle is the title of the section with the latest comments of slipped items
+const commentsSectionTitle = "💬 Slip Context"
+
+// SlippedItems returns the changed items with a high or extreme delay, for which
+// the latest comment is worth fetching as context
+func SlippedItems(diff types.ProjectDiff, options FormatterOptions) []types.ItemDiff {
+	var slipped []types.ItemDiff
+	for _, change := range diff.ChangedItems {
+		if level := timelineDelayLevel(change, options); level == DelayLevelHigh || level == DelayLevelExtreme {
+			slipped = append(slipped, change)
+		}
+	}
+	return slipped
+}
+
+// timelineDelayLevel returns the delay level of a change, or an empty level if its timeline didn't change
+func timelineDelayLevel(change types.ItemDiff, options FormatterOptions) DelayLevel {
+	if change.DateChange == nil {
+		return ""
+	}
+	return calculateTimelineDelayLevel(
+		change.DateChange.StartDaysDelta,
+		change.DateChange.DurationDelta,
+		options.ModerateDelayThreshold,
+		options.HighDelayThreshold,
+		options.ExtremeDelayThreshold,
+	)
+}
+
+// buildCommentsTable builds the table of the latest comments of slipped items,
+// or nil if no comments were fetched
+func buildCommentsTable(diff types.ProjectDiff, options FormatterOptions) *Table {
+	if len(options.Comments) == 0 {
+		return nil
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Task", Alignment: AlignLeft},
+			{Header: "Status", Alignment: AlignLeft},
+			{Header: "Latest Comment", Alignment: AlignLeft},
+		},
+	}
+	for _, change := range SlippedItems(diff, options) {
+		comment, ok := options.Comments[change.ItemID]
+		if !ok {
+			continue
+		}
+		table.Rows = append(table.Rows, []string{
+			change.After.GetTitle(),
+			options.label(string(timelineDelayLevel(change, options))),
+			formatComment(comment, options),
+		})
+	}
+
+	if len(table.Rows) == 0 {
+		return nil
+	}
+	return table
+}
+
+// formatComment formats a comment as a single line, e.g. "@alice on Jan 2, 2024: Blocked on review"
+func formatComment(comment types.Comment, options FormatterOptions) string {
+	return fmt.Sprintf("%s on %s: %s", types.Mention(comment.Author), formatTimestamp(comment.CreatedAt, options), comment.FirstLine())
+}
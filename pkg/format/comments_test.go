@@ -0,0 +1,55 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlippedItems(t *testing.T) {
+	diff := createTestDiff() // 8 days delay
+
+	assert.Empty(t, SlippedItems(diff, DefaultOptions()))
+
+	options := DefaultOptions()
+	WithHighDelayThreshold(8)(&options)
+	slipped := SlippedItems(diff, options)
+	require.Len(t, slipped, 1)
+	assert.Equal(t, "changed-1", slipped[0].ItemID)
+}
+
+func TestFormattersComments(t *testing.T) {
+	diff := createTestDiff()
+	comments := map[string]types.Comment{
+		"changed-1": {
+			Author:    "alice",
+			CreatedAt: time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC),
+			Body:      "Blocked on the API review\nMore details",
+		},
+	}
+
+	t.Run("only slipped items", func(t *testing.T) {
+		output := NewTableFormatter(WithComments(comments)).Format(diff)
+		assert.NotContains(t, output, commentsSectionTitle)
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		output := NewTableFormatter(WithHighDelayThreshold(8), WithComments(comments)).Format(diff)
+		assert.Contains(t, output, "## 💬 Slip Context")
+		assert.Contains(t, output, "| Changed Task | 🔴 High delay | @alice on Jan 20, 2024: Blocked on the API review |")
+		assert.NotContains(t, output, "More details")
+	})
+
+	t.Run("text", func(t *testing.T) {
+		output := NewTextFormatter(WithHighDelayThreshold(8), WithComments(comments)).Format(diff)
+		assert.Contains(t, output, "Slip Context:\n- Changed Task (🔴 High delay)\n  @alice on Jan 20, 2024: Blocked on the API review\n")
+	})
+
+	t.Run("section can be deselected", func(t *testing.T) {
+		output := NewTableFormatter(WithHighDelayThreshold(8), WithComments(comments), WithSections([]string{SectionTimeline})).Format(diff)
+		assert.NotContains(t, output, commentsSectionTitle)
+	})
+}
@@ -0,0 +1,125 @@
+package format
+
+import (
+	"html"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// confluenceStatusColours maps delay levels, in English and with emoji, to the colour of
+// their Confluence status macro. Status macros have no orange, so moderate delays are
+// yellow, and extreme delays are purple to set them apart from high ones.
+var confluenceStatusColours = map[string]string{
+	string(DelayLevelAhead):    "Green",
+	string(DelayLevelOnTrack):  "Blue",
+	string(DelayLevelModerate): "Yellow",
+	string(DelayLevelHigh):     "Red",
+	string(DelayLevelExtreme):  "Purple",
+}
+
+// ConfluenceFormatter formats project diffs in the Confluence storage format, the XHTML
+// of Confluence pages, with delay levels as status macros. The report title is left
+// to the page.
+type ConfluenceFormatter struct {
+	tables   *TableFormatter
+	renderer *ConfluenceRenderer
+}
+
+// NewConfluenceFormatter creates a new Confluence formatter with the given options
+func NewConfluenceFormatter(opts ...func(*FormatterOptions)) *ConfluenceFormatter {
+	return &ConfluenceFormatter{
+		tables:   NewTableFormatter(opts...),
+		renderer: &ConfluenceRenderer{},
+	}
+}
+
+// Format formats the project diff in the Confluence storage format
+func (f *ConfluenceFormatter) Format(diff types.ProjectDiff) string {
+	if !hasChanges(diff) {
+		return "<p>" + html.EscapeString(noChangesMessage(diff)) + "</p>\n"
+	}
+
+	doc := f.tables.buildDocument(diff)
+	return f.renderer.RenderDocument(&doc)
+}
+
+// ConfluenceRenderer handles rendering generic types into the Confluence storage format
+type ConfluenceRenderer struct{}
+
+// RenderTable converts a generic Table to a Confluence table. Delay levels are
+// rendered as status macros and first-column values with a link as anchors.
+func (r *ConfluenceRenderer) RenderTable(t *Table) string {
+	if len(t.Columns) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n<tbody>\n<tr>")
+	for _, col := range t.Columns {
+		sb.WriteString("<th>" + html.EscapeString(col.Header) + "</th>")
+	}
+	sb.WriteString("</tr>\n")
+
+	for _, row := range t.Rows {
+		sb.WriteString("<tr>")
+		for i, col := range t.Columns {
+			value := "-"
+			if i < len(row) {
+				value = row[i]
+			}
+
+			cell := html.EscapeString(value)
+			if colour, ok := confluenceStatusColours[canonicalStatus(value)]; ok {
+				cell = confluenceStatusMacro(value, colour)
+			} else if url, ok := t.Links[value]; ok && i == 0 {
+				cell = `<a href="` + html.EscapeString(url) + `">` + cell + "</a>"
+			}
+			sb.WriteString(`<td style="text-align: ` + string(col.Alignment) + `;">` + cell + "</td>")
+		}
+		sb.WriteString("</tr>\n")
+	}
+
+	sb.WriteString("</tbody>\n</table>\n")
+	return sb.String()
+}
+
+// confluenceStatusMacro returns a status macro showing a status without its emoji or
+// brackets, e.g. "HIGH DELAY" in red for "🔴 High delay"
+func confluenceStatusMacro(status, colour string) string {
+	title := strings.Trim(stripEmoji(status), "[]")
+	return `<ac:structured-macro ac:name="status">` +
+		`<ac:parameter ac:name="colour">` + colour + `</ac:parameter>` +
+		`<ac:parameter ac:name="title">` + html.EscapeString(title) + `</ac:parameter>` +
+		`</ac:structured-macro>`
+}
+
+// RenderSection converts a generic Section to the Confluence storage format
+func (r *ConfluenceRenderer) RenderSection(s *Section) string {
+	var sb strings.Builder
+
+	if s.Title != "" {
+		sb.WriteString("<h2>" + html.EscapeString(s.Title) + "</h2>\n")
+	}
+
+	if s.Table != nil {
+		sb.WriteString(r.RenderTable(s.Table))
+	} else if s.Text != "" {
+		sb.WriteString("<p>" + html.EscapeString(s.Text) + "</p>\n")
+	}
+
+	if s.Note != "" {
+		sb.WriteString("<p><em>" + html.EscapeString(s.Note) + "</em></p>\n")
+	}
+
+	return sb.String()
+}
+
+// RenderDocument converts a generic Document to the body of a Confluence page
+func (r *ConfluenceRenderer) RenderDocument(d *Document) string {
+	var sb strings.Builder
+	for _, section := range d.Sections {
+		sb.WriteString(r.RenderSection(&section))
+	}
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfluenceFormatter(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems[0].After.URL = "https://github.com/org/repo/issues/1"
+	diff.AddedItems[0].Attributes["Title"] = "New <Task>"
+
+	output := NewConfluenceFormatter().Format(diff)
+
+	assert.NotContains(t, output, "<h1>")
+	assert.Contains(t, output, "<h2>📅 Timeline Changes</h2>\n<table>\n<tbody>\n<tr><th>Task</th><th>Status</th>")
+	assert.Contains(t, output, `<td style="text-align: left;"><a href="https://github.com/org/repo/issues/1">Changed Task</a></td>`)
+	assert.Contains(t, output, `<td style="text-align: center;"><ac:structured-macro ac:name="status"><ac:parameter ac:name="colour">Yellow</ac:parameter><ac:parameter ac:name="title">Moderate delay</ac:parameter></ac:structured-macro></td>`)
+	assert.Contains(t, output, `<td style="text-align: center;">Added</td>`)
+	assert.Contains(t, output, "New &lt;Task&gt;")
+}
+
+func TestConfluenceFormatterNoChanges(t *testing.T) {
+	output := NewConfluenceFormatter().Format(types.ProjectDiff{})
+	assert.Equal(t, "<p>No changes found in the project timeline.</p>\n", output)
+}
+
+func TestConfluenceStatusMacro(t *testing.T) {
+	tests := []struct {
+		status string
+		title  string
+	}{
+		{string(DelayLevelHigh), "High delay"},
+		{"[HIGH]", "HIGH"},
+		{LanguageGerman.Translate(string(DelayLevelHigh)), "Hohe Verzögerung"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, `<ac:structured-macro ac:name="status"><ac:parameter ac:name="colour">Red</ac:parameter><ac:parameter ac:name="title">`+tt.title+`</ac:parameter></ac:structured-macro>`,
+			confluenceStatusMacro(tt.status, "Red"), tt.status)
+	}
+}
+
+func TestConfluenceStatusColours(t *testing.T) {
+	r := &ConfluenceRenderer{}
+	table := &Table{
+		Columns: []TableColumn{{Header: "Status", Alignment: AlignCenter}},
+		Rows:    [][]string{{string(DelayLevelHigh)}, {string(DelayLevelExtreme)}},
+	}
+
+	output := r.RenderTable(table)
+	assert.Contains(t, output, `<ac:parameter ac:name="colour">Red</ac:parameter><ac:parameter ac:name="title">High delay</ac:parameter>`)
+	assert.Contains(t, output, `<ac:parameter ac:name="colour">Purple</ac:parameter><ac:parameter ac:name="title">Extreme delay</ac:parameter>`)
+
+	// Every delay level has a colour of its own
+	colours := make(map[string]DelayLevel)
+	for _, level := range []DelayLevel{DelayLevelAhead, DelayLevelOnTrack, DelayLevelModerate, DelayLevelHigh, DelayLevelExtreme} {
+		colour, ok := confluenceStatusColours[string(level)]
+		assert.True(t, ok, level)
+		assert.NotContains(t, colours, colour, "%s has the colour of %s", level, colours[colour])
+		colours[colour] = level
+	}
+}
+
+func TestConfluenceRendererNote(t *testing.T) {
+	r := &ConfluenceRenderer{}
+	output := r.RenderSection(&Section{Title: "Items", Text: "a & b", Note: "…and 2 more"})
+	assert.Equal(t, "<h2>Items</h2>\n<p>a &amp; b</p>\n<p><em>…and 2 more</em></p>\n", output)
+}
@@ -0,0 +1,51 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// coverageBarWidth is the width of the bars in the coverage report
+const coverageBarWidth = 10
+
+// BuildCoverageDocument builds the attribute coverage report, one row per snapshot
+func BuildCoverageDocument(coverages []types.Coverage, estimateField, ownerField string, options FormatterOptions) Document {
+	if ownerField == "" {
+		ownerField = types.AssigneesAttribute
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Snapshot", Alignment: AlignLeft},
+			{Header: "Items", Alignment: AlignRight},
+			{Header: "Dates", Alignment: AlignLeft},
+			{Header: estimateField, Alignment: AlignLeft},
+			{Header: ownerField, Alignment: AlignLeft},
+		},
+	}
+	for _, c := range coverages {
+		table.Rows = append(table.Rows, []string{
+			formatTimestamp(c.Timestamp, options),
+			fmt.Sprintf("%d", c.Items),
+			formatCoverage(c.Ratio(c.WithDates)),
+			formatCoverage(c.Ratio(c.WithEstimate)),
+			formatCoverage(c.Ratio(c.WithOwner)),
+		})
+	}
+
+	return Document{
+		Title: "Attribute Coverage",
+		Sections: []Section{{
+			Title: "🧮 Share of items with planning attributes set",
+			Table: table,
+		}},
+	}
+}
+
+// formatCoverage formats a ratio as a percentage with a bar, e.g. "60% ██████░░░░"
+func formatCoverage(ratio float64) string {
+	filled := int(ratio*coverageBarWidth + 0.5)
+	return fmt.Sprintf("%3.0f%% %s%s", ratio*100, strings.Repeat("█", filled), strings.Repeat("░", coverageBarWidth-filled))
+}
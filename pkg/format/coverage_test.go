@@ -0,0 +1,24 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCoverageDocument(t *testing.T) {
+	coverages := []types.Coverage{
+		{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Items: 10, WithDates: 5, WithEstimate: 2, WithOwner: 10},
+		{Timestamp: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	doc := BuildCoverageDocument(coverages, "Estimate", "", DefaultOptions())
+	require.Len(t, doc.Sections, 1)
+	table := doc.Sections[0].Table
+	assert.Equal(t, "Assignees", table.Columns[4].Header)
+	assert.Equal(t, []string{"Jan 1, 2024", "10", " 50% █████░░░░░", " 20% ██░░░░░░░░", "100% ██████████"}, table.Rows[0])
+	assert.Equal(t, "  0% ░░░░░░░░░░", table.Rows[1][2])
+}
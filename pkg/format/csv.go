@@ -0,0 +1,85 @@
+package format
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// csvHeader lists the columns of the CSV export
+var csvHeader = []string{
+	"id",
+	"title",
+	"change",
+	"start_before",
+	"start_after",
+	"end_before",
+	"end_after",
+	"duration_delta_days",
+	"delay_level",
+}
+
+// CSVFormatter formats project diffs as CSV with one row per added, removed or changed item
+type CSVFormatter struct {
+	options FormatterOptions
+}
+
+// NewCSVFormatter creates a new CSV formatter with the given options
+func NewCSVFormatter(opts ...func(*FormatterOptions)) *CSVFormatter {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &CSVFormatter{options: options}
+}
+
+// Format formats the project diff as CSV. Dates use the YYYY-MM-DD format and
+// delay levels their machine-readable names, e.g. "high".
+func (f *CSVFormatter) Format(diff types.ProjectDiff) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	w.Write(csvHeader)
+
+	for _, item := range diff.AddedItems {
+		w.Write([]string{
+			item.ID, item.GetTitle(), "added",
+			"", csvDate(item.DateSpan.Start),
+			"", csvDate(item.DateSpan.End),
+			"", "",
+		})
+	}
+	for _, item := range diff.RemovedItems {
+		w.Write([]string{
+			item.ID, item.GetTitle(), "removed",
+			csvDate(item.DateSpan.Start), "",
+			csvDate(item.DateSpan.End), "",
+			"", "",
+		})
+	}
+	for _, change := range diff.ChangedItems {
+		durationDelta := ""
+		if change.DateChange != nil {
+			durationDelta = strconv.Itoa(change.DateChange.DurationDelta)
+		}
+		w.Write([]string{
+			change.ItemID, change.After.GetTitle(), "changed",
+			csvDate(change.Before.DateSpan.Start), csvDate(change.After.DateSpan.Start),
+			csvDate(change.Before.DateSpan.End), csvDate(change.After.DateSpan.End),
+			durationDelta, delayLevelKeys[timelineDelayLevel(change, f.options)],
+		})
+	}
+
+	w.Flush()
+	return sb.String()
+}
+
+// csvDate formats a date for CSV, leaving unset dates empty
+func csvDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,34 @@
+package format
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVFormatter(t *testing.T) {
+	diff := createTestDiff()
+	diff.AddedItems[0].Attributes["Title"] = "New Task, with comma"
+
+	output := NewCSVFormatter().Format(diff)
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, "New Task, with comma", records[1][1])
+	assert.Equal(t, "added", records[1][2])
+	assert.Equal(t, "", records[1][3])
+	assert.Equal(t, "removed", records[2][2])
+	assert.Equal(t, []string{"changed-1", "Changed Task", "changed", "2024-01-01", "2024-01-01", "2024-01-15", "2024-01-31", "8", "moderate"}, records[3])
+}
+
+func TestCSVFormatterNoChanges(t *testing.T) {
+	output := NewCSVFormatter().Format(types.ProjectDiff{})
+	assert.Equal(t, strings.Join(csvHeader, ",")+"\n", output)
+}
@@ -0,0 +1,143 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+const (
+	// dashboardLogLines is the number of recent changes kept in the dashboard log
+	dashboardLogLines = 15
+	// dashboardGaugeWidth is the width of the delayed-item gauge in characters
+	dashboardGaugeWidth = 30
+	// clearScreen moves the cursor home and clears the terminal
+	clearScreen = "\033[H\033[2J"
+)
+
+// Dashboard is a live terminal view of a watched project, refreshed after each capture
+type Dashboard struct {
+	ProjectNumber int
+	Interval      time.Duration
+	LastCapture   time.Time
+	Items         int
+	Delayed       int // Items whose end date has passed
+	Log           []string
+}
+
+// NewDashboard creates an empty dashboard for the given project
+func NewDashboard(projectNumber int, interval time.Duration) *Dashboard {
+	return &Dashboard{ProjectNumber: projectNumber, Interval: interval}
+}
+
+// Update records a new capture. The diff against the previous capture is added
+// to the change log; it is nil for the first capture.
+func (d *Dashboard) Update(state *types.ProjectState, diff *types.ProjectDiff) error {
+	loc, err := state.Location()
+	if err != nil {
+		return err
+	}
+
+	d.LastCapture = state.Timestamp
+	d.Items = len(state.Items)
+	d.Delayed = 0
+	for _, item := range state.Items {
+		if item.DateSpan.IsOverdue(state.Timestamp, loc) && !item.IsClosed() {
+			d.Delayed++
+		}
+	}
+
+	for _, warning := range state.Warnings {
+		d.log(state.Timestamp, "Warning: "+warning)
+	}
+
+	if diff == nil {
+		d.log(state.Timestamp, fmt.Sprintf("Initial capture with %d items", d.Items))
+		return nil
+	}
+	if !hasChanges(*diff) {
+		d.log(state.Timestamp, "No changes")
+		return nil
+	}
+	for _, item := range diff.AddedItems {
+		d.log(state.Timestamp, "+ "+item.GetTitle())
+	}
+	for _, item := range diff.RemovedItems {
+		d.log(state.Timestamp, "- "+item.GetTitle())
+	}
+	for _, change := range diff.ChangedItems {
+		d.log(state.Timestamp, "~ "+describeChange(change))
+	}
+	return nil
+}
+
+// LogMessage adds a message to the change log
+func (d *Dashboard) LogMessage(at time.Time, message string) {
+	d.log(at, message)
+}
+
+// LogError records a failed capture in the change log
+func (d *Dashboard) LogError(at time.Time, err error) {
+	d.log(at, "Error: "+err.Error())
+}
+
+// log appends an entry to the change log, dropping the oldest entries
+func (d *Dashboard) log(at time.Time, entry string) {
+	d.Log = append(d.Log, fmt.Sprintf("%s  %s", at.Format("15:04:05"), entry))
+	if len(d.Log) > dashboardLogLines {
+		d.Log = d.Log[len(d.Log)-dashboardLogLines:]
+	}
+}
+
+// Render clears the terminal and draws the dashboard
+func (d *Dashboard) Render(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString(clearScreen)
+	sb.WriteString(fmt.Sprintf("gh-project-report — project %d (every %s)\n\n", d.ProjectNumber, d.Interval))
+	if d.LastCapture.IsZero() {
+		sb.WriteString("Last capture: waiting for first capture…\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Last capture: %s\n", d.LastCapture.Format(time.RFC1123)))
+	}
+	sb.WriteString(fmt.Sprintf("Items:        %d\n", d.Items))
+	sb.WriteString(fmt.Sprintf("Delayed:      %s %d/%d\n\n", gauge(d.Delayed, d.Items, dashboardGaugeWidth), d.Delayed, d.Items))
+	sb.WriteString("Recent changes:\n")
+	for i := len(d.Log) - 1; i >= 0; i-- {
+		sb.WriteString("  " + d.Log[i] + "\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// gauge draws a horizontal bar filled proportionally to value/total
+func gauge(value, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = value * width / total
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
+}
+
+// describeChange summarizes an item change in a single line
+func describeChange(change types.ItemDiff) string {
+	title := change.After.GetTitle()
+	if change.DateChange != nil && change.DateChange.EndDaysDelta != 0 {
+		return fmt.Sprintf("%s: end date %+dd", title, change.DateChange.EndDaysDelta)
+	}
+
+	var fields []string
+	for _, fieldChange := range change.FieldChanges {
+		if fieldChange.Field == "updated_at" || fieldChange.Field == "created_at" {
+			continue
+		}
+		fields = append(fields, fieldChange.Field+" "+formatFieldChange(fieldChange))
+	}
+	if len(fields) == 0 {
+		return title + ": changed"
+	}
+	return title + ": " + strings.Join(fields, ", ")
+}
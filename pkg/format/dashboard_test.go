@@ -0,0 +1,66 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboard(t *testing.T) {
+	now := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+	state := &types.ProjectState{
+		Timestamp: now,
+		Items: []types.Item{
+			{ID: "1", DateSpan: types.MustNewDateSpan("2024-01-01", "2024-01-31"), Attributes: map[string]interface{}{"Title": "Overdue Task"}},
+			{ID: "2", DateSpan: types.MustNewDateSpan("2024-01-15", "2024-02-15"), Attributes: map[string]interface{}{"Title": "Running Task"}},
+		},
+	}
+
+	d := NewDashboard(123, 5*time.Minute)
+	require.NoError(t, d.Update(state, nil))
+	assert.Equal(t, 2, d.Items)
+	assert.Equal(t, 1, d.Delayed)
+
+	diff := createTestDiff()
+	require.NoError(t, d.Update(state, &diff))
+	d.LogMessage(now, "Capture triggered by user defined signal 1")
+	d.LogError(now, errors.New("rate limited"))
+
+	var sb strings.Builder
+	require.NoError(t, d.Render(&sb))
+	output := sb.String()
+
+	assert.True(t, strings.HasPrefix(output, clearScreen))
+	assert.Contains(t, output, "project 123 (every 5m0s)")
+	assert.Contains(t, output, "Items:        2\n")
+	assert.Contains(t, output, "[###############---------------] 1/2")
+	assert.Contains(t, output, "12:00:00  Initial capture with 2 items")
+	assert.Contains(t, output, "+ New Task")
+	assert.Contains(t, output, "- Removed Task")
+	assert.Contains(t, output, "~ Changed Task: end date +")
+	assert.Contains(t, output, "12:00:00  Capture triggered by user defined signal 1")
+
+	// Newest entries are shown first
+	assert.Less(t, strings.Index(output, "Error: rate limited"), strings.Index(output, "Initial capture"))
+}
+
+func TestDashboardLogIsBounded(t *testing.T) {
+	d := NewDashboard(123, time.Minute)
+	for i := 0; i < dashboardLogLines+5; i++ {
+		d.LogError(time.Now(), fmt.Errorf("error %d", i))
+	}
+	require.Len(t, d.Log, dashboardLogLines)
+	assert.Contains(t, d.Log[0], "error 5")
+}
+
+func TestGauge(t *testing.T) {
+	assert.Equal(t, "[----]", gauge(0, 0, 4))
+	assert.Equal(t, "[##--]", gauge(1, 2, 4))
+	assert.Equal(t, "[####]", gauge(3, 3, 4))
+}
@@ -0,0 +1,76 @@
+package format
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// DiagnosticLevel is the severity of a diagnostic
+type DiagnosticLevel string
+
+const (
+	DiagnosticInfo    DiagnosticLevel = "info"
+	DiagnosticWarning DiagnosticLevel = "warning"
+)
+
+// Stable codes of diagnostics, for automation to match on
+const (
+	DiagnosticStateWarning  = "state_warning"  // Warning recorded in a snapshot when it was captured
+	DiagnosticSnapshotDrift = "snapshot_drift" // A compared snapshot is far from the requested time
+	DiagnosticRangeExpanded = "range_expanded" // The range was expanded to the nearest two snapshots
+	DiagnosticUndatedItems  = "undated_items"  // Items without start and end date have no delay level
+)
+
+// DefaultDriftTolerance is how far a snapshot may be from the requested time before it is reported
+const DefaultDriftTolerance = 24 * time.Hour
+
+// Diagnostic is a caveat of a report, e.g. that a compared snapshot is older than requested
+type Diagnostic struct {
+	Level   DiagnosticLevel `json:"level"`
+	Code    string          `json:"code"`
+	Message string          `json:"message"`
+}
+
+// SnapshotDrift returns a diagnostic if the snapshot compared as one end of the
+// range ("from" or "to") was taken more than the tolerance away from the requested time
+func SnapshotDrift(end string, requested, actual time.Time, tolerance time.Duration) (Diagnostic, bool) {
+	drift := actual.Sub(requested)
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift <= tolerance {
+		return Diagnostic{}, false
+	}
+
+	relation := "after"
+	if actual.Before(requested) {
+		relation = "before"
+	}
+	return Diagnostic{
+		Level: DiagnosticWarning,
+		Code:  DiagnosticSnapshotDrift,
+		Message: fmt.Sprintf("%s snapshot was captured %s, %s %s the requested %s",
+			end, actual.Format(time.RFC3339), drift.Round(time.Minute), relation, requested.Format(time.RFC3339)),
+	}, true
+}
+
+// undatedItemsDiagnostic returns a diagnostic if items of the current state lack
+// a start or end date and are therefore left out of the timeline analysis
+func undatedItemsDiagnostic(diff types.ProjectDiff) (Diagnostic, bool) {
+	undated := 0
+	for _, item := range diff.CurrentItems {
+		if item.DateSpan.Start.IsZero() || item.DateSpan.End.IsZero() {
+			undated++
+		}
+	}
+	if undated == 0 {
+		return Diagnostic{}, false
+	}
+	return Diagnostic{
+		Level:   DiagnosticInfo,
+		Code:    DiagnosticUndatedItems,
+		Message: fmt.Sprintf("%d of %d items have no start or end date and are left out of the timeline analysis", undated, len(diff.CurrentItems)),
+	}, true
+}
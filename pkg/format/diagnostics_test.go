@@ -0,0 +1,56 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotDrift(t *testing.T) {
+	requested := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		actual   time.Time
+		wantOK   bool
+		contains string
+	}{
+		{name: "exact", actual: requested},
+		{name: "within tolerance", actual: requested.Add(-23 * time.Hour)},
+		{name: "before", actual: requested.Add(-72 * time.Hour), wantOK: true, contains: "72h0m0s before"},
+		{name: "after", actual: requested.Add(36 * time.Hour), wantOK: true, contains: "36h0m0s after"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostic, ok := SnapshotDrift("from", requested, tt.actual, DefaultDriftTolerance)
+			assert.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			assert.Equal(t, DiagnosticWarning, diagnostic.Level)
+			assert.Equal(t, DiagnosticSnapshotDrift, diagnostic.Code)
+			assert.Contains(t, diagnostic.Message, tt.contains)
+			assert.Contains(t, diagnostic.Message, "from snapshot")
+		})
+	}
+}
+
+func TestJSONFormatterDiagnostics(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	diff := types.ProjectDiff{
+		CurrentItems: []types.Item{
+			{ID: "1", DateSpan: types.DateSpan{Start: start, End: start.AddDate(0, 0, 5)}},
+			{ID: "2"},
+		},
+	}
+	drift := Diagnostic{Level: DiagnosticWarning, Code: DiagnosticSnapshotDrift, Message: "from snapshot drifted"}
+
+	output := NewJSONFormatter(WithDiagnostics([]Diagnostic{drift})).Format(diff)
+
+	assert.Contains(t, output, `"code": "snapshot_drift"`)
+	assert.Contains(t, output, `"code": "undated_items"`)
+	assert.Contains(t, output, "1 of 2 items have no start or end date")
+}
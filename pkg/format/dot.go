@@ -0,0 +1,107 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// dotColors maps delay levels to node fill colors
+var dotColors = map[DelayLevel]string{
+	DelayLevelAhead:    "#c2e0c6",
+	DelayLevelOnTrack:  "#c5def5",
+	DelayLevelModerate: "#fbca04",
+	DelayLevelHigh:     "#f9a03f",
+	DelayLevelExtreme:  "#e99695",
+}
+
+// DOTFormatter formats the blocking relationships of a project as a Graphviz graph,
+// with nodes colored by delay level
+type DOTFormatter struct {
+	options FormatterOptions
+}
+
+// NewDOTFormatter creates a new DOT formatter with the given options
+func NewDOTFormatter(opts ...func(*FormatterOptions)) *DOTFormatter {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &DOTFormatter{options: options}
+}
+
+// Format formats the project diff as a Graphviz DOT graph. The graph contains
+// all current items that block or are blocked by another item, or are delayed.
+// Edges point from the blocking item to the blocked item and are drawn red when
+// the blocking item is delayed, showing where slip propagates.
+func (f *DOTFormatter) Format(diff types.ProjectDiff) string {
+	levels := make(map[string]DelayLevel)
+	for _, change := range diff.ChangedItems {
+		if level := timelineDelayLevel(change, f.options); level != "" {
+			levels[change.ItemID] = level
+		}
+	}
+
+	// Resolve blocking relationships between items of the project
+	byContentID := make(map[string]types.Item)
+	for _, item := range diff.CurrentItems {
+		if item.ContentID != "" {
+			byContentID[item.ContentID] = item
+		}
+	}
+	type edge struct{ from, to string }
+	var edges []edge
+	linked := make(map[string]bool)
+	for _, item := range diff.CurrentItems {
+		for _, contentID := range item.BlockedBy {
+			blocker, ok := byContentID[contentID]
+			if !ok {
+				continue
+			}
+			edges = append(edges, edge{from: blocker.ID, to: item.ID})
+			linked[blocker.ID] = true
+			linked[item.ID] = true
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph project {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=\"#ffffff\", fontname=\"Helvetica\"];\n")
+
+	for _, item := range diff.CurrentItems {
+		level, delayed := levels[item.ID]
+		if !linked[item.ID] && !isDelayed(level) {
+			continue
+		}
+
+		label := item.GetTitle()
+		attrs := ""
+		if delayed {
+			label += "\n" + delayLevelName(level)
+			attrs = fmt.Sprintf(", fillcolor=%q", dotColors[level])
+		}
+		sb.WriteString(fmt.Sprintf("  %q [label=%q%s];\n", item.ID, label, attrs))
+	}
+
+	for _, e := range edges {
+		attrs := ""
+		if isDelayed(levels[e.from]) {
+			attrs = " [color=\"#b60205\", penwidth=2]"
+		}
+		sb.WriteString(fmt.Sprintf("  %q -> %q%s;\n", e.from, e.to, attrs))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// delayLevelName returns the delay level without its emoji, e.g. "High delay"
+func delayLevelName(level DelayLevel) string {
+	parts := strings.SplitN(string(level), " ", 2)
+	if len(parts) != 2 {
+		return string(level)
+	}
+	return parts[1]
+}
@@ -0,0 +1,42 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDOTFormatter(t *testing.T) {
+	diff := createTestDiff()
+	changed := diff.ChangedItems[0].After
+	changed.ContentID = "I_changed"
+
+	blocked := types.Item{
+		ID:         "blocked-1",
+		ContentID:  "I_blocked",
+		BlockedBy:  []string{"I_changed", "I_outside_project"},
+		Attributes: map[string]interface{}{"Title": "Blocked \"Task\""},
+	}
+	unrelated := types.Item{ID: "unrelated-1", Attributes: map[string]interface{}{"Title": "Unrelated Task"}}
+	diff.CurrentItems = []types.Item{changed, blocked, unrelated}
+
+	output := NewDOTFormatter().Format(diff)
+
+	assert.Contains(t, output, "digraph project {\n")
+	assert.Contains(t, output, `  "changed-1" [label="Changed Task\nModerate delay", fillcolor="#fbca04"];`)
+	assert.Contains(t, output, `  "blocked-1" [label="Blocked \"Task\""];`)
+	assert.Contains(t, output, `  "changed-1" -> "blocked-1" [color="#b60205", penwidth=2];`)
+	assert.NotContains(t, output, "Unrelated Task")
+	assert.NotContains(t, output, "I_outside_project")
+
+	// Without delay, blocking edges are drawn plainly
+	output = NewDOTFormatter(WithModerateDelayThreshold(10), WithHighDelayThreshold(15)).Format(diff)
+	assert.Contains(t, output, `  "changed-1" [label="Changed Task\nOn track", fillcolor="#c5def5"];`)
+	assert.Contains(t, output, `  "changed-1" -> "blocked-1";`)
+}
+
+func TestDelayLevelName(t *testing.T) {
+	assert.Equal(t, "Extreme delay", delayLevelName(DelayLevelExtreme))
+	assert.Equal(t, "custom", delayLevelName("custom"))
+}
@@ -0,0 +1,100 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DurationUnits selects the units durations are phrased in
+type DurationUnits string
+
+const (
+	// DurationUnitsMonths phrases durations in years, months, weeks and days
+	DurationUnitsMonths DurationUnits = "months"
+	// DurationUnitsWeeks phrases durations in weeks and days only
+	DurationUnitsWeeks DurationUnits = "weeks"
+)
+
+// maxDurationUnits is the largest number of units a duration is phrased in
+const maxDurationUnits = 2
+
+// durationUnit is a unit of a human-readable duration
+type durationUnit struct {
+	name string
+	days int
+}
+
+// durationUnitSets lists the units of each phrasing, largest first. Years and
+// months are approximated with 365 and 30 days.
+var durationUnitSets = map[DurationUnits][]durationUnit{
+	DurationUnitsMonths: {{"year", 365}, {"month", 30}, {"week", 7}, {"day", 1}},
+	DurationUnitsWeeks:  {{"week", 7}, {"day", 1}},
+}
+
+// DurationStyle controls how durations in days are rendered
+type DurationStyle struct {
+	Units     DurationUnits // Phrasing, defaults to DurationUnitsMonths
+	MaxUnits  int           // Number of units shown, 1 or 2, defaults to 2
+	ExactDays bool          // Append the exact number of days when the phrasing drops a remainder
+	Language  Language      // Language of unit names, defaults to English
+}
+
+// DefaultDurationStyle returns the default duration style, e.g. "1 month 1 week"
+func DefaultDurationStyle() DurationStyle {
+	return DurationStyle{Units: DurationUnitsMonths, MaxUnits: maxDurationUnits}
+}
+
+// ParseDurationUnits parses the name of a duration phrasing
+func ParseDurationUnits(s string) (DurationUnits, error) {
+	units := DurationUnits(s)
+	if _, ok := durationUnitSets[units]; !ok {
+		return "", fmt.Errorf("invalid duration units: %s (must be one of: %s, %s)", s, DurationUnitsMonths, DurationUnitsWeeks)
+	}
+	return units, nil
+}
+
+// Format formats a duration in days. The largest unit that fits is followed by
+// the next smaller units up to MaxUnits, e.g. "1 month 1 week" for 40 days;
+// smaller remainders are dropped unless ExactDays is set, e.g. "1 month 1 week (40 days)".
+func (s DurationStyle) Format(days int) string {
+	if days == 0 {
+		return s.Language.Translate("no change")
+	}
+	if days < 0 {
+		return "-" + s.Format(-days)
+	}
+
+	units, ok := durationUnitSets[s.Units]
+	if !ok {
+		units = durationUnitSets[DurationUnitsMonths]
+	}
+	maxUnits := s.MaxUnits
+	if maxUnits < 1 || maxUnits > maxDurationUnits {
+		maxUnits = maxDurationUnits
+	}
+
+	// Start at the largest unit that fits
+	first := len(units) - 1
+	for i, unit := range units {
+		if days >= unit.days {
+			first = i
+			break
+		}
+	}
+
+	var parts []string
+	remaining := days
+	for _, unit := range units[first:min(first+maxUnits, len(units))] {
+		n := remaining / unit.days
+		remaining %= unit.days
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, s.Language.Translate(unit.name+pluralize(n))))
+		}
+	}
+
+	result := strings.Join(parts, " ")
+	if s.ExactDays && remaining > 0 {
+		result += " " + fmt.Sprintf(s.Language.Translate("(%d days)"), days)
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationStyleDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		days     int
+		expected string
+	}{
+		{
+			name:     "zero_days",
+			days:     0,
+			expected: "no change",
+		},
+		{
+			name:     "single_day",
+			days:     1,
+			expected: "1 day",
+		},
+		{
+			name:     "multiple_days",
+			days:     5,
+			expected: "5 days",
+		},
+		{
+			name:     "one_week",
+			days:     7,
+			expected: "1 week",
+		},
+		{
+			name:     "one_week_and_days",
+			days:     9,
+			expected: "1 week 2 days",
+		},
+		{
+			name:     "multiple_weeks",
+			days:     14,
+			expected: "2 weeks",
+		},
+		{
+			name:     "multiple_weeks_and_days",
+			days:     17,
+			expected: "2 weeks 3 days",
+		},
+		{
+			name:     "one_month",
+			days:     30,
+			expected: "1 month",
+		},
+		{
+			name:     "one_month_and_weeks",
+			days:     37,
+			expected: "1 month 1 week",
+		},
+		{
+			name:     "multiple_months",
+			days:     90,
+			expected: "3 months",
+		},
+		{
+			name:     "multiple_months_and_weeks",
+			days:     95,
+			expected: "3 months",
+		},
+		{
+			name:     "one_year",
+			days:     365,
+			expected: "1 year",
+		},
+		{
+			name:     "one_year_and_months",
+			days:     395,
+			expected: "1 year 1 month",
+		},
+		{
+			name:     "multiple_years",
+			days:     730,
+			expected: "2 years",
+		},
+		{
+			name:     "multiple_years_and_months",
+			days:     760,
+			expected: "2 years 1 month",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultDurationStyle().Format(tt.days)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestDurationStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    DurationStyle
+		days     int
+		expected string
+	}{
+		{"single unit", DurationStyle{MaxUnits: 1}, 37, "1 month"},
+		{"exact days", DurationStyle{MaxUnits: 2, ExactDays: true}, 95, "3 months (95 days)"},
+		{"exact days without remainder", DurationStyle{MaxUnits: 2, ExactDays: true}, 37, "1 month 1 week"},
+		{"single unit with exact days", DurationStyle{MaxUnits: 1, ExactDays: true}, 13, "1 week (13 days)"},
+		{"weeks", DurationStyle{Units: DurationUnitsWeeks, MaxUnits: 2}, 95, "13 weeks 4 days"},
+		{"weeks beyond a year", DurationStyle{Units: DurationUnitsWeeks, MaxUnits: 1}, 400, "57 weeks"},
+		{"zero value defaults", DurationStyle{}, 37, "1 month 1 week"},
+		{"negative", DefaultDurationStyle(), -10, "-1 week 3 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.style.Format(tt.days))
+		})
+	}
+}
+
+func TestParseDurationUnits(t *testing.T) {
+	units, err := ParseDurationUnits("weeks")
+	require.NoError(t, err)
+	assert.Equal(t, DurationUnitsWeeks, units)
+
+	_, err = ParseDurationUnits("fortnights")
+	assert.ErrorContains(t, err, "invalid duration units: fortnights")
+}
@@ -0,0 +1,87 @@
+package format
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EmojiStyle selects whether delay levels and section titles are marked with emoji
+type EmojiStyle string
+
+const (
+	// EmojiStyleGlyphs marks delay levels and titles with emoji, e.g. "🔴 High delay"
+	EmojiStyleGlyphs EmojiStyle = "emoji"
+	// EmojiStyleASCII renders delay levels as tags, e.g. "[HIGH]", and titles without emoji
+	EmojiStyleASCII EmojiStyle = "ascii"
+)
+
+// asciiDelayLevels are the tags of delay levels in EmojiStyleASCII
+var asciiDelayLevels = map[DelayLevel]string{
+	DelayLevelAhead:    "[AHEAD]",
+	DelayLevelOnTrack:  "[ON TRACK]",
+	DelayLevelModerate: "[MODERATE]",
+	DelayLevelHigh:     "[HIGH]",
+	DelayLevelExtreme:  "[EXTREME]",
+}
+
+// label returns a status, such as a delay level or "Added", in the language and emoji
+// style of the options
+func (o FormatterOptions) label(status string) string {
+	if o.EmojiStyle == EmojiStyleASCII {
+		if tag, ok := asciiDelayLevels[DelayLevel(status)]; ok {
+			status = tag
+		}
+	}
+	return o.Language.Translate(status)
+}
+
+// title returns a section title in the language and emoji style of the options
+func (o FormatterOptions) title(title string) string {
+	title = o.Language.Translate(title)
+	if o.EmojiStyle == EmojiStyleASCII {
+		return stripEmoji(title)
+	}
+	return title
+}
+
+// localizeTitles sets the title of the document and its sections in the language and
+// emoji style of the options
+func localizeTitles(doc *Document, options FormatterOptions) {
+	doc.Title = options.title(doc.Title)
+	for i := range doc.Sections {
+		doc.Sections[i].Title = options.title(doc.Sections[i].Title)
+	}
+}
+
+// stripEmoji removes the leading emoji of a title, e.g. "📅 Timeline Changes" becomes
+// "Timeline Changes". Titles starting with a letter, digit or ASCII are unchanged.
+func stripEmoji(title string) string {
+	r, _ := utf8.DecodeRuneInString(title)
+	if r <= unicode.MaxASCII || unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return title
+	}
+	if _, rest, ok := strings.Cut(title, " "); ok {
+		return rest
+	}
+	return title
+}
+
+// canonicalStatus returns the English delay level of a status label in any language
+// and emoji style, other statuses in English
+func canonicalStatus(label string) string {
+	label = englishMessage(label)
+	for level, tag := range asciiDelayLevels {
+		if tag == label {
+			return string(level)
+		}
+	}
+	return label
+}
+
+// workloadHeatTags are the tags of the heat levels of workload cells in EmojiStyleASCII
+var workloadHeatTags = map[string]string{
+	"🔴": "[OVER]",
+	"🟠": "[FULL]",
+	"🟢": "[OK]",
+}
@@ -0,0 +1,68 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripEmoji(t *testing.T) {
+	tests := map[string]string{
+		"📅 Timeline Changes":         "Timeline Changes",
+		"⏱️ Status SLA Violations":   "Status SLA Violations",
+		"🏷️ Slip Causes":             "Slip Causes",
+		"Project Timeline Analysis":  "Project Timeline Analysis",
+		"Änderungen nach Repository": "Änderungen nach Repository",
+		"🧭":                          "🧭",
+	}
+	for title, want := range tests {
+		assert.Equal(t, want, stripEmoji(title), title)
+	}
+}
+
+func TestOptionsLabel(t *testing.T) {
+	ascii := DefaultOptions()
+	WithEmojiStyle(EmojiStyleASCII)(&ascii)
+	asciiGerman := ascii
+	WithLanguage(LanguageGerman)(&asciiGerman)
+
+	assert.Equal(t, string(DelayLevelHigh), DefaultOptions().label(string(DelayLevelHigh)))
+	assert.Equal(t, "[HIGH]", ascii.label(string(DelayLevelHigh)))
+	assert.Equal(t, "[ON TRACK]", ascii.label(string(DelayLevelOnTrack)))
+	assert.Equal(t, "Added", ascii.label("Added"))
+	assert.Equal(t, "[HOCH]", asciiGerman.label(string(DelayLevelHigh)))
+}
+
+func TestCanonicalStatus(t *testing.T) {
+	for _, label := range []string{string(DelayLevelExtreme), "[EXTREME]", "[EXTREM]", "🚫 Retard extrême"} {
+		assert.Equal(t, string(DelayLevelExtreme), canonicalStatus(label), label)
+	}
+	assert.Equal(t, "Added", canonicalStatus("Hinzugefügt"))
+	assert.Equal(t, "Custom", canonicalStatus("Custom"))
+}
+
+func TestTableFormatterASCII(t *testing.T) {
+	diff := types.ProjectDiff{ChangedItems: []types.ItemDiff{
+		delayedChange("1", 3),
+		delayedChange("2", 20),
+		delayedChange("3", 40),
+	}}
+
+	output := NewTableFormatter(WithEmojiStyle(EmojiStyleASCII), WithLimit(2)).Format(diff)
+
+	assert.Contains(t, output, "## Timeline Changes\n")
+	assert.Contains(t, output, "[HIGH]")
+	assert.Contains(t, output, "[EXTREME]")
+	assert.Contains(t, output, "([ON TRACK]: 1)")
+	assert.NotContains(t, output, "📅")
+	assert.NotContains(t, output, "🔴")
+}
+
+func TestHTMLFormatterASCIIKeepsStatusClasses(t *testing.T) {
+	diff := types.ProjectDiff{ChangedItems: []types.ItemDiff{delayedChange("1", 20)}}
+
+	output := NewHTMLFormatter(WithEmojiStyle(EmojiStyleASCII)).Format(diff)
+
+	assert.Contains(t, output, `<td class="center high">[HIGH]</td>`)
+}
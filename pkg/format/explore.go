@@ -0,0 +1,99 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// ExploreEntry is an added, removed or changed item of a diff browsed interactively,
+// with a one-line summary and the full details shown when the entry is expanded
+type ExploreEntry struct {
+	Title   string
+	URL     string
+	Change  string   // "Added", "Removed" or "Changed", in the language of the report
+	Summary string   // e.g. "🟠 Moderate delay +2 weeks" or "2 fields changed"
+	Details []string // Timeline and all field changes, or all attributes of added and removed items
+}
+
+// BuildExploreEntries lists the added, removed and changed items of the diff in the
+// order of reports. Unlike tables, details keep all field changes and untruncated values.
+func BuildExploreEntries(diff types.ProjectDiff, options FormatterOptions) []ExploreEntry {
+	var entries []ExploreEntry
+
+	listed := func(item types.Item, change string) ExploreEntry {
+		details := []string{fmt.Sprintf("Timeline: %s → %s (%s)",
+			formatDate(item.DateSpan.Start, options),
+			formatDate(item.DateSpan.End, options),
+			options.Duration.Format(item.DateSpan.DurationDays()),
+		)}
+		return ExploreEntry{
+			Title:   itemHeading(item),
+			URL:     item.URL,
+			Change:  options.label(change),
+			Summary: fmt.Sprintf("%s → %s", formatDate(item.DateSpan.Start, options), formatDate(item.DateSpan.End, options)),
+			Details: append(details, exploreAttributes(item.Attributes)...),
+		}
+	}
+	for _, item := range diff.AddedItems {
+		entries = append(entries, listed(item, "Added"))
+	}
+	for _, item := range diff.RemovedItems {
+		entries = append(entries, listed(item, "Removed"))
+	}
+
+	for _, change := range diff.ChangedItems {
+		entry := ExploreEntry{
+			Title:  itemHeading(change.After),
+			URL:    change.After.URL,
+			Change: options.label("Changed"),
+		}
+		if change.IsRecreated() {
+			entry.Details = append(entry.Details, "Re-created: replaces removed item "+change.PreviousID)
+		}
+
+		var fields []string
+		for _, fieldChange := range change.FieldChanges {
+			if fieldChange.Field == "updated_at" || fieldChange.Field == "created_at" {
+				continue
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s", fieldChange.Field, formatFieldChange(fieldChange)))
+		}
+
+		if change.DateChange != nil {
+			entry.Summary = fmt.Sprintf("%s %s",
+				options.label(string(timelineDelayLevel(change, options))),
+				options.Duration.Format(change.DateChange.DurationDelta),
+			)
+			entry.Details = append(entry.Details,
+				fmt.Sprintf("Before: %s → %s", formatDate(change.Before.DateSpan.Start, options), formatDate(change.Before.DateSpan.End, options)),
+				fmt.Sprintf("After:  %s → %s", formatDate(change.After.DateSpan.Start, options), formatDate(change.After.DateSpan.End, options)),
+			)
+		} else if len(fields) == 1 {
+			entry.Summary = "1 field changed"
+		} else {
+			entry.Summary = fmt.Sprintf("%d fields changed", len(fields))
+		}
+		entry.Details = append(entry.Details, fields...)
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// exploreAttributes lists the attributes of an item other than its title and
+// timestamps, sorted by name
+func exploreAttributes(attrs map[string]interface{}) []string {
+	var lines []string
+	for k, v := range attrs {
+		switch strings.ToLower(k) {
+		case "title", "created_at", "updated_at":
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", k, types.FormatValue(v)))
+	}
+	sort.Strings(lines)
+	return lines
+}
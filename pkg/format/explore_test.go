@@ -0,0 +1,46 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildExploreEntries(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems = append(diff.ChangedItems, types.ItemDiff{
+		ItemID: "labels-1",
+		After:  types.Item{ID: "labels-1", Attributes: map[string]interface{}{"Title": "Relabeled Task"}},
+		FieldChanges: []types.FieldChange{
+			{Field: "Status", OldValue: "Todo", NewValue: "Done"},
+			{Field: "updated_at", OldValue: "2024-01-01", NewValue: "2024-01-02"},
+		},
+	})
+
+	entries := BuildExploreEntries(diff, DefaultOptions())
+	assert.Len(t, entries, 4)
+
+	assert.Equal(t, "New Task", entries[0].Title)
+	assert.Equal(t, "Added", entries[0].Change)
+	assert.Equal(t, "Jan 1, 2024 → Jan 31, 2024", entries[0].Summary)
+	assert.Equal(t, []string{"Timeline: Jan 1, 2024 → Jan 31, 2024 (1 month)", "priority: High", "status: Todo"}, entries[0].Details)
+
+	assert.Equal(t, "Removed", entries[1].Change)
+
+	assert.Equal(t, "Changed Task", entries[2].Title)
+	assert.Equal(t, "🟠 Moderate delay 1 week 1 day", entries[2].Summary)
+	assert.Contains(t, entries[2].Details, "Before: Jan 1, 2024 → Jan 15, 2024")
+	assert.Contains(t, entries[2].Details, "After:  Jan 1, 2024 → Jan 31, 2024")
+
+	// Timestamps are left out of field changes
+	assert.Equal(t, "1 field changed", entries[3].Summary)
+	assert.Equal(t, []string{"Status: Todo → Done"}, entries[3].Details)
+}
+
+func TestBuildExploreEntriesASCII(t *testing.T) {
+	options := DefaultOptions()
+	WithEmojiStyle(EmojiStyleASCII)(&options)
+	entries := BuildExploreEntries(createTestDiff(), options)
+	assert.Equal(t, "[MODERATE] 1 week 1 day", entries[2].Summary)
+}
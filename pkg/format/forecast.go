@@ -0,0 +1,191 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/forecast"
+)
+
+// ForecastInput describes a simulated forecast and the history it was based on
+type ForecastInput struct {
+	Result         forecast.Result
+	Start          time.Time     // Timestamp of the snapshot the remaining work was taken from
+	Period         time.Duration // Length of a simulated period
+	HistoryPeriods int           // Number of historical periods sampled
+	WeightField    string        // Numeric field of the remaining work, empty for items
+	Percentiles    []float64
+	Capacity       *forecast.CapacityForecast // Projection of the scheduled work from the availability of the team, nil if not configured
+}
+
+// JSONForecast is the JSON representation of a forecast
+type JSONForecast struct {
+	Start          time.Time             `json:"start"`
+	Remaining      float64               `json:"remaining"`
+	Unit           string                `json:"unit"`
+	Trials         int                   `json:"trials"`
+	HistoryPeriods int                   `json:"history_periods"`
+	PeriodDays     int                   `json:"period_days"`
+	Capped         int                   `json:"capped_trials"`
+	Forecasts      []JSONForecastResult  `json:"forecasts"`
+	Capacity       *JSONCapacityForecast `json:"capacity,omitempty"`
+}
+
+// JSONCapacityForecast is the JSON representation of a capacity-aware forecast
+type JSONCapacityForecast struct {
+	People     []JSONPersonForecast `json:"people"`
+	Unassigned float64              `json:"unassigned_days"` // Remaining days shared by the team
+	Done       *time.Time           `json:"done,omitempty"`  // Omitted if the work is never done
+	Items      int                  `json:"items"`
+	Undated    int                  `json:"undated_items"`
+}
+
+// JSONPersonForecast is the projected completion of the work of one person
+type JSONPersonForecast struct {
+	Login       string     `json:"login"`
+	DaysPerWeek float64    `json:"days_per_week"`
+	Remaining   float64    `json:"remaining_days"`
+	Done        *time.Time `json:"done,omitempty"`
+}
+
+// JSONForecastResult is the completion forecast at one confidence level
+type JSONForecastResult struct {
+	Percentile float64   `json:"percentile"`
+	Periods    int       `json:"periods"`
+	Date       time.Time `json:"date"`
+}
+
+// BuildForecastDocument builds the forecast report with one row per confidence level
+func BuildForecastDocument(input ForecastInput, options FormatterOptions) Document {
+	unit := burndownUnit(input.WeightField)
+	periodDays := int(input.Period / (24 * time.Hour))
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Confidence", Alignment: AlignLeft},
+			{Header: "Periods", Alignment: AlignRight},
+			{Header: "Done by", Alignment: AlignLeft},
+		},
+	}
+	for _, p := range input.Percentiles {
+		table.Rows = append(table.Rows, []string{
+			"P" + formatWeight(p),
+			strconv.Itoa(input.Result.Percentile(p)),
+			formatDate(input.Result.Date(p, input.Start, input.Period), options),
+		})
+	}
+
+	note := fmt.Sprintf("%s %s remaining on %s, simulated %d times from %d period%s of %d days.",
+		formatWeight(input.Result.Remaining), unit, formatDate(input.Start, options),
+		input.Result.Trials, input.HistoryPeriods, pluralize(input.HistoryPeriods), periodDays)
+	if input.Result.Capped > 0 {
+		note += fmt.Sprintf(" %d trial%s did not finish within %d periods.", input.Result.Capped, pluralize(input.Result.Capped), forecast.MaxPeriods)
+	}
+
+	doc := Document{Title: "Forecast", Sections: []Section{{Title: "🔮 Completion forecast", Table: table, Note: note}}}
+	if input.Capacity != nil {
+		doc.Sections = append(doc.Sections, capacitySection(*input.Capacity, options))
+	}
+	return doc
+}
+
+// capacitySection lists when each person and the team complete the remaining scheduled work
+func capacitySection(capacity forecast.CapacityForecast, options FormatterOptions) Section {
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Person", Alignment: AlignLeft},
+			{Header: "Days/week", Alignment: AlignRight},
+			{Header: "Remaining days", Alignment: AlignRight},
+			{Header: "Done by", Alignment: AlignLeft},
+		},
+	}
+	doneBy := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return formatDate(t, options)
+	}
+	for _, p := range capacity.People {
+		table.Rows = append(table.Rows, []string{p.Login, formatWeight(p.DaysPerWeek), formatWeight(roundDays(p.Remaining)), doneBy(p.Done)})
+	}
+	if capacity.Unassigned > 0 {
+		table.Rows = append(table.Rows, []string{"Shared", "-", formatWeight(roundDays(capacity.Unassigned)), "-"})
+	}
+	teamDone := doneBy(capacity.Done)
+	if capacity.Capped {
+		teamDone = fmt.Sprintf("not within %d weeks", forecast.MaxPeriods)
+	}
+	table.Rows = append(table.Rows, []string{"Team", "-", "-", teamDone})
+
+	note := fmt.Sprintf("Remaining working days of %d open item%s with dates, scheduled from the availability and holidays in the config file.",
+		capacity.Items, pluralize(capacity.Items))
+	if capacity.Unassigned > 0 {
+		note += " Shared days of unassigned items and of assignees not in the config are worked on by whoever is available."
+	}
+	if capacity.Undated > 0 {
+		note += fmt.Sprintf(" Not included: %d open item%s without dates.", capacity.Undated, pluralize(capacity.Undated))
+	}
+	return Section{Title: "👥 Capacity forecast", Table: table, Note: note}
+}
+
+// optionalTime returns nil for the zero time, to omit it from JSON
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// roundDays rounds a number of days to one decimal
+func roundDays(days float64) float64 {
+	return math.Round(days*10) / 10
+}
+
+// FormatForecastJSON formats the forecast as indented JSON
+func FormatForecastJSON(input ForecastInput) (string, error) {
+	out := JSONForecast{
+		Start:          input.Start,
+		Remaining:      input.Result.Remaining,
+		Unit:           burndownUnit(input.WeightField),
+		Trials:         input.Result.Trials,
+		HistoryPeriods: input.HistoryPeriods,
+		PeriodDays:     int(input.Period / (24 * time.Hour)),
+		Capped:         input.Result.Capped,
+		Forecasts:      make([]JSONForecastResult, 0, len(input.Percentiles)),
+	}
+	for _, p := range input.Percentiles {
+		out.Forecasts = append(out.Forecasts, JSONForecastResult{
+			Percentile: p,
+			Periods:    input.Result.Percentile(p),
+			Date:       input.Result.Date(p, input.Start, input.Period),
+		})
+	}
+
+	if c := input.Capacity; c != nil {
+		capacity := &JSONCapacityForecast{
+			People:     make([]JSONPersonForecast, 0, len(c.People)),
+			Unassigned: roundDays(c.Unassigned),
+			Done:       optionalTime(c.Done),
+			Items:      c.Items,
+			Undated:    c.Undated,
+		}
+		for _, p := range c.People {
+			capacity.People = append(capacity.People, JSONPersonForecast{
+				Login:       p.Login,
+				DaysPerWeek: p.DaysPerWeek,
+				Remaining:   roundDays(p.Remaining),
+				Done:        optionalTime(p.Done),
+			})
+		}
+		out.Capacity = capacity
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal forecast: %w", err)
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,84 @@
+package format
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/forecast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func forecastInput() ForecastInput {
+	return ForecastInput{
+		Result:         forecast.Result{Remaining: 12, Trials: 4, Periods: []int{1, 2, 2, 3}},
+		Start:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Period:         7 * 24 * time.Hour,
+		HistoryPeriods: 6,
+		WeightField:    "Points",
+		Percentiles:    forecast.DefaultPercentiles,
+	}
+}
+
+func TestBuildForecastDocument(t *testing.T) {
+	doc := BuildForecastDocument(forecastInput(), DefaultOptions())
+
+	require.Len(t, doc.Sections, 1)
+	section := doc.Sections[0]
+	assert.Equal(t, [][]string{
+		{"P50", "2", "Jan 15, 2024"},
+		{"P85", "3", "Jan 22, 2024"},
+		{"P95", "3", "Jan 22, 2024"},
+	}, section.Table.Rows)
+	assert.Equal(t, "12 Points remaining on Jan 1, 2024, simulated 4 times from 6 periods of 7 days.", section.Note)
+}
+
+func TestFormatForecastJSON(t *testing.T) {
+	output, err := FormatForecastJSON(forecastInput())
+	require.NoError(t, err)
+
+	var got JSONForecast
+	require.NoError(t, json.Unmarshal([]byte(output), &got))
+	assert.Equal(t, "Points", got.Unit)
+	assert.Equal(t, 7, got.PeriodDays)
+	require.Len(t, got.Forecasts, 3)
+	assert.Equal(t, JSONForecastResult{Percentile: 85, Periods: 3, Date: time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC)}, got.Forecasts[1])
+}
+
+func TestForecastCapacity(t *testing.T) {
+	input := forecastInput()
+	input.Capacity = &forecast.CapacityForecast{
+		People: []forecast.PersonForecast{
+			{Login: "@alice", DaysPerWeek: 5, Remaining: 4.25, Done: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+			{Login: "@bob", DaysPerWeek: 2.5},
+		},
+		Unassigned: 2,
+		Done:       time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		Items:      3,
+		Undated:    1,
+	}
+
+	doc := BuildForecastDocument(input, DefaultOptions())
+	require.Len(t, doc.Sections, 2)
+	section := doc.Sections[1]
+	assert.Equal(t, "👥 Capacity forecast", section.Title)
+	assert.Equal(t, [][]string{
+		{"@alice", "5", "4.3", "Jan 5, 2024"},
+		{"@bob", "2.5", "0", "-"},
+		{"Shared", "-", "2", "-"},
+		{"Team", "-", "-", "Jan 10, 2024"},
+	}, section.Table.Rows)
+	assert.Contains(t, section.Note, "Remaining working days of 3 open items with dates")
+	assert.Contains(t, section.Note, "Not included: 1 open item without dates.")
+
+	output, err := FormatForecastJSON(input)
+	require.NoError(t, err)
+	var got JSONForecast
+	require.NoError(t, json.Unmarshal([]byte(output), &got))
+	require.NotNil(t, got.Capacity)
+	assert.Equal(t, float64(2), got.Capacity.Unassigned)
+	require.Len(t, got.Capacity.People, 2)
+	assert.Nil(t, got.Capacity.People[1].Done)
+	assert.Equal(t, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), *got.Capacity.Done)
+}
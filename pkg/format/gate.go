@@ -0,0 +1,81 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// FailCondition is a condition of a report that fails a CI gate
+type FailCondition string
+
+const (
+	FailOnModerate FailCondition = "moderate" // An item has a moderate delay or worse
+	FailOnHigh     FailCondition = "high"     // An item has a high delay or worse
+	FailOnExtreme  FailCondition = "extreme"  // An item has an extreme delay
+	FailOnScope    FailCondition = "scope"    // Items were added or removed
+)
+
+// FailConditions lists the valid fail conditions
+var FailConditions = []FailCondition{FailOnModerate, FailOnHigh, FailOnExtreme, FailOnScope}
+
+// failLevels are the delay levels of the delay conditions
+var failLevels = map[FailCondition]DelayLevel{
+	FailOnModerate: DelayLevelModerate,
+	FailOnHigh:     DelayLevelHigh,
+	FailOnExtreme:  DelayLevelExtreme,
+}
+
+// ParseFailConditions parses the names of fail conditions
+func ParseFailConditions(names []string) ([]FailCondition, error) {
+	conditions := make([]FailCondition, 0, len(names))
+	for _, name := range names {
+		condition := FailCondition(strings.ToLower(strings.TrimSpace(name)))
+		valid := false
+		for _, c := range FailConditions {
+			valid = valid || condition == c
+		}
+		if !valid {
+			names := make([]string, len(FailConditions))
+			for i, c := range FailConditions {
+				names[i] = string(c)
+			}
+			return nil, fmt.Errorf("invalid fail condition: %s (must be one of: %s)", name, strings.Join(names, ", "))
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// Failures returns why the diff meets any of the conditions, e.g. "2 items with a high
+// delay or worse", using the delay thresholds of the options. It is empty if none is met.
+func Failures(diff types.ProjectDiff, options FormatterOptions, conditions []FailCondition) []string {
+	var failures []string
+	report := NewReport(diff, options)
+	for _, condition := range conditions {
+		if condition == FailOnScope {
+			if added, removed := len(diff.AddedItems), len(diff.RemovedItems); added+removed > 0 {
+				failures = append(failures, fmt.Sprintf("scope changed: %d added, %d removed", added, removed))
+			}
+			continue
+		}
+
+		level := failLevels[condition]
+		count := 0
+		for _, item := range report.Delayed {
+			if statusRank(string(item.Level)) <= statusRank(string(level)) {
+				count++
+			}
+		}
+		if count == 0 {
+			continue
+		}
+		phrase := fmt.Sprintf("a %s delay or worse", condition)
+		if condition == FailOnExtreme {
+			phrase = "an extreme delay"
+		}
+		failures = append(failures, fmt.Sprintf("%d item%s with %s", count, pluralize(count), phrase))
+	}
+	return failures
+}
@@ -0,0 +1,51 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailConditions(t *testing.T) {
+	conditions, err := ParseFailConditions([]string{"High", " scope"})
+	require.NoError(t, err)
+	assert.Equal(t, []FailCondition{FailOnHigh, FailOnScope}, conditions)
+
+	_, err = ParseFailConditions([]string{"critical"})
+	assert.EqualError(t, err, "invalid fail condition: critical (must be one of: moderate, high, extreme, scope)")
+}
+
+func TestFailures(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	slipped := func(id string, days int) types.ItemDiff {
+		before := types.Item{ID: id, DateSpan: types.DateSpan{Start: day(1), End: day(10)}}
+		after := types.Item{ID: id, DateSpan: types.DateSpan{Start: day(1 + days), End: day(10 + days)}}
+		return before.CompareTo(after)
+	}
+	diff := types.ProjectDiff{
+		AddedItems:   []types.Item{{ID: "new"}},
+		ChangedItems: []types.ItemDiff{slipped("1", 8), slipped("2", 15)},
+	}
+
+	tests := []struct {
+		name       string
+		diff       types.ProjectDiff
+		conditions []FailCondition
+		expected   []string
+	}{
+		{"moderate", diff, []FailCondition{FailOnModerate}, []string{"2 items with a moderate delay or worse"}},
+		{"high", diff, []FailCondition{FailOnHigh}, []string{"1 item with a high delay or worse"}},
+		{"extreme", diff, []FailCondition{FailOnExtreme}, nil},
+		{"scope", diff, []FailCondition{FailOnScope}, []string{"scope changed: 1 added, 0 removed"}},
+		{"scope unchanged", types.ProjectDiff{}, []FailCondition{FailOnScope}, nil},
+		{"several", diff, []FailCondition{FailOnHigh, FailOnScope}, []string{"1 item with a high delay or worse", "scope changed: 1 added, 0 removed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Failures(tt.diff, DefaultOptions(), tt.conditions))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/naag/gh-project-report/pkg/actions"
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// GitHubSummaryFormatter formats reports for the job summary of a GitHub Actions run,
+// in Markdown, with annotations for items with a high or extreme delay
+type GitHubSummaryFormatter struct {
+	*TableFormatter
+}
+
+// NewGitHubSummaryFormatter creates a new GitHub job summary formatter with the given options
+func NewGitHubSummaryFormatter(opts ...func(*FormatterOptions)) *GitHubSummaryFormatter {
+	return &GitHubSummaryFormatter{TableFormatter: NewTableFormatter(opts...)}
+}
+
+// delayAnnotations are the level and title of annotations of the annotated delay levels
+var delayAnnotations = map[DelayLevel]actions.Annotation{
+	DelayLevelHigh:    {Level: actions.AnnotationNotice, Title: "High delay"},
+	DelayLevelExtreme: {Level: actions.AnnotationWarning, Title: "Extreme delay"},
+}
+
+// Annotations returns a notice for each item with a high delay and a warning for each
+// item with an extreme delay, e.g. "Migrate DB: Start delayed by 2 weeks (Jan 31, 2024 → Feb 14, 2024)"
+func (f *GitHubSummaryFormatter) Annotations(diff types.ProjectDiff) []actions.Annotation {
+	var annotations []actions.Annotation
+	for _, change := range diff.ChangedItems {
+		annotation, ok := delayAnnotations[timelineDelayLevel(change, f.options)]
+		if !ok {
+			continue
+		}
+		annotation.Message = fmt.Sprintf("%s: %s (%s)",
+			change.After.GetTitle(),
+			formatTimelineDetails(change.DateChange, change.Before.DateSpan, change.After.DateSpan, f.options.Duration),
+			formatDateWithChange(change.After.DateSpan.End, change.Before.DateSpan.End, f.options),
+		)
+		if change.After.URL != "" {
+			annotation.Message += " " + change.After.URL
+		}
+		annotations = append(annotations, annotation)
+	}
+	return annotations
+}
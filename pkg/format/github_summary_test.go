@@ -0,0 +1,29 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/actions"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubSummaryAnnotations(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	slipped := func(title string, days int) types.ItemDiff {
+		before := types.Item{ID: title, Attributes: map[string]interface{}{"Title": title}, DateSpan: types.DateSpan{Start: day(1), End: day(5)}}
+		after := before
+		after.DateSpan = types.DateSpan{Start: before.DateSpan.Start.AddDate(0, 0, days), End: before.DateSpan.End.AddDate(0, 0, days)}
+		after.URL = "https://github.com/org/repo/issues/1"
+		return before.CompareTo(after)
+	}
+	diff := types.ProjectDiff{ChangedItems: []types.ItemDiff{slipped("Moderate", 8), slipped("High", 14), slipped("Extreme", 30)}}
+
+	formatter := NewGitHubSummaryFormatter()
+	assert.Equal(t, []actions.Annotation{
+		{Level: actions.AnnotationNotice, Title: "High delay", Message: "High: Start delayed by 2 weeks (Jan 5, 2024 → Jan 19, 2024) https://github.com/org/repo/issues/1"},
+		{Level: actions.AnnotationWarning, Title: "Extreme delay", Message: "Extreme: Start delayed by 1 month (Jan 5, 2024 → Feb 4, 2024) https://github.com/org/repo/issues/1"},
+	}, formatter.Annotations(diff))
+	assert.Equal(t, NewTableFormatter().Format(diff), formatter.Format(diff))
+}
@@ -0,0 +1,176 @@
+package format
+
+import (
+	"html"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// htmlStyle is the stylesheet embedded in HTML reports
+const htmlStyle = `body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; margin: 2rem auto; max-width: 72rem; padding: 0 1rem; }
+h1 { border-bottom: 1px solid #d1d9e0; padding-bottom: .3em; }
+h2 { margin-top: 2rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #d1d9e0; padding: .4rem .8rem; vertical-align: top; }
+th { background: #f6f8fa; }
+tr:nth-child(even) td { background: #fafbfc; }
+td.left, th.left { text-align: left; }
+td.center, th.center { text-align: center; }
+td.right, th.right { text-align: right; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+.note { color: #59636e; font-style: italic; }
+.ahead { background: #dafbe1 !important; }
+.on-track { background: #ddf4ff !important; }
+.moderate { background: #fff8c5 !important; }
+.high { background: #ffe2cc !important; }
+.extreme { background: #ffebe9 !important; color: #82071e; font-weight: 600; }
+.swatch { display: inline-block; width: .7em; height: .7em; border-radius: 50%; margin-right: .35em; }
+`
+
+// htmlStatusClasses maps status cell values, in English and with emoji, to CSS classes
+var htmlStatusClasses = map[string]string{
+	string(DelayLevelAhead):    "ahead",
+	string(DelayLevelOnTrack):  "on-track",
+	string(DelayLevelModerate): "moderate",
+	string(DelayLevelHigh):     "high",
+	string(DelayLevelExtreme):  "extreme",
+}
+
+// HTMLFormatter formats project diffs as a standalone HTML page
+type HTMLFormatter struct {
+	tables   *TableFormatter
+	renderer *HTMLRenderer
+}
+
+// NewHTMLFormatter creates a new HTML formatter with the given options
+func NewHTMLFormatter(opts ...func(*FormatterOptions)) *HTMLFormatter {
+	return &HTMLFormatter{
+		tables:   NewTableFormatter(opts...),
+		renderer: &HTMLRenderer{},
+	}
+}
+
+// Format formats the project diff as an HTML page
+func (f *HTMLFormatter) Format(diff types.ProjectDiff) string {
+	if !hasChanges(diff) {
+		return f.renderer.RenderDocument(&Document{
+			Title:    "Project Timeline Analysis",
+			Sections: []Section{{Text: noChangesMessage(diff)}},
+		})
+	}
+
+	doc := f.tables.buildDocument(diff)
+	return f.renderer.RenderDocument(&doc)
+}
+
+// HTMLRenderer handles rendering generic types into HTML
+type HTMLRenderer struct{}
+
+// RenderTable converts a generic Table to an HTML table. Cells are color coded
+// by delay level, values with a palette color get a swatch, and first-column
+// values with a link are rendered as anchors.
+func (r *HTMLRenderer) RenderTable(t *Table) string {
+	if len(t.Columns) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n<thead>\n<tr>")
+	for _, col := range t.Columns {
+		sb.WriteString(`<th class="` + string(col.Alignment) + `">` + html.EscapeString(col.Header) + "</th>")
+	}
+	sb.WriteString("</tr>\n</thead>\n<tbody>\n")
+
+	for _, row := range t.Rows {
+		sb.WriteString("<tr>")
+		for i, col := range t.Columns {
+			value := "-"
+			if i < len(row) {
+				value = row[i]
+			}
+
+			class := string(col.Alignment)
+			if status, ok := htmlStatusClasses[canonicalStatus(value)]; ok {
+				class += " " + status
+			}
+
+			cell := htmlColoredCell(value, t.Colors)
+			if url, ok := t.Links[value]; ok && i == 0 {
+				cell = `<a href="` + html.EscapeString(url) + `">` + cell + "</a>"
+			}
+			sb.WriteString(`<td class="` + class + `">` + cell + "</td>")
+		}
+		sb.WriteString("</tr>\n")
+	}
+
+	sb.WriteString("</tbody>\n</table>\n")
+	return sb.String()
+}
+
+// htmlColoredCell escapes a cell value and prefixes values with a color, including
+// both sides of changes like "Todo → Done", with a swatch of that color
+func htmlColoredCell(value string, colors map[string]string) string {
+	if len(colors) == 0 {
+		return html.EscapeString(value)
+	}
+
+	parts := strings.Split(value, " → ")
+	for i, part := range parts {
+		cell := html.EscapeString(part)
+		if color, ok := colors[part]; ok {
+			cell = `<span class="swatch" style="background: ` + html.EscapeString(color) + `"></span>` + cell
+		}
+		parts[i] = cell
+	}
+	return strings.Join(parts, " → ")
+}
+
+// RenderSection converts a generic Section to HTML
+func (r *HTMLRenderer) RenderSection(s *Section) string {
+	var sb strings.Builder
+	sb.WriteString("<section>\n")
+
+	if s.Title != "" {
+		sb.WriteString("<h2>" + html.EscapeString(s.Title) + "</h2>\n")
+	}
+
+	if s.Table != nil {
+		sb.WriteString(r.RenderTable(s.Table))
+	} else if s.Text != "" {
+		sb.WriteString("<p>" + html.EscapeString(s.Text) + "</p>\n")
+	}
+
+	if s.Note != "" {
+		sb.WriteString(`<p class="note">` + html.EscapeString(s.Note) + "</p>\n")
+	}
+
+	sb.WriteString("</section>\n")
+	return sb.String()
+}
+
+// RenderDocument converts a generic Document to a standalone HTML page
+func (r *HTMLRenderer) RenderDocument(d *Document) string {
+	var sb strings.Builder
+	for _, section := range d.Sections {
+		sb.WriteString(r.RenderSection(&section))
+	}
+	return r.RenderPage(d.Title, sb.String())
+}
+
+// RenderPage wraps HTML content into a standalone page with the report stylesheet
+func (r *HTMLRenderer) RenderPage(title, body string) string {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+	sb.WriteString("<style>\n" + htmlStyle + "</style>\n</head>\n<body>\n")
+
+	if title != "" {
+		sb.WriteString("<h1>" + html.EscapeString(title) + "</h1>\n")
+	}
+
+	sb.WriteString(body)
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
@@ -0,0 +1,50 @@
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLFormatter(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems[0].After.URL = "https://github.com/org/repo/issues/1"
+	diff.AddedItems[0].Attributes["Title"] = "New <Task>"
+
+	output := NewHTMLFormatter().Format(diff)
+
+	assert.True(t, strings.HasPrefix(output, "<!DOCTYPE html>"))
+	assert.Contains(t, output, "<style>")
+	assert.Contains(t, output, "<h1>Project Timeline Analysis</h1>")
+	assert.Contains(t, output, "<h2>📅 Timeline Changes</h2>")
+	assert.Contains(t, output, `<td class="left"><a href="https://github.com/org/repo/issues/1">Changed Task</a></td>`)
+	assert.Contains(t, output, `<td class="center moderate">🟠 Moderate delay</td>`)
+	assert.Contains(t, output, "New &lt;Task&gt;")
+	assert.NotContains(t, output, "<Task>")
+	assert.True(t, strings.HasSuffix(output, "</html>\n"))
+}
+
+func TestHTMLFormatterNoChanges(t *testing.T) {
+	output := NewHTMLFormatter().Format(types.ProjectDiff{})
+	assert.Contains(t, output, "<p>No changes found in the project timeline.</p>")
+}
+
+func TestHTMLRendererNote(t *testing.T) {
+	r := &HTMLRenderer{}
+	output := r.RenderSection(&Section{Title: "Items", Text: "a & b", Note: "…and 2 more"})
+	assert.Equal(t, "<section>\n<h2>Items</h2>\n<p>a &amp; b</p>\n<p class=\"note\">…and 2 more</p>\n</section>\n", output)
+}
+
+func TestHTMLRendererColors(t *testing.T) {
+	r := &HTMLRenderer{}
+	output := r.RenderTable(&Table{
+		Columns: []TableColumn{{Header: "Task", Alignment: AlignLeft}, {Header: "Status", Alignment: AlignCenter}},
+		Rows:    [][]string{{"API", "Todo → Done"}, {"UI", "Blocked"}},
+		Colors:  map[string]string{"Todo": "#0969da", "Done": "#1a7f37"},
+	})
+
+	assert.Contains(t, output, `<td class="center"><span class="swatch" style="background: #0969da"></span>Todo → <span class="swatch" style="background: #1a7f37"></span>Done</td>`)
+	assert.Contains(t, output, `<td class="center">Blocked</td>`)
+}
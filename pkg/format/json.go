@@ -0,0 +1,186 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// delayLevelKeys maps delay levels to stable machine-readable names
+var delayLevelKeys = map[DelayLevel]string{
+	DelayLevelAhead:    "ahead",
+	DelayLevelOnTrack:  "on_track",
+	DelayLevelModerate: "moderate",
+	DelayLevelHigh:     "high",
+	DelayLevelExtreme:  "extreme",
+}
+
+// JSONDiff is the JSON representation of a project diff
+type JSONDiff struct {
+	Added        []JSONItem   `json:"added"`
+	Removed      []JSONItem   `json:"removed"`
+	Changed      []JSONChange `json:"changed"`
+	EmptyProject bool         `json:"empty_project"`
+
+	WorkflowChanges []JSONWorkflowChange `json:"workflow_changes,omitempty"`
+
+	// Caveats of the report, empty for a clean report
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// JSONWorkflowChange is the JSON representation of a changed project workflow
+type JSONWorkflowChange struct {
+	Name    string `json:"name"`
+	Change  string `json:"change"` // enabled, disabled, added or removed
+	Enabled bool   `json:"enabled"`
+}
+
+// JSONItem is the JSON representation of an item
+type JSONItem struct {
+	ID          string                 `json:"id"`
+	Title       string                 `json:"title"`
+	ContentType string                 `json:"content_type,omitempty"`
+	URL         string                 `json:"url,omitempty"`        // Web URL of the issue or pull request
+	Number      int                    `json:"number,omitempty"`     // Number of the issue or pull request
+	Repository  string                 `json:"repository,omitempty"` // Repository of the issue or pull request, e.g. org/repo
+	Start       string                 `json:"start,omitempty"`      // YYYY-MM-DD
+	End         string                 `json:"end,omitempty"`        // YYYY-MM-DD
+	Attributes  map[string]interface{} `json:"attributes"`
+}
+
+// JSONChange is the JSON representation of a changed item
+type JSONChange struct {
+	ID           string            `json:"id"`
+	Title        string            `json:"title"`
+	Before       JSONItem          `json:"before"`
+	After        JSONItem          `json:"after"`
+	DateChange   *JSONDateChange   `json:"date_change,omitempty"`
+	DelayLevel   string            `json:"delay_level,omitempty"` // ahead, on_track, moderate, high or extreme
+	FieldChanges []JSONFieldChange `json:"field_changes"`
+	PreviousID   string            `json:"previous_id,omitempty"` // ID of the removed item this item replaced
+}
+
+// JSONDateChange is the JSON representation of a timeline change
+type JSONDateChange struct {
+	StartDaysDelta int `json:"start_days_delta"`
+	EndDaysDelta   int `json:"end_days_delta"`
+	DurationDelta  int `json:"duration_delta"`
+}
+
+// JSONFieldChange is the JSON representation of an attribute change
+type JSONFieldChange struct {
+	Field      string           `json:"field"`
+	Provenance types.Provenance `json:"provenance"` // field, content or derived
+	OldValue   interface{}      `json:"old_value"`
+	NewValue   interface{}      `json:"new_value"`
+	Added      []string         `json:"added,omitempty"`   // Users added to a list of users such as the assignees
+	Removed    []string         `json:"removed,omitempty"` // Users removed from a list of users
+}
+
+// JSONFormatter formats project diffs as JSON for processing with other tools
+type JSONFormatter struct {
+	options FormatterOptions
+}
+
+// NewJSONFormatter creates a new JSON formatter with the given options
+func NewJSONFormatter(opts ...func(*FormatterOptions)) *JSONFormatter {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &JSONFormatter{options: options}
+}
+
+// Format formats the project diff as indented JSON
+func (f *JSONFormatter) Format(diff types.ProjectDiff) string {
+	data, err := json.MarshalIndent(f.convert(diff), "", "  ")
+	if err != nil {
+		return fmt.Sprintf("{\"error\": %q}\n", fmt.Sprintf("failed to marshal diff: %v", err))
+	}
+	return string(data) + "\n"
+}
+
+// convert converts the diff into its JSON representation
+func (f *JSONFormatter) convert(diff types.ProjectDiff) JSONDiff {
+	out := JSONDiff{
+		Added:        make([]JSONItem, 0, len(diff.AddedItems)),
+		Removed:      make([]JSONItem, 0, len(diff.RemovedItems)),
+		Changed:      make([]JSONChange, 0, len(diff.ChangedItems)),
+		EmptyProject: diff.EmptyProject,
+		Diagnostics:  append([]Diagnostic{}, f.options.Diagnostics...),
+	}
+	if diagnostic, ok := undatedItemsDiagnostic(diff); ok {
+		out.Diagnostics = append(out.Diagnostics, diagnostic)
+	}
+
+	for _, item := range diff.AddedItems {
+		out.Added = append(out.Added, toJSONItem(item))
+	}
+	for _, change := range diff.WorkflowChanges {
+		out.WorkflowChanges = append(out.WorkflowChanges, JSONWorkflowChange{
+			Name:    change.Name,
+			Change:  string(change.Kind),
+			Enabled: change.Enabled,
+		})
+	}
+	for _, item := range diff.RemovedItems {
+		out.Removed = append(out.Removed, toJSONItem(item))
+	}
+	for _, change := range diff.ChangedItems {
+		jsonChange := JSONChange{
+			ID:           change.ItemID,
+			Title:        change.After.GetTitle(),
+			Before:       toJSONItem(change.Before),
+			After:        toJSONItem(change.After),
+			FieldChanges: make([]JSONFieldChange, 0, len(change.FieldChanges)),
+			PreviousID:   change.PreviousID,
+		}
+		if change.DateChange != nil {
+			jsonChange.DateChange = &JSONDateChange{
+				StartDaysDelta: change.DateChange.StartDaysDelta,
+				EndDaysDelta:   change.DateChange.EndDaysDelta,
+				DurationDelta:  change.DateChange.DurationDelta,
+			}
+			jsonChange.DelayLevel = delayLevelKeys[timelineDelayLevel(change, f.options)]
+		}
+		for _, fieldChange := range change.FieldChanges {
+			jsonFieldChange := JSONFieldChange{
+				Field:      fieldChange.Field,
+				Provenance: change.FieldProvenance(fieldChange.Field),
+				OldValue:   fieldChange.OldValue,
+				NewValue:   fieldChange.NewValue,
+			}
+			if added, removed, ok := types.ListChanges(fieldChange.OldValue, fieldChange.NewValue); ok {
+				jsonFieldChange.Added, jsonFieldChange.Removed = added, removed
+			}
+			jsonChange.FieldChanges = append(jsonChange.FieldChanges, jsonFieldChange)
+		}
+		out.Changed = append(out.Changed, jsonChange)
+	}
+
+	return out
+}
+
+// toJSONItem converts an item into its JSON representation
+func toJSONItem(item types.Item) JSONItem {
+	jsonItem := JSONItem{
+		ID:          item.ID,
+		Title:       item.GetTitle(),
+		ContentType: item.ContentType,
+		URL:         item.URL,
+		Number:      item.Number,
+		Repository:  item.Repository,
+		Attributes:  item.Attributes,
+	}
+	if !item.DateSpan.Start.IsZero() {
+		jsonItem.Start = item.DateSpan.Start.Format("2006-01-02")
+	}
+	if !item.DateSpan.End.IsZero() {
+		jsonItem.End = item.DateSpan.End.Format("2006-01-02")
+	}
+	if jsonItem.Attributes == nil {
+		jsonItem.Attributes = map[string]interface{}{}
+	}
+	return jsonItem
+}
@@ -0,0 +1,61 @@
+package format
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFormatter(t *testing.T) {
+	output := NewJSONFormatter().Format(createTestDiff())
+
+	var decoded JSONDiff
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+
+	require.Len(t, decoded.Added, 1)
+	assert.Equal(t, "New Task", decoded.Added[0].Title)
+	require.Len(t, decoded.Removed, 1)
+	assert.Equal(t, "Removed Task", decoded.Removed[0].Title)
+
+	require.Len(t, decoded.Changed, 1)
+	change := decoded.Changed[0]
+	assert.Equal(t, "changed-1", change.ID)
+	assert.Equal(t, "moderate", change.DelayLevel)
+	assert.Equal(t, &JSONDateChange{StartDaysDelta: 0, EndDaysDelta: 16, DurationDelta: 8}, change.DateChange)
+	assert.Contains(t, change.FieldChanges, JSONFieldChange{Field: "priority", Provenance: types.ProvenanceField, OldValue: "Medium", NewValue: "High"})
+
+	// Stable field names
+	var raw struct {
+		Changed []map[string]interface{} `json:"changed"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &raw))
+	for _, key := range []string{"id", "title", "before", "after", "date_change", "delay_level", "field_changes"} {
+		assert.Contains(t, raw.Changed[0], key)
+	}
+	assert.Equal(t, "2024-01-01", raw.Changed[0]["before"].(map[string]interface{})["start"])
+}
+
+func TestJSONFormatterNoChanges(t *testing.T) {
+	output := NewJSONFormatter().Format(types.ProjectDiff{EmptyProject: true})
+	assert.JSONEq(t, `{"added": [], "removed": [], "changed": [], "empty_project": true, "diagnostics": []}`, output)
+}
+
+func TestJSONFormatterDelayThresholds(t *testing.T) {
+	output := NewJSONFormatter(WithModerateDelayThreshold(10), WithHighDelayThreshold(15)).Format(createTestDiff())
+	assert.Contains(t, output, `"delay_level": "on_track"`)
+}
+
+func TestJSONFormatterAssigneeChanges(t *testing.T) {
+	diff := types.ProjectDiff{ChangedItems: []types.ItemDiff{{
+		ItemID:       "1",
+		FieldChanges: []types.FieldChange{{Field: types.AssigneesAttribute, OldValue: "@alice", NewValue: "@alice, @bob"}},
+	}}}
+
+	var decoded JSONDiff
+	require.NoError(t, json.Unmarshal([]byte(NewJSONFormatter().Format(diff)), &decoded))
+	require.Len(t, decoded.Changed, 1)
+	assert.Equal(t, []JSONFieldChange{{Field: "Assignees", Provenance: types.ProvenanceContent, OldValue: "@alice", NewValue: "@alice, @bob", Added: []string{"@bob"}}}, decoded.Changed[0].FieldChanges)
+}
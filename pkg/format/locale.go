@@ -0,0 +1,124 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultDateFormat is the layout of dates in reports unless a locale or another
+// format is set
+const DefaultDateFormat = "Jan 2, 2006"
+
+// Locale selects the language of month and weekday names in dates
+type Locale string
+
+// Supported locales
+const (
+	LocaleEnglish  Locale = "en"
+	LocaleGerman   Locale = "de"
+	LocaleFrench   Locale = "fr"
+	LocaleJapanese Locale = "ja"
+)
+
+// localeNames are the month and weekday names of a locale, from the CLDR gregorian
+// calendar in format context
+type localeNames struct {
+	layout     string // Medium date of the locale (yMMMd skeleton) as Go layout
+	months     [12]string
+	monthsAbbr [12]string
+	days       [7]string // Starting with Sunday, like time.Weekday
+	daysAbbr   [7]string
+}
+
+// locales maps the supported locales except English, which Go formats natively
+var locales = map[Locale]localeNames{
+	LocaleGerman: {
+		layout:     "2. Jan 2006",
+		months:     [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthsAbbr: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		days:       [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		daysAbbr:   [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+	},
+	LocaleFrench: {
+		layout:     "2 Jan 2006",
+		months:     [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthsAbbr: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		days:       [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		daysAbbr:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	},
+	LocaleJapanese: {
+		layout:     "2006年1月2日",
+		months:     [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		monthsAbbr: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		days:       [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		daysAbbr:   [7]string{"日", "月", "火", "水", "木", "金", "土"},
+	},
+}
+
+// ParseLocale parses a locale, empty is English
+func ParseLocale(s string) (Locale, error) {
+	locale := Locale(strings.ToLower(s))
+	if locale == "" || locale == LocaleEnglish {
+		return LocaleEnglish, nil
+	}
+	if _, ok := locales[locale]; !ok {
+		return "", fmt.Errorf("invalid locale: %s (must be one of: %s)", s, strings.Join(localeNamesList(), ", "))
+	}
+	return locale, nil
+}
+
+// localeNamesList returns the names of all supported locales
+func localeNamesList() []string {
+	names := []string{string(LocaleEnglish)}
+	for locale := range locales {
+		names = append(names, string(locale))
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// FormatDate formats a date with a Go layout, naming months and weekdays in the
+// language of the locale. The default layout is replaced by the usual medium date
+// of the locale, e.g. "2. Jan. 2006" in German.
+func (l Locale) FormatDate(t time.Time, layout string) string {
+	names, ok := locales[l]
+	if !ok {
+		return t.Format(layout)
+	}
+	if layout == DefaultDateFormat {
+		layout = names.layout
+	}
+
+	// Names are substituted for their layout elements, the rest of the layout is
+	// formatted by Go. Longer elements come first, as "Jan" is part of "January".
+	elements := []struct {
+		element string
+		name    string
+	}{
+		{"January", names.months[t.Month()-1]},
+		{"Monday", names.days[t.Weekday()]},
+		{"Jan", names.monthsAbbr[t.Month()-1]},
+		{"Mon", names.daysAbbr[t.Weekday()]},
+	}
+	var sb strings.Builder
+	for layout != "" {
+		first, match := -1, 0
+		for j, e := range elements {
+			if i := strings.Index(layout, e.element); i >= 0 && (first < 0 || i < first) {
+				first, match = i, j
+			}
+		}
+		if first < 0 {
+			sb.WriteString(t.Format(layout))
+			break
+		}
+		if first > 0 {
+			sb.WriteString(t.Format(layout[:first]))
+		}
+		sb.WriteString(elements[match].name)
+		layout = layout[first+len(elements[match].element):]
+	}
+	return sb.String()
+}
@@ -0,0 +1,51 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocale(t *testing.T) {
+	for input, want := range map[string]Locale{"": LocaleEnglish, "en": LocaleEnglish, "DE": LocaleGerman, "fr": LocaleFrench, "ja": LocaleJapanese} {
+		locale, err := ParseLocale(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, locale, input)
+	}
+
+	_, err := ParseLocale("es")
+	assert.EqualError(t, err, "invalid locale: es (must be one of: en, de, fr, ja)")
+}
+
+func TestLocaleFormatDate(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC) // A Tuesday
+
+	tests := []struct {
+		locale Locale
+		layout string
+		want   string
+	}{
+		{LocaleEnglish, DefaultDateFormat, "Mar 5, 2024"},
+		{LocaleGerman, DefaultDateFormat, "5. März 2024"},
+		{LocaleFrench, DefaultDateFormat, "5 mars 2024"},
+		{LocaleJapanese, DefaultDateFormat, "2024年3月5日"},
+		{LocaleGerman, "Monday, 2. January 2006", "Dienstag, 5. März 2024"},
+		{LocaleFrench, "Mon 2 Jan", "mar. 5 mars"},
+		{LocaleFrench, "2006-01-02", "2024-03-05"},
+		{Locale(""), DefaultDateFormat, "Mar 5, 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale)+" "+tt.layout, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.locale.FormatDate(date, tt.layout))
+		})
+	}
+}
+
+func TestFormatDateUsesLocale(t *testing.T) {
+	options := DefaultOptions()
+	WithLocale(LocaleGerman)(&options)
+	assert.Equal(t, "2. Jan. 2006", formatDate(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC), options))
+}
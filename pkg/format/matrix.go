@@ -0,0 +1,174 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// MatrixColumn is one time horizon of a diff matrix: the diff between a baseline
+// snapshot and the current state
+type MatrixColumn struct {
+	Label    string    // Name of the horizon, e.g. "1 week"
+	Baseline time.Time // Timestamp of the baseline snapshot
+	Diff     types.ProjectDiff
+}
+
+// BuildMatrixDocument builds the report comparing the current state against several
+// baselines at once. Each section has one column per baseline, so a single report
+// shows the changes at several time horizons.
+func BuildMatrixDocument(columns []MatrixColumn, options FormatterOptions) Document {
+	summary := &Table{Columns: []TableColumn{{Header: "Change", Alignment: AlignLeft}}}
+	for _, column := range columns {
+		summary.Columns = append(summary.Columns, TableColumn{Header: column.Label, Alignment: AlignRight})
+	}
+
+	rows := []struct {
+		label string
+		value func(MatrixColumn, []ReportItem) string
+	}{
+		{"Baseline", func(c MatrixColumn, _ []ReportItem) string { return formatTimestamp(c.Baseline, options) }},
+		{options.label("Added"), func(c MatrixColumn, _ []ReportItem) string { return strconv.Itoa(len(c.Diff.AddedItems)) }},
+		{options.label("Removed"), func(c MatrixColumn, _ []ReportItem) string { return strconv.Itoa(len(c.Diff.RemovedItems)) }},
+		{options.label("Changed"), func(c MatrixColumn, _ []ReportItem) string { return strconv.Itoa(len(c.Diff.ChangedItems)) }},
+		{"Delayed", func(_ MatrixColumn, d []ReportItem) string { return strconv.Itoa(len(d)) }},
+		{options.label(string(DelayLevelModerate)), countLevel(DelayLevelModerate)},
+		{options.label(string(DelayLevelHigh)), countLevel(DelayLevelHigh)},
+		{options.label(string(DelayLevelExtreme)), countLevel(DelayLevelExtreme)},
+	}
+
+	delayed := make([][]ReportItem, len(columns))
+	for i, column := range columns {
+		delayed[i] = NewReport(column.Diff, options).Delayed
+	}
+	for _, row := range rows {
+		cells := []string{row.label}
+		for i, column := range columns {
+			cells = append(cells, row.value(column, delayed[i]))
+		}
+		summary.Rows = append(summary.Rows, cells)
+	}
+
+	sections := []Section{{Title: "📊 Changes by time horizon", Table: summary}}
+	if slips := buildMatrixSlipTable(columns, options); slips != nil {
+		sections = append(sections, Section{
+			Title: "📅 Timeline changes by time horizon",
+			Table: slips,
+			Note:  "Shifts of the end date since each baseline, marked with their delay level.",
+		})
+	}
+	doc := Document{Title: "Project Report Matrix", Sections: sections}
+	localizeTitles(&doc, options)
+	return doc
+}
+
+// countLevel returns a matrix cell counting the delayed items of a level
+func countLevel(level DelayLevel) func(MatrixColumn, []ReportItem) string {
+	return func(_ MatrixColumn, delayed []ReportItem) string {
+		count := 0
+		for _, item := range delayed {
+			if item.Level == level {
+				count++
+			}
+		}
+		return strconv.Itoa(count)
+	}
+}
+
+// buildMatrixSlipTable builds the table of items delayed in any of the columns, with
+// the shift of their end date per column, or nil if no item is delayed. Items with
+// the worst delay in any column come first.
+func buildMatrixSlipTable(columns []MatrixColumn, options FormatterOptions) *Table {
+	type slipRow struct {
+		item  types.Item
+		worst int
+		cells []string
+	}
+	rows := make(map[string]*slipRow)
+	var order []string
+
+	for _, column := range columns {
+		for _, change := range column.Diff.ChangedItems {
+			level := timelineDelayLevel(change, options)
+			if !isDelayed(level) {
+				continue
+			}
+			row, ok := rows[change.ItemID]
+			if !ok {
+				row = &slipRow{item: change.After, worst: statusRank(string(level)), cells: make([]string, len(columns))}
+				rows[change.ItemID] = row
+				order = append(order, change.ItemID)
+			}
+			row.worst = min(row.worst, statusRank(string(level)))
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	// Delayed items are shown in every column they changed in, including minor shifts
+	for i, column := range columns {
+		for _, change := range column.Diff.ChangedItems {
+			row, ok := rows[change.ItemID]
+			if !ok || change.DateChange == nil {
+				continue
+			}
+			row.cells[i] = formatMatrixSlip(change, options)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := rows[order[i]], rows[order[j]]
+		if a.worst != b.worst {
+			return a.worst < b.worst
+		}
+		return a.item.GetTitle() < b.item.GetTitle()
+	})
+
+	table := &Table{
+		Columns: []TableColumn{{Header: "Task", Alignment: AlignLeft}},
+		Links:   make(map[string]string),
+	}
+	for _, column := range columns {
+		table.Columns = append(table.Columns, TableColumn{Header: column.Label, Alignment: AlignLeft})
+	}
+	for _, id := range order {
+		row := rows[id]
+		title := row.item.GetTitle()
+		if row.item.URL != "" {
+			table.Links[title] = row.item.URL
+		}
+		cells := []string{title}
+		for _, cell := range row.cells {
+			if cell == "" {
+				cell = "–"
+			}
+			cells = append(cells, cell)
+		}
+		table.Rows = append(table.Rows, cells)
+	}
+	return table
+}
+
+// formatMatrixSlip formats the shift of the end date of a change with the emoji of
+// its delay level, e.g. "🔴 +3 weeks", or its tag in EmojiStyleASCII, e.g. "[HIGH] +3 weeks"
+func formatMatrixSlip(change types.ItemDiff, options FormatterOptions) string {
+	days := change.DateChange.EndDaysDelta
+	shift := options.Duration.Format(days)
+	if days > 0 {
+		shift = "+" + shift
+	}
+
+	level := string(timelineDelayLevel(change, options))
+	if options.EmojiStyle == EmojiStyleASCII && level != "" {
+		return fmt.Sprintf("%s %s", options.label(level), shift)
+	}
+	if emoji, _, ok := strings.Cut(level, " "); ok {
+		return fmt.Sprintf("%s %s", emoji, shift)
+	}
+	return shift
+}
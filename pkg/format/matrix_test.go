@@ -0,0 +1,83 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slipChange returns a change of an item whose end moved by the given days
+func slipChange(id, title string, endDays int) types.ItemDiff {
+	item := types.Item{ID: id, URL: "https://github.com/org/repo/issues/" + id, Attributes: map[string]interface{}{"Title": title}}
+	return types.ItemDiff{
+		ItemID:     id,
+		Before:     item,
+		After:      item,
+		DateChange: &types.DateSpanChange{EndDaysDelta: endDays, DurationDelta: endDays},
+	}
+}
+
+func matrixColumns() []MatrixColumn {
+	return []MatrixColumn{
+		{
+			Label:    "1 day",
+			Baseline: time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC),
+			Diff:     types.ProjectDiff{ChangedItems: []types.ItemDiff{slipChange("1", "API", 2)}},
+		},
+		{
+			Label:    "1 week",
+			Baseline: time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC),
+			Diff: types.ProjectDiff{
+				AddedItems:   []types.Item{{ID: "3"}},
+				ChangedItems: []types.ItemDiff{slipChange("1", "API", 9), slipChange("2", "UI", 3)},
+			},
+		},
+		{
+			Label:    "First snapshot",
+			Baseline: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Diff: types.ProjectDiff{
+				AddedItems:   []types.Item{{ID: "2"}, {ID: "3"}},
+				RemovedItems: []types.Item{{ID: "4"}},
+				ChangedItems: []types.ItemDiff{slipChange("1", "API", 35)},
+			},
+		},
+	}
+}
+
+func TestBuildMatrixDocument(t *testing.T) {
+	doc := BuildMatrixDocument(matrixColumns(), DefaultOptions())
+	require.Len(t, doc.Sections, 2)
+
+	summary := doc.Sections[0].Table
+	assert.Equal(t, []string{"Change", "1 day", "1 week", "First snapshot"}, []string{
+		summary.Columns[0].Header, summary.Columns[1].Header, summary.Columns[2].Header, summary.Columns[3].Header,
+	})
+	assert.Equal(t, [][]string{
+		{"Baseline", "Mar 14, 2024", "Mar 8, 2024", "Jan 1, 2024"},
+		{"Added", "0", "1", "2"},
+		{"Removed", "0", "0", "1"},
+		{"Changed", "1", "2", "1"},
+		{"Delayed", "0", "1", "1"},
+		{string(DelayLevelModerate), "0", "1", "0"},
+		{string(DelayLevelHigh), "0", "0", "0"},
+		{string(DelayLevelExtreme), "0", "0", "1"},
+	}, summary.Rows)
+
+	// Only items delayed in some column are listed, with their shift in every column
+	slips := doc.Sections[1].Table
+	assert.Equal(t, [][]string{
+		{"API", "🔵 +2 days", "🟠 +1 week 2 days", "🚫 +1 month"},
+	}, slips.Rows)
+	assert.Equal(t, "https://github.com/org/repo/issues/1", slips.Links["API"])
+}
+
+func TestBuildMatrixDocumentWithoutDelays(t *testing.T) {
+	doc := BuildMatrixDocument([]MatrixColumn{{Label: "1 day", Diff: types.ProjectDiff{}}}, DefaultOptions())
+	require.Len(t, doc.Sections, 1)
+
+	markdown := (&MarkdownRenderer{}).RenderDocument(&doc)
+	assert.Contains(t, markdown, "| Change | 1 day |")
+}
@@ -0,0 +1,99 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// mermaidSections lists the delay levels in the order of their Gantt sections
+var mermaidSections = []DelayLevel{
+	DelayLevelExtreme,
+	DelayLevelHigh,
+	DelayLevelModerate,
+	DelayLevelOnTrack,
+	DelayLevelAhead,
+}
+
+// mermaidTags maps delay levels to Mermaid task tags, which determine the bar color
+var mermaidTags = map[DelayLevel]string{
+	DelayLevelExtreme:  "crit, ",
+	DelayLevelHigh:     "crit, ",
+	DelayLevelModerate: "active, ",
+	DelayLevelAhead:    "done, ",
+}
+
+// mermaidEscaper removes characters that Mermaid interprets in task names
+var mermaidEscaper = strings.NewReplacer(":", " -", ";", ",", "#", "", "\n", " ")
+
+// MermaidFormatter formats the timeline of a project as a Mermaid Gantt chart,
+// with tasks grouped and colored by delay level
+type MermaidFormatter struct {
+	options FormatterOptions
+}
+
+// NewMermaidFormatter creates a new Mermaid formatter with the given options
+func NewMermaidFormatter(opts ...func(*FormatterOptions)) *MermaidFormatter {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &MermaidFormatter{options: options}
+}
+
+// Format formats the current items of the project diff as a fenced Mermaid
+// gantt block, which GitHub renders natively in Markdown. Items without dates
+// are omitted, and items without timeline changes are shown as on track, so
+// diffing a snapshot with itself charts that single snapshot.
+func (f *MermaidFormatter) Format(diff types.ProjectDiff) string {
+	levels := make(map[string]DelayLevel)
+	for _, change := range diff.ChangedItems {
+		if level := timelineDelayLevel(change, f.options); level != "" {
+			levels[change.ItemID] = level
+		}
+	}
+
+	bySection := make(map[DelayLevel][]types.Item)
+	for _, item := range diff.CurrentItems {
+		if item.DateSpan.Start.IsZero() || item.DateSpan.End.IsZero() {
+			continue
+		}
+		level, ok := levels[item.ID]
+		if !ok {
+			level = DelayLevelOnTrack
+		}
+		bySection[level] = append(bySection[level], item)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("```mermaid\n")
+	sb.WriteString("gantt\n")
+	sb.WriteString("    title Project Timeline\n")
+	sb.WriteString("    dateFormat YYYY-MM-DD\n")
+	sb.WriteString("    axisFormat %b %d\n")
+
+	task := 0
+	for _, level := range mermaidSections {
+		items := bySection[level]
+		if len(items) == 0 {
+			continue
+		}
+
+		sb.WriteString("    section " + delayLevelName(level) + "\n")
+		for _, item := range items {
+			task++
+			// Mermaid bars end at the beginning of the end date, while end dates are inclusive
+			sb.WriteString(fmt.Sprintf("    %s :%st%d, %s, %s\n",
+				mermaidEscaper.Replace(item.GetTitle()),
+				mermaidTags[level],
+				task,
+				item.DateSpan.Start.Format("2006-01-02"),
+				item.DateSpan.End.AddDate(0, 0, 1).Format("2006-01-02"),
+			))
+		}
+	}
+
+	sb.WriteString("```\n")
+	return sb.String()
+}
@@ -0,0 +1,38 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMermaidFormatter(t *testing.T) {
+	diff := createTestDiff()
+	undated := types.Item{ID: "undated-1", Attributes: map[string]interface{}{"Title": "Undated Task"}}
+	stable := types.Item{
+		ID:         "stable-1",
+		DateSpan:   types.MustNewDateSpan("2024-02-01", "2024-02-10"),
+		Attributes: map[string]interface{}{"Title": "Release: v1; #42"},
+	}
+	diff.CurrentItems = []types.Item{diff.ChangedItems[0].After, stable, undated}
+
+	output := NewMermaidFormatter().Format(diff)
+
+	expected := "```mermaid\n" +
+		"gantt\n" +
+		"    title Project Timeline\n" +
+		"    dateFormat YYYY-MM-DD\n" +
+		"    axisFormat %b %d\n" +
+		"    section Moderate delay\n" +
+		"    Changed Task :active, t1, 2024-01-01, 2024-02-01\n" +
+		"    section On track\n" +
+		"    Release - v1, 42 :t2, 2024-02-01, 2024-02-11\n" +
+		"```\n"
+	assert.Equal(t, expected, output)
+	assert.NotContains(t, output, "Undated Task")
+
+	// Lower delay levels use other tags
+	output = NewMermaidFormatter(WithHighDelayThreshold(8)).Format(diff)
+	assert.Contains(t, output, "    section High delay\n    Changed Task :crit, t1, 2024-01-01, 2024-02-01\n")
+}
@@ -0,0 +1,210 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Language selects the language of durations, delay levels and section titles in reports
+type Language string
+
+// Languages with a built-in message catalog
+const (
+	LanguageEnglish Language = "en"
+	LanguageGerman  Language = "de"
+	LanguageFrench  Language = "fr"
+)
+
+// Catalog maps English messages to their translation. Messages missing from a catalog
+// are shown in English.
+type Catalog map[string]string
+
+// catalogs holds the message catalogs of all languages except English, the language
+// of the messages themselves
+var catalogs = map[Language]Catalog{
+	LanguageGerman: {
+		// Durations
+		"year":      "Jahr",
+		"years":     "Jahre",
+		"month":     "Monat",
+		"months":    "Monate",
+		"week":      "Woche",
+		"weeks":     "Wochen",
+		"day":       "Tag",
+		"days":      "Tage",
+		"no change": "keine Änderung",
+		"(%d days)": "(%d Tage)",
+
+		// Truncated sections
+		"…and %d more": "…und %d weitere",
+
+		// Timeline statuses and details
+		"Added":        "Hinzugefügt",
+		"Removed":      "Entfernt",
+		"Changed":      "Geändert",
+		"New task":     "Neue Aufgabe",
+		"Task removed": "Aufgabe entfernt",
+
+		string(DelayLevelOnTrack):  "🔵 Im Plan",
+		string(DelayLevelAhead):    "🚀 Vor dem Zeitplan",
+		string(DelayLevelModerate): "🟠 Mäßige Verzögerung",
+		string(DelayLevelHigh):     "🔴 Hohe Verzögerung",
+		string(DelayLevelExtreme):  "🚫 Extreme Verzögerung",
+		"[AHEAD]":                  "[VORAUS]",
+		"[ON TRACK]":               "[IM PLAN]",
+		"[MODERATE]":               "[MÄSSIG]",
+		"[HIGH]":                   "[HOCH]",
+		"[EXTREME]":                "[EXTREM]",
+
+		// Section titles
+		"Project Timeline Analysis":  "Analyse des Projektzeitplans",
+		"🔁 Since Previous Report":    "🔁 Seit dem letzten Bericht",
+		"📊 Summary (weighted by %s)": "📊 Zusammenfassung (gewichtet nach %s)",
+		"📅 Timeline Changes":         "📅 Zeitplanänderungen",
+		commentsSectionTitle:         "💬 Kontext der Verzögerungen",
+		aheadSectionTitle:            "🚀 Vor dem Zeitplan / Früher abgeschlossen",
+		"📋 Other Changes":            "📋 Weitere Änderungen",
+		workflowsSectionTitle:        "⚙️ Workflow-Änderungen",
+		dataQualitySectionTitle:      "🧹 Datenqualität",
+		workloadSectionTitle:         "👥 Auslastung",
+		causesSectionTitle:           "🏷️ Ursachen der Verzögerungen",
+		slaSectionTitle:              "⏱️ SLA-Verletzungen nach Status",
+		trajectorySectionTitle:       "🧭 Verlauf",
+		repositoriesSectionTitle:     "📦 Änderungen nach Repository",
+		"Since Previous Report":      "Seit dem letzten Bericht",
+		"Added Items":                "Hinzugefügte Aufgaben",
+		"Removed Items":              "Entfernte Aufgaben",
+		"Changed Items":              "Geänderte Aufgaben",
+		"Slip Context":               "Kontext der Verzögerungen",
+		"Ahead / Completed early":    "Vor dem Zeitplan / Früher abgeschlossen",
+		"Workflow Changes":           "Workflow-Änderungen",
+		"Data Quality":               "Datenqualität",
+		"Workload":                   "Auslastung",
+		"Slip causes":                "Ursachen der Verzögerungen",
+		"Status SLA Violations":      "SLA-Verletzungen nach Status",
+		"Trajectory":                 "Verlauf",
+		"Changes by repository":      "Änderungen nach Repository",
+	},
+	LanguageFrench: {
+		// Durations
+		"year":      "an",
+		"years":     "ans",
+		"month":     "mois",
+		"months":    "mois",
+		"week":      "semaine",
+		"weeks":     "semaines",
+		"day":       "jour",
+		"days":      "jours",
+		"no change": "aucun changement",
+		"(%d days)": "(%d jours)",
+
+		// Truncated sections
+		"…and %d more": "…et %d de plus",
+
+		// Timeline statuses and details
+		"Added":        "Ajoutée",
+		"Removed":      "Supprimée",
+		"Changed":      "Modifiée",
+		"New task":     "Nouvelle tâche",
+		"Task removed": "Tâche supprimée",
+
+		string(DelayLevelOnTrack):  "🔵 Dans les temps",
+		string(DelayLevelAhead):    "🚀 En avance",
+		string(DelayLevelModerate): "🟠 Retard modéré",
+		string(DelayLevelHigh):     "🔴 Retard important",
+		string(DelayLevelExtreme):  "🚫 Retard extrême",
+		"[AHEAD]":                  "[EN AVANCE]",
+		"[ON TRACK]":               "[DANS LES TEMPS]",
+		"[MODERATE]":               "[MODÉRÉ]",
+		"[HIGH]":                   "[IMPORTANT]",
+		"[EXTREME]":                "[EXTRÊME]",
+
+		// Section titles
+		"Project Timeline Analysis":  "Analyse du calendrier du projet",
+		"🔁 Since Previous Report":    "🔁 Depuis le rapport précédent",
+		"📊 Summary (weighted by %s)": "📊 Résumé (pondéré par %s)",
+		"📅 Timeline Changes":         "📅 Changements de calendrier",
+		commentsSectionTitle:         "💬 Contexte des retards",
+		aheadSectionTitle:            "🚀 En avance / Terminées plus tôt",
+		"📋 Other Changes":            "📋 Autres changements",
+		workflowsSectionTitle:        "⚙️ Changements de workflow",
+		dataQualitySectionTitle:      "🧹 Qualité des données",
+		workloadSectionTitle:         "👥 Charge de travail",
+		causesSectionTitle:           "🏷️ Causes des retards",
+		slaSectionTitle:              "⏱️ Dépassements de SLA par statut",
+		trajectorySectionTitle:       "🧭 Trajectoire",
+		repositoriesSectionTitle:     "📦 Changements par dépôt",
+		"Since Previous Report":      "Depuis le rapport précédent",
+		"Added Items":                "Tâches ajoutées",
+		"Removed Items":              "Tâches supprimées",
+		"Changed Items":              "Tâches modifiées",
+		"Slip Context":               "Contexte des retards",
+		"Ahead / Completed early":    "En avance / Terminées plus tôt",
+		"Workflow Changes":           "Changements de workflow",
+		"Data Quality":               "Qualité des données",
+		"Workload":                   "Charge de travail",
+		"Slip causes":                "Causes des retards",
+		"Status SLA Violations":      "Dépassements de SLA par statut",
+		"Trajectory":                 "Trajectoire",
+		"Changes by repository":      "Changements par dépôt",
+	},
+}
+
+// RegisterCatalog adds the messages of a catalog to the catalog of a language,
+// replacing existing translations. Registering a new language makes it available to
+// ParseLanguage.
+func RegisterCatalog(lang Language, catalog Catalog) {
+	existing, ok := catalogs[lang]
+	if !ok {
+		existing = Catalog{}
+		catalogs[lang] = existing
+	}
+	for message, translation := range catalog {
+		existing[message] = translation
+	}
+}
+
+// ParseLanguage parses a language, empty is English
+func ParseLanguage(s string) (Language, error) {
+	lang := Language(strings.ToLower(s))
+	if lang == "" || lang == LanguageEnglish {
+		return LanguageEnglish, nil
+	}
+	if _, ok := catalogs[lang]; !ok {
+		return "", fmt.Errorf("invalid language: %s (must be one of: %s)", s, strings.Join(languageNames(), ", "))
+	}
+	return lang, nil
+}
+
+// languageNames returns the names of all languages with a catalog
+func languageNames() []string {
+	names := []string{string(LanguageEnglish)}
+	for lang := range catalogs {
+		names = append(names, string(lang))
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// Translate returns the message in the language, or the message itself if the
+// language has no translation of it
+func (l Language) Translate(message string) string {
+	if translation, ok := catalogs[l][message]; ok {
+		return translation
+	}
+	return message
+}
+
+// englishMessage returns the English message of a message translated to any language,
+// e.g. to rank translated delay levels. Other messages are returned unchanged.
+func englishMessage(s string) string {
+	for _, catalog := range catalogs {
+		for message, translation := range catalog {
+			if translation == s {
+				return message
+			}
+		}
+	}
+	return s
+}
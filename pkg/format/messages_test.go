@@ -0,0 +1,105 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLanguage(t *testing.T) {
+	for input, want := range map[string]Language{"": LanguageEnglish, "en": LanguageEnglish, "DE": LanguageGerman, "fr": LanguageFrench} {
+		lang, err := ParseLanguage(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, lang, input)
+	}
+
+	_, err := ParseLanguage("es")
+	assert.EqualError(t, err, "invalid language: es (must be one of: en, de, fr)")
+}
+
+func TestLanguageTranslate(t *testing.T) {
+	assert.Equal(t, "🔴 Hohe Verzögerung", LanguageGerman.Translate(string(DelayLevelHigh)))
+	assert.Equal(t, "📅 Changements de calendrier", LanguageFrench.Translate("📅 Timeline Changes"))
+	assert.Equal(t, string(DelayLevelHigh), LanguageEnglish.Translate(string(DelayLevelHigh)))
+	assert.Equal(t, "Unknown message", LanguageGerman.Translate("Unknown message"))
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	lang := Language("es")
+	t.Cleanup(func() { delete(catalogs, lang) })
+
+	RegisterCatalog(lang, Catalog{"week": "semana", "weeks": "semanas", "day": "día", "days": "días"})
+	parsed, err := ParseLanguage("es")
+	require.NoError(t, err)
+	assert.Equal(t, "2 semanas 3 días", DurationStyle{Language: parsed}.Format(17))
+	assert.Equal(t, "no change", DurationStyle{Language: parsed}.Format(0))
+}
+
+func TestDurationStyleLanguage(t *testing.T) {
+	tests := []struct {
+		lang     Language
+		style    DurationStyle
+		days     int
+		expected string
+	}{
+		{LanguageGerman, DefaultDurationStyle(), 0, "keine Änderung"},
+		{LanguageGerman, DefaultDurationStyle(), 1, "1 Tag"},
+		{LanguageGerman, DefaultDurationStyle(), 17, "2 Wochen 3 Tage"},
+		{LanguageGerman, DefaultDurationStyle(), 400, "1 Jahr 1 Monat"},
+		{LanguageGerman, DurationStyle{MaxUnits: 1, ExactDays: true}, 40, "1 Monat (40 Tage)"},
+		{LanguageFrench, DefaultDurationStyle(), 767, "2 ans 1 mois"},
+		{LanguageFrench, DefaultDurationStyle(), -8, "-1 semaine 1 jour"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lang)+" "+tt.expected, func(t *testing.T) {
+			tt.style.Language = tt.lang
+			assert.Equal(t, tt.expected, tt.style.Format(tt.days))
+		})
+	}
+}
+
+func TestWithLanguageKeepsDurationStyle(t *testing.T) {
+	style := DurationStyle{Units: DurationUnitsWeeks, MaxUnits: 1}
+	for name, opts := range map[string][]func(*FormatterOptions){
+		"language first": {WithLanguage(LanguageGerman), WithDurationStyle(style)},
+		"style first":    {WithDurationStyle(style), WithLanguage(LanguageGerman)},
+	} {
+		options, err := NewOptions(opts...)
+		require.NoError(t, err, name)
+		assert.Equal(t, "5 Wochen", options.Duration.Format(37), name)
+	}
+}
+
+func TestTableFormatterLanguage(t *testing.T) {
+	diff := types.ProjectDiff{ChangedItems: []types.ItemDiff{
+		delayedChange("1", 3),
+		delayedChange("2", 20),
+		delayedChange("3", 20),
+	}}
+
+	output := NewTableFormatter(WithLanguage(LanguageGerman), WithLimit(1)).Format(diff)
+
+	assert.Contains(t, output, "# Analyse des Projektzeitplans")
+	assert.Contains(t, output, "## 📅 Zeitplanänderungen")
+	assert.Contains(t, output, "| Task 2 | 🔴 Hohe Verzögerung |")
+	assert.Contains(t, output, "_…und 2 weitere (🔴 Hohe Verzögerung: 1, 🔵 Im Plan: 1)_")
+	assert.NotContains(t, output, "Timeline Changes")
+	assert.NotContains(t, output, "more")
+}
+
+func TestTextFormatterLanguage(t *testing.T) {
+	diff := types.ProjectDiff{ChangedItems: []types.ItemDiff{delayedChange("1", 20)}}
+
+	output := NewTextFormatter(WithLanguage(LanguageFrench)).Format(diff)
+
+	assert.Contains(t, output, "Tâches modifiées:\n")
+	assert.Contains(t, output, "Timeline: 🔴 Retard important 2 semaines 6 jours\n")
+}
+
+func TestStatusRankTranslated(t *testing.T) {
+	assert.Equal(t, statusRank(string(DelayLevelHigh)), statusRank(LanguageGerman.Translate(string(DelayLevelHigh))))
+	assert.Equal(t, statusRank("Removed"), statusRank(LanguageFrench.Translate("Removed")))
+}
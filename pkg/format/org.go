@@ -0,0 +1,179 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// orgAgendaSectionTitle is the title of the section listing items as TODO headings
+const orgAgendaSectionTitle = "🗓️ Agenda"
+
+// orgTimestampFormat is the layout of active org timestamps, e.g. "<2024-03-05 Tue>"
+const orgTimestampFormat = "<2006-01-02 Mon>"
+
+// orgCellEscaper escapes characters ending a cell of an org table
+var orgCellEscaper = strings.NewReplacer("|", `\vert{}`)
+
+// orgLinkEscaper replaces brackets, which can't be escaped in the description of an
+// org link
+var orgLinkEscaper = strings.NewReplacer("[", "{", "]", "}")
+
+// OrgFormatter formats project diffs as an Emacs org-mode document
+type OrgFormatter struct {
+	options  FormatterOptions
+	tables   *TableFormatter
+	renderer *OrgRenderer
+}
+
+// NewOrgFormatter creates a new org-mode formatter with the given options
+func NewOrgFormatter(opts ...func(*FormatterOptions)) *OrgFormatter {
+	tables := NewTableFormatter(opts...)
+	return &OrgFormatter{
+		options:  tables.options,
+		tables:   tables,
+		renderer: &OrgRenderer{},
+	}
+}
+
+// Format formats the project diff as an org-mode document. With OrgTodo, the added
+// and changed items are also listed as headings with a TODO or DONE keyword.
+func (f *OrgFormatter) Format(diff types.ProjectDiff) string {
+	if !hasChanges(diff) {
+		return noChangesMessage(diff) + "\n"
+	}
+
+	doc := f.tables.buildDocument(diff)
+	if f.options.OrgTodo {
+		if agenda := f.formatAgenda(diff); agenda != "" {
+			doc.Sections = append(doc.Sections, Section{Title: f.options.title(orgAgendaSectionTitle), Text: agenda})
+		}
+	}
+	return f.renderer.RenderDocument(&doc)
+}
+
+// formatAgenda formats the added and changed items as second-level headings with a
+// TODO keyword derived from their status, and their dates as SCHEDULED and DEADLINE
+func (f *OrgFormatter) formatAgenda(diff types.ProjectDiff) string {
+	items := append([]types.Item{}, diff.AddedItems...)
+	for _, change := range diff.ChangedItems {
+		items = append(items, change.After)
+	}
+
+	var sb strings.Builder
+	for _, item := range items {
+		keyword := "TODO"
+		if item.IsDone(f.options.StatusField, f.options.DoneStatuses) {
+			keyword = "DONE"
+		}
+		title := strings.ReplaceAll(item.GetTitle(), "\n", " ")
+		if item.URL != "" {
+			title = orgLink(title, item.URL)
+		}
+		sb.WriteString(fmt.Sprintf("** %s %s\n", keyword, title))
+
+		var planning []string
+		if !item.DateSpan.Start.IsZero() {
+			planning = append(planning, "SCHEDULED: "+orgTimestamp(item.DateSpan.Start))
+		}
+		if !item.DateSpan.End.IsZero() {
+			planning = append(planning, "DEADLINE: "+orgTimestamp(item.DateSpan.End))
+		}
+		if len(planning) > 0 {
+			sb.WriteString("   " + strings.Join(planning, " ") + "\n")
+		}
+		if status, ok := item.Attributes[f.options.StatusField].(string); ok && status != "" {
+			sb.WriteString("   :PROPERTIES:\n   :STATUS: " + status + "\n   :END:\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// orgTimestamp formats a calendar date as an active org timestamp
+func orgTimestamp(t time.Time) string {
+	return t.Format(orgTimestampFormat)
+}
+
+// orgLink formats an org link to the URL
+func orgLink(text, url string) string {
+	return "[[" + url + "][" + orgLinkEscaper.Replace(text) + "]]"
+}
+
+// OrgRenderer handles rendering generic types into org-mode
+type OrgRenderer struct{}
+
+// RenderTable converts a generic Table to an org table with a header row.
+// First-column values with a link are rendered as links.
+func (r *OrgRenderer) RenderTable(t *Table) string {
+	if len(t.Columns) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	headers := make([]string, len(t.Columns))
+	rules := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		headers[i] = orgCellEscaper.Replace(col.Header)
+		rules[i] = strings.Repeat("-", len(headers[i])+2)
+	}
+	sb.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	sb.WriteString("|" + strings.Join(rules, "+") + "|\n")
+
+	for _, row := range t.Rows {
+		cells := make([]string, len(t.Columns))
+		for i := range t.Columns {
+			value := "-"
+			if i < len(row) {
+				value = row[i]
+			}
+			if url, ok := t.Links[value]; ok && i == 0 {
+				cells[i] = orgCellEscaper.Replace(orgLink(value, url))
+			} else {
+				cells[i] = orgCellEscaper.Replace(value)
+			}
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return sb.String()
+}
+
+// RenderSection converts a generic Section to a first-level org heading
+func (r *OrgRenderer) RenderSection(s *Section) string {
+	var sb strings.Builder
+
+	if s.Title != "" {
+		sb.WriteString("* " + s.Title + "\n\n")
+	}
+
+	if s.Table != nil {
+		sb.WriteString(r.RenderTable(s.Table))
+	} else if s.Text != "" {
+		sb.WriteString(s.Text + "\n")
+	}
+
+	if s.Note != "" {
+		sb.WriteString("\n/" + s.Note + "/\n")
+	}
+
+	return sb.String()
+}
+
+// RenderDocument converts a generic Document to an org-mode document
+func (r *OrgRenderer) RenderDocument(d *Document) string {
+	var sb strings.Builder
+
+	if d.Title != "" {
+		sb.WriteString("#+TITLE: " + d.Title + "\n\n")
+	}
+
+	for i, section := range d.Sections {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(r.RenderSection(&section))
+	}
+
+	return sb.String()
+}
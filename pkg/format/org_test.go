@@ -0,0 +1,59 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrgFormatter(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems[0].After.URL = "https://github.com/org/repo/issues/1"
+	diff.AddedItems[0].Attributes["Title"] = "New | Task"
+
+	output := NewOrgFormatter().Format(diff)
+
+	assert.Contains(t, output, "#+TITLE: Project Timeline Analysis\n\n* 📅 Timeline Changes\n\n")
+	assert.Contains(t, output, "| Task | Status | Details | Start Date | End Date | Duration |\n|------+--------+---------+------------+----------+----------|\n")
+	assert.Contains(t, output, "| [[https://github.com/org/repo/issues/1][Changed Task]] | 🟠 Moderate delay |")
+	assert.Contains(t, output, `| New \vert{} Task | Added |`)
+	assert.NotContains(t, output, "Agenda")
+}
+
+func TestOrgFormatterTodo(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems[0].After.URL = "https://github.com/org/repo/issues/1"
+
+	output := NewOrgFormatter(WithOrgTodo(true), WithDoneStatuses("status", []string{"in progress"})).Format(diff)
+
+	assert.Contains(t, output, `* 🗓️ Agenda
+
+** TODO New Task
+   SCHEDULED: <2024-01-01 Mon> DEADLINE: <2024-01-31 Wed>
+   :PROPERTIES:
+   :STATUS: Todo
+   :END:
+** DONE [[https://github.com/org/repo/issues/1][Changed Task]]
+   SCHEDULED: <2024-01-01 Mon> DEADLINE: <2024-01-31 Wed>
+   :PROPERTIES:
+   :STATUS: In Progress
+   :END:
+`)
+	assert.NotContains(t, output, "Removed Task\n")
+}
+
+func TestOrgFormatterNoChanges(t *testing.T) {
+	output := NewOrgFormatter().Format(types.ProjectDiff{})
+	assert.Equal(t, "No changes found in the project timeline.\n", output)
+}
+
+func TestOrgRendererNote(t *testing.T) {
+	r := &OrgRenderer{}
+	output := r.RenderSection(&Section{Title: "Items", Text: "Plain text", Note: "…and 2 more"})
+	assert.Equal(t, "* Items\n\nPlain text\n\n/…and 2 more/\n", output)
+}
+
+func TestOrgLink(t *testing.T) {
+	assert.Equal(t, "[[https://example.com/1][API {v2}]]", orgLink("API [v2]", "https://example.com/1"))
+}
@@ -0,0 +1,42 @@
+package format
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// dataQualitySectionTitle is the title of the data quality section
+const dataQualitySectionTitle = "🧹 Data Quality"
+
+// buildDataQualityTable builds the table of data quality problems in the current state,
+// or nil if there are none
+func buildDataQualityTable(diff types.ProjectDiff, options FormatterOptions, now time.Time) *Table {
+	if options.StaleDraftAge <= 0 {
+		return nil
+	}
+
+	drafts := diff.StaleDrafts(now, options.StaleDraftAge, options.FreezeWindows)
+	if len(drafts) == 0 {
+		return nil
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Task", Alignment: AlignLeft},
+			{Header: "Problem", Alignment: AlignLeft},
+			{Header: "Created", Alignment: AlignRight},
+		},
+	}
+	for _, item := range drafts {
+		age := int(now.Sub(item.GetCreatedAt()).Hours() / 24)
+		table.Rows = append(table.Rows, []string{
+			item.GetTitle(),
+			"Draft issue never converted",
+			fmt.Sprintf("%s (%s ago)", formatTimestamp(item.GetCreatedAt(), options), options.Duration.Format(age)),
+		})
+		table.severity = append(table.severity, age)
+	}
+	return table
+}
@@ -0,0 +1,52 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDataQualityTable(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	diff := types.ProjectDiff{CurrentItems: []types.Item{
+		{
+			ID:          "draft-1",
+			ContentType: types.ContentTypeDraftIssue,
+			Attributes:  map[string]interface{}{"Title": "Forgotten Draft", "created_at": now.AddDate(0, 0, -45)},
+		},
+	}}
+
+	assert.Nil(t, buildDataQualityTable(diff, DefaultOptions(), now), "check is disabled by default")
+
+	options := DefaultOptions()
+	WithStaleDraftAge(30 * 24 * time.Hour)(&options)
+	table := buildDataQualityTable(diff, options, now)
+	require.NotNil(t, table)
+	assert.Equal(t, [][]string{
+		{"Forgotten Draft", "Draft issue never converted", "Jan 16, 2024 (1 month 2 weeks ago)"},
+	}, table.Rows)
+
+	WithStaleDraftAge(60 * 24 * time.Hour)(&options)
+	assert.Nil(t, buildDataQualityTable(diff, options, now))
+}
+
+func TestFormattersDataQuality(t *testing.T) {
+	diff := createTestDiff()
+	diff.CurrentItems = []types.Item{
+		{
+			ID:          "draft-1",
+			ContentType: types.ContentTypeDraftIssue,
+			Attributes:  map[string]interface{}{"Title": "Forgotten Draft", "created_at": time.Now().AddDate(0, -2, 0)},
+		},
+	}
+
+	output := NewTableFormatter(WithStaleDraftAge(30 * 24 * time.Hour)).Format(diff)
+	assert.Contains(t, output, "## 🧹 Data Quality")
+	assert.Contains(t, output, "| Forgotten Draft | Draft issue never converted |")
+
+	output = NewTextFormatter(WithStaleDraftAge(30 * 24 * time.Hour)).Format(diff)
+	assert.Contains(t, output, "Data Quality:\n- Forgotten Draft: Draft issue never converted, created")
+}
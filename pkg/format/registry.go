@@ -0,0 +1,117 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Constructor creates a formatter with the given options
+type Constructor func(opts ...func(*FormatterOptions)) Formatter
+
+// registry maps output format names to formatter constructors
+var registry = map[string]Constructor{
+	"text": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewTextFormatter(opts...)
+	},
+	"markdown": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewTableFormatter(opts...)
+	},
+	"org": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewOrgFormatter(opts...)
+	},
+	"xlsx": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewXLSXFormatter(opts...)
+	},
+	"svg": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewSVGFormatter(opts...)
+	},
+	"tableplain": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewPlainTableFormatter(opts...)
+	},
+	"dot": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewDOTFormatter(opts...)
+	},
+	"json": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewJSONFormatter(opts...)
+	},
+	"csv": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewCSVFormatter(opts...)
+	},
+	"html": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewHTMLFormatter(opts...)
+	},
+	"github-summary": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewGitHubSummaryFormatter(opts...)
+	},
+	"asciidoc": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewAsciiDocFormatter(opts...)
+	},
+	"confluence": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewConfluenceFormatter(opts...)
+	},
+	"mermaid": func(opts ...func(*FormatterOptions)) Formatter {
+		return NewMermaidFormatter(opts...)
+	},
+}
+
+// descriptions describes the registered output formats for help texts
+var descriptions = map[string]string{
+	"asciidoc":       "AsciiDoc document, for Antora or Asciidoctor documentation sites",
+	"confluence":     "Confluence storage format, with delay levels as status macros",
+	"csv":            "One row per added, removed or changed item, for spreadsheets",
+	"dot":            "Graphviz graph of blocking relationships, colored by delay level",
+	"github-summary": "Markdown job summary of a GitHub Actions run, with annotations for high or extreme delays",
+	"html":           "Standalone HTML page with color-coded delay levels and item links",
+	"json":           "JSON with added, removed and changed items, date changes and delay levels",
+	"markdown":       "Markdown table output",
+	"mermaid":        "Mermaid Gantt chart, with tasks grouped and colored by delay level",
+	"org":            "Emacs org-mode document",
+	"svg":            "Standalone SVG Gantt chart, with bars colored by delay level",
+	"tableplain":     "Plain table output",
+	"text":           "Plain text output (default)",
+	"xlsx":           "Excel workbook with one worksheet per section, requires --output-file",
+}
+
+// Register registers a formatter constructor under the given output format name
+func Register(name string, constructor Constructor) {
+	registry[name] = constructor
+}
+
+// Describe sets the description of an output format shown in help texts
+func Describe(name, description string) {
+	descriptions[name] = description
+}
+
+// Help lists the registered output formats with their descriptions, one per line,
+// e.g. "- text: Plain text output (default)"
+func Help() string {
+	var sb strings.Builder
+	for _, name := range Names() {
+		sb.WriteString("- " + name)
+		if description := descriptions[name]; description != "" {
+			sb.WriteString(": " + description)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Names returns the names of all registered output formats in sorted order
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the formatter registered under the given output format name
+func New(name string, opts ...func(*FormatterOptions)) (Formatter, error) {
+	constructor, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid output format: %s (must be one of: %s)", name, strings.Join(Names(), ", "))
+	}
+	return constructor(opts...), nil
+}
@@ -0,0 +1,50 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	assert.Subset(t, Names(), []string{"asciidoc", "confluence", "csv", "dot", "html", "json", "markdown", "mermaid", "org", "svg", "tableplain", "text", "xlsx"})
+
+	formatter, err := New("markdown")
+	require.NoError(t, err)
+	assert.IsType(t, &TableFormatter{}, formatter)
+
+	formatter, err = New("text", WithDateFormat("2006-01-02"))
+	require.NoError(t, err)
+	assert.Equal(t, "2006-01-02", formatter.(*TextFormatter).options.DateFormat)
+
+	_, err = New("pdf")
+	assert.ErrorContains(t, err, "invalid output format: pdf")
+}
+
+func TestHelp(t *testing.T) {
+	help := Help()
+	for _, name := range Names() {
+		assert.NotEmpty(t, descriptions[name], name)
+		assert.Contains(t, help, "- "+name+": "+descriptions[name]+"\n")
+	}
+}
+
+type staticFormatter struct{}
+
+func (staticFormatter) Format(diff types.ProjectDiff) string { return "static" }
+
+func TestRegister(t *testing.T) {
+	Register("static", func(opts ...func(*FormatterOptions)) Formatter { return staticFormatter{} })
+	defer delete(registry, "static")
+
+	assert.Contains(t, Names(), "static")
+	assert.Contains(t, Help(), "- static\n")
+	Describe("static", "Always the same output")
+	defer delete(descriptions, "static")
+	assert.Contains(t, Help(), "- static: Always the same output\n")
+	formatter, err := New("static")
+	require.NoError(t, err)
+	assert.Equal(t, "static", formatter.Format(types.ProjectDiff{}))
+}
@@ -0,0 +1,188 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// Report is a machine-readable summary of a rendered diff. It is saved next to
+// a report so that the next report run can highlight what changed since.
+type Report struct {
+	GeneratedAt   time.Time    `json:"generated_at"`
+	ProjectNumber int          `json:"project_number,omitempty"`
+	Delayed       []ReportItem `json:"delayed"`
+}
+
+// ReportItem is an item on the delayed list of a report
+type ReportItem struct {
+	ID    string     `json:"id"`
+	Title string     `json:"title"`
+	URL   string     `json:"url,omitempty"` // Web URL of the issue or pull request
+	Level DelayLevel `json:"level"`
+}
+
+// ReportChangeKind describes how an item's presence on the delayed list changed between two reports
+type ReportChangeKind string
+
+const (
+	ReportChangeEntered   ReportChangeKind = "Newly delayed"
+	ReportChangeEscalated ReportChangeKind = "Escalated"
+	ReportChangeImproved  ReportChangeKind = "Improved"
+	ReportChangeLeft      ReportChangeKind = "No longer delayed"
+)
+
+// ReportChange describes the change of a single item between two reports
+type ReportChange struct {
+	Kind     ReportChangeKind
+	Item     ReportItem
+	Previous DelayLevel // Level in the previous report, empty for newly delayed items
+}
+
+// NewReport builds the report of a diff using the delay thresholds of the given options
+func NewReport(diff types.ProjectDiff, options FormatterOptions) Report {
+	report := Report{
+		GeneratedAt: time.Now(),
+		Delayed:     make([]ReportItem, 0),
+	}
+
+	for _, change := range diff.ChangedItems {
+		if change.DateChange == nil {
+			continue
+		}
+		level := calculateTimelineDelayLevel(
+			change.DateChange.StartDaysDelta,
+			change.DateChange.DurationDelta,
+			options.ModerateDelayThreshold,
+			options.HighDelayThreshold,
+			options.ExtremeDelayThreshold,
+		)
+		if isDelayed(level) {
+			report.Delayed = append(report.Delayed, ReportItem{
+				ID:    change.ItemID,
+				Title: change.After.GetTitle(),
+				URL:   change.After.URL,
+				Level: level,
+			})
+		}
+	}
+
+	return report
+}
+
+// DelayLevelKeys returns the machine-readable names of all delay levels, e.g. "on_track",
+// from ahead of schedule to the extreme delay
+func DelayLevelKeys() []string {
+	return []string{
+		delayLevelKeys[DelayLevelAhead],
+		delayLevelKeys[DelayLevelOnTrack],
+		delayLevelKeys[DelayLevelModerate],
+		delayLevelKeys[DelayLevelHigh],
+		delayLevelKeys[DelayLevelExtreme],
+	}
+}
+
+// DelayLevelCounts returns the number of changed items with a date change per delay
+// level, by machine-readable name. Levels without items are included with 0.
+func DelayLevelCounts(diff types.ProjectDiff, options FormatterOptions) map[string]int {
+	counts := make(map[string]int)
+	for _, key := range DelayLevelKeys() {
+		counts[key] = 0
+	}
+	for _, change := range diff.ChangedItems {
+		if level := timelineDelayLevel(change, options); level != "" {
+			counts[delayLevelKeys[level]]++
+		}
+	}
+	return counts
+}
+
+// ReadReport reads a report from JSON
+func ReadReport(r io.Reader) (*Report, error) {
+	var report Report
+	err := json.NewDecoder(r).Decode(&report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse report: %w", err)
+	}
+	return &report, nil
+}
+
+// Write writes the report as JSON
+func (r Report) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// CompareReports returns which items entered, left or changed level on the delayed list
+// between the previous and the current report, newly delayed items first
+func CompareReports(previous, current Report) []ReportChange {
+	before := make(map[string]ReportItem)
+	for _, item := range previous.Delayed {
+		before[item.ID] = item
+	}
+
+	var changes []ReportChange
+	seen := make(map[string]bool)
+	for _, item := range current.Delayed {
+		seen[item.ID] = true
+		old, ok := before[item.ID]
+		switch {
+		case !ok:
+			changes = append(changes, ReportChange{Kind: ReportChangeEntered, Item: item})
+		case statusRank(string(item.Level)) < statusRank(string(old.Level)):
+			changes = append(changes, ReportChange{Kind: ReportChangeEscalated, Item: item, Previous: old.Level})
+		case statusRank(string(item.Level)) > statusRank(string(old.Level)):
+			changes = append(changes, ReportChange{Kind: ReportChangeImproved, Item: item, Previous: old.Level})
+		}
+	}
+	for _, item := range previous.Delayed {
+		if !seen[item.ID] {
+			changes = append(changes, ReportChange{Kind: ReportChangeLeft, Item: item, Previous: item.Level})
+		}
+	}
+
+	kindOrder := map[ReportChangeKind]int{
+		ReportChangeEntered:   0,
+		ReportChangeEscalated: 1,
+		ReportChangeImproved:  2,
+		ReportChangeLeft:      3,
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return kindOrder[changes[i].Kind] < kindOrder[changes[j].Kind]
+	})
+
+	return changes
+}
+
+// isDelayed returns true for delay levels that put an item on the delayed list
+func isDelayed(level DelayLevel) bool {
+	return level == DelayLevelModerate || level == DelayLevelHigh || level == DelayLevelExtreme
+}
+
+// buildReportComparisonTable builds the table of changes since the previous report
+func buildReportComparisonTable(changes []ReportChange) *Table {
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Task", Alignment: AlignLeft},
+			{Header: "Change", Alignment: AlignLeft},
+			{Header: "Previously", Alignment: AlignCenter},
+			{Header: "Now", Alignment: AlignCenter},
+		},
+	}
+	for _, change := range changes {
+		previous, now := "-", string(change.Item.Level)
+		if change.Previous != "" {
+			previous = string(change.Previous)
+		}
+		if change.Kind == ReportChangeLeft {
+			now = "-"
+		}
+		table.Rows = append(table.Rows, []string{change.Item.Title, string(change.Kind), previous, now})
+	}
+	return table
+}
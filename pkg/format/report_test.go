@@ -0,0 +1,88 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems = append(diff.ChangedItems, types.ItemDiff{
+		ItemID:     "on-track",
+		After:      types.Item{ID: "on-track", Attributes: map[string]interface{}{"Title": "On Track Task"}},
+		DateChange: &types.DateSpanChange{StartDaysDelta: 1, EndDaysDelta: 1},
+	})
+
+	report := NewReport(diff, DefaultOptions())
+
+	require.Len(t, report.Delayed, 1)
+	assert.Equal(t, ReportItem{ID: "changed-1", Title: "Changed Task", Level: DelayLevelModerate}, report.Delayed[0])
+}
+
+func TestDelayLevelCounts(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems = append(diff.ChangedItems, types.ItemDiff{
+		ItemID:     "on-track",
+		DateChange: &types.DateSpanChange{StartDaysDelta: 1, EndDaysDelta: 1},
+	})
+
+	assert.Equal(t, map[string]int{"ahead": 0, "on_track": 1, "moderate": 1, "high": 0, "extreme": 0}, DelayLevelCounts(diff, DefaultOptions()))
+}
+
+func TestReportRoundTrip(t *testing.T) {
+	report := NewReport(createTestDiff(), DefaultOptions())
+	report.ProjectNumber = 123
+
+	var buf bytes.Buffer
+	require.NoError(t, report.Write(&buf))
+
+	loaded, err := ReadReport(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, report.Delayed, loaded.Delayed)
+	assert.Equal(t, 123, loaded.ProjectNumber)
+
+	_, err = ReadReport(bytes.NewBufferString("not json"))
+	assert.ErrorContains(t, err, "failed to parse report")
+}
+
+func TestCompareReports(t *testing.T) {
+	previous := Report{Delayed: []ReportItem{
+		{ID: "1", Title: "Still Moderate", Level: DelayLevelModerate},
+		{ID: "2", Title: "Getting Worse", Level: DelayLevelModerate},
+		{ID: "3", Title: "Getting Better", Level: DelayLevelExtreme},
+		{ID: "4", Title: "Recovered", Level: DelayLevelHigh},
+	}}
+	current := Report{Delayed: []ReportItem{
+		{ID: "1", Title: "Still Moderate", Level: DelayLevelModerate},
+		{ID: "2", Title: "Getting Worse", Level: DelayLevelHigh},
+		{ID: "3", Title: "Getting Better", Level: DelayLevelModerate},
+		{ID: "5", Title: "New Slip", Level: DelayLevelHigh},
+	}}
+
+	changes := CompareReports(previous, current)
+
+	assert.Equal(t, []ReportChange{
+		{Kind: ReportChangeEntered, Item: current.Delayed[3]},
+		{Kind: ReportChangeEscalated, Item: current.Delayed[1], Previous: DelayLevelModerate},
+		{Kind: ReportChangeImproved, Item: current.Delayed[2], Previous: DelayLevelExtreme},
+		{Kind: ReportChangeLeft, Item: previous.Delayed[3], Previous: DelayLevelHigh},
+	}, changes)
+}
+
+func TestFormattersPreviousReport(t *testing.T) {
+	previous := &Report{Delayed: []ReportItem{
+		{ID: "gone", Title: "Recovered Task", Level: DelayLevelHigh},
+	}}
+
+	output := NewTableFormatter(WithPreviousReport(previous)).Format(createTestDiff())
+	assert.Contains(t, output, "## 🔁 Since Previous Report")
+	assert.Contains(t, output, "| Changed Task | Newly delayed | - | 🟠 Moderate delay |")
+	assert.Contains(t, output, "| Recovered Task | No longer delayed | 🔴 High delay | - |")
+
+	output = NewTextFormatter(WithPreviousReport(previous)).Format(createTestDiff())
+	assert.Contains(t, output, "- Changed Task: Newly delayed (- → 🟠 Moderate delay)")
+}
@@ -0,0 +1,90 @@
+package format
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// repositoriesSectionTitle is the title of the changes by repository section
+const repositoriesSectionTitle = "📦 Changes by Repository"
+
+// noRepository groups draft issues and items of snapshots without repositories
+const noRepository = "No repository"
+
+// repositoryChanges counts the changes of the items of one repository
+type repositoryChanges struct {
+	added, removed, changed, delayed int
+}
+
+// buildRepositoriesTable builds the number of added, removed, changed and delayed items
+// per repository, or nil if the changes don't span at least two repositories, counting
+// items without repository as one
+func buildRepositoriesTable(diff types.ProjectDiff, options FormatterOptions) *Table {
+	counts := make(map[string]*repositoryChanges)
+	count := func(item types.Item) *repositoryChanges {
+		repo := item.Repository
+		if repo == "" {
+			repo = noRepository
+		}
+		if counts[repo] == nil {
+			counts[repo] = &repositoryChanges{}
+		}
+		return counts[repo]
+	}
+	for _, item := range diff.AddedItems {
+		count(item).added++
+	}
+	for _, item := range diff.RemovedItems {
+		count(item).removed++
+	}
+	for _, change := range diff.ChangedItems {
+		c := count(change.After)
+		c.changed++
+		if change.DateChange != nil && isDelayed(timelineDelayLevel(change, options)) {
+			c.delayed++
+		}
+	}
+	if len(counts) < 2 {
+		return nil
+	}
+
+	repos := make([]string, 0, len(counts))
+	for repo := range counts {
+		repos = append(repos, repo)
+	}
+	// Repositories with the most changes first, items without repository last
+	sort.Slice(repos, func(i, j int) bool {
+		if (repos[i] == noRepository) != (repos[j] == noRepository) {
+			return repos[j] == noRepository
+		}
+		ci, cj := counts[repos[i]], counts[repos[j]]
+		if ti, tj := ci.added+ci.removed+ci.changed, cj.added+cj.removed+cj.changed; ti != tj {
+			return ti > tj
+		}
+		return repos[i] < repos[j]
+	})
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Repository", Alignment: AlignLeft},
+			{Header: "Added", Alignment: AlignRight},
+			{Header: "Removed", Alignment: AlignRight},
+			{Header: "Changed", Alignment: AlignRight},
+			{Header: "Delayed", Alignment: AlignRight},
+		},
+	}
+	for _, repo := range repos {
+		c := counts[repo]
+		table.Rows = append(table.Rows, []string{
+			repo,
+			strconv.Itoa(c.added),
+			strconv.Itoa(c.removed),
+			strconv.Itoa(c.changed),
+			strconv.Itoa(c.delayed),
+		})
+		table.severity = append(table.severity, c.delayed)
+	}
+	return table
+}
@@ -0,0 +1,59 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func repositoriesDiff() types.ProjectDiff {
+	inRepo := func(change types.ItemDiff, repo string, number int) types.ItemDiff {
+		change.After.Repository, change.After.Number = repo, number
+		return change
+	}
+	return types.ProjectDiff{
+		AddedItems: []types.Item{
+			{ID: "a", Repository: "org/web", Number: 7, Attributes: map[string]interface{}{"Title": "New page"}},
+			{ID: "draft", Attributes: map[string]interface{}{"Title": "Idea"}},
+		},
+		ChangedItems: []types.ItemDiff{
+			inRepo(delayedChange("b", 20), "org/api", 1),
+			inRepo(delayedChange("c", 2), "org/api", 2),
+			inRepo(delayedChange("d", 1), "org/web", 3),
+		},
+	}
+}
+
+func TestBuildRepositoriesTable(t *testing.T) {
+	table := buildRepositoriesTable(repositoriesDiff(), DefaultOptions())
+	require.NotNil(t, table)
+	assert.Equal(t, [][]string{
+		{"org/api", "0", "0", "2", "1"},
+		{"org/web", "1", "0", "1", "0"},
+		{"No repository", "1", "0", "0", "0"},
+	}, table.Rows)
+
+	// A single repository needs no breakdown
+	single := types.ProjectDiff{ChangedItems: []types.ItemDiff{delayedChange("b", 20)}}
+	assert.Nil(t, buildRepositoriesTable(single, DefaultOptions()))
+}
+
+func TestRepositoriesSection(t *testing.T) {
+	opts := []func(*FormatterOptions){WithSections([]string{SectionRepos})}
+
+	markdown := NewTableFormatter(opts...).Format(repositoriesDiff())
+	assert.Contains(t, markdown, "## 📦 Changes by Repository")
+	assert.Contains(t, markdown, "| org/api | 0 | 0 | 2 | 1 |")
+
+	text := NewTextFormatter(opts...).Format(repositoriesDiff())
+	assert.Contains(t, text, "Changes by repository:\n- org/api: 0 added, 0 removed, 2 changed, 1 delayed\n")
+}
+
+func TestTextFormatterItemReference(t *testing.T) {
+	output := NewTextFormatter().Format(repositoriesDiff())
+	assert.Contains(t, output, "- New page (org/web#7)\n")
+	assert.Contains(t, output, "- Idea\n")
+	assert.Contains(t, output, "- Task b (org/api#1)\n")
+}
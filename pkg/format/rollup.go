@@ -0,0 +1,45 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// summarySectionTitle returns the title of the summary section for a rollup in the language
+func summarySectionTitle(r types.Rollup, lang Language) string {
+	return fmt.Sprintf(lang.Translate("📊 Summary (weighted by %s)"), r.WeightField)
+}
+
+// buildRollupTable builds the summary table for a weighted rollup
+func buildRollupTable(r types.Rollup) *Table {
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Metric", Alignment: AlignLeft},
+			{Header: r.WeightField, Alignment: AlignRight},
+		},
+		Rows: [][]string{
+			{"Added", formatWeight(r.Added)},
+			{"Removed", formatWeight(r.Removed)},
+			{"Changed", formatWeight(r.Changed)},
+			{"Delayed", formatWeight(r.Delayed)},
+			{"Weighted slip (days)", formatWeight(r.SlipDays)},
+		},
+	}
+	if r.MissingWeight > 0 {
+		table.Rows = append(table.Rows, []string{
+			fmt.Sprintf("Items without %s", r.WeightField),
+			strconv.Itoa(r.MissingWeight),
+		})
+	}
+	if r.Restricted > 0 {
+		table.Rows = append(table.Rows, []string{"Restricted items", strconv.Itoa(r.Restricted)})
+	}
+	return table
+}
+
+// formatWeight formats a weight without trailing zeros
+func formatWeight(w float64) string {
+	return strconv.FormatFloat(w, 'f', -1, 64)
+}
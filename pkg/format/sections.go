@@ -0,0 +1,101 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section IDs that can be selected and ordered with WithSections
+const (
+	SectionPrevious   = "previous"     // Changes to the delayed list since the previous report
+	SectionSummary    = "summary"      // Weighted summary
+	SectionTimeline   = "timeline"     // Timeline changes of added, removed and changed items
+	SectionComments   = "comments"     // Latest comments of items with a high or extreme delay
+	SectionAhead      = "ahead"        // Items completed early or with their end date pulled in
+	SectionOther      = "other"        // Changes of other fields
+	SectionWorkflows  = "workflows"    // Built-in project workflows that were enabled, disabled, added or removed
+	SectionQuality    = "quality"      // Data quality problems such as stale drafts
+	SectionWorkload   = "workload"     // Items scheduled per assignee in the upcoming weeks
+	SectionCauses     = "causes"       // Slip days attributed to cause labels
+	SectionSLA        = "sla"          // Items in a status for longer than its SLA
+	SectionTrajectory = "trajectory"   // When the dates of items moved across intermediate snapshots
+	SectionRepos      = "repositories" // Changes per repository of the items
+)
+
+// sectionIDs lists all section IDs in their default order
+var sectionIDs = []string{
+	SectionPrevious,
+	SectionSummary,
+	SectionTimeline,
+	SectionComments,
+	SectionAhead,
+	SectionOther,
+	SectionWorkflows,
+	SectionQuality,
+	SectionWorkload,
+	SectionCauses,
+	SectionSLA,
+	SectionTrajectory,
+	SectionRepos,
+}
+
+// SectionIDs returns the IDs of all report sections in their default order
+func SectionIDs() []string {
+	return append([]string(nil), sectionIDs...)
+}
+
+// ValidateSections checks that all given section IDs are known and not repeated
+func ValidateSections(ids []string) error {
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if !isSectionID(id) {
+			return fmt.Errorf("unknown report section: %s (must be one of: %s)", id, strings.Join(sectionIDs, ", "))
+		}
+		if seen[id] {
+			return fmt.Errorf("report section listed twice: %s", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// isSectionID returns true if the ID names a known section
+func isSectionID(id string) bool {
+	for _, known := range sectionIDs {
+		if known == id {
+			return true
+		}
+	}
+	return false
+}
+
+// includesSection returns true if the section is part of the selection. An empty selection includes all sections.
+func includesSection(selection []string, id string) bool {
+	if len(selection) == 0 {
+		return true
+	}
+	for _, selected := range selection {
+		if selected == id {
+			return true
+		}
+	}
+	return false
+}
+
+// orderSections filters and orders sections by their ID according to the selection.
+// An empty selection keeps all sections in their original order.
+func orderSections(sections []Section, selection []string) []Section {
+	if len(selection) == 0 {
+		return sections
+	}
+
+	var ordered []Section
+	for _, id := range selection {
+		for _, section := range sections {
+			if section.ID == id {
+				ordered = append(ordered, section)
+			}
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,45 @@
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSections(t *testing.T) {
+	assert.NoError(t, ValidateSections(nil))
+	assert.NoError(t, ValidateSections([]string{"summary", "timeline"}))
+	assert.ErrorContains(t, ValidateSections([]string{"timeline", "gantt"}), "unknown report section: gantt")
+	assert.ErrorContains(t, ValidateSections([]string{"timeline", "timeline"}), "listed twice")
+}
+
+func TestOrderSections(t *testing.T) {
+	sections := []Section{{ID: SectionSummary}, {ID: SectionTimeline}, {ID: SectionOther}}
+
+	assert.Equal(t, sections, orderSections(sections, nil))
+	assert.Equal(t,
+		[]Section{{ID: SectionOther}, {ID: SectionSummary}},
+		orderSections(sections, []string{SectionOther, SectionPrevious, SectionSummary}),
+	)
+}
+
+func TestFormattersSections(t *testing.T) {
+	diff := createTestDiff()
+
+	t.Run("markdown reorders and excludes sections", func(t *testing.T) {
+		output := NewTableFormatter(
+			WithWeightField("Estimate"),
+			WithSections([]string{SectionOther, SectionSummary}),
+		).Format(diff)
+
+		assert.NotContains(t, output, "Timeline Changes")
+		assert.Less(t, strings.Index(output, "Other Changes"), strings.Index(output, "Summary"))
+	})
+
+	t.Run("text without other changes", func(t *testing.T) {
+		output := NewTextFormatter(WithSections([]string{SectionTimeline})).Format(diff)
+		assert.Contains(t, output, "Changed Task")
+		assert.NotContains(t, output, "status: Todo → In Progress")
+	})
+}
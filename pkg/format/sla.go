@@ -0,0 +1,52 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// slaSectionTitle is the title of the status SLA section
+const slaSectionTitle = "⏱️ Status SLA Violations"
+
+// buildSLATable builds the table of items in a status for longer than its SLA, or
+// nil if there are none
+func buildSLATable(options FormatterOptions) *Table {
+	if len(options.SLAViolations) == 0 {
+		return nil
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Task", Alignment: AlignLeft},
+			{Header: "Status", Alignment: AlignLeft},
+			{Header: "Since", Alignment: AlignLeft},
+			{Header: "In Status", Alignment: AlignRight},
+			{Header: "SLA", Alignment: AlignRight},
+			{Header: "Overage", Alignment: AlignRight},
+		},
+		Links:  make(map[string]string),
+		Colors: options.Palette.Colors(types.PaletteStatus),
+	}
+	for _, v := range options.SLAViolations {
+		title := v.Item.GetTitle()
+		if v.Item.URL != "" {
+			table.Links[title] = v.Item.URL
+		}
+		table.Rows = append(table.Rows, []string{
+			title,
+			v.Status,
+			formatTimestamp(v.Since, options),
+			formatBusinessDays(v.BusinessDays),
+			formatBusinessDays(v.Limit),
+			formatBusinessDays(v.Overage()),
+		})
+		table.severity = append(table.severity, v.Overage())
+	}
+	return table
+}
+
+// formatBusinessDays formats a number of business days
+func formatBusinessDays(days int) string {
+	return fmt.Sprintf("%d business day%s", days, pluralize(days))
+}
@@ -0,0 +1,64 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func slaViolations() []types.SLAViolation {
+	return []types.SLAViolation{
+		{
+			Item:         types.Item{ID: "1", URL: "https://github.com/org/repo/issues/1", Attributes: map[string]interface{}{"Title": "Migrate DB"}},
+			Status:       "Blocked",
+			Since:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			BusinessDays: 7,
+			Limit:        5,
+		},
+	}
+}
+
+func TestBuildSLATable(t *testing.T) {
+	assert.Nil(t, buildSLATable(DefaultOptions()))
+
+	options := DefaultOptions()
+	WithSLAViolations(slaViolations())(&options)
+	table := buildSLATable(options)
+	require.NotNil(t, table)
+	assert.Equal(t, [][]string{
+		{"Migrate DB", "Blocked", "Jan 1, 2024", "7 business days", "5 business days", "2 business days"},
+	}, table.Rows)
+	assert.Equal(t, "https://github.com/org/repo/issues/1", table.Links["Migrate DB"])
+}
+
+func TestSLASection(t *testing.T) {
+	opts := []func(*FormatterOptions){WithSLAViolations(slaViolations()), WithSections([]string{SectionSLA})}
+
+	markdown := NewTableFormatter(opts...).Format(createTestDiff())
+	assert.Contains(t, markdown, slaSectionTitle)
+	assert.Contains(t, markdown, "| Blocked |")
+
+	text := NewTextFormatter(opts...).Format(createTestDiff())
+	assert.Equal(t, "Status SLA Violations:\n- Migrate DB: Blocked for 7 business days since Jan 1, 2024 (SLA 5 business days, 2 business days over)\n\n", text)
+}
+
+func TestSLASectionLimitKeepsLargestOverage(t *testing.T) {
+	violations := append([]types.SLAViolation{{
+		Item:         types.Item{ID: "2", Attributes: map[string]interface{}{"Title": "Write docs"}},
+		Status:       "In Review",
+		Since:        time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		BusinessDays: 4,
+		Limit:        3,
+	}}, slaViolations()...)
+	opts := []func(*FormatterOptions){WithSLAViolations(violations), WithSections([]string{SectionSLA}), WithLimit(1)}
+
+	markdown := NewTableFormatter(opts...).Format(createTestDiff())
+	assert.Contains(t, markdown, "| [Migrate DB](https://github.com/org/repo/issues/1) | Blocked |")
+	assert.NotContains(t, markdown, "Write docs")
+
+	text := NewTextFormatter(opts...).Format(createTestDiff())
+	assert.Equal(t, "Status SLA Violations:\n- Migrate DB: Blocked for 7 business days since Jan 1, 2024 (SLA 5 business days, 2 business days over)\n…and 1 more\n\n", text)
+}
@@ -0,0 +1,69 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// snapshotTimeFormat is the format of snapshot timestamps in listings
+const snapshotTimeFormat = "2006-01-02 15:04:05"
+
+// Snapshot describes a stored snapshot in a listing
+type Snapshot struct {
+	Timestamp time.Time `json:"timestamp"`
+	Filename  string    `json:"filename"`
+	Items     int       `json:"items"`
+	Size      int64     `json:"size_bytes"`
+}
+
+// BuildSnapshotsDocument builds the listing of stored snapshots, one row per snapshot
+func BuildSnapshotsDocument(projectNumber int, snapshots []Snapshot) Document {
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Timestamp", Alignment: AlignLeft},
+			{Header: "Items", Alignment: AlignRight},
+			{Header: "Size", Alignment: AlignRight},
+			{Header: "File", Alignment: AlignLeft},
+		},
+	}
+	for _, s := range snapshots {
+		table.Rows = append(table.Rows, []string{
+			s.Timestamp.Local().Format(snapshotTimeFormat),
+			fmt.Sprintf("%d", s.Items),
+			formatBytes(s.Size),
+			s.Filename,
+		})
+	}
+
+	return Document{
+		Title:    fmt.Sprintf("Snapshots of project %d", projectNumber),
+		Sections: []Section{{Table: table}},
+	}
+}
+
+// FormatSnapshotsJSON formats the listing of stored snapshots as indented JSON
+func FormatSnapshotsJSON(snapshots []Snapshot) (string, error) {
+	if snapshots == nil {
+		snapshots = []Snapshot{}
+	}
+	data, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshots: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// formatBytes formats a size in bytes with a binary unit, e.g. "1.5 KiB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
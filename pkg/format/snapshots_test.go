@@ -0,0 +1,55 @@
+package format
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSnapshotsDocument(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	doc := BuildSnapshotsDocument(123, []Snapshot{
+		{Timestamp: ts, Filename: "states/project=123/1704110400.json", Items: 42, Size: 2048},
+	})
+
+	assert.Equal(t, "Snapshots of project 123", doc.Title)
+	require.Len(t, doc.Sections, 1)
+	assert.Equal(t, [][]string{
+		{"2024-01-01 12:00:00", "42", "2.0 KiB", "states/project=123/1704110400.json"},
+	}, doc.Sections[0].Table.Rows)
+}
+
+func TestFormatSnapshotsJSON(t *testing.T) {
+	output, err := FormatSnapshotsJSON(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", output)
+
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	output, err = FormatSnapshotsJSON([]Snapshot{{Timestamp: ts, Filename: "a.json.gz", Items: 3, Size: 100}})
+	require.NoError(t, err)
+
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+	assert.Equal(t, []map[string]interface{}{
+		{"timestamp": "2024-01-01T12:00:00Z", "filename": "a.json.gz", "items": float64(3), "size_bytes": float64(100)},
+	}, decoded)
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GiB"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatBytes(tt.n))
+	}
+}
@@ -0,0 +1,122 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// hiddenAttributes are item attributes shown in their own column or only used internally
+var hiddenAttributes = map[string]bool{
+	"Title":      true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// JSONState is the JSON representation of a single snapshot
+type JSONState struct {
+	ProjectNumber int        `json:"project_number,omitempty"`
+	Timestamp     time.Time  `json:"timestamp"`
+	Filename      string     `json:"filename"`
+	Items         []JSONItem `json:"items"`
+	Warnings      []string   `json:"warnings,omitempty"`
+}
+
+// BuildStateDocument builds the view of a single snapshot, one row per item
+// ordered by start date with undated items last
+func BuildStateDocument(state *types.ProjectState, style DurationStyle) Document {
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Title", Alignment: AlignLeft},
+			{Header: "Start", Alignment: AlignLeft},
+			{Header: "End", Alignment: AlignLeft},
+			{Header: "Duration", Alignment: AlignRight},
+			{Header: "Attributes", Alignment: AlignLeft},
+		},
+		Links: make(map[string]string),
+	}
+
+	for _, item := range sortedStateItems(state.Items) {
+		start, end, duration := "", "", ""
+		if !item.DateSpan.Start.IsZero() {
+			start = item.DateSpan.Start.Format("2006-01-02")
+		}
+		if !item.DateSpan.End.IsZero() {
+			end = item.DateSpan.End.Format("2006-01-02")
+		}
+		if start != "" && end != "" {
+			duration = style.Format(item.DateSpan.DurationDays())
+		}
+		table.Rows = append(table.Rows, []string{item.GetTitle(), start, end, duration, formatAttributes(item.Attributes)})
+		if item.URL != "" {
+			table.Links[item.GetTitle()] = item.URL
+		}
+	}
+
+	title := fmt.Sprintf("Project %d at %s", state.ProjectNumber, state.Timestamp.Local().Format(snapshotTimeFormat))
+	if state.ProjectNumber == 0 {
+		title = fmt.Sprintf("Snapshot at %s", state.Timestamp.Local().Format(snapshotTimeFormat))
+	}
+	section := Section{Table: table, Note: fmt.Sprintf("%d item%s", len(state.Items), pluralize(len(state.Items)))}
+	if len(state.Items) == 0 {
+		section = Section{Text: "The snapshot has no items."}
+	}
+	return Document{Title: title, Sections: []Section{section}}
+}
+
+// FormatStateJSON formats a single snapshot as indented JSON
+func FormatStateJSON(state *types.ProjectState) (string, error) {
+	out := JSONState{
+		ProjectNumber: state.ProjectNumber,
+		Timestamp:     state.Timestamp,
+		Filename:      state.Filename,
+		Items:         make([]JSONItem, 0, len(state.Items)),
+		Warnings:      state.Warnings,
+	}
+	for _, item := range sortedStateItems(state.Items) {
+		out.Items = append(out.Items, toJSONItem(item))
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal state: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// sortedStateItems returns the items ordered by start date and title, undated items last
+func sortedStateItems(items []types.Item) []types.Item {
+	sorted := append([]types.Item(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].DateSpan.Start, sorted[j].DateSpan.Start
+		if a.IsZero() != b.IsZero() {
+			return b.IsZero()
+		}
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return sorted[i].GetTitle() < sorted[j].GetTitle()
+	})
+	return sorted
+}
+
+// formatAttributes formats the visible attributes of an item as "name: value" pairs sorted by name
+func formatAttributes(attributes map[string]interface{}) string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		if !hiddenAttributes[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s: %s", name, types.FormatValue(attributes[name]))
+	}
+	return strings.Join(pairs, ", ")
+}
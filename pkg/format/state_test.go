@@ -0,0 +1,66 @@
+package format
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testState() *types.ProjectState {
+	return &types.ProjectState{
+		ProjectNumber: 42,
+		Timestamp:     time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC),
+		Filename:      "states/project=42/1704715200.json",
+		Items: []types.Item{
+			{ID: "1", Attributes: map[string]interface{}{"Title": "Undated", "Status": "Todo"}},
+			{ID: "2", URL: "https://github.com/org/repo/issues/2", DateSpan: types.MustNewDateSpan("2024-01-15", "2024-01-19"),
+				Attributes: map[string]interface{}{"Title": "Later", "Status": "Todo", "Priority": "High", "created_at": "2024-01-01T00:00:00Z"}},
+			{ID: "3", DateSpan: types.MustNewDateSpan("2024-01-08", "2024-01-10"),
+				Attributes: map[string]interface{}{"Title": "Earlier", "Status": "In Progress"}},
+		},
+	}
+}
+
+func TestBuildStateDocument(t *testing.T) {
+	doc := BuildStateDocument(testState(), DefaultDurationStyle())
+
+	assert.Contains(t, doc.Title, "Project 42 at")
+	require.Len(t, doc.Sections, 1)
+	table := doc.Sections[0].Table
+	require.NotNil(t, table)
+	assert.Equal(t, "3 items", doc.Sections[0].Note)
+
+	require.Len(t, table.Rows, 3)
+	assert.Equal(t, []string{"Earlier", "2024-01-08", "2024-01-10", "3 days", "Status: In Progress"}, table.Rows[0])
+	assert.Equal(t, "Later", table.Rows[1][0])
+	assert.Equal(t, "Priority: High, Status: Todo", table.Rows[1][4])
+	assert.Equal(t, []string{"Undated", "", "", "", "Status: Todo"}, table.Rows[2])
+	assert.Equal(t, map[string]string{"Later": "https://github.com/org/repo/issues/2"}, table.Links)
+}
+
+func TestBuildStateDocumentEmpty(t *testing.T) {
+	doc := BuildStateDocument(&types.ProjectState{ProjectNumber: 42}, DefaultDurationStyle())
+
+	require.Len(t, doc.Sections, 1)
+	assert.Nil(t, doc.Sections[0].Table)
+	assert.Equal(t, "The snapshot has no items.", doc.Sections[0].Text)
+}
+
+func TestFormatStateJSON(t *testing.T) {
+	output, err := FormatStateJSON(testState())
+	require.NoError(t, err)
+
+	var got JSONState
+	require.NoError(t, json.Unmarshal([]byte(output), &got))
+	assert.Equal(t, 42, got.ProjectNumber)
+	assert.Equal(t, "states/project=42/1704715200.json", got.Filename)
+	require.Len(t, got.Items, 3)
+	assert.Equal(t, "Earlier", got.Items[0].Title)
+	assert.Equal(t, "2024-01-08", got.Items[0].Start)
+	assert.Equal(t, "Undated", got.Items[2].Title)
+	assert.Empty(t, got.Items[2].Start)
+}
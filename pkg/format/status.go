@@ -0,0 +1,82 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// Health classifies the overall state of a project. The values match the
+// ProjectV2StatusUpdateStatus enum of the GitHub API.
+type Health string
+
+const (
+	HealthOnTrack  Health = "ON_TRACK"
+	HealthAtRisk   Health = "AT_RISK"
+	HealthOffTrack Health = "OFF_TRACK"
+)
+
+// defaultStatusUpdateLimit is the number of delayed items listed in a status update without a limit option
+const defaultStatusUpdateLimit = 10
+
+// ClassifyHealth classifies the project by its worst delay: any extreme delay
+// puts it off track, any high delay at risk
+func ClassifyHealth(diff types.ProjectDiff, options FormatterOptions) Health {
+	health := HealthOnTrack
+	for _, item := range NewReport(diff, options).Delayed {
+		switch item.Level {
+		case DelayLevelExtreme:
+			return HealthOffTrack
+		case DelayLevelHigh:
+			health = HealthAtRisk
+		}
+	}
+	return health
+}
+
+// DelayedItems returns the items on the delayed list, most delayed first
+func DelayedItems(diff types.ProjectDiff, options FormatterOptions) []ReportItem {
+	delayed := NewReport(diff, options).Delayed
+	sort.SliceStable(delayed, func(i, j int) bool {
+		return statusRank(string(delayed[i].Level)) < statusRank(string(delayed[j].Level))
+	})
+	return delayed
+}
+
+// FormatStatusUpdate formats a condensed Markdown report for a project status
+// update: the change counts followed by the most delayed items
+func FormatStatusUpdate(diff types.ProjectDiff, options FormatterOptions) string {
+	if !hasChanges(diff) {
+		return noChangesMessage(diff)
+	}
+
+	delayed := DelayedItems(diff, options)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%d added, %d removed, %d changed, %d delayed**\n",
+		len(diff.AddedItems), len(diff.RemovedItems), len(diff.ChangedItems), len(delayed)))
+
+	if len(delayed) > 0 {
+		limit := options.Limit
+		if limit <= 0 {
+			limit = defaultStatusUpdateLimit
+		}
+
+		sb.WriteString("\n")
+		for i, item := range delayed {
+			if i == limit {
+				sb.WriteString(fmt.Sprintf("- … and %d more\n", len(delayed)-limit))
+				break
+			}
+			title := item.Title
+			if item.URL != "" {
+				title = markdownLink(title, item.URL)
+			}
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", title, delayLevelName(item.Level)))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package format
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// delayedChange creates a changed item whose end date slipped by the given number of days
+func delayedChange(id string, days int) types.ItemDiff {
+	item := types.Item{ID: id, Attributes: map[string]interface{}{"Title": "Task " + id}}
+	return types.ItemDiff{
+		ItemID:     id,
+		Before:     item,
+		After:      item,
+		DateChange: &types.DateSpanChange{EndDaysDelta: days, DurationDelta: days},
+	}
+}
+
+func TestClassifyHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		delays   []int
+		expected Health
+	}{
+		{"no changes", nil, HealthOnTrack},
+		{"moderate delay", []int{8}, HealthOnTrack},
+		{"high delay", []int{8, 20}, HealthAtRisk},
+		{"extreme delay", []int{20, 45}, HealthOffTrack},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diff types.ProjectDiff
+			for i, days := range tt.delays {
+				diff.ChangedItems = append(diff.ChangedItems, delayedChange(fmt.Sprint(i), days))
+			}
+			assert.Equal(t, tt.expected, ClassifyHealth(diff, DefaultOptions()))
+		})
+	}
+}
+
+func TestFormatStatusUpdate(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems = append(diff.ChangedItems, delayedChange("a", 45), delayedChange("b", 20), delayedChange("c", 2))
+
+	options := DefaultOptions()
+	expected := "**1 added, 1 removed, 4 changed, 3 delayed**\n" +
+		"\n" +
+		"- Task a: Extreme delay\n" +
+		"- Task b: High delay\n" +
+		"- Changed Task: Moderate delay\n"
+	assert.Equal(t, expected, FormatStatusUpdate(diff, options))
+
+	options.Limit = 1
+	assert.Contains(t, FormatStatusUpdate(diff, options), "- Task a: Extreme delay\n- … and 2 more\n")
+
+	assert.Equal(t, "No changes found in the project timeline.", FormatStatusUpdate(types.ProjectDiff{}, options))
+
+	// Items with a URL are linked
+	linked := delayedChange("d", 45)
+	linked.After.URL = "https://github.com/org/repo/issues/4"
+	assert.Contains(t, FormatStatusUpdate(types.ProjectDiff{ChangedItems: []types.ItemDiff{linked}}, options),
+		"- [Task d](https://github.com/org/repo/issues/4): Extreme delay\n")
+}
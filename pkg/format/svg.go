@@ -0,0 +1,191 @@
+package format
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// Layout of the SVG Gantt chart in pixels
+const (
+	svgLabelWidth = 260
+	svgChartWidth = 720
+	svgHeaderTop  = 40 // Top of the date axis, below the title
+	svgRowsTop    = 70 // Top of the first row, below the date axis
+	svgRowHeight  = 26
+	svgBarHeight  = 14
+	svgPadding    = 10
+)
+
+// svgMaxLabel is the number of characters of item titles shown next to their bar,
+// longer titles are truncated and shown in full on hover
+const svgMaxLabel = 36
+
+// svgWeeklyTicksDays is the longest span of the chart, in days, with weekly ticks on
+// the date axis, longer charts have monthly ticks
+const svgWeeklyTicksDays = 62
+
+// svgStyle is the stylesheet embedded in SVG charts
+const svgStyle = `text { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; font-size: 12px; fill: #1f2328; }
+.title { font-size: 16px; font-weight: 600; }
+.tick { stroke: #d1d9e0; }
+.tick-label { fill: #59636e; font-size: 11px; }
+.bar { stroke: #59636e; stroke-width: 0.5; }
+.before { fill: none; stroke: #59636e; stroke-width: 1; stroke-dasharray: 3 2; opacity: 0.6; }
+`
+
+// SVGFormatter formats the current timeline of a project as a standalone SVG Gantt
+// chart, with bars colored by delay level and the previous dates of moved items
+// ghosted behind their bars
+type SVGFormatter struct {
+	options FormatterOptions
+}
+
+// NewSVGFormatter creates a new SVG formatter with the given options
+func NewSVGFormatter(opts ...func(*FormatterOptions)) *SVGFormatter {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &SVGFormatter{options: options}
+}
+
+// svgRow is an item of the chart
+type svgRow struct {
+	item   types.Item
+	before types.DateSpan // Dates before the change, zero unless the dates moved
+	level  DelayLevel
+}
+
+// Format formats the current items of the project diff as an SVG Gantt chart in
+// project order. Items without dates are omitted, and items without timeline changes
+// are shown as on track.
+func (f *SVGFormatter) Format(diff types.ProjectDiff) string {
+	changes := make(map[string]types.ItemDiff)
+	for _, change := range diff.ChangedItems {
+		if change.DateChange != nil {
+			changes[change.ItemID] = change
+		}
+	}
+
+	var rows []svgRow
+	var first, last time.Time
+	extend := func(span types.DateSpan) {
+		if first.IsZero() || span.Start.Before(first) {
+			first = span.Start
+		}
+		if last.IsZero() || span.End.After(last) {
+			last = span.End
+		}
+	}
+	for _, item := range diff.CurrentItems {
+		if item.DateSpan.Start.IsZero() || item.DateSpan.End.IsZero() {
+			continue
+		}
+		row := svgRow{item: item, level: DelayLevelOnTrack}
+		if change, ok := changes[item.ID]; ok {
+			row.level = timelineDelayLevel(change, f.options)
+			if before := change.Before.DateSpan; !before.Start.IsZero() && !before.End.IsZero() {
+				row.before = before
+				extend(before)
+			}
+		}
+		extend(item.DateSpan)
+		rows = append(rows, row)
+	}
+
+	height := svgRowsTop + max(len(rows), 1)*svgRowHeight + svgPadding
+	width := svgLabelWidth + svgChartWidth + 2*svgPadding
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height))
+	sb.WriteString("<style>\n" + svgStyle + "</style>\n")
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height))
+	sb.WriteString(fmt.Sprintf(`<text class="title" x="%d" y="24">Project Timeline</text>`+"\n", svgPadding))
+
+	if len(rows) == 0 {
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d">No dated items</text>`+"\n", svgPadding, svgRowsTop+svgRowHeight/2))
+		sb.WriteString("</svg>\n")
+		return sb.String()
+	}
+
+	// End dates are inclusive, so bars end at the end of their last day
+	end := last.AddDate(0, 0, 1)
+	days := end.Sub(first).Hours() / 24
+	x := func(t time.Time) float64 {
+		return svgLabelWidth + svgPadding + t.Sub(first).Hours()/24/days*svgChartWidth
+	}
+
+	for _, tick := range svgTicks(first, end) {
+		tx := x(tick)
+		sb.WriteString(fmt.Sprintf(`<line class="tick" x1="%.1f" y1="%d" x2="%.1f" y2="%d"/>`+"\n", tx, svgHeaderTop+8, tx, height-svgPadding))
+		sb.WriteString(fmt.Sprintf(`<text class="tick-label" x="%.1f" y="%d">%s</text>`+"\n", tx+2, svgHeaderTop+20, html.EscapeString(f.tickLabel(tick, days))))
+	}
+
+	for i, row := range rows {
+		top := svgRowsTop + i*svgRowHeight
+		barTop := top + (svgRowHeight-svgBarHeight)/2
+		title := row.item.GetTitle()
+
+		label := html.EscapeString(truncateLabel(title, svgMaxLabel))
+		if row.item.URL != "" {
+			label = `<a href="` + html.EscapeString(row.item.URL) + `">` + label + "</a>"
+		}
+		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d"><title>%s</title>%s</text>`+"\n", svgPadding, barTop+svgBarHeight-3, html.EscapeString(title), label))
+
+		if !row.before.Start.IsZero() {
+			bx, bw := x(row.before.Start), x(row.before.End.AddDate(0, 0, 1))-x(row.before.Start)
+			sb.WriteString(fmt.Sprintf(`<rect class="before" x="%.1f" y="%d" width="%.1f" height="%d" rx="3"/>`+"\n", bx, barTop-2, bw, svgBarHeight+4))
+		}
+		ax, aw := x(row.item.DateSpan.Start), x(row.item.DateSpan.End.AddDate(0, 0, 1))-x(row.item.DateSpan.Start)
+		tooltip := fmt.Sprintf("%s: %s → %s (%s)", title,
+			formatDate(row.item.DateSpan.Start, f.options), formatDate(row.item.DateSpan.End, f.options), f.options.label(string(row.level)))
+		sb.WriteString(fmt.Sprintf(`<rect class="bar" x="%.1f" y="%d" width="%.1f" height="%d" rx="3" fill="%s"><title>%s</title></rect>`+"\n",
+			ax, barTop, aw, svgBarHeight, dotColors[row.level], html.EscapeString(tooltip)))
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// svgTicks returns the dates of the ticks of the date axis between first and end:
+// Mondays for short charts, the first day of each month otherwise
+func svgTicks(first, end time.Time) []time.Time {
+	var ticks []time.Time
+	if end.Sub(first).Hours()/24 <= svgWeeklyTicksDays {
+		tick := first.AddDate(0, 0, (int(time.Monday)-int(first.Weekday())+7)%7)
+		for ; tick.Before(end); tick = tick.AddDate(0, 0, 7) {
+			ticks = append(ticks, tick)
+		}
+		return ticks
+	}
+	tick := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, first.Location())
+	if tick.Before(first) {
+		tick = tick.AddDate(0, 1, 0)
+	}
+	for ; tick.Before(end); tick = tick.AddDate(0, 1, 0) {
+		ticks = append(ticks, tick)
+	}
+	return ticks
+}
+
+// tickLabel returns the label of a tick, with the day for weekly ticks and the year
+// for monthly ticks
+func (f *SVGFormatter) tickLabel(tick time.Time, days float64) string {
+	if days <= svgWeeklyTicksDays {
+		return f.options.Locale.FormatDate(tick, "Jan 2")
+	}
+	return f.options.Locale.FormatDate(tick, "Jan 2006")
+}
+
+// truncateLabel shortens a label to n characters, ending truncated labels with "…"
+func truncateLabel(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return truncateRunes(s, n-1) + "…"
+}
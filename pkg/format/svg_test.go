@@ -0,0 +1,65 @@
+package format
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSVGFormatter(t *testing.T) {
+	diff := createTestDiff()
+	diff.ChangedItems[0].After.URL = "https://github.com/org/repo/issues/1"
+	undated := types.Item{ID: "undated-1", Attributes: map[string]interface{}{"Title": "Undated Task"}}
+	stable := types.Item{
+		ID:         "stable-1",
+		DateSpan:   types.MustNewDateSpan("2024-02-01", "2024-02-10"),
+		Attributes: map[string]interface{}{"Title": "Release <v1>"},
+	}
+	diff.CurrentItems = []types.Item{diff.ChangedItems[0].After, stable, undated}
+
+	output := NewSVGFormatter().Format(diff)
+
+	assert.True(t, strings.HasPrefix(output, `<svg xmlns="http://www.w3.org/2000/svg" width="1000" height="132" viewBox="0 0 1000 132">`))
+	assert.True(t, strings.HasSuffix(output, "</svg>\n"))
+	assert.NotContains(t, output, "Undated Task")
+
+	// Weekly ticks from the first Monday
+	assert.Contains(t, output, `<line class="tick" x1="270.0" y1="48" x2="270.0" y2="122"/>`)
+	assert.Contains(t, output, `<text class="tick-label" x="272.0" y="60">Jan 1</text>`)
+
+	// Moved items have their previous dates ghosted behind the bar
+	assert.Contains(t, output, `<a href="https://github.com/org/repo/issues/1">Changed Task</a>`)
+	assert.Contains(t, output, `<rect class="before" x="270.0" y="74" width="263.4" height="18" rx="3"/>`)
+	assert.Contains(t, output, `<rect class="bar" x="270.0" y="76" width="544.4" height="14" rx="3" fill="#fbca04"><title>Changed Task: Jan 1, 2024 → Jan 31, 2024 (🟠 Moderate delay)</title></rect>`)
+
+	// Items without timeline changes are on track
+	assert.Equal(t, 1, strings.Count(output, `class="before"`))
+	assert.Contains(t, output, `<rect class="bar" x="814.4" y="102" width="175.6" height="14" rx="3" fill="#c5def5"><title>Release &lt;v1&gt;: Feb 1, 2024 → Feb 10, 2024 (🔵 On track)</title></rect>`)
+}
+
+func TestSVGFormatterNoDatedItems(t *testing.T) {
+	output := NewSVGFormatter().Format(types.ProjectDiff{})
+	assert.Contains(t, output, ">No dated items</text>")
+	assert.True(t, strings.HasSuffix(output, "</svg>\n"))
+}
+
+func TestSVGTicks(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	// Mondays for short charts
+	assert.Equal(t, []time.Time{date("2024-03-04"), date("2024-03-11")}, svgTicks(date("2024-03-01"), date("2024-03-15")))
+
+	// First of each month for long charts
+	assert.Equal(t, []time.Time{date("2024-02-01"), date("2024-03-01"), date("2024-04-01")}, svgTicks(date("2024-01-15"), date("2024-04-20")))
+}
+
+func TestTruncateLabel(t *testing.T) {
+	assert.Equal(t, "Short", truncateLabel("Short", 10))
+	assert.Equal(t, "Migrate t…", truncateLabel("Migrate the database", 10))
+}
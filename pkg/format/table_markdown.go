@@ -29,91 +29,74 @@ func NewTableFormatter(opts ...func(*FormatterOptions)) *TableFormatter {
 
 // Format formats the project diff as a markdown table
 func (f *TableFormatter) Format(diff types.ProjectDiff) string {
-	if len(diff.AddedItems) == 0 && len(diff.RemovedItems) == 0 && len(diff.ChangedItems) == 0 {
-		return "No changes found in the project timeline."
+	if !hasChanges(diff) {
+		return noChangesMessage(diff)
 	}
 
+	doc := f.buildDocument(diff)
+	return f.renderer.RenderDocument(&doc)
+}
+
+// buildDocument builds the report document of the project diff
+func (f *TableFormatter) buildDocument(diff types.ProjectDiff) Document {
 	doc := Document{
 		Title: "Project Timeline Analysis",
 	}
 
 	// Timeline changes section
-	timelineTable := &Table{
-		Columns: []TableColumn{
-			{Header: "Task", Alignment: AlignLeft},
-			{Header: "Status", Alignment: AlignCenter},
-			{Header: "Details", Alignment: AlignLeft},
-			{Header: "Start Date", Alignment: AlignRight},
-			{Header: "End Date", Alignment: AlignRight},
-			{Header: "Duration", Alignment: AlignRight},
-		},
-	}
-
-	// Added items
-	for _, item := range diff.AddedItems {
-		title := item.GetTitle()
-		duration := formatHumanDuration(item.DateSpan.DurationDays())
-		timelineTable.Rows = append(timelineTable.Rows, []string{
-			title,
-			"Added",
-			"New task",
-			formatDate(item.DateSpan.Start, f.options.DateFormat),
-			formatDate(item.DateSpan.End, f.options.DateFormat),
-			duration,
-		})
-	}
-
-	// Removed items
-	for _, item := range diff.RemovedItems {
-		title := item.GetTitle()
-		duration := formatHumanDuration(item.DateSpan.DurationDays())
-		timelineTable.Rows = append(timelineTable.Rows, []string{
-			title,
-			"Removed",
-			"Task removed",
-			formatDate(item.DateSpan.Start, f.options.DateFormat),
-			formatDate(item.DateSpan.End, f.options.DateFormat),
-			duration,
-		})
-	}
+	timelineTable, statusColumn := buildTimelineTable(diff, f.options)
 
-	// Changed items
-	for _, change := range diff.ChangedItems {
-		title := change.After.GetTitle()
-
-		// Handle timeline changes via DateSpan only
-		if change.DateChange != nil {
-			delay := calculateTimelineDelayLevel(
-				change.DateChange.StartDaysDelta,
-				change.DateChange.DurationDelta,
-				f.options.ModerateDelayThreshold,
-				f.options.HighDelayThreshold,
-				f.options.ExtremeDelayThreshold,
-			)
-			details := formatTimelineDetails(change.DateChange, change.Before.DateSpan, change.After.DateSpan)
-			afterDuration := formatHumanDuration(change.After.DateSpan.DurationDays())
-			durationDiff := ""
-			if change.DateChange.DurationDelta != 0 {
-				durationDiff = fmt.Sprintf(" (%+d days)",
-					change.DateChange.DurationDelta,
-				)
-			}
-
-			timelineTable.Rows = append(timelineTable.Rows, []string{
-				title,
-				string(delay),
-				details,
-				formatDateWithChange(change.After.DateSpan.Start, change.Before.DateSpan.Start, f.options.DateFormat),
-				formatDateWithChange(change.After.DateSpan.End, change.Before.DateSpan.End, f.options.DateFormat),
-				fmt.Sprintf("%s%s", afterDuration, durationDiff),
+	// Changes since the previous report
+	if f.options.PreviousReport != nil {
+		changes := CompareReports(*f.options.PreviousReport, NewReport(diff, f.options))
+		if len(changes) > 0 {
+			doc.Sections = append(doc.Sections, Section{
+				ID:    SectionPrevious,
+				Title: "🔁 Since Previous Report",
+				Table: buildReportComparisonTable(changes),
 			})
 		}
 	}
 
+	// Weighted summary section
+	if f.options.WeightField != "" {
+		rollup := diff.Rollup(f.options.WeightField)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionSummary,
+			Title: summarySectionTitle(rollup, f.options.Language),
+			Table: buildRollupTable(rollup),
+		})
+	}
+
 	if len(timelineTable.Rows) > 0 {
+		note := truncateRows(timelineTable, f.options.Limit, statusColumn, f.options.Language)
 		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionTimeline,
 			Title: "📅 Timeline Changes",
 			Table: timelineTable,
+			Note:  note,
+		})
+	}
+
+	// Latest comments of slipped items
+	if commentsTable := buildCommentsTable(diff, f.options); commentsTable != nil {
+		note := truncateRows(commentsTable, f.options.Limit, 1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionComments,
+			Title: commentsSectionTitle,
+			Table: commentsTable,
+			Note:  note,
+		})
+	}
+
+	// Items ahead of schedule
+	if aheadTable, total := buildAheadTable(diff, f.options, time.Now()); aheadTable != nil {
+		note := strings.TrimSpace(total + " " + truncateRows(aheadTable, f.options.Limit, 1, f.options.Language))
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionAhead,
+			Title: aheadSectionTitle,
+			Table: aheadTable,
+			Note:  note,
 		})
 	}
 
@@ -142,7 +125,7 @@ func (f *TableFormatter) Format(diff types.ProjectDiff) string {
 			columns = append(columns, TableColumn{Header: field, Alignment: AlignCenter})
 		}
 
-		otherTable := &Table{Columns: columns}
+		otherTable := &Table{Columns: columns, Colors: f.options.Palette.Colors(types.PaletteStatus)}
 
 		// Add item changes
 		for _, change := range diff.ChangedItems {
@@ -163,7 +146,7 @@ func (f *TableFormatter) Format(diff types.ProjectDiff) string {
 						// Find the column index for this field
 						for i, field := range sortedFields {
 							if field == fieldChange.Field {
-								row[i+1] = fmt.Sprintf("%v → %v", fieldChange.OldValue, fieldChange.NewValue)
+								row[i+1] = formatFieldChange(fieldChange)
 								break
 							}
 						}
@@ -173,19 +156,98 @@ func (f *TableFormatter) Format(diff types.ProjectDiff) string {
 				// Only add the row if there are actual non-time changes
 				if hasNonTimeChange {
 					otherTable.Rows = append(otherTable.Rows, row)
+					otherTable.severity = append(otherTable.severity, statusSeverity(string(timelineDelayLevel(change, f.options))))
 				}
 			}
 		}
 
 		if len(otherTable.Rows) > 0 {
+			note := truncateRows(otherTable, f.options.Limit, -1, f.options.Language)
 			doc.Sections = append(doc.Sections, Section{
+				ID:    SectionOther,
 				Title: "📋 Other Changes",
 				Table: otherTable,
+				Note:  note,
 			})
 		}
 	}
 
-	return f.renderer.RenderDocument(&doc)
+	// Workflow changes section
+	if workflowsTable := buildWorkflowsTable(diff); workflowsTable != nil {
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionWorkflows,
+			Title: workflowsSectionTitle,
+			Table: workflowsTable,
+		})
+	}
+
+	// Data quality section
+	if qualityTable := buildDataQualityTable(diff, f.options, time.Now()); qualityTable != nil {
+		note := truncateRows(qualityTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionQuality,
+			Title: dataQualitySectionTitle,
+			Table: qualityTable,
+			Note:  note,
+		})
+	}
+
+	// Workload section
+	if workloadTable := buildWorkloadTable(diff, f.options, time.Now()); workloadTable != nil {
+		note := truncateRows(workloadTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionWorkload,
+			Title: workloadSectionTitle,
+			Table: workloadTable,
+			Note:  note,
+		})
+	}
+
+	// Slip causes section
+	if causesTable := buildCausesTable(diff, f.options); causesTable != nil {
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionCauses,
+			Title: causesSectionTitle,
+			Table: causesTable,
+		})
+	}
+
+	// Status SLA section
+	if slaTable := buildSLATable(f.options); slaTable != nil {
+		note := truncateRows(slaTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionSLA,
+			Title: slaSectionTitle,
+			Table: slaTable,
+			Note:  note,
+		})
+	}
+
+	// Trajectory section
+	if trajectoryTable := buildTrajectoryTable(f.options); trajectoryTable != nil {
+		note := truncateRows(trajectoryTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionTrajectory,
+			Title: trajectorySectionTitle,
+			Table: trajectoryTable,
+			Note:  note,
+		})
+	}
+
+	// Changes by repository section
+	if reposTable := buildRepositoriesTable(diff, f.options); reposTable != nil {
+		note := truncateRows(reposTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionRepos,
+			Title: repositoriesSectionTitle,
+			Table: reposTable,
+			Note:  note,
+		})
+	}
+
+	localizeTitles(&doc, f.options)
+	doc.Sections = orderSections(doc.Sections, f.options.Sections)
+	return doc
 }
 
 // hasFieldChanges checks if there are any field changes in the changed items
@@ -199,14 +261,14 @@ func hasFieldChanges(changes []types.ItemDiff) bool {
 }
 
 // formatTimelineDetails formats the timeline change details
-func formatTimelineDetails(change *types.DateSpanChange, before, after types.DateSpan) string {
+func formatTimelineDetails(change *types.DateSpanChange, before, after types.DateSpan, style DurationStyle) string {
 	var parts []string
 	if change.StartDaysDelta != 0 {
 		verb := "delayed"
 		if change.StartDaysDelta < 0 {
 			verb = "moved earlier"
 		}
-		duration := formatHumanDuration(abs(change.StartDaysDelta))
+		duration := style.Format(abs(change.StartDaysDelta))
 		part := fmt.Sprintf("start %s by %s", verb, duration)
 		parts = append(parts, part)
 	}
@@ -215,7 +277,7 @@ func formatTimelineDetails(change *types.DateSpanChange, before, after types.Dat
 		if change.DurationDelta < 0 {
 			verb = "decreased"
 		}
-		duration := formatHumanDuration(abs(change.DurationDelta))
+		duration := style.Format(abs(change.DurationDelta))
 		part := fmt.Sprintf("duration %s by %s", verb, duration)
 		parts = append(parts, part)
 	}
@@ -235,16 +297,24 @@ func abs(n int) int {
 }
 
 // formatDateWithChange formats a date with its change, if any
-func formatDateWithChange(after, before time.Time, format string) string {
+func formatDateWithChange(after, before time.Time, options FormatterOptions) string {
 	if after.Equal(before) {
-		return formatDate(after, format)
+		return formatDate(after, options)
 	}
 	return fmt.Sprintf("%s → %s",
-		formatDate(before, format),
-		formatDate(after, format),
+		formatDate(before, options),
+		formatDate(after, options),
 	)
 }
 
+// markdownLinkEscaper escapes characters ending the text of a Markdown link
+var markdownLinkEscaper = strings.NewReplacer("[", `\[`, "]", `\]`)
+
+// markdownLink formats a Markdown link to the URL
+func markdownLink(text, url string) string {
+	return "[" + markdownLinkEscaper.Replace(text) + "](" + strings.ReplaceAll(url, ")", "%29") + ")"
+}
+
 // MarkdownRenderer handles rendering generic types into markdown format
 type MarkdownRenderer struct{}
 
@@ -288,6 +358,9 @@ func (r *MarkdownRenderer) RenderTable(t *Table) string {
 			if i < len(row) {
 				value = row[i]
 			}
+			if url, ok := t.Links[value]; ok && i == 0 {
+				value = markdownLink(value, url)
+			}
 			sb.WriteString(" " + value + " |")
 		}
 		sb.WriteString("\n")
@@ -310,6 +383,10 @@ func (r *MarkdownRenderer) RenderSection(s *Section) string {
 		sb.WriteString(s.Text + "\n")
 	}
 
+	if s.Note != "" {
+		sb.WriteString("\n_" + s.Note + "_\n")
+	}
+
 	return sb.String()
 }
 
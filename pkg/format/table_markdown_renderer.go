@@ -52,6 +52,9 @@ func (r *MarkdownTableRenderer) RenderTable(t *Table) string {
 			if i < len(row) {
 				value = row[i]
 			}
+			if url, ok := t.Links[value]; ok && i == 0 {
+				value = markdownLink(value, url)
+			}
 			sb.WriteString(" " + value + " |")
 		}
 		sb.WriteString("\n")
@@ -74,6 +77,10 @@ func (r *MarkdownTableRenderer) RenderSection(s *Section) string {
 		sb.WriteString(s.Text + "\n")
 	}
 
+	if s.Note != "" {
+		sb.WriteString("\n_" + s.Note + "_\n")
+	}
+
 	return sb.String()
 }
 
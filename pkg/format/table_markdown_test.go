@@ -58,6 +58,28 @@ func TestMarkdownRenderer_RenderTable(t *testing.T) {
 |:------|------:|:-----:|
 | Alice | 25 | - |
 | Bob | 30 | Active |
+`,
+		},
+		{
+			name: "table with linked titles",
+			table: Table{
+				Columns: []TableColumn{
+					{Header: "Task", Alignment: AlignLeft},
+					{Header: "Status", Alignment: AlignLeft},
+				},
+				Rows: [][]string{
+					{"Fix [flaky] login", "Done"},
+					{"Done", "Done"},
+				},
+				Links: map[string]string{
+					"Fix [flaky] login": "https://github.com/org/repo/issues/1",
+					"Done":              "https://github.com/org/repo/issues/2",
+				},
+			},
+			expected: `| Task | Status |
+|:------|:------|
+| [Fix \[flaky\] login](https://github.com/org/repo/issues/1) | Done |
+| [Done](https://github.com/org/repo/issues/2) | Done |
 `,
 		},
 	}
@@ -349,8 +371,23 @@ func TestFormatTimelineDetails(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := formatTimelineDetails(tt.change, tt.before, tt.after)
+			got := formatTimelineDetails(tt.change, tt.before, tt.after, DefaultDurationStyle())
 			assert.Equal(t, tt.expected, got)
 		})
 	}
 }
+
+func TestTableFormatterWeightedSummary(t *testing.T) {
+	diff := createTestDiff()
+	diff.AddedItems[0].Attributes["Estimate"] = float64(5)
+	diff.RemovedItems[0].Attributes["Estimate"] = float64(2)
+	diff.ChangedItems[0].After.Attributes["Estimate"] = float64(13)
+
+	output := NewTableFormatter(WithWeightField("Estimate")).Format(diff)
+
+	assert.Contains(t, output, "## 📊 Summary (weighted by Estimate)")
+	assert.Contains(t, output, "| Metric | Estimate |")
+	assert.Contains(t, output, "| Removed | 2 |")
+	assert.Contains(t, output, "| Delayed | 13 |")
+	assert.NotContains(t, output, "Items without Estimate")
+}
@@ -1,9 +1,9 @@
 package format
 
 import (
-	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/naag/gh-project-report/pkg/types"
 	"github.com/olekukonko/tablewriter"
@@ -27,8 +27,8 @@ func NewPlainTableFormatter(opts ...func(*FormatterOptions)) *PlainTableFormatte
 
 // Format formats the project diff as a plain table
 func (f *PlainTableFormatter) Format(diff types.ProjectDiff) string {
-	if len(diff.AddedItems) == 0 && len(diff.RemovedItems) == 0 && len(diff.ChangedItems) == 0 {
-		return "No changes found in the project timeline."
+	if !hasChanges(diff) {
+		return noChangesMessage(diff)
 	}
 
 	doc := Document{
@@ -36,82 +36,59 @@ func (f *PlainTableFormatter) Format(diff types.ProjectDiff) string {
 	}
 
 	// Timeline changes section
-	timelineTable := &Table{
-		Columns: []TableColumn{
-			{Header: "Task", Alignment: AlignLeft},
-			{Header: "Status", Alignment: AlignCenter},
-			{Header: "Details", Alignment: AlignLeft},
-			{Header: "Start Date", Alignment: AlignRight},
-			{Header: "End Date", Alignment: AlignRight},
-			{Header: "Duration", Alignment: AlignRight},
-		},
+	timelineTable, statusColumn := buildTimelineTable(diff, f.options)
+
+	// Changes since the previous report
+	if f.options.PreviousReport != nil {
+		changes := CompareReports(*f.options.PreviousReport, NewReport(diff, f.options))
+		if len(changes) > 0 {
+			doc.Sections = append(doc.Sections, Section{
+				ID:    SectionPrevious,
+				Title: "🔁 Since Previous Report",
+				Table: buildReportComparisonTable(changes),
+			})
+		}
 	}
 
-	// Added items
-	for _, item := range diff.AddedItems {
-		title := item.GetTitle()
-		duration := formatHumanDuration(item.DateSpan.DurationDays())
-		timelineTable.Rows = append(timelineTable.Rows, []string{
-			title,
-			"Added",
-			"New task",
-			formatDate(item.DateSpan.Start, f.options.DateFormat),
-			formatDate(item.DateSpan.End, f.options.DateFormat),
-			duration,
+	// Weighted summary section
+	if f.options.WeightField != "" {
+		rollup := diff.Rollup(f.options.WeightField)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionSummary,
+			Title: summarySectionTitle(rollup, f.options.Language),
+			Table: buildRollupTable(rollup),
 		})
 	}
 
-	// Removed items
-	for _, item := range diff.RemovedItems {
-		title := item.GetTitle()
-		duration := formatHumanDuration(item.DateSpan.DurationDays())
-		timelineTable.Rows = append(timelineTable.Rows, []string{
-			title,
-			"Removed",
-			"Task removed",
-			formatDate(item.DateSpan.Start, f.options.DateFormat),
-			formatDate(item.DateSpan.End, f.options.DateFormat),
-			duration,
+	if len(timelineTable.Rows) > 0 {
+		note := truncateRows(timelineTable, f.options.Limit, statusColumn, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionTimeline,
+			Title: "📅 Timeline Changes",
+			Table: timelineTable,
+			Note:  note,
 		})
 	}
 
-	// Changed items
-	for _, change := range diff.ChangedItems {
-		title := change.After.GetTitle()
-
-		// Handle timeline changes via DateSpan only
-		if change.DateChange != nil {
-			delay := calculateTimelineDelayLevel(
-				change.DateChange.StartDaysDelta,
-				change.DateChange.DurationDelta,
-				f.options.ModerateDelayThreshold,
-				f.options.HighDelayThreshold,
-				f.options.ExtremeDelayThreshold,
-			)
-			details := formatTimelineDetails(change.DateChange, change.Before.DateSpan, change.After.DateSpan)
-			afterDuration := formatHumanDuration(change.After.DateSpan.DurationDays())
-			durationDiff := ""
-			if change.DateChange.DurationDelta != 0 {
-				durationDiff = fmt.Sprintf(" (%+d days)",
-					change.DateChange.DurationDelta,
-				)
-			}
-
-			timelineTable.Rows = append(timelineTable.Rows, []string{
-				title,
-				string(delay),
-				details,
-				formatDateWithChange(change.After.DateSpan.Start, change.Before.DateSpan.Start, f.options.DateFormat),
-				formatDateWithChange(change.After.DateSpan.End, change.Before.DateSpan.End, f.options.DateFormat),
-				fmt.Sprintf("%s%s", afterDuration, durationDiff),
-			})
-		}
+	// Latest comments of slipped items
+	if commentsTable := buildCommentsTable(diff, f.options); commentsTable != nil {
+		note := truncateRows(commentsTable, f.options.Limit, 1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionComments,
+			Title: commentsSectionTitle,
+			Table: commentsTable,
+			Note:  note,
+		})
 	}
 
-	if len(timelineTable.Rows) > 0 {
+	// Items ahead of schedule
+	if aheadTable, total := buildAheadTable(diff, f.options, time.Now()); aheadTable != nil {
+		note := strings.TrimSpace(total + " " + truncateRows(aheadTable, f.options.Limit, 1, f.options.Language))
 		doc.Sections = append(doc.Sections, Section{
-			Title: "📅 Timeline Changes",
-			Table: timelineTable,
+			ID:    SectionAhead,
+			Title: aheadSectionTitle,
+			Table: aheadTable,
+			Note:  note,
 		})
 	}
 
@@ -161,7 +138,7 @@ func (f *PlainTableFormatter) Format(diff types.ProjectDiff) string {
 						// Find the column index for this field
 						for i, field := range sortedFields {
 							if field == fieldChange.Field {
-								row[i+1] = fmt.Sprintf("%v → %v", fieldChange.OldValue, fieldChange.NewValue)
+								row[i+1] = formatFieldChange(fieldChange)
 								break
 							}
 						}
@@ -171,18 +148,97 @@ func (f *PlainTableFormatter) Format(diff types.ProjectDiff) string {
 				// Only add the row if there are actual non-time changes
 				if hasNonTimeChange {
 					otherTable.Rows = append(otherTable.Rows, row)
+					otherTable.severity = append(otherTable.severity, statusSeverity(string(timelineDelayLevel(change, f.options))))
 				}
 			}
 		}
 
 		if len(otherTable.Rows) > 0 {
+			note := truncateRows(otherTable, f.options.Limit, -1, f.options.Language)
 			doc.Sections = append(doc.Sections, Section{
+				ID:    SectionOther,
 				Title: "📋 Other Changes",
 				Table: otherTable,
+				Note:  note,
 			})
 		}
 	}
 
+	// Workflow changes section
+	if workflowsTable := buildWorkflowsTable(diff); workflowsTable != nil {
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionWorkflows,
+			Title: workflowsSectionTitle,
+			Table: workflowsTable,
+		})
+	}
+
+	// Data quality section
+	if qualityTable := buildDataQualityTable(diff, f.options, time.Now()); qualityTable != nil {
+		note := truncateRows(qualityTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionQuality,
+			Title: dataQualitySectionTitle,
+			Table: qualityTable,
+			Note:  note,
+		})
+	}
+
+	// Workload section
+	if workloadTable := buildWorkloadTable(diff, f.options, time.Now()); workloadTable != nil {
+		note := truncateRows(workloadTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionWorkload,
+			Title: workloadSectionTitle,
+			Table: workloadTable,
+			Note:  note,
+		})
+	}
+
+	// Slip causes section
+	if causesTable := buildCausesTable(diff, f.options); causesTable != nil {
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionCauses,
+			Title: causesSectionTitle,
+			Table: causesTable,
+		})
+	}
+
+	// Status SLA section
+	if slaTable := buildSLATable(f.options); slaTable != nil {
+		note := truncateRows(slaTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionSLA,
+			Title: slaSectionTitle,
+			Table: slaTable,
+			Note:  note,
+		})
+	}
+
+	// Trajectory section
+	if trajectoryTable := buildTrajectoryTable(f.options); trajectoryTable != nil {
+		note := truncateRows(trajectoryTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionTrajectory,
+			Title: trajectorySectionTitle,
+			Table: trajectoryTable,
+			Note:  note,
+		})
+	}
+
+	// Changes by repository section
+	if reposTable := buildRepositoriesTable(diff, f.options); reposTable != nil {
+		note := truncateRows(reposTable, f.options.Limit, -1, f.options.Language)
+		doc.Sections = append(doc.Sections, Section{
+			ID:    SectionRepos,
+			Title: repositoriesSectionTitle,
+			Table: reposTable,
+			Note:  note,
+		})
+	}
+
+	localizeTitles(&doc, f.options)
+	doc.Sections = orderSections(doc.Sections, f.options.Sections)
 	return f.renderDocument(&doc)
 }
 
@@ -215,6 +271,10 @@ func (f *PlainTableFormatter) renderSection(s *Section) string {
 		sb.WriteString(s.Text + "\n")
 	}
 
+	if s.Note != "" {
+		sb.WriteString("\n" + s.Note + "\n")
+	}
+
 	return sb.String()
 }
 
@@ -268,7 +328,7 @@ func (f *PlainTableFormatter) renderTable(t *Table) string {
 		paddedRow := make([]string, len(t.Columns))
 		for i := range t.Columns {
 			if i < len(row) {
-				paddedRow[i] = row[i]
+				paddedRow[i] = f.options.colorArrows(f.options.colorize(row[i]))
 			} else {
 				paddedRow[i] = "-"
 			}
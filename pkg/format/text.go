@@ -2,7 +2,9 @@ package format
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/naag/gh-project-report/pkg/types"
 )
@@ -23,54 +25,257 @@ func NewTextFormatter(opts ...func(*FormatterOptions)) *TextFormatter {
 
 // Format formats the project diff as plain text
 func (f *TextFormatter) Format(diff types.ProjectDiff) string {
-	if len(diff.AddedItems) == 0 && len(diff.RemovedItems) == 0 && len(diff.ChangedItems) == 0 {
-		return "No changes found in the project timeline."
+	if !hasChanges(diff) {
+		return noChangesMessage(diff)
 	}
 
+	var sections []Section
+
+	// Changes since the previous report
+	if f.options.PreviousReport != nil {
+		changes := CompareReports(*f.options.PreviousReport, NewReport(diff, f.options))
+		if len(changes) > 0 {
+			var sb strings.Builder
+			sb.WriteString(f.options.Language.Translate("Since Previous Report") + ":\n")
+			for _, row := range buildReportComparisonTable(changes).Rows {
+				sb.WriteString(fmt.Sprintf("- %s: %s (%s → %s)\n", row[0], row[1], row[2], row[3]))
+			}
+			sb.WriteString("\n")
+			sections = append(sections, Section{ID: SectionPrevious, Text: sb.String()})
+		}
+	}
+
+	// Weighted summary
+	if f.options.WeightField != "" {
+		var sb strings.Builder
+		rollup := diff.Rollup(f.options.WeightField)
+		sb.WriteString(strings.TrimPrefix(summarySectionTitle(rollup, f.options.Language), "📊 ") + ":\n")
+		for _, row := range buildRollupTable(rollup).Rows {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", row[0], row[1]))
+		}
+		sb.WriteString("\n")
+		sections = append(sections, Section{ID: SectionSummary, Text: sb.String()})
+	}
+
+	// Item listings
+	sections = append(sections, Section{ID: SectionTimeline, Text: f.formatItems(diff)})
+
+	// Latest comments of slipped items
+	if commentsTable := buildCommentsTable(diff, f.options); commentsTable != nil {
+		var sb strings.Builder
+		sb.WriteString(f.options.Language.Translate("Slip Context") + ":\n")
+		for _, row := range commentsTable.Rows {
+			sb.WriteString(fmt.Sprintf("- %s (%s)\n  %s\n", row[0], row[1], row[2]))
+		}
+		sb.WriteString("\n")
+		sections = append(sections, Section{ID: SectionComments, Text: sb.String()})
+	}
+
+	// Items ahead of schedule
+	if aheadTable, total := buildAheadTable(diff, f.options, time.Now()); aheadTable != nil {
+		var sb strings.Builder
+		sb.WriteString(f.options.Language.Translate("Ahead / Completed early") + ":\n")
+		note := truncateRows(aheadTable, f.options.Limit, -1, f.options.Language)
+		for _, row := range aheadTable.Rows {
+			sb.WriteString(fmt.Sprintf("- %s: %s, %s saved (planned %s, now %s)\n", row[0], row[1], row[4], row[2], row[3]))
+		}
+		if note != "" {
+			sb.WriteString(note + "\n")
+		}
+		sb.WriteString(total + "\n\n")
+		sections = append(sections, Section{ID: SectionAhead, Text: sb.String()})
+	}
+
+	// Workflow changes
+	if workflowsTable := buildWorkflowsTable(diff); workflowsTable != nil {
+		var sb strings.Builder
+		sb.WriteString(f.options.Language.Translate("Workflow Changes") + ":\n")
+		for _, row := range workflowsTable.Rows {
+			line := fmt.Sprintf("- %s: %s", row[0], row[1])
+			if row[2] != "-" {
+				line += " (" + row[2] + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+		sections = append(sections, Section{ID: SectionWorkflows, Text: sb.String()})
+	}
+
+	// Data quality
+	if qualityTable := buildDataQualityTable(diff, f.options, time.Now()); qualityTable != nil {
+		var sb strings.Builder
+		sb.WriteString(f.options.Language.Translate("Data Quality") + ":\n")
+		note := truncateRows(qualityTable, f.options.Limit, -1, f.options.Language)
+		for _, row := range qualityTable.Rows {
+			sb.WriteString(fmt.Sprintf("- %s: %s, created %s\n", row[0], row[1], row[2]))
+		}
+		if note != "" {
+			sb.WriteString(note + "\n")
+		}
+		sb.WriteString("\n")
+		sections = append(sections, Section{ID: SectionQuality, Text: sb.String()})
+	}
+
+	// Workload
+	if workloadTable := buildWorkloadTable(diff, f.options, time.Now()); workloadTable != nil {
+		var sb strings.Builder
+		sb.WriteString(f.options.Language.Translate("Workload") + ":\n")
+		note := truncateRows(workloadTable, f.options.Limit, -1, f.options.Language)
+		for _, row := range workloadTable.Rows {
+			line := fmt.Sprintf("- %s: %s (%s scheduled days)", row[0], strings.Join(row[1:len(row)-2], " | "), row[len(row)-2])
+			if load := row[len(row)-1]; load != workloadOK {
+				line += " " + load
+			}
+			sb.WriteString(line + "\n")
+		}
+		if note != "" {
+			sb.WriteString(note + "\n")
+		}
+		sb.WriteString("\n")
+		sections = append(sections, Section{ID: SectionWorkload, Text: sb.String()})
+	}
+
+	// Slip causes
+	if causesTable := buildCausesTable(diff, f.options); causesTable != nil {
+		var sb strings.Builder
+		sb.WriteString(f.options.Language.Translate("Slip causes") + ":\n")
+		for _, row := range causesTable.Rows {
+			sb.WriteString(fmt.Sprintf("- %s: %s, %s of the slip (items: %s)\n", row[0], row[2], row[3], row[1]))
+		}
+		sb.WriteString("\n")
+		sections = append(sections, Section{ID: SectionCauses, Text: sb.String()})
+	}
+
+	// Status SLA violations
+	if slaTable := buildSLATable(f.options); slaTable != nil {
+		var sb strings.Builder
+		sb.WriteString(f.options.Language.Translate("Status SLA Violations") + ":\n")
+		note := truncateRows(slaTable, f.options.Limit, -1, f.options.Language)
+		for _, row := range slaTable.Rows {
+			sb.WriteString(fmt.Sprintf("- %s: %s for %s since %s (SLA %s, %s over)\n", row[0], row[1], row[3], row[2], row[4], row[5]))
+		}
+		if note != "" {
+			sb.WriteString(note + "\n")
+		}
+		sb.WriteString("\n")
+		sections = append(sections, Section{ID: SectionSLA, Text: sb.String()})
+	}
+
+	// Trajectory
+	if trajectoryTable := buildTrajectoryTable(f.options); trajectoryTable != nil {
+		var sb strings.Builder
+		sb.WriteString(f.options.Language.Translate("Trajectory") + ":\n")
+		note := truncateRows(trajectoryTable, f.options.Limit, -1, f.options.Language)
+		for _, row := range trajectoryTable.Rows {
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", row[0], row[3]))
+		}
+		if note != "" {
+			sb.WriteString(note + "\n")
+		}
+		sb.WriteString("\n")
+		sections = append(sections, Section{ID: SectionTrajectory, Text: sb.String()})
+	}
+
+	// Changes by repository
+	if reposTable := buildRepositoriesTable(diff, f.options); reposTable != nil {
+		var sb strings.Builder
+		sb.WriteString(f.options.Language.Translate("Changes by repository") + ":\n")
+		note := truncateRows(reposTable, f.options.Limit, -1, f.options.Language)
+		for _, row := range reposTable.Rows {
+			sb.WriteString(fmt.Sprintf("- %s: %s added, %s removed, %s changed, %s delayed\n", row[0], row[1], row[2], row[3], row[4]))
+		}
+		if note != "" {
+			sb.WriteString(note + "\n")
+		}
+		sb.WriteString("\n")
+		sections = append(sections, Section{ID: SectionRepos, Text: sb.String()})
+	}
+
+	var sb strings.Builder
+	for _, section := range orderSections(sections, f.options.Sections) {
+		sb.WriteString(section.Text)
+	}
+	return f.options.colorArrows(sb.String())
+}
+
+// itemHeading returns the title of an item followed by its reference, e.g.
+// "Fix login (org/repo#123)", if the repository and number are known
+func itemHeading(item types.Item) string {
+	if ref := item.Reference(); ref != "" {
+		return fmt.Sprintf("%s (%s)", item.GetTitle(), ref)
+	}
+	return item.GetTitle()
+}
+
+// formatItems formats the added, removed and changed items
+func (f *TextFormatter) formatItems(diff types.ProjectDiff) string {
 	var sb strings.Builder
 
 	// Added items
 	if len(diff.AddedItems) > 0 {
-		sb.WriteString("Added Items:\n")
-		for _, item := range diff.AddedItems {
-			title := item.GetTitle()
+		sb.WriteString(f.options.Language.Translate("Added Items") + ":\n")
+		shown := f.limit(len(diff.AddedItems))
+		for _, item := range diff.AddedItems[:shown] {
+			title := itemHeading(item)
 			duration := item.DateSpan.DurationDays()
 			sb.WriteString(fmt.Sprintf("- %s\n", title))
-			sb.WriteString(fmt.Sprintf("  Status: Added\n"))
+			sb.WriteString(fmt.Sprintf("  Status: %s\n", f.options.colorize("Added")))
 			sb.WriteString(fmt.Sprintf("  Timeline: %s → %s (%s)\n",
-				formatDate(item.DateSpan.Start, f.options.DateFormat),
-				formatDate(item.DateSpan.End, f.options.DateFormat),
-				formatHumanDuration(duration),
+				formatDate(item.DateSpan.Start, f.options),
+				formatDate(item.DateSpan.End, f.options),
+				f.options.Duration.Format(duration),
 			))
-			sb.WriteString(f.formatAttributes(item.Attributes))
+			sb.WriteString(f.formatDueLine(item))
+			if f.options.ShowAttributes.listsAddedRemoved() {
+				sb.WriteString(f.formatAttributes(item.Attributes, "  "))
+			}
 			sb.WriteString("\n")
 		}
+		if omitted := len(diff.AddedItems) - shown; omitted > 0 {
+			sb.WriteString(formatOmitted(omitted, nil, f.options.Language) + "\n\n")
+		}
 	}
 
 	// Removed items
 	if len(diff.RemovedItems) > 0 {
-		sb.WriteString("Removed Items:\n")
-		for _, item := range diff.RemovedItems {
-			title := item.GetTitle()
+		sb.WriteString(f.options.Language.Translate("Removed Items") + ":\n")
+		shown := f.limit(len(diff.RemovedItems))
+		for _, item := range diff.RemovedItems[:shown] {
+			title := itemHeading(item)
 			duration := item.DateSpan.DurationDays()
 			sb.WriteString(fmt.Sprintf("- %s\n", title))
-			sb.WriteString(fmt.Sprintf("  Status: Removed\n"))
+			sb.WriteString(fmt.Sprintf("  Status: %s\n", f.options.colorize("Removed")))
 			sb.WriteString(fmt.Sprintf("  Timeline: %s → %s (%s)\n",
-				formatDate(item.DateSpan.Start, f.options.DateFormat),
-				formatDate(item.DateSpan.End, f.options.DateFormat),
-				formatHumanDuration(duration),
+				formatDate(item.DateSpan.Start, f.options),
+				formatDate(item.DateSpan.End, f.options),
+				f.options.Duration.Format(duration),
 			))
-			sb.WriteString(f.formatAttributes(item.Attributes))
+			if f.options.ShowAttributes.listsAddedRemoved() {
+				sb.WriteString(f.formatAttributes(item.Attributes, "  "))
+			}
 			sb.WriteString("\n")
 		}
+		if omitted := len(diff.RemovedItems) - shown; omitted > 0 {
+			sb.WriteString(formatOmitted(omitted, nil, f.options.Language) + "\n\n")
+		}
 	}
 
 	// Changed items
 	if len(diff.ChangedItems) > 0 {
-		sb.WriteString("Changed Items:\n")
-		for _, change := range diff.ChangedItems {
-			title := change.After.GetTitle()
+		sb.WriteString(f.options.Language.Translate("Changed Items") + ":\n")
+		// The most delayed items are kept by a limit
+		severity := make([]int, len(diff.ChangedItems))
+		for i, change := range diff.ChangedItems {
+			severity[i] = statusSeverity(string(timelineDelayLevel(change, f.options)))
+		}
+		kept, omitted := mostSevere(severity, f.limit(len(diff.ChangedItems)))
+		for _, i := range kept {
+			change := diff.ChangedItems[i]
+			title := itemHeading(change.After)
 			sb.WriteString(fmt.Sprintf("- %s\n", title))
+			if change.IsRecreated() {
+				sb.WriteString(fmt.Sprintf("  Re-created: replaces removed item %s\n", change.PreviousID))
+			}
 
 			// Timeline changes
 			if change.DateChange != nil {
@@ -82,47 +287,102 @@ func (f *TextFormatter) Format(diff types.ProjectDiff) string {
 					f.options.ExtremeDelayThreshold,
 				)
 				sb.WriteString(fmt.Sprintf("  Timeline: %s %s\n",
-					string(delay),
-					formatHumanDuration(change.DateChange.DurationDelta),
+					f.options.colorize(f.options.label(string(delay))),
+					f.options.Duration.Format(change.DateChange.DurationDelta),
 				))
 				sb.WriteString(fmt.Sprintf("  Before: %s → %s\n",
-					formatDate(change.Before.DateSpan.Start, f.options.DateFormat),
-					formatDate(change.Before.DateSpan.End, f.options.DateFormat),
+					formatDate(change.Before.DateSpan.Start, f.options),
+					formatDate(change.Before.DateSpan.End, f.options),
 				))
 				sb.WriteString(fmt.Sprintf("  After:  %s → %s\n",
-					formatDate(change.After.DateSpan.Start, f.options.DateFormat),
-					formatDate(change.After.DateSpan.End, f.options.DateFormat),
+					formatDate(change.After.DateSpan.Start, f.options),
+					formatDate(change.After.DateSpan.End, f.options),
 				))
+				sb.WriteString(f.formatDueLine(change.After))
 			}
 
 			// Field changes
-			if len(change.FieldChanges) > 0 {
+			if len(change.FieldChanges) > 0 && f.options.ShowAttributes != AttributesNone && includesSection(f.options.Sections, SectionOther) {
 				sb.WriteString("  Changes:\n")
 				for _, fieldChange := range change.FieldChanges {
 					if fieldChange.Field == "updated_at" || fieldChange.Field == "created_at" {
 						continue
 					}
-					sb.WriteString(fmt.Sprintf("    %s: %v → %v\n",
-						fieldChange.Field,
-						fieldChange.OldValue,
-						fieldChange.NewValue,
-					))
+					sb.WriteString(fmt.Sprintf("    %s: %s\n", fieldChange.Field, formatFieldChange(fieldChange)))
+				}
+			}
+
+			// Current attributes
+			if f.options.ShowAttributes == AttributesAll {
+				if attributes := f.formatAttributes(change.After.Attributes, "    "); attributes != "" {
+					sb.WriteString("  Attributes:\n" + attributes)
 				}
 			}
 			sb.WriteString("\n")
 		}
+		if len(omitted) > 0 {
+			changes := make([]types.ItemDiff, len(omitted))
+			for j, i := range omitted {
+				changes[j] = diff.ChangedItems[i]
+			}
+			sb.WriteString(formatOmitted(len(changes), f.changeStatuses(changes), f.options.Language) + "\n\n")
+		}
 	}
 
 	return sb.String()
 }
 
-// formatAttributes formats item attributes as a string
-func (f *TextFormatter) formatAttributes(attrs map[string]interface{}) string {
-	var sb strings.Builder
-	for k, v := range attrs {
+// limit returns how many of n entries should be shown
+func (f *TextFormatter) limit(n int) int {
+	if f.options.Limit > 0 && n > f.options.Limit {
+		return f.options.Limit
+	}
+	return n
+}
+
+// changeStatuses returns the delay level of each change, or "Changed" for changes without timeline changes
+func (f *TextFormatter) changeStatuses(changes []types.ItemDiff) []string {
+	statuses := make([]string, len(changes))
+	for i, change := range changes {
+		statuses[i] = f.options.label("Changed")
+		if change.DateChange != nil {
+			statuses[i] = f.options.label(string(calculateTimelineDelayLevel(
+				change.DateChange.StartDaysDelta,
+				change.DateChange.DurationDelta,
+				f.options.ModerateDelayThreshold,
+				f.options.HighDelayThreshold,
+				f.options.ExtremeDelayThreshold,
+			)))
+		}
+	}
+	return statuses
+}
+
+// formatDueLine formats the relative due date of an item, if a project timezone is configured
+func (f *TextFormatter) formatDueLine(item types.Item) string {
+	if f.options.Location == nil {
+		return ""
+	}
+	due := formatItemDue(item, time.Now(), f.options.Location, f.options.Duration)
+	if due == "" {
+		return ""
+	}
+	return fmt.Sprintf("  Due: %s\n", due)
+}
+
+// formatAttributes formats item attributes sorted by name, one per line with the given indent
+func (f *TextFormatter) formatAttributes(attrs map[string]interface{}, indent string) string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
 		if strings.ToLower(k) != "title" && strings.ToLower(k) != "created_at" && strings.ToLower(k) != "updated_at" {
-			sb.WriteString(fmt.Sprintf("  %s: %v\n", k, v))
+			keys = append(keys, k)
 		}
 	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("%s%s: %s\n", indent, k, types.FormatValue(attrs[k])))
+	}
 	return sb.String()
 }
@@ -2,6 +2,7 @@ package format
 
 import (
 	"testing"
+	"time"
 
 	"github.com/naag/gh-project-report/pkg/types"
 	"github.com/stretchr/testify/assert"
@@ -43,6 +44,15 @@ func TestTextFormatterCustomOptions(t *testing.T) {
 		assert.Contains(t, output, string(DelayLevelOnTrack)) // 8 days < 10 day threshold
 	})
 
+	t.Run("project timezone adds due dates", func(t *testing.T) {
+		formatter := NewTextFormatter(WithLocation(time.UTC))
+		output := formatter.Format(diff)
+		assert.Contains(t, output, "  Due: overdue by")
+
+		output = NewTextFormatter().Format(diff)
+		assert.NotContains(t, output, "Due:")
+	})
+
 	t.Run("custom date format", func(t *testing.T) {
 		formatter := NewTextFormatter(
 			WithDateFormat("2006-01-02"),
@@ -52,3 +62,106 @@ func TestTextFormatterCustomOptions(t *testing.T) {
 		assert.Contains(t, output, "2024-01-31")
 	})
 }
+
+func TestTextFormatterWeightedSummary(t *testing.T) {
+	diff := createTestDiff()
+	diff.AddedItems[0].Attributes["Estimate"] = float64(5)
+	diff.ChangedItems[0].After.Attributes["Estimate"] = float64(13)
+
+	output := NewTextFormatter(WithWeightField("Estimate")).Format(diff)
+
+	assert.Contains(t, output, "Summary (weighted by Estimate):")
+	assert.Contains(t, output, "  Added: 5\n")
+	assert.Contains(t, output, "  Delayed: 13\n")
+	assert.Contains(t, output, "  Weighted slip (days): 208\n")
+	assert.Contains(t, output, "  Items without Estimate: 1\n")
+
+	output = NewTextFormatter().Format(diff)
+	assert.NotContains(t, output, "Summary")
+}
+
+func TestTextFormatterEmptyProject(t *testing.T) {
+	output := NewTextFormatter().Format(types.ProjectDiff{EmptyProject: true})
+	assert.Contains(t, output, "The project has no items in the compared snapshots.")
+
+	output = NewTextFormatter().Format(types.ProjectDiff{})
+	assert.Equal(t, "No changes found in the project timeline.", output)
+}
+
+func TestTextFormatterRecreatedItem(t *testing.T) {
+	before := &types.ProjectState{Items: []types.Item{{ID: "PVTI_1", ContentType: types.ContentTypeDraftIssue, Attributes: map[string]interface{}{"Title": "Login"}}}}
+	after := &types.ProjectState{Items: []types.Item{{ID: "PVTI_2", ContentType: types.ContentTypeIssue, Attributes: map[string]interface{}{"Title": "Login"}}}}
+
+	output := NewTextFormatter().Format(*before.CompareTo(after))
+	assert.NotContains(t, output, "Added Items:")
+	assert.NotContains(t, output, "Removed Items:")
+	assert.Contains(t, output, "Re-created: replaces removed item PVTI_1")
+	assert.Contains(t, output, "content_type: DraftIssue → Issue")
+}
+
+func TestTextFormatterShowAttributes(t *testing.T) {
+	diff := createTestDiff()
+
+	tests := []struct {
+		listing  AttributeListing
+		contains []string
+		excludes []string
+	}{
+		{
+			listing:  AttributesAddedRemoved,
+			contains: []string{"  priority: High\n  status: Todo\n", "status: Todo → In Progress"},
+			excludes: []string{"Attributes:"},
+		},
+		{
+			listing:  AttributesAll,
+			contains: []string{"  priority: High\n  status: Todo\n", "  Attributes:\n    priority: High\n", "status: Todo → In Progress"},
+		},
+		{
+			listing:  AttributesChanged,
+			contains: []string{"status: Todo → In Progress"},
+			excludes: []string{"  priority: High\n", "Attributes:"},
+		},
+		{
+			listing:  AttributesNone,
+			excludes: []string{"  priority: High\n", "Attributes:", "Changes:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.listing), func(t *testing.T) {
+			output := NewTextFormatter(WithShowAttributes(tt.listing)).Format(diff)
+			assert.Contains(t, output, "Changed Task")
+			for _, s := range tt.contains {
+				assert.Contains(t, output, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, output, s)
+			}
+		})
+	}
+}
+
+func TestTextFormatterDefaultAttributesMatchBaseline(t *testing.T) {
+	added := types.Item{ID: "1", DateSpan: types.MustNewDateSpan("2024-01-01", "2024-01-10"), Attributes: map[string]interface{}{"Title": "New Task", "Status": "Todo"}}
+	removed := types.Item{ID: "2", DateSpan: types.MustNewDateSpan("2024-01-05", "2024-01-12"), Attributes: map[string]interface{}{"Title": "Old Task", "Priority": "Low"}}
+	before := types.Item{ID: "3", DateSpan: types.MustNewDateSpan("2024-01-01", "2024-01-10"), Attributes: map[string]interface{}{"Title": "Changed Task", "Status": "Todo", "Priority": "High"}}
+	after := types.Item{ID: "3", DateSpan: types.MustNewDateSpan("2024-01-01", "2024-01-17"), Attributes: map[string]interface{}{"Title": "Changed Task", "Status": "In Progress", "Priority": "High"}}
+	from := &types.ProjectState{ProjectNumber: 1, Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Items: []types.Item{removed, before}}
+	to := &types.ProjectState{ProjectNumber: 1, Timestamp: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), Items: []types.Item{added, after}}
+
+	// Output of the text formatter before --show-attributes was introduced: attributes
+	// of added and removed items, only the field changes of changed items
+	expected := "Added Items:\n- New Task\n  Status: Added\n  Timeline: Jan 1, 2024 → Jan 10, 2024 (1 week 3 days)\n  Status: Todo\n\n" +
+		"Removed Items:\n- Old Task\n  Status: Removed\n  Timeline: Jan 5, 2024 → Jan 12, 2024 (1 week 1 day)\n  Priority: Low\n\n" +
+		"Changed Items:\n- Changed Task\n  Timeline: 🟠 Moderate delay 1 week\n  Before: Jan 1, 2024 → Jan 10, 2024\n  After:  Jan 1, 2024 → Jan 17, 2024\n  Changes:\n    Status: Todo → In Progress\n\n"
+	assert.Equal(t, expected, NewTextFormatter().Format(*from.CompareTo(to)))
+}
+
+func TestParseAttributeListing(t *testing.T) {
+	listing, err := ParseAttributeListing("changed")
+	assert.NoError(t, err)
+	assert.Equal(t, AttributesChanged, listing)
+
+	_, err = ParseAttributeListing("some")
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// trajectorySectionTitle is the title of the trajectory section
+const trajectorySectionTitle = "🧭 Trajectory"
+
+// buildTrajectoryTable builds the table of items whose dates moved between the
+// intermediate snapshots of the range, or nil if there are none
+func buildTrajectoryTable(options FormatterOptions) *Table {
+	if len(options.Trajectories) == 0 {
+		return nil
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Task", Alignment: AlignLeft},
+			{Header: "Moves", Alignment: AlignRight},
+			{Header: "End Date", Alignment: AlignRight},
+			{Header: "History", Alignment: AlignLeft},
+		},
+		Links: make(map[string]string),
+	}
+	for _, trajectory := range options.Trajectories {
+		title := trajectory.Item.GetTitle()
+		if trajectory.Item.URL != "" {
+			table.Links[title] = trajectory.Item.URL
+		}
+		table.Rows = append(table.Rows, []string{
+			title,
+			strconv.Itoa(len(trajectory.Moves)),
+			formatTrajectoryEnd(trajectory, options),
+			formatMoves(trajectory.Moves, options),
+		})
+		// The items that slipped the most are kept by a limit
+		table.severity = append(table.severity, trajectory.EndDays())
+	}
+	return table
+}
+
+// formatTrajectoryEnd formats the end date before the first and after the last move,
+// e.g. "Jan 31, 2024 → Feb 21, 2024 (+21 days)", or "-" if the end date never moved
+func formatTrajectoryEnd(trajectory types.ItemTrajectory, options FormatterOptions) string {
+	var from, to time.Time
+	for _, move := range trajectory.Moves {
+		if move.Field != types.DateFieldEnd {
+			continue
+		}
+		if from.IsZero() {
+			from = move.From
+		}
+		to = move.To
+	}
+	if from.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%+d days)", formatDateWithChange(to, from, options), trajectory.EndDays())
+}
+
+// formatMoves describes when the dates moved, e.g. "end date slipped 1 week on
+// Jan 14, 2024, again 2 weeks on Jan 28, 2024"
+func formatMoves(moves []types.DateMove, options FormatterOptions) string {
+	parts := make([]string, 0, len(moves))
+	for i, move := range moves {
+		days := move.Days()
+		verb := moveVerb(move.Field, days)
+		if days < 0 {
+			days = -days
+		}
+		amount := options.Duration.Format(days)
+		on := formatTimestamp(move.At, options)
+
+		if i > 0 && moves[i-1].Field == move.Field && moveVerb(move.Field, moves[i-1].Days()) == verb {
+			parts = append(parts, fmt.Sprintf("again %s on %s", amount, on))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s date %s %s on %s", move.Field, verb, amount, on))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// moveVerb describes the direction of a move of the date field
+func moveVerb(field string, days int) string {
+	switch {
+	case field == types.DateFieldEnd && days > 0:
+		return "slipped"
+	case field == types.DateFieldEnd:
+		return "pulled in"
+	case days > 0:
+		return "moved later"
+	default:
+		return "moved earlier"
+	}
+}
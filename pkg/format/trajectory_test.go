@@ -0,0 +1,84 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trajectories() []types.ItemTrajectory {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	return []types.ItemTrajectory{
+		{
+			Item: types.Item{ID: "1", URL: "https://github.com/org/repo/issues/1", Attributes: map[string]interface{}{"Title": "Migrate DB"}},
+			Moves: []types.DateMove{
+				{At: day(14), Field: types.DateFieldEnd, From: day(20), To: day(27)},
+				{At: day(28), Field: types.DateFieldEnd, From: day(27), To: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)},
+				{At: day(29), Field: types.DateFieldStart, From: day(2), To: day(1)},
+			},
+		},
+	}
+}
+
+func TestBuildTrajectoryTable(t *testing.T) {
+	assert.Nil(t, buildTrajectoryTable(DefaultOptions()))
+
+	options := DefaultOptions()
+	WithTrajectories(trajectories())(&options)
+	table := buildTrajectoryTable(options)
+	require.NotNil(t, table)
+	assert.Equal(t, [][]string{
+		{
+			"Migrate DB",
+			"3",
+			"Jan 20, 2024 → Feb 10, 2024 (+21 days)",
+			"end date slipped 1 week on Jan 14, 2024, again 2 weeks on Jan 28, 2024, start date moved earlier 1 day on Jan 29, 2024",
+		},
+	}, table.Rows)
+	assert.Equal(t, "https://github.com/org/repo/issues/1", table.Links["Migrate DB"])
+}
+
+func TestFormatMoves(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name     string
+		moves    []types.DateMove
+		expected string
+	}{
+		{
+			name: "pulled in after slipping",
+			moves: []types.DateMove{
+				{At: day(10), Field: types.DateFieldEnd, From: day(20), To: day(27)},
+				{At: day(17), Field: types.DateFieldEnd, From: day(27), To: day(24)},
+			},
+			expected: "end date slipped 1 week on Jan 10, 2024, end date pulled in 3 days on Jan 17, 2024",
+		},
+		{
+			name: "start moved later twice",
+			moves: []types.DateMove{
+				{At: day(10), Field: types.DateFieldStart, From: day(1), To: day(2)},
+				{At: day(17), Field: types.DateFieldStart, From: day(2), To: day(3)},
+			},
+			expected: "start date moved later 1 day on Jan 10, 2024, again 1 day on Jan 17, 2024",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatMoves(tt.moves, DefaultOptions()))
+		})
+	}
+}
+
+func TestTrajectorySection(t *testing.T) {
+	opts := []func(*FormatterOptions){WithTrajectories(trajectories()), WithSections([]string{SectionTrajectory})}
+
+	markdown := NewTableFormatter(opts...).Format(createTestDiff())
+	assert.Contains(t, markdown, trajectorySectionTitle)
+	assert.Contains(t, markdown, "again 2 weeks on Jan 28, 2024")
+
+	text := NewTextFormatter(opts...).Format(createTestDiff())
+	assert.Equal(t, "Trajectory:\n- Migrate DB: end date slipped 1 week on Jan 14, 2024, again 2 weeks on Jan 28, 2024, start date moved earlier 1 day on Jan 29, 2024\n\n", text)
+}
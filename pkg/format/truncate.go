@@ -0,0 +1,133 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// statusOrder defines the order in which omitted statuses are summarized, most severe first
+var statusOrder = []string{
+	string(DelayLevelExtreme),
+	string(DelayLevelHigh),
+	string(DelayLevelModerate),
+	string(DelayLevelOnTrack),
+	string(DelayLevelAhead),
+	"Added",
+	"Removed",
+}
+
+// truncateRows limits the table to the given number of rows and returns a note
+// describing what was omitted. The most severe rows are kept in their order, by the
+// severity set by the table builder or else by the delay in the status column, so
+// that delayed items aren't dropped in favor of earlier rows. If statusColumn is
+// non-negative, omitted rows are counted per value of that column. A limit of 0
+// disables truncation.
+func truncateRows(t *Table, limit, statusColumn int, lang Language) string {
+	if limit <= 0 || len(t.Rows) <= limit {
+		return ""
+	}
+
+	severity := t.severity
+	if severity == nil {
+		severity = make([]int, len(t.Rows))
+		for i, row := range t.Rows {
+			if statusColumn >= 0 && statusColumn < len(row) {
+				severity[i] = statusSeverity(row[statusColumn])
+			}
+		}
+	}
+	kept, omitted := mostSevere(severity, limit)
+
+	rows := t.Rows
+	t.Rows = make([][]string, 0, len(kept))
+	t.severity = nil
+	for _, i := range kept {
+		t.Rows = append(t.Rows, rows[i])
+	}
+
+	var statuses []string
+	if statusColumn >= 0 {
+		for _, i := range omitted {
+			if statusColumn < len(rows[i]) {
+				statuses = append(statuses, rows[i][statusColumn])
+			}
+		}
+	}
+
+	return formatOmitted(len(omitted), statuses, lang)
+}
+
+// mostSevere returns the indexes of the limit entries with the highest severity and
+// of the others, both in their original order. Entries of the same severity keep their
+// order, so the first entries are kept if none is more severe.
+func mostSevere(severity []int, limit int) (kept, omitted []int) {
+	order := make([]int, len(severity))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return severity[order[i]] > severity[order[j]]
+	})
+	if limit > len(order) {
+		limit = len(order)
+	}
+
+	kept, omitted = order[:limit], order[limit:]
+	sort.Ints(kept)
+	sort.Ints(omitted)
+	return kept, omitted
+}
+
+// statusSeverity returns how severe the delay of a status is, 0 if it isn't delayed
+func statusSeverity(status string) int {
+	onTrack := statusRank(string(DelayLevelOnTrack))
+	if rank := statusRank(status); rank < onTrack {
+		return onTrack - rank
+	}
+	return 0
+}
+
+// formatOmitted formats the truncation note for the given number of omitted items and their
+// statuses in the language
+func formatOmitted(count int, statuses []string, lang Language) string {
+	note := fmt.Sprintf(lang.Translate("…and %d more"), count)
+	if len(statuses) == 0 {
+		return note
+	}
+
+	counts := make(map[string]int)
+	for _, status := range statuses {
+		counts[status]++
+	}
+
+	keys := make([]string, 0, len(counts))
+	for status := range counts {
+		keys = append(keys, status)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := statusRank(keys[i]), statusRank(keys[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, len(keys))
+	for i, status := range keys {
+		parts[i] = fmt.Sprintf("%s: %d", status, counts[status])
+	}
+	return fmt.Sprintf("%s (%s)", note, strings.Join(parts, ", "))
+}
+
+// statusRank returns the position of a status in statusOrder, in any language, unknown
+// statuses sort last
+func statusRank(status string) int {
+	status = canonicalStatus(status)
+	for i, s := range statusOrder {
+		if s == status {
+			return i
+		}
+	}
+	return len(statusOrder)
+}
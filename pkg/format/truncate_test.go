@@ -0,0 +1,89 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateRows(t *testing.T) {
+	newTable := func() *Table {
+		return &Table{
+			Columns: []TableColumn{{Header: "Task"}, {Header: "Status"}},
+			Rows: [][]string{
+				{"Task 1", string(DelayLevelOnTrack)},
+				{"Task 2", string(DelayLevelHigh)},
+				{"Task 3", string(DelayLevelOnTrack)},
+				{"Task 4", "Added"},
+				{"Task 5", string(DelayLevelExtreme)},
+			},
+		}
+	}
+
+	t.Run("no limit", func(t *testing.T) {
+		table := newTable()
+		assert.Empty(t, truncateRows(table, 0, 1, LanguageEnglish))
+		assert.Len(t, table.Rows, 5)
+	})
+
+	t.Run("limit above row count", func(t *testing.T) {
+		table := newTable()
+		assert.Empty(t, truncateRows(table, 5, 1, LanguageEnglish))
+		assert.Len(t, table.Rows, 5)
+	})
+
+	t.Run("counts omitted rows per status", func(t *testing.T) {
+		table := newTable()
+		note := truncateRows(table, 1, 1, LanguageEnglish)
+		assert.Equal(t, [][]string{{"Task 5", string(DelayLevelExtreme)}}, table.Rows)
+		assert.Equal(t, "…and 4 more (🔴 High delay: 1, 🔵 On track: 2, Added: 1)", note)
+	})
+
+	t.Run("keeps delayed rows in order", func(t *testing.T) {
+		table := newTable()
+		assert.Equal(t, "…and 3 more (🔵 On track: 2, Added: 1)", truncateRows(table, 2, 1, LanguageEnglish))
+		assert.Equal(t, [][]string{{"Task 2", string(DelayLevelHigh)}, {"Task 5", string(DelayLevelExtreme)}}, table.Rows)
+	})
+
+	t.Run("translated", func(t *testing.T) {
+		assert.Equal(t, "…et 3 de plus", truncateRows(newTable(), 2, -1, LanguageFrench))
+	})
+
+	t.Run("without status column", func(t *testing.T) {
+		table := newTable()
+		assert.Equal(t, "…and 3 more", truncateRows(table, 2, -1, LanguageEnglish))
+		assert.Equal(t, "Task 2", table.Rows[1][0])
+	})
+
+	t.Run("severity set by the builder", func(t *testing.T) {
+		table := newTable()
+		table.severity = []int{0, 0, 0, 0, 3}
+		assert.Equal(t, "…and 3 more", truncateRows(table, 2, -1, LanguageEnglish))
+		assert.Equal(t, [][]string{{"Task 1", string(DelayLevelOnTrack)}, {"Task 5", string(DelayLevelExtreme)}}, table.Rows)
+	})
+}
+
+func TestFormattersLimit(t *testing.T) {
+	diff := createTestDiff()
+
+	// The delayed item is kept although it comes after the added one
+	output := NewTableFormatter(WithLimit(1)).Format(diff)
+	assert.Contains(t, output, "| Changed Task | 🟠 Moderate delay |")
+	assert.Contains(t, output, "_…and 2 more (Added: 1, Removed: 1)_")
+	assert.NotContains(t, output, "Removed Task")
+
+	output = NewPlainTableFormatter(WithLimit(1)).Format(diff)
+	assert.Contains(t, output, "…and 2 more (Added: 1, Removed: 1)")
+
+	diff.AddedItems = append(diff.AddedItems, diff.AddedItems[0], diff.AddedItems[0])
+	output = NewTextFormatter(WithLimit(1)).Format(diff)
+	assert.Contains(t, output, "…and 2 more\n")
+
+	// Text keeps the most delayed change past the limit
+	diff = types.ProjectDiff{ChangedItems: []types.ItemDiff{delayedChange("1", 3), delayedChange("2", 20)}}
+	output = NewTextFormatter(WithLimit(1)).Format(diff)
+	assert.Contains(t, output, "- Task 2\n")
+	assert.NotContains(t, output, "- Task 1\n")
+	assert.Contains(t, output, "…and 1 more (🔵 On track: 1)")
+}
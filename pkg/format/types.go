@@ -1,15 +1,72 @@
 package format
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/naag/gh-project-report/pkg/types"
 )
 
 // FormatterOptions contains configuration options for formatters
 type FormatterOptions struct {
-	DateFormat             string
+	DateFormat             string     // Go layout of dates, the default is replaced by the medium date of the locale
+	Locale                 Locale     // Language of month and weekday names in dates
+	Language               Language   // Language of durations, delay levels and section titles
+	EmojiStyle             EmojiStyle // Whether delay levels and section titles are marked with emoji
+	Color                  bool       // Color delay levels, change markers and arrows with ANSI colors in terminal output
 	ModerateDelayThreshold int
 	HighDelayThreshold     int
 	ExtremeDelayThreshold  int
+	WeightField            string                   // Numeric attribute used to weight the summary, e.g. "Estimate"
+	Location               *time.Location           // Project timezone used for due dates, nil disables them
+	Limit                  int                      // Maximum number of rows per section, 0 means unlimited
+	PreviousReport         *Report                  // Report of the previous run to compare the delayed list with
+	Sections               []string                 // Sections to include in order, empty includes all
+	StaleDraftAge          time.Duration            // Age after which unconverted draft issues are reported, 0 disables the check
+	Comments               map[string]types.Comment // Latest comment of slipped items by item ID
+	FreezeWindows          []types.FreezeWindow     // Periods of expected inactivity that don't count towards staleness
+	Duration               DurationStyle            // How durations in days are rendered
+	WorkloadWeeks          int                      // Upcoming weeks shown in the workload section, 0 disables it
+	MaxConcurrentItems     int                      // Items an assignee can work on at the same time before being overloaded
+	Diagnostics            []Diagnostic             // Caveats of the report included in machine-readable output
+	CauseLabels            []string                 // Labels naming the cause of a slip, empty disables the causes section
+	Palette                *types.Palette           // Stable order and colors of statuses, assignees and labels, nil keeps the default order
+	ShowAttributes         AttributeListing         // Which attributes the text formatter lists under items
+	SLAViolations          []types.SLAViolation     // Items in a status for longer than its SLA
+	Trajectories           []types.ItemTrajectory   // Items whose dates moved across intermediate snapshots
+	StatusField            string                   // Field containing the status of items
+	DoneStatuses           []string                 // Status values of done items, matched ignoring case
+	Columns                []string                 // Columns of the timeline table, built-in or item attributes, empty uses DefaultColumns
+	OrgTodo                bool                     // List items as headings with TODO keywords in org output
+}
+
+// AttributeListing selects which attributes the text formatter lists under items
+type AttributeListing string
+
+const (
+	// AttributesAddedRemoved lists all attributes of added and removed items, and the
+	// field changes of changed items. It is the default.
+	AttributesAddedRemoved AttributeListing = "added-removed"
+	// AttributesAll lists all attributes of added, removed and changed items, and field changes
+	AttributesAll AttributeListing = "all"
+	// AttributesChanged lists only the field changes of changed items
+	AttributesChanged AttributeListing = "changed"
+	// AttributesNone lists no attributes, only titles and timelines
+	AttributesNone AttributeListing = "none"
+)
+
+// ParseAttributeListing parses the name of an attribute listing
+func ParseAttributeListing(s string) (AttributeListing, error) {
+	switch listing := AttributeListing(s); listing {
+	case AttributesAddedRemoved, AttributesAll, AttributesChanged, AttributesNone:
+		return listing, nil
+	}
+	return "", fmt.Errorf("invalid attribute listing: %s (must be one of: %s, %s, %s, %s)", s, AttributesAddedRemoved, AttributesAll, AttributesChanged, AttributesNone)
+}
+
+// listsAddedRemoved returns true if the attributes of added and removed items are listed
+func (l AttributeListing) listsAddedRemoved() bool {
+	return l == AttributesAddedRemoved || l == AttributesAll
 }
 
 // Formatter interface defines methods that all formatters must implement
@@ -31,11 +88,55 @@ const (
 // DefaultOptions returns the default formatter options
 func DefaultOptions() FormatterOptions {
 	return FormatterOptions{
-		DateFormat:             "Jan 2, 2006",
+		DateFormat:             DefaultDateFormat,
+		Locale:                 LocaleEnglish,
+		Language:               LanguageEnglish,
+		EmojiStyle:             EmojiStyleGlyphs,
 		ModerateDelayThreshold: 7,  // 1 week
 		HighDelayThreshold:     14, // 2 weeks
 		ExtremeDelayThreshold:  30, // 1 month
+		Duration:               DefaultDurationStyle(),
+		MaxConcurrentItems:     3,
+		ShowAttributes:         AttributesAddedRemoved,
+		StatusField:            "Status",
+		DoneStatuses:           []string{"Done"},
+	}
+}
+
+// NewOptions applies the options to the default options and validates the result
+func NewOptions(opts ...func(*FormatterOptions)) (FormatterOptions, error) {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := options.Validate(); err != nil {
+		return FormatterOptions{}, err
 	}
+	return options, nil
+}
+
+// Validate returns an error if the options contradict each other: delay thresholds
+// must be positive and strictly increasing from moderate to high to extreme, as
+// otherwise some delay levels can never be reached
+func (o FormatterOptions) Validate() error {
+	switch {
+	case o.ModerateDelayThreshold < 1:
+		return fmt.Errorf("invalid moderate delay threshold: %d days (must be at least 1)", o.ModerateDelayThreshold)
+	case o.HighDelayThreshold <= o.ModerateDelayThreshold:
+		return fmt.Errorf("invalid high delay threshold: %d days (must be above the moderate threshold of %d days)", o.HighDelayThreshold, o.ModerateDelayThreshold)
+	case o.ExtremeDelayThreshold <= o.HighDelayThreshold:
+		return fmt.Errorf("invalid extreme delay threshold: %d days (must be above the high threshold of %d days)", o.ExtremeDelayThreshold, o.HighDelayThreshold)
+	case o.Limit < 0:
+		return fmt.Errorf("invalid limit: %d (must not be negative)", o.Limit)
+	case o.WorkloadWeeks < 0:
+		return fmt.Errorf("invalid workload weeks: %d (must not be negative)", o.WorkloadWeeks)
+	}
+	for _, column := range o.Columns {
+		if column == "" {
+			return fmt.Errorf("invalid columns: column names must not be empty")
+		}
+	}
+	return nil
 }
 
 // WithDateFormat sets the date format option
@@ -45,6 +146,36 @@ func WithDateFormat(format string) func(*FormatterOptions) {
 	}
 }
 
+// WithLocale sets the language of month and weekday names in dates
+func WithLocale(locale Locale) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Locale = locale
+	}
+}
+
+// WithLanguage sets the language of durations, delay levels and section titles
+func WithLanguage(lang Language) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Language = lang
+		o.Duration.Language = lang
+	}
+}
+
+// WithEmojiStyle sets whether delay levels and section titles are marked with emoji
+func WithEmojiStyle(style EmojiStyle) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.EmojiStyle = style
+	}
+}
+
+// WithColor sets whether the text and plain table formatters color delay levels,
+// added and removed markers and the arrows between before and after values
+func WithColor(enabled bool) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Color = enabled
+	}
+}
+
 // WithModerateDelayThreshold sets the moderate delay threshold option
 func WithModerateDelayThreshold(days int) func(*FormatterOptions) {
 	return func(o *FormatterOptions) {
@@ -66,6 +197,151 @@ func WithExtremeDelayThreshold(days int) func(*FormatterOptions) {
 	}
 }
 
+// WithWeightField enables the summary section, weighted by the given numeric attribute
+func WithWeightField(field string) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.WeightField = field
+	}
+}
+
+// WithLocation sets the project timezone and enables relative due dates
+func WithLocation(loc *time.Location) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Location = loc
+	}
+}
+
+// WithColumns sets the columns of the timeline table: built-in columns such as task and
+// end, or names of item attributes
+func WithColumns(columns []string) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Columns = columns
+	}
+}
+
+// WithLimit sets the maximum number of rows per section
+func WithLimit(limit int) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Limit = limit
+	}
+}
+
+// WithPreviousReport enables highlighting changes to the delayed list since the given report
+func WithPreviousReport(report *Report) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.PreviousReport = report
+	}
+}
+
+// WithSections selects the report sections to include and their order
+func WithSections(ids []string) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Sections = ids
+	}
+}
+
+// WithStaleDraftAge reports draft issues older than the given age in the data quality section
+func WithStaleDraftAge(age time.Duration) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.StaleDraftAge = age
+	}
+}
+
+// WithComments adds the latest comments of slipped items, keyed by item ID, as context to the report
+func WithComments(comments map[string]types.Comment) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Comments = comments
+	}
+}
+
+// WithFreezeWindows excludes days in freeze windows from the age of stale items
+func WithFreezeWindows(windows []types.FreezeWindow) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.FreezeWindows = windows
+	}
+}
+
+// WithDurationStyle sets how durations in days are rendered, keeping the language unless
+// the style sets one
+func WithDurationStyle(style DurationStyle) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		if style.Language == "" {
+			style.Language = o.Duration.Language
+		}
+		o.Duration = style
+	}
+}
+
+// WithWorkload enables the workload section for the given number of upcoming weeks,
+// flagging assignees with more than maxConcurrent items scheduled at the same time
+func WithWorkload(weeks, maxConcurrent int) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.WorkloadWeeks = weeks
+		o.MaxConcurrentItems = maxConcurrent
+	}
+}
+
+// WithCauseLabels enables the breakdown of slip days by the given cause labels
+func WithCauseLabels(labels []string) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.CauseLabels = labels
+	}
+}
+
+// WithPalette keeps the order and colors of statuses, assignees and labels stable
+// between reports using a palette persisted next to the snapshots
+func WithPalette(palette *types.Palette) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Palette = palette
+	}
+}
+
+// WithShowAttributes sets which attributes the text formatter lists under items
+func WithShowAttributes(listing AttributeListing) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.ShowAttributes = listing
+	}
+}
+
+// WithSLAViolations adds the section of items in a status for longer than its SLA
+func WithSLAViolations(violations []types.SLAViolation) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.SLAViolations = violations
+	}
+}
+
+// WithTrajectories adds the section of items whose dates moved across the intermediate
+// snapshots of the range
+func WithTrajectories(trajectories []types.ItemTrajectory) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Trajectories = trajectories
+	}
+}
+
+// WithDoneStatuses sets the status field and the values of done items, used to
+// find items completed ahead of schedule
+func WithDoneStatuses(field string, values []string) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.StatusField = field
+		o.DoneStatuses = values
+	}
+}
+
+// WithOrgTodo lists the added and changed items as headings with a TODO or DONE
+// keyword, derived from their status, in org output
+func WithOrgTodo(enabled bool) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.OrgTodo = enabled
+	}
+}
+
+// WithDiagnostics adds caveats of the report, e.g. snapshot warnings, to machine-readable output
+func WithDiagnostics(diagnostics []Diagnostic) func(*FormatterOptions) {
+	return func(o *FormatterOptions) {
+		o.Diagnostics = diagnostics
+	}
+}
+
 // Alignment represents text alignment in table columns
 type Alignment string
 
@@ -86,8 +362,12 @@ type TableColumn struct {
 
 // Table represents a generic table structure that can be rendered in different formats
 type Table struct {
-	Columns []TableColumn // Column definitions including headers and formatting
-	Rows    [][]string    // Table rows (data only)
+	Columns []TableColumn     // Column definitions including headers and formatting
+	Rows    [][]string        // Table rows (data only)
+	Links   map[string]string // Optional URLs of first-column values, e.g. item titles
+	Colors  map[string]string // Optional colors of cell values, e.g. statuses, used by visual renderers
+
+	severity []int // Optional severity of each row, the most severe rows are kept by a limit
 }
 
 // Document represents a structured document with sections
@@ -98,7 +378,9 @@ type Document struct {
 
 // Section represents a section in a document
 type Section struct {
+	ID    string // Identifies the section for selection and ordering, see SectionIDs
 	Title string
 	Table *Table // Optional table content
 	Text  string // Optional text content
+	Note  string // Optional note rendered after the content
 }
@@ -40,6 +40,12 @@ func TestOptionFunctions(t *testing.T) {
 		assert.Equal(t, 45, opts.ExtremeDelayThreshold)
 	})
 
+	t.Run("WithWeightField", func(t *testing.T) {
+		opts := DefaultOptions()
+		WithWeightField("Estimate")(&opts)
+		assert.Equal(t, "Estimate", opts.WeightField)
+	})
+
 	t.Run("chaining options", func(t *testing.T) {
 		opts := DefaultOptions()
 		WithModerateDelayThreshold(10)(&opts)
@@ -54,6 +60,33 @@ func TestOptionFunctions(t *testing.T) {
 	})
 }
 
+func TestNewOptions(t *testing.T) {
+	opts, err := NewOptions(WithModerateDelayThreshold(3), WithHighDelayThreshold(5))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, opts.ModerateDelayThreshold)
+	assert.Equal(t, 5, opts.HighDelayThreshold)
+	assert.Equal(t, 30, opts.ExtremeDelayThreshold)
+
+	tests := []struct {
+		name     string
+		opts     []func(*FormatterOptions)
+		expected string
+	}{
+		{name: "zero moderate", opts: []func(*FormatterOptions){WithModerateDelayThreshold(0)}, expected: "invalid moderate delay threshold: 0 days (must be at least 1)"},
+		{name: "moderate above high", opts: []func(*FormatterOptions){WithModerateDelayThreshold(20)}, expected: "invalid high delay threshold: 14 days (must be above the moderate threshold of 20 days)"},
+		{name: "equal high and extreme", opts: []func(*FormatterOptions){WithExtremeDelayThreshold(14)}, expected: "invalid extreme delay threshold: 14 days (must be above the high threshold of 14 days)"},
+		{name: "negative limit", opts: []func(*FormatterOptions){WithLimit(-1)}, expected: "invalid limit: -1 (must not be negative)"},
+		{name: "empty column", opts: []func(*FormatterOptions){WithColumns([]string{"task", ""})}, expected: "invalid columns: column names must not be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewOptions(tt.opts...)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+	assert.NoError(t, DefaultOptions().Validate())
+}
+
 func TestDelayLevelConstants(t *testing.T) {
 	assert.Equal(t, DelayLevel("🔵 On track"), DelayLevelOnTrack)
 	assert.Equal(t, DelayLevel("🚀 Ahead of schedule"), DelayLevelAhead)
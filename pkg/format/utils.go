@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
 )
 
 // calculateDelayLevel determines the delay level based on duration delta and thresholds
@@ -55,58 +57,121 @@ func calculateTimelineDelayLevel(startDaysDelta, durationDelta, moderateDelay, h
 	return DelayLevelOnTrack
 }
 
-// formatHumanDuration formats a duration in days into a human-readable string
-func formatHumanDuration(days int) string {
-	if days == 0 {
-		return "no change"
+// pluralize returns "s" if n != 1, empty string otherwise
+func pluralize(n int) string {
+	if n == 1 {
+		return ""
 	}
+	return "s"
+}
 
-	years := days / 365
-	remainingDays := days % 365
-	months := remainingDays / 30
-	remainingDays = remainingDays % 30
-	weeks := remainingDays / 7
-	remainingDays = remainingDays % 7
+// formatDate formats a time.Time using the date format and locale of the options
+func formatDate(t time.Time, options FormatterOptions) string {
+	return options.Locale.FormatDate(t, options.DateFormat)
+}
 
-	if years > 0 {
-		if months == 0 {
-			return fmt.Sprintf("%d year%s", years, pluralize(years))
-		}
-		return fmt.Sprintf("%d year%s %d month%s", years, pluralize(years), months, pluralize(months))
+// formatTimestamp formats the day of a point in time, such as the time of a snapshot,
+// in the project timezone. Dates of date fields are formatted with formatDate, as they
+// are calendar days already.
+func formatTimestamp(t time.Time, options FormatterOptions) string {
+	if options.Location != nil {
+		t = t.In(options.Location)
 	}
+	return formatDate(t, options)
+}
 
-	if months > 0 {
-		if weeks == 0 {
-			return fmt.Sprintf("%d month%s", months, pluralize(months))
+// formatFieldChange formats the values of an attribute change, e.g. "Todo → Done".
+// Changes of labels and lists of users such as the assignees show the entries added
+// and removed, e.g. "+@bob, −@alice".
+func formatFieldChange(change types.FieldChange) string {
+	if added, removed, ok := types.ListChanges(change.OldValue, change.NewValue); ok {
+		parts := make([]string, 0, len(added)+len(removed))
+		for _, entry := range added {
+			parts = append(parts, "+"+entry)
+		}
+		for _, entry := range removed {
+			parts = append(parts, "−"+entry)
 		}
-		return fmt.Sprintf("%d month%s %d week%s", months, pluralize(months), weeks, pluralize(weeks))
+		return strings.Join(parts, ", ")
 	}
+	return fmt.Sprintf("%s → %s", types.FormatValue(change.OldValue), types.FormatValue(change.NewValue))
+}
 
-	if weeks > 0 {
-		if remainingDays == 0 {
-			return fmt.Sprintf("%d week%s", weeks, pluralize(weeks))
+// itemLinks maps the titles of all items in the diff to their URLs
+func itemLinks(diff types.ProjectDiff) map[string]string {
+	links := make(map[string]string)
+	add := func(item types.Item) {
+		if item.URL != "" {
+			links[item.GetTitle()] = item.URL
 		}
-		return fmt.Sprintf("%d week%s %d day%s", weeks, pluralize(weeks), remainingDays, pluralize(remainingDays))
 	}
+	for _, item := range diff.AddedItems {
+		add(item)
+	}
+	for _, item := range diff.RemovedItems {
+		add(item)
+	}
+	for _, change := range diff.ChangedItems {
+		add(change.After)
+	}
+	return links
+}
 
-	return fmt.Sprintf("%d day%s", days, pluralize(days))
+// hasChanges returns true if the diff contains any added, removed or changed items or workflows
+func hasChanges(diff types.ProjectDiff) bool {
+	return len(diff.AddedItems) > 0 || len(diff.RemovedItems) > 0 || len(diff.ChangedItems) > 0 ||
+		len(diff.WorkflowChanges) > 0
 }
 
-// pluralize returns "s" if n != 1, empty string otherwise
-func pluralize(n int) string {
-	if n == 1 {
+// noChangesMessage returns the message shown instead of a report when the diff has no changes
+func noChangesMessage(diff types.ProjectDiff) string {
+	if diff.EmptyProject {
+		return "The project has no items in the compared snapshots. " +
+			"Check that the project number and organization are correct and that items have been added to the project."
+	}
+	return "No changes found in the project timeline."
+}
+
+// formatDue formats the end date of a span relative to now in the given location
+func formatDue(span types.DateSpan, now time.Time, loc *time.Location, style DurationStyle) string {
+	if span.End.IsZero() {
 		return ""
 	}
-	return "s"
+	days := span.DaysUntilEnd(now, loc)
+	switch {
+	case days < 0:
+		return fmt.Sprintf("overdue by %s", style.Format(-days))
+	case days == 0:
+		return "due today"
+	default:
+		return fmt.Sprintf("due in %s", style.Format(days))
+	}
 }
 
-// formatDate formats a time.Time using the specified format string
-func formatDate(t time.Time, format string) string {
-	return t.Format(format)
+// formatItemDue formats the end date of an item relative to now like formatDue.
+// Closed issues and closed or merged pull requests are not due anymore, and open
+// ones past their end date are marked as still open.
+func formatItemDue(item types.Item, now time.Time, loc *time.Location, style DurationStyle) string {
+	due := formatDue(item.DateSpan, now, loc, style)
+	switch {
+	case due == "":
+		return ""
+	case item.IsClosed():
+		return strings.ToLower(item.GetState())
+	case item.GetState() == types.StateOpen && item.DateSpan.DaysUntilEnd(now, loc) < 0:
+		return due + ", still open"
+	}
+	return due
 }
 
-// ParseHumanRange parses a human-readable time range
+// ParseHumanRange parses a human-readable time range, with dates in UTC
 func ParseHumanRange(timeRange string) (time.Time, time.Time, error) {
+	return ParseHumanRangeIn(timeRange, time.UTC)
+}
+
+// ParseHumanRangeIn parses a human-readable time range, with dates starting at midnight
+// in the given location
+func ParseHumanRangeIn(timeRange string, loc *time.Location) (time.Time, time.Time, error) {
 	// Handle relative time ranges
 	if strings.HasPrefix(timeRange, "last ") {
 		duration, err := parseRelativeDuration(strings.TrimPrefix(timeRange, "last "))
@@ -126,12 +191,12 @@ func ParseHumanRange(timeRange string) (time.Time, time.Time, error) {
 	fromStr := strings.TrimSpace(parts[0])
 	toStr := strings.TrimSpace(parts[1])
 
-	from, err := time.Parse("2006-01-02", fromStr)
+	from, err := time.ParseInLocation("2006-01-02", fromStr, loc)
 	if err != nil {
 		return time.Time{}, time.Time{}, fmt.Errorf("invalid from date: %w", err)
 	}
 
-	to, err := time.Parse("2006-01-02", toStr)
+	to, err := time.ParseInLocation("2006-01-02", toStr, loc)
 	if err != nil {
 		return time.Time{}, time.Time{}, fmt.Errorf("invalid to date: %w", err)
 	}
@@ -143,6 +208,55 @@ func ParseHumanRange(timeRange string) (time.Time, time.Time, error) {
 	return from, to, nil
 }
 
+// Cadences that range boundaries can be snapped to
+const (
+	CadenceDay       = "day"
+	CadenceWeek      = "week"
+	CadenceMonth     = "month"
+	CadenceIteration = "iteration" // Snapped by SnapToIteration
+)
+
+// SnapToCadence moves a timestamp back to the start of its cadence period in
+// the given location: midnight for days, Monday 00:00 for weeks and the first
+// of the month for months
+func SnapToCadence(t time.Time, cadence string, loc *time.Location) (time.Time, error) {
+	t = t.In(loc)
+	y, m, d := t.Date()
+	switch cadence {
+	case CadenceDay:
+		return time.Date(y, m, d, 0, 0, 0, 0, loc), nil
+	case CadenceWeek:
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-daysSinceMonday, 0, 0, 0, 0, loc), nil
+	case CadenceMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid cadence: %s (must be one of: %s, %s, %s)", cadence, CadenceDay, CadenceWeek, CadenceMonth)
+	}
+}
+
+// SnapToIteration moves a timestamp back to the start of its iteration in the given
+// location, i.e. midnight of the latest of the start dates of iterations on or before
+// its date
+func SnapToIteration(t time.Time, starts []time.Time, loc *time.Location) (time.Time, error) {
+	t = t.In(loc)
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+	var snapped time.Time
+	for _, start := range starts {
+		sy, sm, sd := start.Date()
+		start = time.Date(sy, sm, sd, 0, 0, 0, 0, loc)
+		if !start.After(day) && start.After(snapped) {
+			snapped = start
+		}
+	}
+	if snapped.IsZero() {
+		return time.Time{}, fmt.Errorf("no iteration starts on or before %s", day.Format("2006-01-02"))
+	}
+	return snapped, nil
+}
+
 // parseRelativeDuration parses strings like "12 hours", "2 days", "1 week"
 func parseRelativeDuration(s string) (time.Duration, error) {
 	parts := strings.Fields(s)
@@ -4,100 +4,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/naag/gh-project-report/pkg/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestFormatHumanDuration(t *testing.T) {
-	tests := []struct {
-		name     string
-		days     int
-		expected string
-	}{
-		{
-			name:     "zero_days",
-			days:     0,
-			expected: "no change",
-		},
-		{
-			name:     "single_day",
-			days:     1,
-			expected: "1 day",
-		},
-		{
-			name:     "multiple_days",
-			days:     5,
-			expected: "5 days",
-		},
-		{
-			name:     "one_week",
-			days:     7,
-			expected: "1 week",
-		},
-		{
-			name:     "one_week_and_days",
-			days:     9,
-			expected: "1 week 2 days",
-		},
-		{
-			name:     "multiple_weeks",
-			days:     14,
-			expected: "2 weeks",
-		},
-		{
-			name:     "multiple_weeks_and_days",
-			days:     17,
-			expected: "2 weeks 3 days",
-		},
-		{
-			name:     "one_month",
-			days:     30,
-			expected: "1 month",
-		},
-		{
-			name:     "one_month_and_weeks",
-			days:     37,
-			expected: "1 month 1 week",
-		},
-		{
-			name:     "multiple_months",
-			days:     90,
-			expected: "3 months",
-		},
-		{
-			name:     "multiple_months_and_weeks",
-			days:     95,
-			expected: "3 months",
-		},
-		{
-			name:     "one_year",
-			days:     365,
-			expected: "1 year",
-		},
-		{
-			name:     "one_year_and_months",
-			days:     395,
-			expected: "1 year 1 month",
-		},
-		{
-			name:     "multiple_years",
-			days:     730,
-			expected: "2 years",
-		},
-		{
-			name:     "multiple_years_and_months",
-			days:     760,
-			expected: "2 years 1 month",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := formatHumanDuration(tt.days)
-			assert.Equal(t, tt.expected, got)
-		})
-	}
-}
-
 func TestParseHumanRange(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -199,6 +110,28 @@ func TestParseHumanRange(t *testing.T) {
 	}
 }
 
+func TestParseHumanRangeIn(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	from, to, err := ParseHumanRangeIn("2024-01-01 → 2024-01-31", berlin)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC), from.UTC())
+	assert.Equal(t, time.Date(2024, 1, 30, 23, 0, 0, 0, time.UTC), to.UTC())
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	// Late evening in UTC is already the next day in Tokyo
+	ts := time.Date(2024, 3, 8, 20, 0, 0, 0, time.UTC)
+	options := DefaultOptions()
+	assert.Equal(t, "Mar 8, 2024", formatTimestamp(ts, options))
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	WithLocation(tokyo)(&options)
+	assert.Equal(t, "Mar 9, 2024", formatTimestamp(ts, options))
+}
+
 func TestParseRelativeDuration(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -453,3 +386,140 @@ func TestCalculateTimelineDelayLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatDue(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	span := types.MustNewDateSpan("2024-01-01", "2024-01-10")
+	now := time.Date(2024, 1, 10, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		span     types.DateSpan
+		now      time.Time
+		loc      *time.Location
+		expected string
+	}{
+		{name: "due today in UTC", span: span, now: now, loc: time.UTC, expected: "due today"},
+		{name: "overdue in Tokyo", span: span, now: now, loc: tokyo, expected: "overdue by 1 day"},
+		{name: "due in future", span: span, now: now.AddDate(0, 0, -9), loc: time.UTC, expected: "due in 1 week 2 days"},
+		{name: "no end date", span: types.DateSpan{}, now: now, loc: time.UTC, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatDue(tt.span, tt.now, tt.loc, DefaultDurationStyle()))
+		})
+	}
+}
+
+func TestFormatItemDue(t *testing.T) {
+	item := func(state string) types.Item {
+		item := types.Item{DateSpan: types.MustNewDateSpan("2024-01-01", "2024-01-10"), Attributes: map[string]interface{}{}}
+		if state != "" {
+			item.Attributes[types.StateAttribute] = state
+		}
+		return item
+	}
+	overdue := time.Date(2024, 1, 12, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		item     types.Item
+		now      time.Time
+		expected string
+	}{
+		{name: "overdue and still open", item: item(types.StateOpen), now: overdue, expected: "overdue by 2 days, still open"},
+		{name: "overdue but closed", item: item(types.StateClosed), now: overdue, expected: "closed"},
+		{name: "overdue but merged", item: item(types.StateMerged), now: overdue, expected: "merged"},
+		{name: "overdue with unknown state", item: item(""), now: overdue, expected: "overdue by 2 days"},
+		{name: "open and due in future", item: item(types.StateOpen), now: overdue.AddDate(0, 0, -4), expected: "due in 2 days"},
+		{name: "no end date", item: types.Item{Attributes: map[string]interface{}{types.StateAttribute: types.StateClosed}}, now: overdue, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatItemDue(tt.item, tt.now, time.UTC, DefaultDurationStyle()))
+		})
+	}
+}
+
+func TestSnapToCadence(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// Wednesday, 23:30 UTC is already Thursday in Berlin
+	ts := time.Date(2024, 1, 10, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		cadence string
+		loc     *time.Location
+		want    time.Time
+	}{
+		{CadenceDay, time.UTC, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{CadenceDay, berlin, time.Date(2024, 1, 11, 0, 0, 0, 0, berlin)},
+		{CadenceWeek, time.UTC, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{CadenceWeek, berlin, time.Date(2024, 1, 8, 0, 0, 0, 0, berlin)},
+		{CadenceMonth, time.UTC, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cadence+" "+tt.loc.String(), func(t *testing.T) {
+			got, err := SnapToCadence(ts, tt.cadence, tt.loc)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+
+	t.Run("monday stays on monday", func(t *testing.T) {
+		monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+		got, err := SnapToCadence(monday.Add(time.Hour), CadenceWeek, time.UTC)
+		require.NoError(t, err)
+		assert.Equal(t, monday, got)
+
+		sunday := time.Date(2024, 1, 14, 22, 0, 0, 0, time.UTC)
+		got, err = SnapToCadence(sunday, CadenceWeek, time.UTC)
+		require.NoError(t, err)
+		assert.Equal(t, monday, got)
+	})
+
+	_, err = SnapToCadence(ts, "fortnight", time.UTC)
+	assert.ErrorContains(t, err, "invalid cadence: fortnight")
+}
+
+func TestSnapToIteration(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	starts := []time.Time{
+		time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Sunday, 23:30 UTC is already Monday, the start of the second iteration, in Berlin
+	ts := time.Date(2024, 1, 21, 23, 30, 0, 0, time.UTC)
+	got, err := SnapToIteration(ts, starts, time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), got)
+	got, err = SnapToIteration(ts, starts, berlin)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 22, 0, 0, 0, 0, berlin), got)
+
+	// After the last known iteration
+	got, err = SnapToIteration(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), starts, time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC), got)
+
+	_, err = SnapToIteration(time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC), starts, time.UTC)
+	assert.EqualError(t, err, "no iteration starts on or before 2024-01-07")
+}
+
+func TestFormatFieldChange(t *testing.T) {
+	assert.Equal(t, "Todo → Done", formatFieldChange(types.FieldChange{Field: "Status", OldValue: "Todo", NewValue: "Done"}))
+	assert.Equal(t, "+@carol, −@alice", formatFieldChange(types.FieldChange{Field: types.AssigneesAttribute, OldValue: "@alice, @bob", NewValue: "@bob, @carol"}))
+	assert.Equal(t, "+@alice", formatFieldChange(types.FieldChange{Field: "Owner", OldValue: nil, NewValue: "@alice"}))
+}
+
+func TestFormatFieldChangeLabels(t *testing.T) {
+	assert.Equal(t, "+scope-change, −bug", formatFieldChange(types.FieldChange{Field: types.LabelsField, OldValue: []string{"bug", "p1"}, NewValue: []string{"p1", "scope-change"}}))
+}
@@ -0,0 +1,89 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// BuildVelocityDocument builds the velocity report, one row per period with a bar
+// of the completed work and the average per period. An empty weightField counts items.
+func BuildVelocityDocument(periods []types.VelocityPeriod, weightField string, options FormatterOptions) Document {
+	unit := burndownUnit(weightField)
+
+	peak := 0.0
+	total := 0.0
+	missing := 0
+	for _, p := range periods {
+		peak = max(peak, p.Completed)
+		total += p.Completed
+		missing += p.MissingWeight
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Period", Alignment: AlignLeft},
+			{Header: "Items", Alignment: AlignRight},
+			{Header: "Completed " + unit, Alignment: AlignRight},
+			{Header: "Velocity", Alignment: AlignLeft},
+		},
+	}
+	for _, p := range periods {
+		table.Rows = append(table.Rows, []string{
+			formatVelocityPeriod(p, options),
+			strconv.Itoa(p.Items),
+			formatWeight(p.Completed),
+			burndownBar(p.Completed, peak),
+		})
+	}
+
+	section := Section{Title: "🚀 Completed " + unit + " per period", Table: table}
+	var notes []string
+	if len(periods) > 0 {
+		average := total / float64(len(periods))
+		notes = append(notes, fmt.Sprintf("Average: %s %s per period.", formatWeight(math.Round(average*10)/10), unit))
+	}
+	if missing > 0 {
+		notes = append(notes, fmt.Sprintf("%d completed item%s had no %s and counted as 0.", missing, pluralize(missing), weightField))
+	}
+	section.Note = strings.Join(notes, " ")
+	return Document{Title: "Velocity", Sections: []Section{section}}
+}
+
+// FormatVelocityMermaid formats the completed work per period as a fenced Mermaid
+// bar chart, which GitHub renders natively in Markdown
+func FormatVelocityMermaid(periods []types.VelocityPeriod, weightField string, options FormatterOptions) string {
+	labels := make([]string, len(periods))
+	values := make([]string, len(periods))
+	peak := 0.0
+	for i, p := range periods {
+		labels[i] = strconv.Quote(formatDate(p.Start, options))
+		values[i] = formatWeight(p.Completed)
+		peak = max(peak, p.Completed)
+	}
+
+	// The y-axis needs a non-empty range
+	peak = max(peak, 1)
+
+	var sb strings.Builder
+	sb.WriteString("```mermaid\n")
+	sb.WriteString("xychart-beta\n")
+	sb.WriteString("    title \"Velocity\"\n")
+	sb.WriteString("    x-axis [" + strings.Join(labels, ", ") + "]\n")
+	sb.WriteString(fmt.Sprintf("    y-axis \"Completed %s\" 0 --> %s\n", burndownUnit(weightField), formatWeight(peak)))
+	sb.WriteString("    bar [" + strings.Join(values, ", ") + "]\n")
+	sb.WriteString("```\n")
+	return sb.String()
+}
+
+// formatVelocityPeriod formats a period as its first and last day
+func formatVelocityPeriod(p types.VelocityPeriod, options FormatterOptions) string {
+	last := p.End.AddDate(0, 0, -1)
+	if !last.After(p.Start) {
+		return formatDate(p.Start, options)
+	}
+	return formatDate(p.Start, options) + " → " + formatDate(last, options)
+}
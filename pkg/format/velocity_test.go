@@ -0,0 +1,44 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func velocityPeriods() []types.VelocityPeriod {
+	week := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []types.VelocityPeriod{
+		{Start: week, End: week.AddDate(0, 0, 7), Items: 3, Completed: 8, MissingWeight: 1},
+		{Start: week.AddDate(0, 0, 7), End: week.AddDate(0, 0, 14)},
+		{Start: week.AddDate(0, 0, 14), End: week.AddDate(0, 0, 21), Items: 1, Completed: 2},
+	}
+}
+
+func TestBuildVelocityDocument(t *testing.T) {
+	doc := BuildVelocityDocument(velocityPeriods(), "Points", DefaultOptions())
+
+	require.Len(t, doc.Sections, 1)
+	section := doc.Sections[0]
+	assert.Equal(t, "Completed Points", section.Table.Columns[2].Header)
+	require.Len(t, section.Table.Rows, 3)
+	assert.Equal(t, []string{"Jan 1, 2024 → Jan 7, 2024", "3", "8"}, section.Table.Rows[0][:3])
+	assert.Len(t, []rune(section.Table.Rows[0][3]), burndownBarWidth)
+	assert.Equal(t, "", section.Table.Rows[1][3])
+	assert.Equal(t, "Average: 3.3 Points per period. 1 completed item had no Points and counted as 0.", section.Note)
+}
+
+func TestFormatVelocityMermaid(t *testing.T) {
+	got := FormatVelocityMermaid(velocityPeriods(), "", DefaultOptions())
+
+	assert.Equal(t, "```mermaid\n"+
+		"xychart-beta\n"+
+		"    title \"Velocity\"\n"+
+		"    x-axis [\"Jan 1, 2024\", \"Jan 8, 2024\", \"Jan 15, 2024\"]\n"+
+		"    y-axis \"Completed items\" 0 --> 8\n"+
+		"    bar [8, 0, 2]\n"+
+		"```\n", got)
+}
@@ -0,0 +1,41 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// workflowsSectionTitle is the title of the workflow changes section
+const workflowsSectionTitle = "⚙️ Workflow Changes"
+
+// autoArchiveNote explains the effect of an enabled auto-archive workflow on reports
+const autoArchiveNote = "Archived items disappear from later snapshots and are reported as removed"
+
+// buildWorkflowsTable builds the table of changed project workflows, or nil if there are none
+func buildWorkflowsTable(diff types.ProjectDiff) *Table {
+	if len(diff.WorkflowChanges) == 0 {
+		return nil
+	}
+
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "Workflow", Alignment: AlignLeft},
+			{Header: "Change", Alignment: AlignLeft},
+			{Header: "Impact", Alignment: AlignLeft},
+		},
+	}
+	for _, change := range diff.WorkflowChanges {
+		kind := string(change.Kind)
+		if change.Kind == types.WorkflowAdded && change.Enabled {
+			kind = "added (enabled)"
+		}
+
+		impact := "-"
+		if change.Enabled && strings.Contains(strings.ToLower(change.Name), "archive") {
+			impact = autoArchiveNote
+		}
+		table.Rows = append(table.Rows, []string{change.Name, kind, impact})
+	}
+	return table
+}
@@ -0,0 +1,40 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildWorkflowsTable(t *testing.T) {
+	assert.Nil(t, buildWorkflowsTable(types.ProjectDiff{}))
+
+	diff := types.ProjectDiff{WorkflowChanges: []types.WorkflowChange{
+		{Name: "Auto-add to project", Kind: types.WorkflowAdded, Enabled: true},
+		{Name: "Auto-archive items", Kind: types.WorkflowEnabled, Enabled: true},
+		{Name: "Item closed", Kind: types.WorkflowDisabled},
+	}}
+
+	table := buildWorkflowsTable(diff)
+	require.NotNil(t, table)
+	assert.Equal(t, [][]string{
+		{"Auto-add to project", "added (enabled)", "-"},
+		{"Auto-archive items", "enabled", autoArchiveNote},
+		{"Item closed", "disabled", "-"},
+	}, table.Rows)
+}
+
+func TestWorkflowChangesOnlyDiff(t *testing.T) {
+	diff := types.ProjectDiff{WorkflowChanges: []types.WorkflowChange{
+		{Name: "Auto-archive items", Kind: types.WorkflowEnabled, Enabled: true},
+	}}
+
+	output := NewTextFormatter().Format(diff)
+	assert.Contains(t, output, "Workflow Changes:\n- Auto-archive items: enabled ("+autoArchiveNote+")\n")
+
+	output = NewTableFormatter().Format(diff)
+	assert.Contains(t, output, workflowsSectionTitle)
+	assert.NotContains(t, output, "No changes found")
+}
@@ -0,0 +1,92 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// workloadSectionTitle is the title of the assignee workload section
+const workloadSectionTitle = "👥 Workload"
+
+// workloadWeekFormat is the date format of the week columns of the workload table
+const workloadWeekFormat = "Jan 2"
+
+// workloadOK is the load of assignees who aren't overloaded
+const workloadOK = "OK"
+
+// buildWorkloadTable builds the heatmap of items scheduled per assignee in the upcoming
+// weeks, or nil if the section is disabled or nobody has scheduled work
+func buildWorkloadTable(diff types.ProjectDiff, options FormatterOptions, now time.Time) *Table {
+	if options.WorkloadWeeks <= 0 {
+		return nil
+	}
+	if options.Location != nil {
+		now = now.In(options.Location)
+	}
+
+	workloads := diff.Workloads(now, options.WorkloadWeeks)
+	if len(workloads) == 0 {
+		return nil
+	}
+	if options.Palette != nil {
+		// Keep assignees in the same rows week over week
+		sort.SliceStable(workloads, func(i, j int) bool {
+			return options.Palette.Less(types.PaletteAssignee, workloads[i].Assignee, workloads[j].Assignee)
+		})
+	}
+
+	table := &Table{
+		Columns: []TableColumn{{Header: "Assignee", Alignment: AlignLeft}},
+		Colors:  options.Palette.Colors(types.PaletteAssignee),
+	}
+	for week := 0; week < options.WorkloadWeeks; week++ {
+		header := "Week of " + options.Locale.FormatDate(now.AddDate(0, 0, 7*week), workloadWeekFormat)
+		table.Columns = append(table.Columns, TableColumn{Header: header, Alignment: AlignCenter})
+	}
+	table.Columns = append(table.Columns,
+		TableColumn{Header: "Scheduled Days", Alignment: AlignRight},
+		TableColumn{Header: "Load", Alignment: AlignLeft},
+	)
+
+	for _, w := range workloads {
+		row := []string{w.Assignee}
+		for _, n := range w.Weekly {
+			row = append(row, workloadCell(n, options.MaxConcurrentItems, options.EmojiStyle))
+		}
+		load, severity := workloadOK, 0
+		if isOverloaded(w, options) {
+			load = options.title(fmt.Sprintf("⚠️ Overloaded (%d at once)", w.Peak))
+			severity = w.Peak
+		}
+		row = append(row, strconv.Itoa(w.Days), load)
+		table.Rows = append(table.Rows, row)
+		table.severity = append(table.severity, severity)
+	}
+	return table
+}
+
+// workloadCell formats the number of concurrently scheduled items of a week as a heat level
+func workloadCell(n, maxConcurrent int, style EmojiStyle) string {
+	heat := "🟢"
+	switch {
+	case n == 0:
+		return "-"
+	case maxConcurrent > 0 && n > maxConcurrent:
+		heat = "🔴"
+	case n == maxConcurrent:
+		heat = "🟠"
+	}
+	if style == EmojiStyleASCII {
+		heat = workloadHeatTags[heat]
+	}
+	return fmt.Sprintf("%s %d", heat, n)
+}
+
+// isOverloaded returns true if the assignee has more items scheduled at once than allowed
+func isOverloaded(w types.Workload, options FormatterOptions) bool {
+	return options.MaxConcurrentItems > 0 && w.Peak > options.MaxConcurrentItems
+}
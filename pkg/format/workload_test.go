@@ -0,0 +1,100 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// workloadTestItems returns items scheduled in the two weeks starting on the given day
+func workloadTestItems(day time.Time) []types.Item {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	item := func(title, assignees string, start, end int) types.Item {
+		return types.Item{
+			ID:         title,
+			DateSpan:   types.DateSpan{Start: day.AddDate(0, 0, start), End: day.AddDate(0, 0, end)},
+			Attributes: map[string]interface{}{"Title": title, types.AssigneesAttribute: assignees},
+		}
+	}
+	return []types.Item{
+		item("API", "@alice", 0, 11),
+		item("UI", "@alice", 0, 4),
+		item("Docs", "@alice, @bob", 1, 2),
+		item("Tests", "@bob", 7, 8),
+	}
+}
+
+func TestBuildWorkloadTable(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	diff := types.ProjectDiff{CurrentItems: workloadTestItems(now)}
+
+	assert.Nil(t, buildWorkloadTable(diff, DefaultOptions(), now), "section is disabled by default")
+
+	options := DefaultOptions()
+	WithWorkload(2, 2)(&options)
+	table := buildWorkloadTable(diff, options, now)
+	require.NotNil(t, table)
+
+	var headers []string
+	for _, column := range table.Columns {
+		headers = append(headers, column.Header)
+	}
+	assert.Equal(t, []string{"Assignee", "Week of Jan 1", "Week of Jan 8", "Scheduled Days", "Load"}, headers)
+	assert.Equal(t, [][]string{
+		{"@alice", "🔴 3", "🟢 1", "19", "⚠️ Overloaded (3 at once)"},
+		{"@bob", "🟢 1", "🟢 1", "4", "OK"},
+	}, table.Rows)
+
+	// Nobody has work scheduled in the window
+	assert.Nil(t, buildWorkloadTable(diff, options, now.AddDate(0, 1, 0)))
+
+	// A palette keeps assignees in the order they were first seen
+	palette := types.NewPalette()
+	palette.Observe(types.PaletteAssignee, []string{"@bob"})
+	palette.Observe(types.PaletteAssignee, []string{"@alice"})
+	WithPalette(palette)(&options)
+	table = buildWorkloadTable(diff, options, now)
+	require.NotNil(t, table)
+	assert.Equal(t, "@bob", table.Rows[0][0])
+	assert.Equal(t, "@alice", table.Rows[1][0])
+	assert.Equal(t, types.PaletteColors[1], table.Colors["@alice"])
+}
+
+func TestWorkloadCell(t *testing.T) {
+	tests := []struct {
+		n, max int
+		style  EmojiStyle
+		want   string
+	}{
+		{0, 3, EmojiStyleGlyphs, "-"},
+		{1, 3, EmojiStyleGlyphs, "🟢 1"},
+		{3, 3, EmojiStyleGlyphs, "🟠 3"},
+		{4, 3, EmojiStyleGlyphs, "🔴 4"},
+		{4, 0, EmojiStyleGlyphs, "🟢 4"},
+		{0, 3, EmojiStyleASCII, "-"},
+		{3, 3, EmojiStyleASCII, "[FULL] 3"},
+		{4, 3, EmojiStyleASCII, "[OVER] 4"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, workloadCell(tt.n, tt.max, tt.style))
+	}
+}
+
+func TestFormattersWorkload(t *testing.T) {
+	diff := createTestDiff()
+	diff.CurrentItems = workloadTestItems(time.Now())
+
+	output := NewTableFormatter(WithWorkload(2, 2)).Format(diff)
+	assert.Contains(t, output, "## 👥 Workload")
+	assert.Contains(t, output, "| @bob |")
+
+	output = NewTextFormatter(WithWorkload(2, 2)).Format(diff)
+	assert.Contains(t, output, "Workload:\n- @alice: ")
+	assert.Contains(t, output, "⚠️ Overloaded")
+
+	output = NewTextFormatter().Format(diff)
+	assert.NotContains(t, output, "Workload:")
+}
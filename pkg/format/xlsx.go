@@ -0,0 +1,303 @@
+package format
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// Styles of xlsxStyles referenced by cells
+const (
+	xlsxStyleHeader = 1 // Bold
+	xlsxStyleNote   = 2 // Italic
+)
+
+// Limits of worksheets in Excel
+const (
+	xlsxMaxSheetName   = 31
+	xlsxMaxColumnWidth = 60
+)
+
+// xlsxDelayLevels are the delay levels highlighted by conditional formatting, in the
+// order of their differential formats in xlsxStyles
+var xlsxDelayLevels = []DelayLevel{DelayLevelAhead, DelayLevelOnTrack, DelayLevelModerate, DelayLevelHigh, DelayLevelExtreme}
+
+// xlsxNumber matches plain decimals written as numeric cells, e.g. counts and days of
+// delay. Other values that parse as floats, like "NaN", "1e5" or IDs with leading zeros
+// like "007", are written as text, as Excel rejects the former and drops the zeros.
+var xlsxNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+
+// xlsxSheetNameReplacer removes characters not allowed in worksheet names
+var xlsxSheetNameReplacer = strings.NewReplacer("[", "", "]", "", ":", "", "*", "", "?", "", "/", "", `\`, "")
+
+const xlsxContentTypesHead = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// xlsxStyles defines the cell styles and, in the order of xlsxDelayLevels, the
+// differential formats of delay levels, colored like the html output
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="3"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font><font><i/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="0" fontId="2" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`<dxfs count="5">` +
+	`<dxf><fill><patternFill><bgColor rgb="FFDAFBE1"/></patternFill></fill></dxf>` +
+	`<dxf><fill><patternFill><bgColor rgb="FFDDF4FF"/></patternFill></fill></dxf>` +
+	`<dxf><fill><patternFill><bgColor rgb="FFFFF8C5"/></patternFill></fill></dxf>` +
+	`<dxf><fill><patternFill><bgColor rgb="FFFFE2CC"/></patternFill></fill></dxf>` +
+	`<dxf><font><b/><color rgb="FF82071E"/></font><fill><patternFill><bgColor rgb="FFFFEBE9"/></patternFill></fill></dxf>` +
+	`</dxfs></styleSheet>`
+
+// XLSXFormatter formats project diffs as an Excel workbook with one worksheet per
+// section, frozen header rows and delay levels highlighted by conditional formatting.
+// The workbook is binary and should be written to a file.
+type XLSXFormatter struct {
+	options FormatterOptions
+	tables  *TableFormatter
+}
+
+// NewXLSXFormatter creates a new Excel formatter with the given options
+func NewXLSXFormatter(opts ...func(*FormatterOptions)) *XLSXFormatter {
+	tables := NewTableFormatter(opts...)
+	return &XLSXFormatter{options: tables.options, tables: tables}
+}
+
+// Format formats the project diff as the bytes of an Excel workbook
+func (f *XLSXFormatter) Format(diff types.ProjectDiff) string {
+	var sheets []xlsxSheet
+	if !hasChanges(diff) {
+		sheets = append(sheets, xlsxSheet{name: "Report", rows: [][]string{{noChangesMessage(diff)}}})
+	} else {
+		doc := f.tables.buildDocument(diff)
+		names := map[string]bool{}
+		for _, section := range doc.Sections {
+			if section.Table == nil {
+				continue
+			}
+			sheet := xlsxSheet{name: xlsxSheetName(section.Title, names), header: true}
+			sheet.rows = append(sheet.rows, tableHeaders(section.Table))
+			for _, row := range section.Table.Rows {
+				cells := make([]string, len(section.Table.Columns))
+				copy(cells, row)
+				sheet.rows = append(sheet.rows, cells)
+			}
+			sheet.note = section.Note
+			sheets = append(sheets, sheet)
+		}
+	}
+
+	data, err := f.workbook(sheets)
+	if err != nil {
+		return fmt.Sprintf("failed to write workbook: %v\n", err)
+	}
+	return string(data)
+}
+
+// xlsxSheet is a worksheet of the workbook
+type xlsxSheet struct {
+	name   string
+	rows   [][]string
+	header bool   // The first row is a header, frozen when scrolling
+	note   string // Shown below the rows
+}
+
+// xlsxPart is a file of the workbook package
+type xlsxPart struct {
+	path    string
+	content string
+}
+
+// tableHeaders returns the headers of the columns of a table
+func tableHeaders(t *Table) []string {
+	row := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		row[i] = col.Header
+	}
+	return row
+}
+
+// xlsxSheetName returns a unique worksheet name for a section title without emoji
+// and characters Excel doesn't allow, truncated to the maximum length
+func xlsxSheetName(title string, used map[string]bool) string {
+	base := strings.TrimSpace(xlsxSheetNameReplacer.Replace(stripEmoji(title)))
+	if base == "" {
+		base = "Report"
+	}
+	name := truncateRunes(base, xlsxMaxSheetName)
+	for i := 2; used[strings.ToLower(name)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		name = truncateRunes(base, xlsxMaxSheetName-len(suffix)) + suffix
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+// truncateRunes returns the first n runes of s
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// workbook writes the worksheets as an Excel workbook
+func (f *XLSXFormatter) workbook(sheets []xlsxSheet) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	var contentTypes, workbook, rels strings.Builder
+	contentTypes.WriteString(xlsxContentTypesHead)
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	rels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	var sheetParts []xlsxPart
+	for i, sheet := range sheets {
+		n := i + 1
+		path := fmt.Sprintf("xl/worksheets/sheet%d.xml", n)
+		contentTypes.WriteString(`<Override PartName="/` + path + `" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`)
+		workbook.WriteString(fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(sheet.name), n, n))
+		rels.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n))
+		sheetParts = append(sheetParts, xlsxPart{path, f.worksheet(sheet)})
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	rels.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1))
+	rels.WriteString(`</Relationships>`)
+
+	parts := append([]xlsxPart{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", rels.String()},
+		{"xl/styles.xml", xlsxStyles},
+	}, sheetParts...)
+	for _, part := range parts {
+		fw, err := w.Create(part.path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// worksheet returns the XML of a worksheet. Numbers are written as numeric cells,
+// everything else as text.
+func (f *XLSXFormatter) worksheet(sheet xlsxSheet) string {
+	columns := 0
+	for _, row := range sheet.rows {
+		columns = max(columns, len(row))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if sheet.header {
+		sb.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+
+	// Column widths fitting the longest value
+	if columns > 0 {
+		sb.WriteString("<cols>")
+		for c := 0; c < columns; c++ {
+			width := 8
+			for _, row := range sheet.rows {
+				if c < len(row) {
+					width = max(width, utf8.RuneCountInString(row[c])+2)
+				}
+			}
+			sb.WriteString(fmt.Sprintf(`<col min="%d" max="%d" width="%d" customWidth="1"/>`, c+1, c+1, min(width, xlsxMaxColumnWidth)))
+		}
+		sb.WriteString("</cols>")
+	}
+
+	sb.WriteString("<sheetData>")
+	for r, row := range sheet.rows {
+		style := 0
+		if sheet.header && r == 0 {
+			style = xlsxStyleHeader
+		}
+		sb.WriteString(xlsxRow(r+1, row, style))
+	}
+	if sheet.note != "" {
+		sb.WriteString(xlsxRow(len(sheet.rows)+2, []string{sheet.note}, xlsxStyleNote))
+	}
+	sb.WriteString("</sheetData>")
+
+	// Highlight delay levels in all cells below the header
+	if sheet.header && len(sheet.rows) > 1 && columns > 0 {
+		sb.WriteString(fmt.Sprintf(`<conditionalFormatting sqref="A2:%s%d">`, xlsxColumn(columns-1), len(sheet.rows)))
+		for i, level := range xlsxDelayLevels {
+			label := strings.ReplaceAll(f.options.label(string(level)), `"`, `""`)
+			sb.WriteString(fmt.Sprintf(`<cfRule type="cellIs" dxfId="%d" priority="%d" operator="equal"><formula>"%s"</formula></cfRule>`, i, i+1, xlsxEscape(label)))
+		}
+		sb.WriteString("</conditionalFormatting>")
+	}
+
+	sb.WriteString("</worksheet>")
+	return sb.String()
+}
+
+// xlsxRow returns the XML of a row of cells with the given style
+func xlsxRow(r int, cells []string, style int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<row r="%d">`, r))
+	for c, value := range cells {
+		ref := fmt.Sprintf("%s%d", xlsxColumn(c), r)
+		styleAttr := ""
+		if style != 0 {
+			styleAttr = fmt.Sprintf(` s="%d"`, style)
+		}
+		if xlsxNumber.MatchString(value) && style == 0 {
+			sb.WriteString(fmt.Sprintf(`<c r="%s"><v>%s</v></c>`, ref, value))
+		} else {
+			sb.WriteString(fmt.Sprintf(`<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, ref, styleAttr, xlsxEscape(value)))
+		}
+	}
+	sb.WriteString("</row>")
+	return sb.String()
+}
+
+// xlsxColumn returns the letters of a zero-based column index, e.g. "A" or "AB"
+func xlsxColumn(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxEscape escapes text for XML content and attributes
+func xlsxEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
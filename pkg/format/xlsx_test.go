@@ -0,0 +1,109 @@
+package format
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readWorkbook returns the files of an Excel workbook by path
+func readWorkbook(t *testing.T, data string) map[string]string {
+	r, err := zip.NewReader(bytes.NewReader([]byte(data)), int64(len(data)))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, file := range r.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[file.Name] = string(content)
+	}
+	return files
+}
+
+func TestXLSXFormatter(t *testing.T) {
+	diff := createTestDiff()
+	diff.AddedItems[0].Attributes["Title"] = "New <Task>"
+
+	files := readWorkbook(t, NewXLSXFormatter(WithWeightField("priority")).Format(diff))
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files, "_rels/.rels")
+	assert.Contains(t, files, "xl/styles.xml")
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Summary (weighted by priority)" sheetId="1" r:id="rId1"/><sheet name="Timeline Changes" sheetId="2" r:id="rId2"/>`)
+	assert.Contains(t, files["xl/_rels/workbook.xml.rels"], `Target="worksheets/sheet2.xml"`)
+	assert.Contains(t, files["[Content_Types].xml"], `<Override PartName="/xl/worksheets/sheet2.xml"`)
+
+	sheet := files["xl/worksheets/sheet2.xml"]
+	assert.Contains(t, sheet, `<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">Task</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">New &lt;Task&gt;</t></is></c>`)
+	assert.Contains(t, sheet, `<conditionalFormatting sqref="A2:F4">`)
+	assert.Contains(t, sheet, `<cfRule type="cellIs" dxfId="3" priority="4" operator="equal"><formula>"🔴 High delay"</formula></cfRule>`)
+}
+
+func TestXLSXFormatterNumbersAndNotes(t *testing.T) {
+	diff := types.ProjectDiff{ChangedItems: []types.ItemDiff{delayedChange("1", 20), delayedChange("2", 20)}}
+
+	files := readWorkbook(t, NewXLSXFormatter(WithLimit(1), WithEmojiStyle(EmojiStyleASCII), WithColumns([]string{ColumnTask, ColumnStatus, "Points"})).Format(diff))
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">[HIGH]</t></is></c>`)
+	assert.Contains(t, sheet, `<row r="4"><c r="A4" t="inlineStr" s="2"><is><t xml:space="preserve">…and 1 more ([HIGH]: 1)</t></is></c></row>`)
+	assert.Contains(t, sheet, `<formula>"[HIGH]"</formula>`)
+}
+
+func TestXLSXFormatterNoChanges(t *testing.T) {
+	files := readWorkbook(t, NewXLSXFormatter().Format(types.ProjectDiff{}))
+
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Report" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], "No changes found in the project timeline.")
+	assert.NotContains(t, files["xl/worksheets/sheet1.xml"], "frozen")
+}
+
+func TestXLSXSheetName(t *testing.T) {
+	used := map[string]bool{}
+	assert.Equal(t, "Timeline Changes", xlsxSheetName("📅 Timeline Changes", used))
+	assert.Equal(t, "Timeline Changes (2)", xlsxSheetName("📅 Timeline Changes", used))
+	assert.Equal(t, "Ahead  Completed early", xlsxSheetName("🚀 Ahead / Completed early", used))
+	assert.Equal(t, "A very long section title that ", xlsxSheetName("A very long section title that doesn't fit", used))
+	assert.Equal(t, "Report", xlsxSheetName("", used))
+}
+
+func TestXLSXRowNumbers(t *testing.T) {
+	assert.Equal(t, `<row r="2"><c r="A2"><v>13</v></c><c r="B2" t="inlineStr"><is><t xml:space="preserve">-</t></is></c></row>`, xlsxRow(2, []string{"13", "-"}, 0))
+}
+
+func TestXLSXFormatterNumericTitles(t *testing.T) {
+	nan, leadingZero := delayedChange("1", 20), delayedChange("2", 20)
+	nan.After.Attributes = map[string]interface{}{"Title": "NaN"}
+	leadingZero.After.Attributes = map[string]interface{}{"Title": "007"}
+	diff := types.ProjectDiff{ChangedItems: []types.ItemDiff{nan, leadingZero}}
+
+	sheet := readWorkbook(t, NewXLSXFormatter(WithColumns([]string{ColumnTask, ColumnStatus})).Format(diff))["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">NaN</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A3" t="inlineStr"><is><t xml:space="preserve">007</t></is></c>`)
+	assert.NotContains(t, sheet, "<v>")
+}
+
+func TestXLSXRowValues(t *testing.T) {
+	for _, value := range []string{"NaN", "Inf", "-Infinity", "1e5", "0x1p-2", "007", "1.", "+3"} {
+		assert.Contains(t, xlsxRow(1, []string{value}, 0), `t="inlineStr"`, value)
+	}
+	for _, value := range []string{"0", "-3", "12", "2.5", "-0.25"} {
+		assert.Equal(t, `<row r="1"><c r="A1"><v>`+value+`</v></c></row>`, xlsxRow(1, []string{value}, 0), value)
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, want, xlsxColumn(index))
+	}
+}
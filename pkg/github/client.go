@@ -5,44 +5,82 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"sort"
 	"time"
 
+	"github.com/naag/gh-project-report/pkg/redact"
 	"github.com/naag/gh-project-report/pkg/types"
 	"github.com/shurcooL/graphql"
 )
 
 // Client represents a GitHub client
 type Client struct {
-	graphql *graphql.Client
-	verbose bool
+	executor   Executor
+	options    ClientOptions
+	httpClient *http.Client // Sends REST API requests, nil for clients created with an executor
 }
 
-// NewClient creates a new GitHub client
-func NewClient(httpClient *http.Client, verbose bool) *Client {
-	return NewClientWithBaseURL(httpClient, "https://api.github.com/graphql", verbose)
-}
+// NewClient creates a new GitHub client. Authentication is left to the given HTTP client.
+func NewClient(httpClient *http.Client, opts ...ClientOption) *Client {
+	options := buildClientOptions(opts)
 
-// NewClientWithBaseURL creates a new GitHub client with a custom base URL
-func NewClientWithBaseURL(httpClient *http.Client, baseURL string, verbose bool) *Client {
-	if verbose {
-		// Wrap the transport with our logging transport
-		transport := httpClient.Transport
-		if transport == nil {
-			transport = http.DefaultTransport
-		}
+	// Copy the client so the caller's client is left untouched
+	configured := *httpClient
+	if options.Timeout > 0 {
+		configured.Timeout = options.Timeout
+	}
 
-		httpClient.Transport = &loggingTransport{
-			transport: transport,
-		}
+	transport := configured.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
 	}
+	if options.Logger != nil {
+		transport = &loggingTransport{transport: transport, logger: options.Logger}
+	}
+
+	// Retries happen in the transport, which sees status codes and rate limit
+	// headers, instead of the executor
+	executorOptions := options
+	if options.RetryPolicy.MaxAttempts > 1 {
+		transport = &retryTransport{transport: transport, policy: options.RetryPolicy, logger: options.Logger, now: time.Now}
+		executorOptions.RetryPolicy.MaxAttempts = 1
+	}
+	configured.Transport = &userAgentTransport{transport: transport, userAgent: options.UserAgent}
 
-	client := graphql.NewClient(baseURL, httpClient)
+	client := newClient(graphql.NewClient(options.BaseURL, &configured), executorOptions)
+	client.options = options
+	client.httpClient = &configured
+	return client
+}
 
+// NewClientWithExecutor creates a new GitHub client that runs its queries through the given executor
+func NewClientWithExecutor(executor Executor, opts ...ClientOption) *Client {
+	return newClient(executor, buildClientOptions(opts))
+}
+
+// newClient creates a client, wrapping the executor with the retry policy and permission error guidance
+func newClient(executor Executor, options ClientOptions) *Client {
+	if options.TokenKind != "" {
+		executor = &authHintExecutor{executor: executor, kind: options.TokenKind}
+	}
+	if options.RetryPolicy.MaxAttempts > 1 {
+		executor = &retryExecutor{executor: executor, policy: options.RetryPolicy}
+	}
 	return &Client{
-		graphql: client,
-		verbose: verbose,
+		executor: executor,
+		options:  options,
+	}
+}
+
+// buildClientOptions applies the options to the defaults
+func buildClientOptions(opts []ClientOption) ClientOptions {
+	options := DefaultClientOptions()
+	for _, opt := range opts {
+		opt(&options)
 	}
+	return options
 }
 
 // FetchProjectState fetches the current state of a project
@@ -53,230 +91,405 @@ func (c *Client) FetchProjectState(projectNumber int, organization, startField,
 		return nil, fmt.Errorf("failed to lookup project ID: %w", err)
 	}
 
-	// Common field types that will be embedded
-	type ProjectV2FieldCommon struct {
-		Name graphql.String
+	// Initialize state
+	state := &types.ProjectState{
+		Timestamp:     time.Now(),
+		ProjectNumber: projectNumber,
+		ProjectID:     projectNodeID,
+		Organization:  organization,
+		Items:         make([]types.Item, 0),
 	}
 
-	type ProjectV2Field struct {
-		Common ProjectV2FieldCommon `graphql:"... on ProjectV2FieldCommon"`
+	nodes, workflows, err := c.fetchProjectItems(projectNodeID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Field value types that will be embedded
-	type TextFieldValue struct {
-		Text  graphql.String
-		Field ProjectV2Field
+	for _, node := range nodes {
+		state.Items = append(state.Items, convertItem(node, startField, endField))
 	}
-
-	type NumberFieldValue struct {
-		Number float64
-		Field  ProjectV2Field
+	for _, workflow := range workflows {
+		state.Workflows = append(state.Workflows, types.Workflow{
+			Name:    string(workflow.Name),
+			Enabled: bool(workflow.Enabled),
+		})
 	}
 
-	type DateFieldValue struct {
-		Date  graphql.String
-		Field ProjectV2Field
+	if len(state.Items) == 0 {
+		state.Warnings = append(state.Warnings, fmt.Sprintf("project %d has no items", projectNumber))
 	}
 
-	type SingleSelectFieldValue struct {
-		Name  graphql.String
-		Field ProjectV2Field
+	restricted := 0
+	for _, item := range state.Items {
+		if item.IsRestricted() {
+			restricted++
+		}
+	}
+	if restricted > 0 {
+		state.Warnings = append(state.Warnings, fmt.Sprintf("%d of %d items are restricted: the token cannot read the repositories of their issues or pull requests", restricted, len(state.Items)))
 	}
 
-	type RepositoryFieldValue struct {
-		Repository struct {
-			Name  graphql.String
-			Owner struct {
-				Login graphql.String
+	return state, nil
+}
+
+// fetchProjectItems fetches all items and the workflows of a project, following pagination
+func (c *Client) fetchProjectItems(projectNodeID string) ([]ProjectItemNode, []WorkflowNode, error) {
+	var workflows []WorkflowNode
+	nodes, err := paginate(nil, func(cursor *graphql.String) ([]ProjectItemNode, PageInfo, error) {
+		var query ProjectItemsQuery
+		err := c.executor.Query(context.Background(), &query, connectionVariables(projectNodeID, cursor, c.options.MaxPageSize))
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("GraphQL query failed: %w", err)
+		}
+
+		// The node ID may point to something other than a project
+		if query.Node.TypeName != "ProjectV2" {
+			if query.Node.TypeName == "" {
+				return nil, PageInfo{}, fmt.Errorf("project node %s not found", projectNodeID)
 			}
+			return nil, PageInfo{}, fmt.Errorf("node %s is not a ProjectV2 (got %s)", projectNodeID, query.Node.TypeName)
+		}
+
+		if cursor == nil {
+			workflows = query.Node.ProjectV2.Workflows.Nodes
 		}
-		Field ProjectV2Field
+		return query.Node.ProjectV2.Items.Nodes, query.Node.ProjectV2.Items.PageInfo, nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Content types that will be embedded
-	type IssueContent struct {
-		Title     graphql.String
-		CreatedAt graphql.String
-		UpdatedAt graphql.String
+	for i := range nodes {
+		if err := c.completeConnections(&nodes[i]); err != nil {
+			return nil, nil, err
+		}
 	}
+	return nodes, workflows, nil
+}
 
-	type PullRequestContent struct {
-		Title     graphql.String
-		CreatedAt graphql.String
-		UpdatedAt graphql.String
+// completeConnections fetches the remaining pages of the connections of an item
+// that didn't fit into the first page fetched with the project items
+func (c *Client) completeConnections(node *ProjectItemNode) error {
+	fieldValues, err := remainingPages(node.FieldValues.PageInfo, func(cursor *graphql.String) ([]FieldValueNode, PageInfo, error) {
+		var query ItemFieldValuesQuery
+		if err := c.executor.Query(context.Background(), &query, connectionVariables(string(node.ID), cursor, c.options.MaxPageSize)); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to query field values of item %s: %w", node.ID, err)
+		}
+		return query.Node.ProjectV2Item.FieldValues.Nodes, query.Node.ProjectV2Item.FieldValues.PageInfo, nil
+	})
+	if err != nil {
+		return err
 	}
+	node.FieldValues.Nodes = append(node.FieldValues.Nodes, fieldValues...)
 
-	type DraftIssueContent struct {
-		Title     graphql.String
-		CreatedAt graphql.String
-		UpdatedAt graphql.String
+	// The users of user fields are nested in the field values, paged by field name
+	for i := range node.FieldValues.Nodes {
+		value := &node.FieldValues.Nodes[i]
+		if value.TypeName != "ProjectV2ItemFieldUserValue" {
+			continue
+		}
+		field := string(value.UserValue.Field.Common.Name)
+		users, err := remainingPages(value.UserValue.Users.PageInfo, func(cursor *graphql.String) ([]User, PageInfo, error) {
+			var query ItemUserFieldQuery
+			if err := c.executor.Query(context.Background(), &query, userFieldVariables(string(node.ID), field, cursor, c.options.MaxPageSize)); err != nil {
+				return nil, PageInfo{}, fmt.Errorf("failed to query users of field %q of item %s: %w", field, node.ID, err)
+			}
+			users := query.Node.ProjectV2Item.FieldValueByName.UserValue.Users
+			return users.Nodes, users.PageInfo, nil
+		})
+		if err != nil {
+			return err
+		}
+		value.UserValue.Users.Nodes = append(value.UserValue.Users.Nodes, users...)
 	}
 
-	var query struct {
-		Node struct {
-			TypeName  graphql.String `graphql:"__typename"`
-			ProjectV2 struct {
-				Title graphql.String
-				Items struct {
-					PageInfo struct {
-						HasNextPage graphql.Boolean
-						EndCursor   graphql.String
-					}
-					Nodes []struct {
-						ID          graphql.String
-						FieldValues struct {
-							Nodes []struct {
-								TypeName     graphql.String         `graphql:"__typename"`
-								TextValue    TextFieldValue         `graphql:"... on ProjectV2ItemFieldTextValue"`
-								NumberValue  NumberFieldValue       `graphql:"... on ProjectV2ItemFieldNumberValue"`
-								DateValue    DateFieldValue         `graphql:"... on ProjectV2ItemFieldDateValue"`
-								SingleSelect SingleSelectFieldValue `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
-								Repository   RepositoryFieldValue   `graphql:"... on ProjectV2ItemFieldRepositoryValue"`
-							}
-						} `graphql:"fieldValues(first: 100)"`
-						Content struct {
-							TypeName    graphql.String     `graphql:"__typename"`
-							Issue       IssueContent       `graphql:"... on Issue"`
-							PullRequest PullRequestContent `graphql:"... on PullRequest"`
-							DraftIssue  DraftIssueContent  `graphql:"... on DraftIssue"`
-						}
-					}
-				} `graphql:"items(first: 100, after: $cursor)"`
-			} `graphql:"... on ProjectV2"`
-		} `graphql:"node(id: $id)"`
+	var contentID graphql.String
+	var assignees *UserConnection
+	var labels *LabelConnection
+	switch node.Content.TypeName {
+	case "Issue":
+		contentID, assignees, labels = node.Content.Issue.ID, &node.Content.Issue.Assignees, &node.Content.Issue.Labels
+	case "PullRequest":
+		contentID, assignees, labels = node.Content.PullRequest.ID, &node.Content.PullRequest.Assignees, &node.Content.PullRequest.Labels
+	default:
+		return nil
 	}
 
-	// Initialize state
-	state := &types.ProjectState{
-		Timestamp:     time.Now(),
-		ProjectNumber: projectNumber,
-		ProjectID:     projectNodeID,
-		Organization:  organization,
-		Items:         make([]types.Item, 0),
+	users, err := remainingPages(assignees.PageInfo, func(cursor *graphql.String) ([]User, PageInfo, error) {
+		var query AssigneesQuery
+		if err := c.executor.Query(context.Background(), &query, connectionVariables(string(contentID), cursor, c.options.MaxPageSize)); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to query assignees of item %s: %w", node.ID, err)
+		}
+		return query.Node.Assignable.Assignees.Nodes, query.Node.Assignable.Assignees.PageInfo, nil
+	})
+	if err != nil {
+		return err
 	}
+	assignees.Nodes = append(assignees.Nodes, users...)
 
-	var cursor *graphql.String
-	for {
-		variables := map[string]interface{}{
-			"id":     graphql.ID(projectNodeID),
-			"cursor": cursor,
+	moreLabels, err := remainingPages(labels.PageInfo, func(cursor *graphql.String) ([]Label, PageInfo, error) {
+		var query LabelsQuery
+		if err := c.executor.Query(context.Background(), &query, connectionVariables(string(contentID), cursor, c.options.MaxPageSize)); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to query labels of item %s: %w", node.ID, err)
 		}
+		return query.Node.Labelable.Labels.Nodes, query.Node.Labelable.Labels.PageInfo, nil
+	})
+	if err != nil {
+		return err
+	}
+	labels.Nodes = append(labels.Nodes, moreLabels...)
 
-		err = c.graphql.Query(context.Background(), &query, variables)
-		if err != nil {
-			return nil, fmt.Errorf("GraphQL query failed: %w", err)
+	blockedBy, err := remainingPages(node.Content.Issue.BlockedBy.PageInfo, func(cursor *graphql.String) ([]IssueRef, PageInfo, error) {
+		var query BlockedByQuery
+		if err := c.executor.Query(context.Background(), &query, connectionVariables(string(contentID), cursor, c.options.MaxPageSize)); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("failed to query blocking issues of item %s: %w", node.ID, err)
+		}
+		return query.Node.Issue.BlockedBy.Nodes, query.Node.Issue.BlockedBy.PageInfo, nil
+	})
+	if err != nil {
+		return err
+	}
+	node.Content.Issue.BlockedBy.Nodes = append(node.Content.Issue.BlockedBy.Nodes, blockedBy...)
+	return nil
+}
+
+// convertItem converts a project item node into an item
+func convertItem(item ProjectItemNode, startField, endField string) types.Item {
+	// Get title and timestamps based on content type
+	var (
+		title      string
+		createdAt  time.Time
+		updatedAt  time.Time
+		assignees  UserConnection
+		contentID  string
+		url        string
+		number     int
+		repository string
+		blockedBy  []string
+		labels     LabelConnection
+		state      graphql.String
+	)
+
+	switch item.Content.TypeName {
+	case "Issue":
+		title = string(item.Content.Issue.Title)
+		createdAt, _ = time.Parse(time.RFC3339, string(item.Content.Issue.CreatedAt))
+		updatedAt, _ = time.Parse(time.RFC3339, string(item.Content.Issue.UpdatedAt))
+		assignees = item.Content.Issue.Assignees
+		contentID = string(item.Content.Issue.ID)
+		url = string(item.Content.Issue.URL)
+		number = int(item.Content.Issue.Number)
+		repository = string(item.Content.Issue.Repository.NameWithOwner)
+		labels = item.Content.Issue.Labels
+		state = item.Content.Issue.State
+		for _, blocker := range item.Content.Issue.BlockedBy.Nodes {
+			blockedBy = append(blockedBy, string(blocker.ID))
 		}
+	case "PullRequest":
+		title = string(item.Content.PullRequest.Title)
+		createdAt, _ = time.Parse(time.RFC3339, string(item.Content.PullRequest.CreatedAt))
+		updatedAt, _ = time.Parse(time.RFC3339, string(item.Content.PullRequest.UpdatedAt))
+		assignees = item.Content.PullRequest.Assignees
+		contentID = string(item.Content.PullRequest.ID)
+		url = string(item.Content.PullRequest.URL)
+		number = int(item.Content.PullRequest.Number)
+		repository = string(item.Content.PullRequest.Repository.NameWithOwner)
+		labels = item.Content.PullRequest.Labels
+		state = item.Content.PullRequest.State
+	case "DraftIssue":
+		title = string(item.Content.DraftIssue.Title)
+		createdAt, _ = time.Parse(time.RFC3339, string(item.Content.DraftIssue.CreatedAt))
+		updatedAt, _ = time.Parse(time.RFC3339, string(item.Content.DraftIssue.UpdatedAt))
+	}
 
-		// Process items from current page
-		for _, item := range query.Node.ProjectV2.Items.Nodes {
-			// Get title and timestamps based on content type
-			var (
-				title     string
-				createdAt time.Time
-				updatedAt time.Time
-			)
-
-			switch item.Content.TypeName {
-			case "Issue":
-				title = string(item.Content.Issue.Title)
-				createdAt, _ = time.Parse(time.RFC3339, string(item.Content.Issue.CreatedAt))
-				updatedAt, _ = time.Parse(time.RFC3339, string(item.Content.Issue.UpdatedAt))
-			case "PullRequest":
-				title = string(item.Content.PullRequest.Title)
-				createdAt, _ = time.Parse(time.RFC3339, string(item.Content.PullRequest.CreatedAt))
-				updatedAt, _ = time.Parse(time.RFC3339, string(item.Content.PullRequest.UpdatedAt))
-			case "DraftIssue":
-				title = string(item.Content.DraftIssue.Title)
-				createdAt, _ = time.Parse(time.RFC3339, string(item.Content.DraftIssue.CreatedAt))
-				updatedAt, _ = time.Parse(time.RFC3339, string(item.Content.DraftIssue.UpdatedAt))
-			}
+	// Items of repositories the token cannot read come back redacted without content
+	restricted := item.Type == "REDACTED" || item.Content.TypeName == ""
+	if title == "" && !restricted {
+		title = fmt.Sprintf("Unknown type: %s", item.Content.TypeName)
+	}
 
-			if title == "" {
-				title = fmt.Sprintf("Unknown type: %s", item.Content.TypeName)
-			}
+	projectItem := types.Item{
+		ID:          string(item.ID),
+		ContentType: string(item.Content.TypeName),
+		ContentID:   contentID,
+		URL:         url,
+		Number:      number,
+		Repository:  repository,
+		BlockedBy:   blockedBy,
+		Labels:      labelNames(labels),
+		Attributes:  map[string]interface{}{},
+		Provenance:  map[string]types.Provenance{},
+	}
 
-			projectItem := types.Item{
-				ID: string(item.ID),
-				Attributes: map[string]interface{}{
-					"Title":      title,
-					"created_at": createdAt,
-					"updated_at": updatedAt,
-				},
-			}
+	// Only the project fields of restricted items are known
+	if restricted {
+		projectItem.SetAttribute(types.RestrictedAttribute, true, types.ProvenanceDerived)
+	} else {
+		projectItem.SetAttribute("Title", title, types.ProvenanceContent)
+		projectItem.SetAttribute(types.CreatedAtAttribute, types.FormatTimestamp(createdAt), types.ProvenanceContent)
+		projectItem.SetAttribute(types.UpdatedAtAttribute, types.FormatTimestamp(updatedAt), types.ProvenanceContent)
+	}
 
-			// Process field values
-			for _, fieldValue := range item.FieldValues.Nodes {
-				switch fieldValue.TypeName {
-				case "ProjectV2ItemFieldTextValue":
-					name := string(fieldValue.TextValue.Field.Common.Name)
-					if name == "Title" {
-						continue
-					}
-					projectItem.Attributes[name] = string(fieldValue.TextValue.Text)
-				case "ProjectV2ItemFieldNumberValue":
-					name := string(fieldValue.NumberValue.Field.Common.Name)
-					projectItem.Attributes[name] = fieldValue.NumberValue.Number
-				case "ProjectV2ItemFieldDateValue":
-					name := string(fieldValue.DateValue.Field.Common.Name)
-					dateStr := string(fieldValue.DateValue.Date)
-
-					if name == startField || name == endField {
-						if date, err := time.Parse("2006-01-02", dateStr); err == nil {
-							if name == startField {
-								projectItem.DateSpan.Start = date
-							} else {
-								projectItem.DateSpan.End = date
-							}
-						}
+	if len(assignees.Nodes) > 0 {
+		projectItem.SetAttribute(types.AssigneesAttribute, formatUsers(assignees), types.ProvenanceContent)
+	}
+	if state := contentState(state); state != "" {
+		projectItem.SetAttribute(types.StateAttribute, state, types.ProvenanceContent)
+	}
+
+	// Process field values
+	var iterationEnd time.Time
+	for _, fieldValue := range item.FieldValues.Nodes {
+		switch fieldValue.TypeName {
+		case "ProjectV2ItemFieldTextValue":
+			name := string(fieldValue.TextValue.Field.Common.Name)
+			if name == "Title" {
+				continue
+			}
+			projectItem.SetAttribute(name, string(fieldValue.TextValue.Text), types.ProvenanceField)
+		case "ProjectV2ItemFieldNumberValue":
+			name := string(fieldValue.NumberValue.Field.Common.Name)
+			projectItem.SetAttribute(name, fieldValue.NumberValue.Number, types.ProvenanceField)
+		case "ProjectV2ItemFieldDateValue":
+			name := string(fieldValue.DateValue.Field.Common.Name)
+			dateStr := string(fieldValue.DateValue.Date)
+
+			if name == startField || name == endField {
+				if date, err := time.Parse("2006-01-02", dateStr); err == nil {
+					if name == startField {
+						projectItem.DateSpan.Start = date
 					} else {
-						projectItem.Attributes[name] = dateStr
+						projectItem.DateSpan.End = date
 					}
-				case "ProjectV2ItemFieldSingleSelectValue":
-					name := string(fieldValue.SingleSelect.Field.Common.Name)
-					projectItem.Attributes[name] = string(fieldValue.SingleSelect.Name)
-				case "ProjectV2ItemFieldRepositoryValue":
-					name := string(fieldValue.Repository.Field.Common.Name)
-					repoValue := fmt.Sprintf("%s/%s",
-						fieldValue.Repository.Repository.Owner.Login,
-						fieldValue.Repository.Repository.Name)
-					projectItem.Attributes[name] = repoValue
 				}
+			} else {
+				projectItem.SetAttribute(name, dateStr, types.ProvenanceField)
 			}
-
-			state.Items = append(state.Items, projectItem)
+		case "ProjectV2ItemFieldSingleSelectValue":
+			name := string(fieldValue.SingleSelect.Field.Common.Name)
+			projectItem.SetAttribute(name, string(fieldValue.SingleSelect.Name), types.ProvenanceField)
+		case "ProjectV2ItemFieldRepositoryValue":
+			name := string(fieldValue.Repository.Field.Common.Name)
+			repoValue := fmt.Sprintf("%s/%s",
+				fieldValue.Repository.Repository.Owner.Login,
+				fieldValue.Repository.Repository.Name)
+			projectItem.SetAttribute(name, repoValue, types.ProvenanceField)
+		case "ProjectV2ItemFieldUserValue":
+			name := string(fieldValue.UserValue.Field.Common.Name)
+			projectItem.SetAttribute(name, formatUsers(fieldValue.UserValue.Users), types.ProvenanceField)
+		case "ProjectV2ItemFieldIterationValue":
+			iteration := iterationValue{
+				field:     string(fieldValue.Iteration.Field.Common.Name),
+				title:     string(fieldValue.Iteration.Title),
+				startDate: string(fieldValue.Iteration.StartDate),
+				duration:  float64(fieldValue.Iteration.Duration),
+			}
+			if end := iteration.apply(&projectItem, startField, endField); isIterationRef(startField, iteration.field) {
+				iterationEnd = end
+			}
+		case "ProjectV2ItemFieldMilestoneValue":
+			milestone := milestoneValue{
+				field: string(fieldValue.Milestone.Field.Common.Name),
+				title: string(fieldValue.Milestone.Milestone.Title),
+				dueOn: string(fieldValue.Milestone.Milestone.DueOn),
+			}
+			milestone.apply(&projectItem, endField)
 		}
+	}
 
-		// Check if there are more pages
-		if !query.Node.ProjectV2.Items.PageInfo.HasNextPage {
-			break
-		}
+	// Items starting with their iteration end with it, unless an end date is set
+	if projectItem.DateSpan.End.IsZero() {
+		projectItem.DateSpan.End = iterationEnd
+	}
 
-		// Update cursor for next page
-		endCursor := graphql.String(query.Node.ProjectV2.Items.PageInfo.EndCursor)
-		cursor = &endCursor
+	return projectItem
+}
+
+// contentState converts the state of an issue or pull request to a StateAttribute value
+func contentState(state graphql.String) string {
+	switch state {
+	case "OPEN":
+		return types.StateOpen
+	case "CLOSED":
+		return types.StateClosed
+	case "MERGED":
+		return types.StateMerged
 	}
+	return ""
+}
 
-	return state, nil
+// formatUsers formats users as a sorted list of mentions, e.g. ["@alice", "@bob"]
+func formatUsers(users UserConnection) []string {
+	logins := make([]string, len(users.Nodes))
+	for i, user := range users.Nodes {
+		logins[i] = types.Mention(string(user.Login))
+	}
+	// A stable order keeps snapshots comparable
+	sort.Strings(logins)
+	return logins
+}
+
+// labelNames returns the sorted names of the labels of a connection, nil if there are none
+func labelNames(labels LabelConnection) []string {
+	var names []string
+	for _, label := range labels.Nodes {
+		names = append(names, string(label.Name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ViewerLogin returns the login of the authenticated user
+func (c *Client) ViewerLogin() (string, error) {
+	var query ViewerLoginQuery
+	if err := c.executor.Query(context.Background(), &query, nil); err != nil {
+		return "", fmt.Errorf("failed to query viewer: %w", err)
+	}
+	if query.Viewer.Login == "" {
+		return "", fmt.Errorf("failed to query viewer: empty login")
+	}
+	return string(query.Viewer.Login), nil
+}
+
+// CreateStatusUpdate posts a status update with the given status (e.g. ON_TRACK)
+// and Markdown body to a project and returns the ID of the status update
+func (c *Client) CreateStatusUpdate(projectNodeID, status, body string) (string, error) {
+	var mutation CreateStatusUpdateMutation
+	if err := c.executor.Mutate(context.Background(), &mutation, createStatusUpdateVariables(projectNodeID, status, body)); err != nil {
+		return "", fmt.Errorf("failed to create status update: %w", err)
+	}
+	return string(mutation.CreateProjectV2StatusUpdate.StatusUpdate.ID), nil
+}
+
+// LatestComment returns the latest comment of the issue or pull request behind
+// a project item, or nil if it has no comments or is a draft issue
+func (c *Client) LatestComment(itemID string) (*types.Comment, error) {
+	var query ItemCommentQuery
+	if err := c.executor.Query(context.Background(), &query, itemCommentVariables(itemID)); err != nil {
+		return nil, fmt.Errorf("failed to query comments of item %s: %w", itemID, err)
+	}
+
+	content := query.Node.ProjectV2Item.Content
+	nodes := append(content.Issue.Comments.Nodes, content.PullRequest.Comments.Nodes...)
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	node := nodes[len(nodes)-1]
+	createdAt, _ := time.Parse(time.RFC3339, string(node.CreatedAt))
+	return &types.Comment{
+		Author:    string(node.Author.Login),
+		CreatedAt: createdAt,
+		Body:      string(node.Body),
+	}, nil
 }
 
 // LookupProjectNodeID looks up the node ID for a project based on its number and optional organization
 func (c *Client) LookupProjectNodeID(projectNumber int, organization string) (string, error) {
 	if organization != "" {
 		// Try organization project first
-		var orgQuery struct {
-			Organization struct {
-				ProjectV2 struct {
-					ID graphql.String
-				} `graphql:"projectV2(number: $number)"`
-			} `graphql:"organization(login: $login)"`
-		}
-
-		variables := map[string]interface{}{
-			"number": graphql.Int(projectNumber),
-			"login":  graphql.String(organization),
-		}
-
-		err := c.graphql.Query(context.Background(), &orgQuery, variables)
+		var orgQuery OrgProjectQuery
+		err := c.executor.Query(context.Background(), &orgQuery, orgProjectVariables(projectNumber, organization))
 		if err != nil {
 			return "", fmt.Errorf("GraphQL query failed: %w", err)
 		}
@@ -288,19 +501,8 @@ func (c *Client) LookupProjectNodeID(projectNumber int, organization string) (st
 	}
 
 	// Fall back to viewer's project
-	var viewerQuery struct {
-		Viewer struct {
-			ProjectV2 struct {
-				ID graphql.String
-			} `graphql:"projectV2(number: $number)"`
-		}
-	}
-
-	variables := map[string]interface{}{
-		"number": graphql.Int(projectNumber),
-	}
-
-	err := c.graphql.Query(context.Background(), &viewerQuery, variables)
+	var viewerQuery ViewerProjectQuery
+	err := c.executor.Query(context.Background(), &viewerQuery, viewerProjectVariables(projectNumber))
 	if err != nil {
 		return "", fmt.Errorf("GraphQL query failed: %w", err)
 	}
@@ -312,8 +514,24 @@ func (c *Client) LookupProjectNodeID(projectNumber int, organization string) (st
 	return "", fmt.Errorf("project %d not found", projectNumber)
 }
 
+// userAgentTransport sets the user agent of all requests
+type userAgentTransport struct {
+	transport http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// loggingTransport logs GraphQL requests and responses, masking secrets
 type loggingTransport struct {
 	transport http.RoundTripper
+	logger    *log.Logger
 }
 
 func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -326,7 +544,7 @@ func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 		// Restore the body for the actual request
 		req.Body = ioutil.NopCloser(bytes.NewBuffer(body))
 
-		fmt.Printf("\nGraphQL Request:\n%s\n", string(body))
+		t.logger.Printf("GraphQL Request:\n%s\n", redact.String(string(body)))
 	}
 
 	resp, err := t.transport.RoundTrip(req)
@@ -343,7 +561,7 @@ func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 		// Restore the body for the actual response
 		resp.Body = ioutil.NopCloser(bytes.NewBuffer(body))
 
-		fmt.Printf("\nGraphQL Response:\n%s\n", string(body))
+		t.logger.Printf("GraphQL Response:\n%s\n", redact.String(string(body)))
 	}
 
 	return resp, nil
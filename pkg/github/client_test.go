@@ -1,13 +1,18 @@
 package github
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
 
+	"github.com/naag/gh-project-report/pkg/github/githubtest"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/shurcooL/graphql"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFetchProjectState(t *testing.T) {
@@ -143,7 +148,7 @@ func TestFetchProjectState(t *testing.T) {
 					},
 				},
 			}
-			client := NewClientWithBaseURL(httpClient, server.URL, false)
+			client := NewClient(httpClient, WithBaseURL(server.URL))
 
 			// Fetch state
 			state, err := client.FetchProjectState(123, "", tt.startField, tt.endField)
@@ -210,7 +215,7 @@ func TestFetchProjectStateErrors(t *testing.T) {
 					Proxy: http.ProxyURL(serverURL),
 				},
 			}
-			client := NewClientWithBaseURL(httpClient, server.URL, false)
+			client := NewClient(httpClient, WithBaseURL(server.URL))
 
 			_, err = client.FetchProjectState(123, "", "Timeline", "Due Date")
 			assert.Error(t, err)
@@ -315,7 +320,7 @@ func TestLookupProjectNodeID(t *testing.T) {
 					},
 				},
 			}
-			client := NewClientWithBaseURL(httpClient, server.URL, false)
+			client := NewClient(httpClient, WithBaseURL(server.URL))
 
 			gotID, err := client.LookupProjectNodeID(tt.projectNum, tt.organization)
 			if tt.wantErr != "" {
@@ -329,3 +334,411 @@ func TestLookupProjectNodeID(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchProjectStatePagination(t *testing.T) {
+	page := func(hasNextPage bool, endCursor string, ids ...string) ProjectItemsQuery {
+		var q ProjectItemsQuery
+		q.Node.TypeName = "ProjectV2"
+		q.Node.ProjectV2.Workflows.Nodes = []WorkflowNode{{Name: "Auto-archive items", Enabled: graphql.Boolean(hasNextPage)}}
+		q.Node.ProjectV2.Items.PageInfo = PageInfo{
+			HasNextPage: graphql.Boolean(hasNextPage),
+			EndCursor:   graphql.String(endCursor),
+		}
+		for _, id := range ids {
+			node := ProjectItemNode{ID: graphql.String(id)}
+			node.Content.TypeName = "DraftIssue"
+			node.Content.DraftIssue.Title = graphql.String("Task " + id)
+			q.Node.ProjectV2.Items.Nodes = append(q.Node.ProjectV2.Items.Nodes, node)
+		}
+		return q
+	}
+
+	var lookup ViewerProjectQuery
+	lookup.Viewer.ProjectV2.ID = "PVT_123"
+
+	executor := githubtest.NewExecutor(
+		githubtest.Respond(lookup),
+		githubtest.Respond(page(true, "cursor-1", "item1", "item2")),
+		githubtest.Respond(page(false, "", "item3")),
+	)
+	client := NewClientWithExecutor(executor)
+
+	state, err := client.FetchProjectState(123, "", "Start", "End")
+	require.NoError(t, err)
+	require.Len(t, state.Items, 3)
+	assert.Equal(t, "PVT_123", state.ProjectID)
+	assert.Equal(t, "Task item3", state.Items[2].GetTitle())
+	assert.Equal(t, []types.Workflow{{Name: "Auto-archive items", Enabled: true}}, state.Workflows)
+
+	calls := executor.Calls()
+	require.Len(t, calls, 3)
+	assert.Nil(t, calls[1].Variables["cursor"])
+	assert.Equal(t, graphql.String("cursor-1"), *calls[2].Variables["cursor"].(*graphql.String))
+}
+
+func TestFetchProjectStateQueryFailure(t *testing.T) {
+	var lookup ViewerProjectQuery
+	lookup.Viewer.ProjectV2.ID = "PVT_123"
+
+	executor := githubtest.NewExecutor(
+		githubtest.Respond(lookup),
+		githubtest.Fail(errors.New("boom")),
+	)
+	client := NewClientWithExecutor(executor)
+
+	_, err := client.FetchProjectState(123, "", "Start", "End")
+	assert.ErrorContains(t, err, "GraphQL query failed: boom")
+}
+
+func TestConvertItem(t *testing.T) {
+	node := ProjectItemNode{ID: "item1"}
+	node.Content.TypeName = "Issue"
+	node.Content.Issue.Title = "Test Issue"
+	node.Content.Issue.CreatedAt = "2024-01-01T00:00:00Z"
+	node.FieldValues.Nodes = []FieldValueNode{
+		{TypeName: "ProjectV2ItemFieldDateValue", DateValue: DateFieldValue{Date: "2024-01-01", Field: FieldRef{Common: FieldCommon{Name: "Start"}}}},
+		{TypeName: "ProjectV2ItemFieldDateValue", DateValue: DateFieldValue{Date: "2024-01-10", Field: FieldRef{Common: FieldCommon{Name: "End"}}}},
+		{TypeName: "ProjectV2ItemFieldSingleSelectValue", SingleSelect: SingleSelectFieldValue{Name: "Todo", Field: FieldRef{Common: FieldCommon{Name: "Status"}}}},
+		{TypeName: "ProjectV2ItemFieldNumberValue", NumberValue: NumberFieldValue{Number: 3, Field: FieldRef{Common: FieldCommon{Name: "Estimate"}}}},
+		{TypeName: "ProjectV2ItemFieldUserValue", UserValue: UserFieldValue{Users: UserConnection{Nodes: []User{{Login: "carol"}}}, Field: FieldRef{Common: FieldCommon{Name: "Owner"}}}},
+	}
+	node.Content.Issue.Assignees.Nodes = []User{{Login: "alice"}, {Login: "bob"}}
+	node.Content.Issue.ID = "I_1"
+	node.Content.Issue.URL = "https://github.com/org/repo/issues/1"
+	node.Content.Issue.BlockedBy.Nodes = []IssueRef{{ID: "I_2"}}
+	node.Content.Issue.Labels.Nodes = []Label{{Name: "blocked-external"}}
+
+	item := convertItem(node, "Start", "End")
+
+	assert.Equal(t, "item1", item.ID)
+	assert.Equal(t, "Test Issue", item.GetTitle())
+	assert.Equal(t, types.MustNewDateSpan("2024-01-01", "2024-01-10"), item.DateSpan)
+	assert.Equal(t, "Todo", item.Attributes["Status"])
+	assert.Equal(t, float64(3), item.Attributes["Estimate"])
+	assert.Equal(t, "2024-01-01T00:00:00Z", item.Attributes[types.CreatedAtAttribute])
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), item.GetCreatedAt())
+	assert.Equal(t, []string{"@alice", "@bob"}, item.Attributes[types.AssigneesAttribute])
+	assert.Equal(t, []string{"@carol"}, item.Attributes["Owner"])
+	assert.Equal(t, "I_1", item.ContentID)
+	assert.Equal(t, "https://github.com/org/repo/issues/1", item.URL)
+	assert.Equal(t, []string{"I_2"}, item.BlockedBy)
+	assert.Equal(t, []string{"blocked-external"}, item.Labels)
+}
+
+func TestConvertItemIteration(t *testing.T) {
+	node := ProjectItemNode{ID: "item1"}
+	node.Content.TypeName = "Issue"
+	node.Content.Issue.Title = "Test Issue"
+	node.FieldValues.Nodes = []FieldValueNode{
+		{TypeName: "ProjectV2ItemFieldIterationValue", Iteration: IterationFieldValue{Title: "Sprint 3", StartDate: "2024-02-05", Duration: 14, Field: FieldRef{Common: FieldCommon{Name: "Sprint"}}}},
+	}
+
+	item := convertItem(node, "Start", "End")
+	assert.Equal(t, "Sprint 3", item.Attributes["Sprint"])
+	assert.Equal(t, "2024-02-05", item.Attributes["Sprint"+IterationStartSuffix])
+	assert.Equal(t, float64(14), item.Attributes["Sprint"+IterationDurationSuffix])
+	assert.True(t, item.DateSpan.Start.IsZero())
+	assert.Equal(t, types.ProvenanceField, item.AttributeProvenance("Sprint"))
+	assert.Equal(t, types.ProvenanceDerived, item.AttributeProvenance("Sprint"+IterationStartSuffix))
+	assert.Equal(t, types.ProvenanceContent, item.Provenance["Title"])
+
+	item = convertItem(node, IterationFieldRef, "End")
+	assert.Equal(t, types.MustNewDateSpan("2024-02-05", "2024-02-18"), item.DateSpan)
+
+	// An end date set on the item takes precedence over the end of the iteration
+	node.FieldValues.Nodes = append(node.FieldValues.Nodes,
+		FieldValueNode{TypeName: "ProjectV2ItemFieldDateValue", DateValue: DateFieldValue{Date: "2024-02-09", Field: FieldRef{Common: FieldCommon{Name: "End"}}}})
+	item = convertItem(node, "@sprint", "End")
+	assert.Equal(t, types.MustNewDateSpan("2024-02-05", "2024-02-09"), item.DateSpan)
+}
+
+func TestConvertItemMilestone(t *testing.T) {
+	node := ProjectItemNode{ID: "item1"}
+	node.Content.TypeName = "Issue"
+	node.Content.Issue.Title = "Test Issue"
+	milestone := FieldValueNode{TypeName: "ProjectV2ItemFieldMilestoneValue", Milestone: MilestoneFieldValue{Field: FieldRef{Common: FieldCommon{Name: "Milestone"}}}}
+	milestone.Milestone.Milestone.Title = "v2.0"
+	milestone.Milestone.Milestone.DueOn = "2024-03-01T08:00:00Z"
+	node.FieldValues.Nodes = []FieldValueNode{
+		{TypeName: "ProjectV2ItemFieldDateValue", DateValue: DateFieldValue{Date: "2024-02-01", Field: FieldRef{Common: FieldCommon{Name: "Start"}}}},
+		milestone,
+	}
+
+	item := convertItem(node, "Start", "End")
+	assert.Equal(t, "v2.0", item.Attributes["Milestone"])
+	assert.Equal(t, "2024-03-01", item.Attributes["Milestone"+MilestoneDueSuffix])
+	assert.True(t, item.DateSpan.End.IsZero())
+
+	item = convertItem(node, "Start", MilestoneFieldRef)
+	assert.Equal(t, types.MustNewDateSpan("2024-02-01", "2024-03-01"), item.DateSpan)
+
+	// Milestones without due date only record the title
+	node.FieldValues.Nodes[1].Milestone.Milestone.DueOn = ""
+	item = convertItem(node, "Start", MilestoneFieldRef)
+	assert.NotContains(t, item.Attributes, "Milestone"+MilestoneDueSuffix)
+	assert.True(t, item.DateSpan.End.IsZero())
+}
+
+func TestConvertItemState(t *testing.T) {
+	issue := ProjectItemNode{ID: "item1"}
+	issue.Content.TypeName = "Issue"
+	issue.Content.Issue.State = "CLOSED"
+	issue.Content.Issue.URL = "https://github.com/org/repo/issues/12"
+	issue.Content.Issue.Number = 12
+	issue.Content.Issue.Repository.NameWithOwner = "org/repo"
+	item := convertItem(issue, "Start", "End")
+	assert.Equal(t, "https://github.com/org/repo/issues/12", item.URL)
+	assert.Equal(t, 12, item.Number)
+	assert.Equal(t, "org/repo", item.Repository)
+	assert.Equal(t, "org/repo#12", item.Reference())
+	assert.Equal(t, types.StateClosed, item.GetState())
+	assert.True(t, item.IsClosed())
+
+	pr := ProjectItemNode{ID: "item2"}
+	pr.Content.TypeName = "PullRequest"
+	pr.Content.PullRequest.State = "MERGED"
+	item = convertItem(pr, "Start", "End")
+	assert.Equal(t, types.StateMerged, item.GetState())
+	assert.True(t, item.IsClosed())
+
+	pr.Content.PullRequest.State = "OPEN"
+	item = convertItem(pr, "Start", "End")
+	assert.Equal(t, types.StateOpen, item.GetState())
+	assert.False(t, item.IsClosed())
+
+	// Draft issues have no state
+	draft := ProjectItemNode{ID: "item3"}
+	draft.Content.TypeName = "DraftIssue"
+	item = convertItem(draft, "Start", "End")
+	assert.NotContains(t, item.Attributes, types.StateAttribute)
+	assert.False(t, item.IsClosed())
+}
+
+func TestConvertItemRestricted(t *testing.T) {
+	node := ProjectItemNode{ID: "item1", Type: "REDACTED"}
+	node.FieldValues.Nodes = []FieldValueNode{
+		{TypeName: "ProjectV2ItemFieldDateValue", DateValue: DateFieldValue{Date: "2024-01-01", Field: FieldRef{Common: FieldCommon{Name: "Start"}}}},
+		{TypeName: "ProjectV2ItemFieldSingleSelectValue", SingleSelect: SingleSelectFieldValue{Name: "Todo", Field: FieldRef{Common: FieldCommon{Name: "Status"}}}},
+	}
+
+	item := convertItem(node, "Start", "End")
+
+	assert.True(t, item.IsRestricted())
+	assert.NotContains(t, item.Attributes, "Title")
+	assert.Equal(t, types.RestrictedTitle, item.GetTitle())
+	assert.Equal(t, "Todo", item.Attributes["Status"])
+	assert.Equal(t, "2024-01-01", item.DateSpan.Start.Format("2006-01-02"))
+}
+
+func TestViewerLogin(t *testing.T) {
+	var query ViewerLoginQuery
+	query.Viewer.Login = "octocat"
+
+	client := NewClientWithExecutor(githubtest.NewExecutor(githubtest.Respond(query)))
+	login, err := client.ViewerLogin()
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", login)
+
+	client = NewClientWithExecutor(githubtest.NewExecutor(githubtest.Fail(errors.New("bad credentials"))))
+	_, err = client.ViewerLogin()
+	assert.ErrorContains(t, err, "bad credentials")
+}
+
+func TestCreateStatusUpdate(t *testing.T) {
+	var mutation CreateStatusUpdateMutation
+	mutation.CreateProjectV2StatusUpdate.StatusUpdate.ID = "PVTSU_1"
+
+	executor := githubtest.NewExecutor(githubtest.Respond(mutation))
+	client := NewClientWithExecutor(executor)
+	id, err := client.CreateStatusUpdate("PVT_123", "AT_RISK", "2 delayed")
+	require.NoError(t, err)
+	assert.Equal(t, "PVTSU_1", id)
+
+	call := executor.Calls()[0]
+	assert.True(t, call.Mutation)
+	input := call.Variables["input"].(CreateProjectV2StatusUpdateInput)
+	assert.Equal(t, graphql.ID("PVT_123"), input.ProjectID)
+	assert.Equal(t, ProjectV2StatusUpdateStatus("AT_RISK"), *input.Status)
+	assert.Equal(t, graphql.String("2 delayed"), *input.Body)
+
+	client = NewClientWithExecutor(githubtest.NewExecutor(githubtest.Fail(errors.New("insufficient scopes"))))
+	_, err = client.CreateStatusUpdate("PVT_123", "AT_RISK", "2 delayed")
+	assert.ErrorContains(t, err, "failed to create status update: insufficient scopes")
+}
+
+func TestFetchProjectStateEmptyProject(t *testing.T) {
+	var lookup ViewerProjectQuery
+	lookup.Viewer.ProjectV2.ID = "PVT_123"
+
+	t.Run("zero items saves an empty state with a warning", func(t *testing.T) {
+		var items ProjectItemsQuery
+		items.Node.TypeName = "ProjectV2"
+
+		client := NewClientWithExecutor(githubtest.NewExecutor(
+			githubtest.Respond(lookup),
+			githubtest.Respond(items),
+		))
+
+		state, err := client.FetchProjectState(123, "", "Start", "End")
+		require.NoError(t, err)
+		assert.Empty(t, state.Items)
+		assert.Equal(t, []string{"project 123 has no items"}, state.Warnings)
+	})
+
+	t.Run("node is not a project", func(t *testing.T) {
+		var items ProjectItemsQuery
+		items.Node.TypeName = "Issue"
+
+		client := NewClientWithExecutor(githubtest.NewExecutor(
+			githubtest.Respond(lookup),
+			githubtest.Respond(items),
+		))
+
+		_, err := client.FetchProjectState(123, "", "Start", "End")
+		assert.ErrorContains(t, err, "node PVT_123 is not a ProjectV2 (got Issue)")
+	})
+
+	t.Run("node not found", func(t *testing.T) {
+		client := NewClientWithExecutor(githubtest.NewExecutor(
+			githubtest.Respond(lookup),
+			githubtest.Respond(ProjectItemsQuery{}),
+		))
+
+		_, err := client.FetchProjectState(123, "", "Start", "End")
+		assert.ErrorContains(t, err, "project node PVT_123 not found")
+	})
+}
+
+func TestLatestComment(t *testing.T) {
+	var query ItemCommentQuery
+	query.Node.ProjectV2Item.Content.Issue.Comments.Nodes = []CommentNode{
+		{CreatedAt: "2024-01-20T10:00:00Z", Body: "Blocked on review"},
+	}
+	query.Node.ProjectV2Item.Content.Issue.Comments.Nodes[0].Author.Login = "alice"
+
+	executor := githubtest.NewExecutor(
+		githubtest.Respond(query),
+		githubtest.Respond(ItemCommentQuery{}),
+	)
+	client := NewClientWithExecutor(executor)
+
+	comment, err := client.LatestComment("item1")
+	require.NoError(t, err)
+	require.NotNil(t, comment)
+	assert.Equal(t, types.Comment{
+		Author:    "alice",
+		CreatedAt: time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC),
+		Body:      "Blocked on review",
+	}, *comment)
+	assert.Equal(t, graphql.ID("item1"), executor.Calls()[0].Variables["id"])
+
+	comment, err = client.LatestComment("draft1")
+	require.NoError(t, err)
+	assert.Nil(t, comment)
+}
+
+func TestFetchProjectStateCompletesConnections(t *testing.T) {
+	var lookup ViewerProjectQuery
+	lookup.Viewer.ProjectV2.ID = "PVT_123"
+
+	// The first page of an item's connections is truncated
+	var items ProjectItemsQuery
+	items.Node.TypeName = "ProjectV2"
+	node := ProjectItemNode{ID: "item1"}
+	node.Content.TypeName = "Issue"
+	node.Content.Issue.ID = "I_1"
+	node.Content.Issue.Title = "Busy Issue"
+	node.FieldValues.PageInfo = PageInfo{HasNextPage: true, EndCursor: "fv-1"}
+	node.FieldValues.Nodes = []FieldValueNode{
+		{TypeName: "ProjectV2ItemFieldSingleSelectValue", SingleSelect: SingleSelectFieldValue{Name: "Todo", Field: FieldRef{Common: FieldCommon{Name: "Status"}}}},
+	}
+	node.Content.Issue.Assignees.PageInfo = PageInfo{HasNextPage: true, EndCursor: "as-1"}
+	node.Content.Issue.Assignees.Nodes = []User{{Login: "alice"}}
+	node.Content.Issue.BlockedBy.Nodes = []IssueRef{{ID: "I_2"}}
+	node.Content.Issue.Labels.PageInfo = PageInfo{HasNextPage: true, EndCursor: "lb-1"}
+	node.Content.Issue.Labels.Nodes = []Label{{Name: "bug"}}
+	items.Node.ProjectV2.Items.Nodes = []ProjectItemNode{node}
+
+	var fieldValues ItemFieldValuesQuery
+	fieldValues.Node.ProjectV2Item.FieldValues.Nodes = []FieldValueNode{
+		{TypeName: "ProjectV2ItemFieldTextValue", TextValue: TextFieldValue{Text: "Late field", Field: FieldRef{Common: FieldCommon{Name: "Notes"}}}},
+	}
+	var assigneesPage1, assigneesPage2 AssigneesQuery
+	assigneesPage1.Node.Assignable.Assignees = UserConnection{
+		PageInfo: PageInfo{HasNextPage: true, EndCursor: "as-2"},
+		Nodes:    []User{{Login: "bob"}},
+	}
+	assigneesPage2.Node.Assignable.Assignees.Nodes = []User{{Login: "carol"}}
+	var labels LabelsQuery
+	labels.Node.Labelable.Labels.Nodes = []Label{{Name: "scope-change"}}
+
+	executor := githubtest.NewExecutor(
+		githubtest.Respond(lookup),
+		githubtest.Respond(items),
+		githubtest.Respond(fieldValues),
+		githubtest.Respond(assigneesPage1),
+		githubtest.Respond(assigneesPage2),
+		githubtest.Respond(labels),
+	)
+	client := NewClientWithExecutor(executor)
+
+	state, err := client.FetchProjectState(123, "", "Start", "End")
+	require.NoError(t, err)
+	require.Len(t, state.Items, 1)
+	item := state.Items[0]
+	assert.Equal(t, "Todo", item.Attributes["Status"])
+	assert.Equal(t, "Late field", item.Attributes["Notes"])
+	assert.Equal(t, []string{"@alice", "@bob", "@carol"}, item.Attributes[types.AssigneesAttribute])
+	assert.Equal(t, []string{"I_2"}, item.BlockedBy)
+	assert.Equal(t, []string{"bug", "scope-change"}, item.Labels)
+
+	calls := executor.Calls()
+	require.Len(t, calls, 6, "blocking issues fit into the first page")
+	assert.Equal(t, graphql.ID("item1"), calls[2].Variables["id"])
+	assert.Equal(t, graphql.String("fv-1"), *calls[2].Variables["cursor"].(*graphql.String))
+	assert.Equal(t, graphql.ID("I_1"), calls[3].Variables["id"])
+	assert.Equal(t, graphql.String("as-1"), *calls[3].Variables["cursor"].(*graphql.String))
+	assert.Equal(t, graphql.String("as-2"), *calls[4].Variables["cursor"].(*graphql.String))
+	assert.Equal(t, graphql.String("lb-1"), *calls[5].Variables["cursor"].(*graphql.String))
+}
+
+func TestFetchProjectStateCompletesUserFields(t *testing.T) {
+	var lookup ViewerProjectQuery
+	lookup.Viewer.ProjectV2.ID = "PVT_123"
+
+	// A user field has more users than fit into the first page
+	var items ProjectItemsQuery
+	items.Node.TypeName = "ProjectV2"
+	node := ProjectItemNode{ID: "item1"}
+	node.Content.TypeName = "DraftIssue"
+	node.Content.DraftIssue.Title = "Crowded Draft"
+	owners := FieldValueNode{TypeName: "ProjectV2ItemFieldUserValue"}
+	owners.UserValue.Field.Common.Name = "Reviewers"
+	owners.UserValue.Users = UserConnection{
+		PageInfo: PageInfo{HasNextPage: true, EndCursor: "us-1"},
+		Nodes:    []User{{Login: "alice"}},
+	}
+	node.FieldValues.Nodes = []FieldValueNode{owners}
+	items.Node.ProjectV2.Items.Nodes = []ProjectItemNode{node}
+
+	var users ItemUserFieldQuery
+	users.Node.ProjectV2Item.FieldValueByName.UserValue.Users.Nodes = []User{{Login: "bob"}}
+
+	executor := githubtest.NewExecutor(
+		githubtest.Respond(lookup),
+		githubtest.Respond(items),
+		githubtest.Respond(users),
+	)
+	client := NewClientWithExecutor(executor)
+
+	state, err := client.FetchProjectState(123, "", "Start", "End")
+	require.NoError(t, err)
+	require.Len(t, state.Items, 1)
+	assert.Equal(t, []string{"@alice", "@bob"}, state.Items[0].Attributes["Reviewers"])
+
+	calls := executor.Calls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, graphql.ID("item1"), calls[2].Variables["id"])
+	assert.Equal(t, graphql.String("Reviewers"), calls[2].Variables["name"])
+	assert.Equal(t, graphql.String("us-1"), *calls[2].Variables["cursor"].(*graphql.String))
+}
@@ -0,0 +1,12 @@
+package github
+
+import (
+	"context"
+)
+
+// Executor executes GraphQL queries and mutations. The query or mutation is a
+// pointer to one of the structs in this package which gets populated with the response.
+type Executor interface {
+	Query(ctx context.Context, q interface{}, variables map[string]interface{}) error
+	Mutate(ctx context.Context, m interface{}, variables map[string]interface{}) error
+}
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// ItemListExport is the JSON written by `gh project item-list --format json`
+type ItemListExport struct {
+	Items      []map[string]interface{} `json:"items"`
+	TotalCount int                      `json:"totalCount"`
+}
+
+// exportContent is the content of an exported item
+type exportContent struct {
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Number     int    `json:"number"`
+	Repository string `json:"repository"`
+}
+
+// exportBuiltinKeys are keys of exported items that are not project fields or are
+// converted separately
+var exportBuiltinKeys = map[string]bool{
+	"id":                   true,
+	"title":                true,
+	"content":              true,
+	"assignees":            true,
+	"labels":               true,
+	"repository":           true,
+	"linked pull requests": true,
+	"reviewers":            true,
+}
+
+// ParseItemListExport converts the JSON export of `gh project item-list --format json`
+// into a project state without a timestamp. Field names are exported with their first
+// letter lowercased, so it is uppercased again to match captured states, and the start
+// and end fields are matched ignoring case. The export has no creation and update
+// times of items.
+func ParseItemListExport(data []byte, startField, endField string) (*types.ProjectState, error) {
+	var export ItemListExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse gh project item-list export: %w", err)
+	}
+	if export.Items == nil {
+		return nil, fmt.Errorf("failed to parse gh project item-list export: no items list found")
+	}
+
+	state := &types.ProjectState{Items: make([]types.Item, 0, len(export.Items))}
+	for i, raw := range export.Items {
+		item, err := convertExportItem(raw, startField, endField)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert exported item %d: %w", i+1, err)
+		}
+		state.Items = append(state.Items, item)
+	}
+
+	// gh exports 30 items unless --limit is given
+	if export.TotalCount > len(export.Items) {
+		state.Warnings = append(state.Warnings, fmt.Sprintf(
+			"the export contains %d of %d items; export again with gh project item-list --limit %d",
+			len(export.Items), export.TotalCount, export.TotalCount))
+	}
+	return state, nil
+}
+
+// convertExportItem converts one exported item
+func convertExportItem(raw map[string]interface{}, startField, endField string) (types.Item, error) {
+	id, _ := raw["id"].(string)
+	if id == "" {
+		return types.Item{}, fmt.Errorf("item has no id")
+	}
+
+	var content exportContent
+	if data, err := json.Marshal(raw["content"]); err == nil {
+		json.Unmarshal(data, &content)
+	}
+
+	title := content.Title
+	if title == "" {
+		title, _ = raw["title"].(string)
+	}
+
+	labels := exportStrings(raw["labels"])
+	sort.Strings(labels)
+
+	item := types.Item{
+		ID:          id,
+		ContentType: content.Type,
+		URL:         content.URL,
+		Number:      content.Number,
+		Repository:  content.Repository,
+		Labels:      labels,
+	}
+	item.SetAttribute("Title", title, types.ProvenanceContent)
+
+	var assignees []string
+	for _, login := range exportStrings(raw["assignees"]) {
+		assignees = append(assignees, types.Mention(login))
+	}
+	if len(assignees) > 0 {
+		sort.Strings(assignees)
+		item.SetAttribute(types.AssigneesAttribute, assignees, types.ProvenanceContent)
+	}
+
+	// Convert the remaining keys in a stable order
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		if !exportBuiltinKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var iterationEnd time.Time
+	for _, key := range keys {
+		name := exportFieldName(key)
+		iteration, isIteration := exportIteration(name, raw[key])
+		milestone, isMilestone := exportMilestone(name, raw[key])
+		switch {
+		case isIteration:
+			if end := iteration.apply(&item, startField, endField); isIterationRef(startField, name) {
+				iterationEnd = end
+			}
+		case isMilestone:
+			milestone.apply(&item, endField)
+		case strings.EqualFold(name, startField), strings.EqualFold(name, endField):
+			value, _ := raw[key].(string)
+			date, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return types.Item{}, fmt.Errorf("invalid date in field %s: %q", name, value)
+			}
+			if strings.EqualFold(name, startField) {
+				item.DateSpan.Start = date
+			} else {
+				item.DateSpan.End = date
+			}
+		default:
+			if value := exportValue(raw[key]); value != nil {
+				item.SetAttribute(name, value, types.ProvenanceField)
+			}
+		}
+	}
+
+	// Items starting with their iteration end with it, unless an end date is set
+	if item.DateSpan.End.IsZero() {
+		item.DateSpan.End = iterationEnd
+	}
+	return item, nil
+}
+
+// exportMilestone returns the milestone of an exported milestone field value, which
+// has a title, description and due date
+func exportMilestone(name string, v interface{}) (milestoneValue, bool) {
+	value, ok := v.(map[string]interface{})
+	if !ok {
+		return milestoneValue{}, false
+	}
+	title, hasTitle := value["title"].(string)
+	dueOn, hasDueOn := value["dueOn"].(string)
+	if !hasTitle || !hasDueOn {
+		return milestoneValue{}, false
+	}
+	return milestoneValue{field: name, title: title, dueOn: dueOn}, true
+}
+
+// exportIteration returns the iteration of an exported iteration field value, which
+// has a title, start date and duration
+func exportIteration(name string, v interface{}) (iterationValue, bool) {
+	value, ok := v.(map[string]interface{})
+	if !ok {
+		return iterationValue{}, false
+	}
+	title, _ := value["title"].(string)
+	startDate, hasStart := value["startDate"].(string)
+	duration, hasDuration := value["duration"].(float64)
+	if !hasStart || !hasDuration {
+		return iterationValue{}, false
+	}
+	return iterationValue{field: name, title: title, startDate: startDate, duration: duration}, true
+}
+
+// exportFieldName restores the field name of an export key, which gh writes with its
+// first letter lowercased
+func exportFieldName(key string) string {
+	r, size := utf8.DecodeRuneInString(key)
+	return string(unicode.ToUpper(r)) + key[size:]
+}
+
+// exportValue converts an exported field value to an attribute value like the ones
+// of captured states: numbers stay numbers, lists of users or names are joined, and
+// milestones and iterations are represented by their title
+func exportValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case string, float64, bool:
+		return value
+	case []interface{}:
+		if names := exportStrings(value); len(names) > 0 {
+			return strings.Join(names, ", ")
+		}
+	case map[string]interface{}:
+		if title, ok := value["title"].(string); ok {
+			return title
+		}
+	}
+	return nil
+}
+
+// exportStrings returns the strings of an exported list
+func exportStrings(v interface{}) []string {
+	list, _ := v.([]interface{})
+	var values []string
+	for _, entry := range list {
+		if s, ok := entry.(string); ok && s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
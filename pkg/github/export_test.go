@@ -0,0 +1,115 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const itemListExport = `{
+  "items": [
+    {
+      "assignees": ["alice", "bob"],
+      "content": {
+        "body": "Details",
+        "number": 1,
+        "repository": "org/repo",
+        "title": "Build API",
+        "type": "Issue",
+        "url": "https://github.com/org/repo/issues/1"
+      },
+      "id": "PVTI_1",
+      "labels": ["bug"],
+      "repository": "https://github.com/org/repo",
+      "status": "In Progress",
+      "start": "2024-01-01",
+      "end": "2024-01-10",
+      "estimate": 3,
+      "milestone": {"title": "v1", "description": "", "dueOn": ""},
+      "sprint": {"title": "Sprint 1", "startDate": "2024-01-01", "duration": 14},
+      "title": "Build API"
+    },
+    {
+      "content": {"body": "", "title": "Write docs", "type": "DraftIssue"},
+      "id": "PVTI_2",
+      "status": "Todo",
+      "title": "Write docs"
+    }
+  ],
+  "totalCount": 5
+}`
+
+func TestParseItemListExport(t *testing.T) {
+	state, err := ParseItemListExport([]byte(itemListExport), "Start", "End")
+	require.NoError(t, err)
+	require.Len(t, state.Items, 2)
+
+	item := state.Items[0]
+	assert.Equal(t, "PVTI_1", item.ID)
+	assert.Equal(t, "Build API", item.GetTitle())
+	assert.Equal(t, types.ContentTypeIssue, item.ContentType)
+	assert.Equal(t, "https://github.com/org/repo/issues/1", item.URL)
+	assert.Equal(t, 1, item.Number)
+	assert.Equal(t, "org/repo#1", item.Reference())
+	assert.Equal(t, []string{"bug"}, item.Labels)
+	assert.Equal(t, types.MustNewDateSpan("2024-01-01", "2024-01-10"), item.DateSpan)
+	assert.Equal(t, map[string]interface{}{
+		"Title":                  "Build API",
+		types.AssigneesAttribute: []string{"@alice", "@bob"},
+		"Status":                 "In Progress",
+		"Estimate":               float64(3),
+		"Milestone":              "v1",
+		"Sprint":                 "Sprint 1",
+		"Sprint Start":           "2024-01-01",
+		"Sprint Duration":        float64(14),
+	}, item.Attributes)
+
+	draft := state.Items[1]
+	assert.Equal(t, "Write docs", draft.GetTitle())
+	assert.Equal(t, types.ContentTypeDraftIssue, draft.ContentType)
+	assert.True(t, draft.DateSpan.Start.IsZero())
+
+	assert.Equal(t, []string{"the export contains 2 of 5 items; export again with gh project item-list --limit 5"}, state.Warnings)
+}
+
+func TestParseItemListExportIterationDates(t *testing.T) {
+	state, err := ParseItemListExport([]byte(itemListExport), IterationFieldRef, "End")
+	require.NoError(t, err)
+	// The end date field takes precedence over the end of the iteration
+	assert.Equal(t, types.MustNewDateSpan("2024-01-01", "2024-01-10"), state.Items[0].DateSpan)
+
+	state, err = ParseItemListExport([]byte(itemListExport), "@Sprint", "Due")
+	require.NoError(t, err)
+	assert.Equal(t, types.MustNewDateSpan("2024-01-01", "2024-01-14"), state.Items[0].DateSpan)
+	assert.Equal(t, "2024-01-10", state.Items[0].Attributes["End"])
+}
+
+func TestParseItemListExportMilestoneDueDate(t *testing.T) {
+	data := `{"items": [{"id": "PVTI_1", "title": "Ship", "start": "2024-02-01", "milestone": {"title": "v2.0", "description": "", "dueOn": "2024-03-01T00:00:00Z"}}]}`
+	state, err := ParseItemListExport([]byte(data), "Start", MilestoneFieldRef)
+	require.NoError(t, err)
+	assert.Equal(t, types.MustNewDateSpan("2024-02-01", "2024-03-01"), state.Items[0].DateSpan)
+	assert.Equal(t, "v2.0", state.Items[0].Attributes["Milestone"])
+	assert.Equal(t, "2024-03-01", state.Items[0].Attributes["Milestone Due"])
+}
+
+func TestParseItemListExportErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "invalid JSON", data: `{`},
+		{name: "no items", data: `{"totalCount": 0}`},
+		{name: "missing id", data: `{"items": [{"title": "Task"}]}`},
+		{name: "invalid date", data: `{"items": [{"id": "PVTI_1", "start": "soon"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseItemListExport([]byte(tt.data), "Start", "End")
+			assert.Error(t, err)
+		})
+	}
+}
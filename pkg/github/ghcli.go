@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ghAuthTimeout limits how long the gh CLI may take to print a token
+const ghAuthTimeout = 10 * time.Second
+
+// ghAuthToken returns the token the gh CLI is logged in with for a host. It is a
+// variable to allow tests to replace the gh CLI.
+var ghAuthToken = func(hostname string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ghAuthTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "gh", "auth", "token", "--hostname", hostname).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run gh auth token: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// HostName returns the host name the gh CLI knows a GitHub host by. The host can be
+// given in any form accepted by GraphQLURL; API subdomains map to their host.
+func HostName(host string) string {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return DefaultHost
+	}
+	raw := host
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return host
+	}
+
+	name := strings.ToLower(u.Hostname())
+	switch {
+	case name == "api.github.com":
+		return DefaultHost
+	case strings.HasSuffix(name, ".ghe.com"):
+		return strings.TrimPrefix(name, "api.")
+	}
+	return name
+}
+
+// ghConfigDir returns the configuration directory of the gh CLI, looking up
+// environment variables with getenv
+func ghConfigDir(getenv func(string) string) string {
+	if dir := getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if dir := getenv("AppData"); dir != "" {
+		return filepath.Join(dir, "GitHub CLI")
+	}
+	if home := getenv("HOME"); home != "" {
+		return filepath.Join(home, ".config", "gh")
+	}
+	return ""
+}
+
+// ghConfigToken reads the token of a host from the hosts.yml file of the gh CLI.
+// Newer gh versions keep tokens in the system keyring instead, which only
+// gh auth token can read. It returns the token and the path of the file.
+func ghConfigToken(hostname string, getenv func(string) string) (string, string, error) {
+	dir := ghConfigDir(getenv)
+	if dir == "" {
+		return "", "", nil
+	}
+	path := filepath.Join(dir, "hosts.yml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("failed to read gh config: %w", err)
+	}
+
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return "", "", fmt.Errorf("failed to parse gh config %s: %w", path, err)
+	}
+	return strings.TrimSpace(hosts[hostname].OAuthToken), path, nil
+}
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubGHAuthToken replaces the gh CLI with tokens by host name for the test
+func stubGHAuthToken(t *testing.T, tokens map[string]string) {
+	original := ghAuthToken
+	ghAuthToken = func(hostname string) (string, error) {
+		if token, ok := tokens[hostname]; ok {
+			return token, nil
+		}
+		return "", errors.New("gh: not logged in")
+	}
+	t.Cleanup(func() { ghAuthToken = original })
+}
+
+func TestHostName(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "", want: "github.com"},
+		{host: "github.com", want: "github.com"},
+		{host: "https://api.github.com/graphql", want: "github.com"},
+		{host: "GitHub.MyCorp.com", want: "github.mycorp.com"},
+		{host: "https://github.mycorp.com/api/graphql", want: "github.mycorp.com"},
+		{host: "octocorp.ghe.com", want: "octocorp.ghe.com"},
+		{host: "https://api.octocorp.ghe.com/graphql", want: "octocorp.ghe.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			assert.Equal(t, tt.want, HostName(tt.host))
+		})
+	}
+}
+
+func TestResolveTokenGHCLI(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	t.Run("gh auth token", func(t *testing.T) {
+		stubGHAuthToken(t, map[string]string{"github.com": "gho_cli", "github.mycorp.com": "gho_ghes"})
+
+		token, source, err := ResolveToken("", "", env(nil))
+		require.NoError(t, err)
+		assert.Equal(t, "gho_cli", token)
+		assert.Equal(t, "gh auth token", source)
+
+		token, _, err = ResolveToken("", "https://github.mycorp.com/api/graphql", env(nil))
+		require.NoError(t, err)
+		assert.Equal(t, "gho_ghes", token)
+
+		// Environment variables take precedence
+		token, _, err = ResolveToken("", "", env(map[string]string{"GH_TOKEN": "ghp_env"}))
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_env", token)
+	})
+
+	t.Run("enterprise environment variables", func(t *testing.T) {
+		stubGHAuthToken(t, nil)
+		vars := env(map[string]string{"GITHUB_TOKEN": "ghp_dotcom", "GH_ENTERPRISE_TOKEN": "ghp_ghes"})
+
+		token, source, err := ResolveToken("", "github.mycorp.com", vars)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_ghes", token)
+		assert.Equal(t, "GH_ENTERPRISE_TOKEN", source)
+
+		token, _, err = ResolveToken("", "", vars)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_dotcom", token)
+	})
+
+	t.Run("hosts.yml", func(t *testing.T) {
+		stubGHAuthToken(t, nil)
+		dir := t.TempDir()
+		path := filepath.Join(dir, "gh", "hosts.yml")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte("github.com:\n    user: octocat\n    oauth_token: gho_file\n    git_protocol: https\n"), 0600))
+
+		token, source, err := ResolveToken("", "", env(map[string]string{"XDG_CONFIG_HOME": dir}))
+		require.NoError(t, err)
+		assert.Equal(t, "gho_file", token)
+		assert.Equal(t, path, source)
+
+		token, _, err = ResolveToken("", "", env(map[string]string{"GH_CONFIG_DIR": filepath.Dir(path)}))
+		require.NoError(t, err)
+		assert.Equal(t, "gho_file", token)
+
+		// Hosts gh isn't logged in to
+		_, _, err = ResolveToken("", "github.mycorp.com", env(map[string]string{"XDG_CONFIG_HOME": dir}))
+		assert.ErrorContains(t, err, "gh auth login --hostname github.mycorp.com")
+	})
+
+	t.Run("invalid hosts.yml", func(t *testing.T) {
+		stubGHAuthToken(t, nil)
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte("github.com: [unclosed"), 0600))
+
+		_, _, err := ResolveToken("", "", env(map[string]string{"GH_CONFIG_DIR": dir}))
+		assert.ErrorContains(t, err, "failed to parse gh config")
+	})
+}
@@ -0,0 +1,92 @@
+// Package githubtest provides test doubles for the github package.
+package githubtest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Handler answers a single query. It receives the pointer to the query struct
+// that should be populated and the variables the query was executed with.
+type Handler func(q interface{}, variables map[string]interface{}) error
+
+// Call records a query or mutation executed against the fake executor
+type Call struct {
+	Query     interface{}
+	Variables map[string]interface{}
+	Mutation  bool
+}
+
+// Executor is a fake github.Executor that answers queries and mutations with handlers in the order they were registered
+type Executor struct {
+	mu       sync.Mutex
+	handlers []Handler
+	calls    []Call
+}
+
+// NewExecutor creates a new fake executor answering queries with the given handlers
+func NewExecutor(handlers ...Handler) *Executor {
+	return &Executor{handlers: handlers}
+}
+
+// Query implements github.Executor
+func (e *Executor) Query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	return e.execute(Call{Query: q, Variables: variables})
+}
+
+// Mutate implements github.Executor. Mutations are answered by the same handlers as queries.
+func (e *Executor) Mutate(ctx context.Context, m interface{}, variables map[string]interface{}) error {
+	return e.execute(Call{Query: m, Variables: variables, Mutation: true})
+}
+
+// execute records the call and answers it with the next handler
+func (e *Executor) execute(call Call) error {
+	e.mu.Lock()
+	index := len(e.calls)
+	e.calls = append(e.calls, call)
+	var handler Handler
+	if index < len(e.handlers) {
+		handler = e.handlers[index]
+	}
+	e.mu.Unlock()
+
+	if handler == nil {
+		return fmt.Errorf("unexpected query %d: no handler registered", index+1)
+	}
+	return handler(call.Query, call.Variables)
+}
+
+// Calls returns all queries executed so far
+func (e *Executor) Calls() []Call {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Call(nil), e.calls...)
+}
+
+// Respond returns a handler that populates the query with the given response.
+// The response must be of the same type as the query struct.
+func Respond(response interface{}) Handler {
+	return func(q interface{}, variables map[string]interface{}) error {
+		target := reflect.ValueOf(q)
+		if target.Kind() != reflect.Ptr || target.IsNil() {
+			return fmt.Errorf("query must be a non-nil pointer, got %T", q)
+		}
+
+		value := reflect.ValueOf(response)
+		if value.Type() != target.Elem().Type() {
+			return fmt.Errorf("response of type %s does not match query of type %s", value.Type(), target.Elem().Type())
+		}
+
+		target.Elem().Set(value)
+		return nil
+	}
+}
+
+// Fail returns a handler that fails the query with the given error
+func Fail(err error) Handler {
+	return func(q interface{}, variables map[string]interface{}) error {
+		return err
+	}
+}
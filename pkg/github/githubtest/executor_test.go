@@ -0,0 +1,52 @@
+package githubtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeQuery struct {
+	Value string
+}
+
+func TestExecutor(t *testing.T) {
+	executor := NewExecutor(
+		Respond(fakeQuery{Value: "first"}),
+		Fail(errors.New("second failed")),
+	)
+
+	var q fakeQuery
+	err := executor.Query(context.Background(), &q, map[string]interface{}{"id": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "first", q.Value)
+
+	err = executor.Query(context.Background(), &q, nil)
+	assert.EqualError(t, err, "second failed")
+
+	err = executor.Query(context.Background(), &q, nil)
+	assert.ErrorContains(t, err, "no handler registered")
+
+	calls := executor.Calls()
+	assert.Len(t, calls, 3)
+	assert.Equal(t, "1", calls[0].Variables["id"])
+	assert.False(t, calls[0].Mutation)
+}
+
+func TestExecutorMutate(t *testing.T) {
+	executor := NewExecutor(Respond(fakeQuery{Value: "created"}))
+
+	var m fakeQuery
+	err := executor.Mutate(context.Background(), &m, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "created", m.Value)
+	assert.True(t, executor.Calls()[0].Mutation)
+}
+
+func TestRespondTypeMismatch(t *testing.T) {
+	var q fakeQuery
+	err := Respond("not a query")(&q, nil)
+	assert.ErrorContains(t, err, "does not match")
+}
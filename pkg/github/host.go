@@ -0,0 +1,51 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultHost is the host of github.com
+const DefaultHost = "github.com"
+
+// GraphQLURL returns the GraphQL endpoint of a GitHub host. The host can be a host
+// name such as github.mycorp.com, a URL of the host, or the full URL of the endpoint.
+// GitHub Enterprise Server serves the API below /api/graphql, GitHub Enterprise Cloud
+// with data residency (*.ghe.com) on the api subdomain.
+func GraphQLURL(host string) (string, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return DefaultBaseURL, nil
+	}
+
+	raw := host
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid GitHub host: %s", host)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("invalid GitHub host: %s (must be a host name or an http(s) URL)", host)
+	}
+
+	// A full endpoint URL is used as is
+	if path := strings.TrimSuffix(u.Path, "/"); path != "" {
+		u.Path = path
+		return u.String(), nil
+	}
+
+	name := strings.ToLower(u.Hostname())
+	switch {
+	case name == DefaultHost || name == "api.github.com":
+		return DefaultBaseURL, nil
+	case strings.HasSuffix(name, ".ghe.com") && !strings.HasPrefix(name, "api."):
+		u.Host = "api." + u.Host
+		u.Path = "/graphql"
+	default:
+		u.Path = "/api/graphql"
+	}
+	return u.String(), nil
+}
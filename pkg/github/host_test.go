@@ -0,0 +1,38 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQLURL(t *testing.T) {
+	tests := []struct {
+		host    string
+		want    string
+		wantErr bool
+	}{
+		{host: "", want: DefaultBaseURL},
+		{host: "github.com", want: DefaultBaseURL},
+		{host: "https://github.com/", want: DefaultBaseURL},
+		{host: "github.mycorp.com", want: "https://github.mycorp.com/api/graphql"},
+		{host: "https://github.mycorp.com", want: "https://github.mycorp.com/api/graphql"},
+		{host: "http://localhost:8080", want: "http://localhost:8080/api/graphql"},
+		{host: "https://github.mycorp.com/api/graphql", want: "https://github.mycorp.com/api/graphql"},
+		{host: "octocorp.ghe.com", want: "https://api.octocorp.ghe.com/graphql"},
+		{host: "ftp://github.mycorp.com", wantErr: true},
+		{host: "https://", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			got, err := GraphQLURL(tt.host)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
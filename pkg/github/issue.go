@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shurcooL/graphql"
+)
+
+// ReportCommentMarker is a hidden marker identifying report comments, so that
+// later runs update the comment instead of adding a new one
+const ReportCommentMarker = "<!-- gh-project-report -->"
+
+// RepoIssue references an issue by repository and number, e.g. owner/repo#123
+type RepoIssue struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// ParseRepoIssue parses an issue reference in owner/repo#123 format
+func ParseRepoIssue(s string) (RepoIssue, error) {
+	repo, number, ok := strings.Cut(s, "#")
+	owner, name, okRepo := strings.Cut(repo, "/")
+	if !ok || !okRepo || owner == "" || name == "" || strings.Contains(name, "/") {
+		return RepoIssue{}, fmt.Errorf("invalid issue reference %q (must be owner/repo#number)", s)
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return RepoIssue{}, fmt.Errorf("invalid issue reference %q (must be owner/repo#number)", s)
+	}
+	return RepoIssue{Owner: owner, Repo: name, Number: n}, nil
+}
+
+// String returns the issue reference in owner/repo#123 format
+func (r RepoIssue) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// PostReportComment posts the body as a comment on the issue and returns the
+// URL of the comment. A report comment of the authenticated user, found by
+// ReportCommentMarker among the latest comments, is updated instead.
+func (c *Client) PostReportComment(ref RepoIssue, body string) (string, error) {
+	var query IssueCommentsQuery
+	if err := c.executor.Query(context.Background(), &query, issueCommentsVariables(ref)); err != nil {
+		return "", fmt.Errorf("failed to query issue %s: %w", ref, err)
+	}
+	issue := query.Repository.Issue
+	if issue.ID == "" {
+		return "", fmt.Errorf("issue %s not found", ref)
+	}
+
+	body = ReportCommentMarker + "\n" + body
+
+	// Update the most recent report comment
+	comments := issue.Comments.Nodes
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+		if !bool(comment.ViewerDidAuthor) || !strings.HasPrefix(string(comment.Body), ReportCommentMarker) {
+			continue
+		}
+
+		var mutation UpdateIssueCommentMutation
+		input := UpdateIssueCommentInput{ID: graphql.ID(string(comment.ID)), Body: graphql.String(body)}
+		if err := c.executor.Mutate(context.Background(), &mutation, map[string]interface{}{"input": input}); err != nil {
+			return "", fmt.Errorf("failed to update comment on issue %s: %w", ref, err)
+		}
+		return string(mutation.UpdateIssueComment.IssueComment.URL), nil
+	}
+
+	var mutation AddCommentMutation
+	input := AddCommentInput{SubjectID: graphql.ID(string(issue.ID)), Body: graphql.String(body)}
+	if err := c.executor.Mutate(context.Background(), &mutation, map[string]interface{}{"input": input}); err != nil {
+		return "", fmt.Errorf("failed to comment on issue %s: %w", ref, err)
+	}
+	return string(mutation.AddComment.CommentEdge.Node.URL), nil
+}
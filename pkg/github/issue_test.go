@@ -0,0 +1,83 @@
+package github
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/github/githubtest"
+	"github.com/shurcooL/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepoIssue(t *testing.T) {
+	issue, err := ParseRepoIssue("octo-org/planning#42")
+	require.NoError(t, err)
+	assert.Equal(t, RepoIssue{Owner: "octo-org", Repo: "planning", Number: 42}, issue)
+	assert.Equal(t, "octo-org/planning#42", issue.String())
+
+	for _, invalid := range []string{"", "planning#42", "octo-org/planning", "octo-org/planning#abc", "/planning#1", "a/b/c#1", "octo-org/planning#0"} {
+		_, err := ParseRepoIssue(invalid)
+		assert.ErrorContains(t, err, "must be owner/repo#number", invalid)
+	}
+}
+
+func TestPostReportComment(t *testing.T) {
+	ref := RepoIssue{Owner: "octo-org", Repo: "planning", Number: 42}
+
+	issueWith := func(comments ...IssueCommentNode) IssueCommentsQuery {
+		var q IssueCommentsQuery
+		q.Repository.Issue.ID = "I_42"
+		q.Repository.Issue.Comments.Nodes = comments
+		return q
+	}
+
+	t.Run("adds a new comment", func(t *testing.T) {
+		var added AddCommentMutation
+		added.AddComment.CommentEdge.Node.URL = "https://github.com/octo-org/planning/issues/42#issuecomment-1"
+
+		// Report comments of other users are left alone
+		executor := githubtest.NewExecutor(
+			githubtest.Respond(issueWith(IssueCommentNode{ID: "IC_other", Body: ReportCommentMarker + "\nold"})),
+			githubtest.Respond(added),
+		)
+		url, err := NewClientWithExecutor(executor).PostReportComment(ref, "report")
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/octo-org/planning/issues/42#issuecomment-1", url)
+
+		calls := executor.Calls()
+		assert.Equal(t, graphql.Int(42), calls[0].Variables["number"])
+		assert.True(t, calls[1].Mutation)
+		assert.Equal(t, AddCommentInput{SubjectID: "I_42", Body: graphql.String(ReportCommentMarker + "\nreport")}, calls[1].Variables["input"])
+	})
+
+	t.Run("updates the previous report comment", func(t *testing.T) {
+		var updated UpdateIssueCommentMutation
+		updated.UpdateIssueComment.IssueComment.URL = "https://github.com/octo-org/planning/issues/42#issuecomment-2"
+
+		executor := githubtest.NewExecutor(
+			githubtest.Respond(issueWith(
+				IssueCommentNode{ID: "IC_1", Body: ReportCommentMarker + "\nfirst", ViewerDidAuthor: true},
+				IssueCommentNode{ID: "IC_2", Body: ReportCommentMarker + "\nsecond", ViewerDidAuthor: true},
+				IssueCommentNode{ID: "IC_3", Body: "Thanks!", ViewerDidAuthor: true},
+			)),
+			githubtest.Respond(updated),
+		)
+		url, err := NewClientWithExecutor(executor).PostReportComment(ref, "report")
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/octo-org/planning/issues/42#issuecomment-2", url)
+		assert.Equal(t, UpdateIssueCommentInput{ID: "IC_2", Body: graphql.String(ReportCommentMarker + "\nreport")}, executor.Calls()[1].Variables["input"])
+	})
+
+	t.Run("issue not found", func(t *testing.T) {
+		executor := githubtest.NewExecutor(githubtest.Respond(IssueCommentsQuery{}))
+		_, err := NewClientWithExecutor(executor).PostReportComment(ref, "report")
+		assert.EqualError(t, err, "issue octo-org/planning#42 not found")
+	})
+
+	t.Run("query fails", func(t *testing.T) {
+		executor := githubtest.NewExecutor(githubtest.Fail(errors.New("Could not resolve to a Repository")))
+		_, err := NewClientWithExecutor(executor).PostReportComment(ref, "report")
+		assert.ErrorContains(t, err, "failed to query issue octo-org/planning#42")
+	})
+}
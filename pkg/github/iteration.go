@@ -0,0 +1,55 @@
+package github
+
+import (
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// IterationFieldRef is the start or end field taking the dates of items from their
+// iteration field. A specific iteration field is referenced as "@" followed by its name.
+const IterationFieldRef = "@iteration"
+
+// Suffixes of the attributes holding the start date and duration of an iteration,
+// e.g. "Sprint Start" and "Sprint Duration" for an iteration field named Sprint
+const (
+	IterationStartSuffix    = " Start"
+	IterationDurationSuffix = " Duration"
+)
+
+// iterationValue is the iteration of an item
+type iterationValue struct {
+	field     string // Name of the iteration field
+	title     string
+	startDate string  // YYYY-MM-DD
+	duration  float64 // Days
+}
+
+// isIterationRef returns true if a start or end field refers to the iteration field
+func isIterationRef(ref, field string) bool {
+	return strings.EqualFold(ref, IterationFieldRef) || strings.EqualFold(ref, "@"+field)
+}
+
+// apply stores the iteration in the attributes of the item: its title under the field
+// name, its start date and duration in days under the suffixed names. If the start or
+// end field refers to the iteration, its first or last day becomes the start or end
+// of the item. It returns the last day of the iteration, zero if the start date is invalid.
+func (v iterationValue) apply(item *types.Item, startField, endField string) time.Time {
+	item.SetAttribute(v.field, v.title, types.ProvenanceField)
+	item.SetAttribute(v.field+IterationStartSuffix, v.startDate, types.ProvenanceDerived)
+	item.SetAttribute(v.field+IterationDurationSuffix, v.duration, types.ProvenanceDerived)
+
+	start, err := time.Parse("2006-01-02", v.startDate)
+	if err != nil || v.duration < 1 {
+		return time.Time{}
+	}
+	end := start.AddDate(0, 0, int(v.duration)-1)
+	if isIterationRef(startField, v.field) {
+		item.DateSpan.Start = start
+	}
+	if isIterationRef(endField, v.field) {
+		item.DateSpan.End = end
+	}
+	return end
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// MilestoneFieldRef is the end field taking the end dates of items from the due
+// dates of their milestones
+const MilestoneFieldRef = "@milestone"
+
+// MilestoneDueSuffix is the suffix of the attribute holding the due date of a
+// milestone, e.g. "Milestone Due" for the milestone field
+const MilestoneDueSuffix = " Due"
+
+// milestoneValue is the milestone of an item
+type milestoneValue struct {
+	field string // Name of the milestone field
+	title string
+	dueOn string // RFC3339 or YYYY-MM-DD, empty if the milestone has no due date
+}
+
+// apply stores the milestone in the attributes of the item: its title under the field
+// name and its due date in YYYY-MM-DD format under the suffixed name. If the end field
+// refers to the milestone, its due date becomes the end of the item.
+func (v milestoneValue) apply(item *types.Item, endField string) {
+	item.SetAttribute(v.field, v.title, types.ProvenanceField)
+
+	due, ok := parseDueOn(v.dueOn)
+	if !ok {
+		return
+	}
+	item.SetAttribute(v.field+MilestoneDueSuffix, due.Format("2006-01-02"), types.ProvenanceDerived)
+	if strings.EqualFold(endField, MilestoneFieldRef) || strings.EqualFold(endField, "@"+v.field) {
+		item.DateSpan.End = due
+	}
+}
+
+// parseDueOn parses the due date of a milestone, which the API returns as midnight
+// UTC or in the time zone of the repository owner; only the date is kept
+func parseDueOn(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), true
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
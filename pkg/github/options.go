@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the GraphQL endpoint of github.com
+	DefaultBaseURL = "https://api.github.com/graphql"
+	// DefaultUserAgent is the user agent sent with every request
+	DefaultUserAgent = "gh-project-report"
+	// MaxPageSize is the largest page size the GitHub API allows for connections
+	MaxPageSize = 100
+)
+
+// RetryPolicy controls how failed queries are retried
+type RetryPolicy struct {
+	MaxAttempts int           // Total number of attempts, 1 disables retries
+	Backoff     time.Duration // Wait before the first retry, doubled for each further retry
+	MaxDelay    time.Duration // Longest wait for a retry, e.g. until a rate limit resets; 0 means no limit
+}
+
+// DefaultRetryPolicy returns the retry policy for long running captures: five
+// attempts starting with a one second backoff, waiting at most five minutes
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxDelay: 5 * time.Minute}
+}
+
+// ClientOptions contains configuration options for the GitHub client
+type ClientOptions struct {
+	BaseURL     string
+	UserAgent   string
+	Timeout     time.Duration // Timeout of a single HTTP request, 0 means no timeout
+	MaxPageSize int           // Number of items fetched per page, between 1 and MaxPageSize
+	RetryPolicy RetryPolicy
+	Logger      *log.Logger // Logs GraphQL requests and responses, nil disables logging
+	TokenKind   TokenKind   // Kind of the token used, adds guidance to permission errors if set
+}
+
+// ClientOption configures the GitHub client
+type ClientOption func(*ClientOptions)
+
+// DefaultClientOptions returns the default client options
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		BaseURL:     DefaultBaseURL,
+		UserAgent:   DefaultUserAgent,
+		MaxPageSize: MaxPageSize,
+		RetryPolicy: RetryPolicy{MaxAttempts: 1},
+	}
+}
+
+// WithBaseURL sets the GraphQL endpoint, e.g. for GitHub Enterprise Server
+func WithBaseURL(url string) ClientOption {
+	return func(o *ClientOptions) {
+		o.BaseURL = url
+	}
+}
+
+// WithUserAgent sets the user agent sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *ClientOptions) {
+		o.UserAgent = userAgent
+	}
+}
+
+// WithTimeout sets the timeout of a single HTTP request
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *ClientOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithMaxPageSize sets the number of items fetched per page, clamped to 1..MaxPageSize
+func WithMaxPageSize(size int) ClientOption {
+	return func(o *ClientOptions) {
+		switch {
+		case size < 1:
+			o.MaxPageSize = 1
+		case size > MaxPageSize:
+			o.MaxPageSize = MaxPageSize
+		default:
+			o.MaxPageSize = size
+		}
+	}
+}
+
+// WithRetryPolicy sets how failed queries are retried
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(o *ClientOptions) {
+		o.RetryPolicy = policy
+	}
+}
+
+// WithLogger logs GraphQL requests and responses to the given logger
+func WithLogger(logger *log.Logger) ClientOption {
+	return func(o *ClientOptions) {
+		o.Logger = logger
+	}
+}
+
+// WithTokenKind adds guidance for the given kind of token to permission errors
+func WithTokenKind(kind TokenKind) ClientOption {
+	return func(o *ClientOptions) {
+		o.TokenKind = kind
+	}
+}
+
+// retryExecutor retries failed queries according to a retry policy
+type retryExecutor struct {
+	executor Executor
+	policy   RetryPolicy
+}
+
+// Query runs the query, retrying failures with exponential backoff
+func (e *retryExecutor) Query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	backoff := e.policy.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = e.executor.Query(ctx, q, variables)
+		if err == nil || attempt >= e.policy.MaxAttempts || errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// Mutate runs the mutation without retries, as mutations are not idempotent
+func (e *retryExecutor) Mutate(ctx context.Context, m interface{}, variables map[string]interface{}) error {
+	return e.executor.Mutate(ctx, m, variables)
+}
@@ -0,0 +1,44 @@
+package github
+
+import (
+	"fmt"
+
+	"github.com/shurcooL/graphql"
+)
+
+// PageFetcher fetches the page of a connection after the cursor, nil for the first page
+type PageFetcher[T any] func(cursor *graphql.String) ([]T, PageInfo, error)
+
+// paginate collects the nodes of all pages of a connection, starting after the
+// given cursor or at the first page if it is nil
+func paginate[T any](after *graphql.String, fetch PageFetcher[T]) ([]T, error) {
+	var nodes []T
+	cursor := after
+	for {
+		page, info, err := fetch(cursor)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, page...)
+
+		if !info.HasNextPage {
+			return nodes, nil
+		}
+
+		// Guard against endless loops if the API doesn't advance the cursor
+		if info.EndCursor == "" || (cursor != nil && info.EndCursor == *cursor) {
+			return nil, fmt.Errorf("pagination did not advance past cursor %q", info.EndCursor)
+		}
+		endCursor := info.EndCursor
+		cursor = &endCursor
+	}
+}
+
+// remainingPages collects the nodes of a nested connection after its first page,
+// which was fetched as part of a larger query, or nothing if it has no more pages
+func remainingPages[T any](first PageInfo, fetch PageFetcher[T]) ([]T, error) {
+	if !first.HasNextPage {
+		return nil, nil
+	}
+	return paginate(&first.EndCursor, fetch)
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/shurcooL/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePages returns a fetcher serving the given pages, where page i is fetched
+// after cursor "i", recording the cursors it was called with
+func fakePages(pages [][]int, cursors *[]string) PageFetcher[int] {
+	return func(cursor *graphql.String) ([]int, PageInfo, error) {
+		index := 0
+		if cursor != nil {
+			*cursors = append(*cursors, string(*cursor))
+			index, _ = strconv.Atoi(string(*cursor))
+		} else {
+			*cursors = append(*cursors, "<nil>")
+		}
+
+		var info PageInfo
+		if index < len(pages)-1 {
+			info = PageInfo{HasNextPage: true, EndCursor: graphql.String(strconv.Itoa(index + 1))}
+		}
+		return pages[index], info, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	var cursors []string
+	nodes, err := paginate(nil, fakePages([][]int{{1, 2}, {3}, {4, 5}}, &cursors))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, nodes)
+	assert.Equal(t, []string{"<nil>", "1", "2"}, cursors)
+
+	t.Run("fetch error", func(t *testing.T) {
+		_, err := paginate(nil, func(cursor *graphql.String) ([]int, PageInfo, error) {
+			return nil, PageInfo{}, errors.New("boom")
+		})
+		assert.EqualError(t, err, "boom")
+	})
+
+	t.Run("cursor doesn't advance", func(t *testing.T) {
+		_, err := paginate(nil, func(cursor *graphql.String) ([]int, PageInfo, error) {
+			return []int{1}, PageInfo{HasNextPage: true, EndCursor: "same"}, nil
+		})
+		assert.ErrorContains(t, err, `pagination did not advance past cursor "same"`)
+	})
+}
+
+func TestRemainingPages(t *testing.T) {
+	var cursors []string
+	fetch := fakePages([][]int{{1, 2}, {3}, {4}}, &cursors)
+
+	nodes, err := remainingPages(PageInfo{HasNextPage: false}, fetch)
+	require.NoError(t, err)
+	assert.Nil(t, nodes)
+	assert.Empty(t, cursors, "nothing is fetched for complete connections")
+
+	nodes, err = remainingPages(PageInfo{HasNextPage: true, EndCursor: "1"}, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, nodes)
+	assert.Equal(t, []string{"1", "2"}, cursors)
+}
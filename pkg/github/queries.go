@@ -0,0 +1,445 @@
+package github
+
+import (
+	"github.com/shurcooL/graphql"
+)
+
+// OrgProjectQuery looks up an organization project by its number
+type OrgProjectQuery struct {
+	Organization struct {
+		ProjectV2 struct {
+			ID graphql.String
+		} `graphql:"projectV2(number: $number)"`
+	} `graphql:"organization(login: $login)"`
+}
+
+// ViewerProjectQuery looks up a project of the authenticated user by its number
+type ViewerProjectQuery struct {
+	Viewer struct {
+		ProjectV2 struct {
+			ID graphql.String
+		} `graphql:"projectV2(number: $number)"`
+	}
+}
+
+// ViewerLoginQuery looks up the login of the authenticated user
+type ViewerLoginQuery struct {
+	Viewer struct {
+		Login graphql.String
+	}
+}
+
+// FieldCommon contains the attributes shared by all project field types
+type FieldCommon struct {
+	Name graphql.String
+}
+
+// FieldRef references the project field a value belongs to
+type FieldRef struct {
+	Common FieldCommon `graphql:"... on ProjectV2FieldCommon"`
+}
+
+// TextFieldValue is the value of a text field
+type TextFieldValue struct {
+	Text  graphql.String
+	Field FieldRef
+}
+
+// NumberFieldValue is the value of a number field
+type NumberFieldValue struct {
+	Number float64
+	Field  FieldRef
+}
+
+// DateFieldValue is the value of a date field
+type DateFieldValue struct {
+	Date  graphql.String
+	Field FieldRef
+}
+
+// SingleSelectFieldValue is the value of a single select field
+type SingleSelectFieldValue struct {
+	Name  graphql.String
+	Field FieldRef
+}
+
+// RepositoryFieldValue is the value of the repository field
+type RepositoryFieldValue struct {
+	Repository struct {
+		Name  graphql.String
+		Owner struct {
+			Login graphql.String
+		}
+	}
+	Field FieldRef
+}
+
+// IterationFieldValue is the value of an iteration field
+type IterationFieldValue struct {
+	Title     graphql.String
+	StartDate graphql.String
+	Duration  int // Days
+	Field     FieldRef
+}
+
+// MilestoneFieldValue is the value of the milestone field
+type MilestoneFieldValue struct {
+	Milestone struct {
+		Title graphql.String
+		DueOn graphql.String
+	}
+	Field FieldRef
+}
+
+// User is a GitHub user
+type User struct {
+	Login graphql.String
+}
+
+// UserConnection is a page of users, e.g. the assignees of an issue
+type UserConnection struct {
+	PageInfo PageInfo
+	Nodes    []User
+}
+
+// UserFieldValue is the value of a user field, e.g. an owner field of the project
+type UserFieldValue struct {
+	Users UserConnection `graphql:"users(first: 10)"`
+	Field FieldRef
+}
+
+// FieldValueNode is a single field value of a project item
+type FieldValueNode struct {
+	TypeName     graphql.String         `graphql:"__typename"`
+	TextValue    TextFieldValue         `graphql:"... on ProjectV2ItemFieldTextValue"`
+	NumberValue  NumberFieldValue       `graphql:"... on ProjectV2ItemFieldNumberValue"`
+	DateValue    DateFieldValue         `graphql:"... on ProjectV2ItemFieldDateValue"`
+	SingleSelect SingleSelectFieldValue `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	Repository   RepositoryFieldValue   `graphql:"... on ProjectV2ItemFieldRepositoryValue"`
+	UserValue    UserFieldValue         `graphql:"... on ProjectV2ItemFieldUserValue"`
+	Iteration    IterationFieldValue    `graphql:"... on ProjectV2ItemFieldIterationValue"`
+	Milestone    MilestoneFieldValue    `graphql:"... on ProjectV2ItemFieldMilestoneValue"`
+}
+
+// IssueRef references an issue by its node ID
+type IssueRef struct {
+	ID graphql.String
+}
+
+// IssueRefConnection is a page of issue references, e.g. the issues blocking an issue
+type IssueRefConnection struct {
+	PageInfo PageInfo
+	Nodes    []IssueRef
+}
+
+// Label is a label of an issue or pull request
+type Label struct {
+	Name graphql.String
+}
+
+// LabelConnection is a page of labels of an issue or pull request
+type LabelConnection struct {
+	PageInfo PageInfo
+	Nodes    []Label
+}
+
+// IssueContent contains the fields fetched for issues
+type IssueContent struct {
+	ID         graphql.String
+	URL        graphql.String
+	Number     graphql.Int
+	Title      graphql.String
+	State      graphql.String // OPEN or CLOSED
+	Repository struct {
+		NameWithOwner graphql.String
+	}
+	CreatedAt graphql.String
+	UpdatedAt graphql.String
+	Assignees UserConnection     `graphql:"assignees(first: 10)"`
+	BlockedBy IssueRefConnection `graphql:"blockedBy(first: 20)"`
+	Labels    LabelConnection    `graphql:"labels(first: 20)"`
+}
+
+// PullRequestContent contains the fields fetched for pull requests
+type PullRequestContent struct {
+	ID         graphql.String
+	URL        graphql.String
+	Number     graphql.Int
+	Title      graphql.String
+	State      graphql.String // OPEN, CLOSED or MERGED
+	Repository struct {
+		NameWithOwner graphql.String
+	}
+	CreatedAt graphql.String
+	UpdatedAt graphql.String
+	Assignees UserConnection  `graphql:"assignees(first: 10)"`
+	Labels    LabelConnection `graphql:"labels(first: 20)"`
+}
+
+// DraftIssueContent contains the fields fetched for draft issues
+type DraftIssueContent struct {
+	Title     graphql.String
+	CreatedAt graphql.String
+	UpdatedAt graphql.String
+}
+
+// ItemContent is the issue, pull request or draft issue behind a project item
+type ItemContent struct {
+	TypeName    graphql.String     `graphql:"__typename"`
+	Issue       IssueContent       `graphql:"... on Issue"`
+	PullRequest PullRequestContent `graphql:"... on PullRequest"`
+	DraftIssue  DraftIssueContent  `graphql:"... on DraftIssue"`
+}
+
+// FieldValueConnection is a page of field values of a project item
+type FieldValueConnection struct {
+	PageInfo PageInfo
+	Nodes    []FieldValueNode
+}
+
+// ProjectItemNode is a single project item
+type ProjectItemNode struct {
+	ID          graphql.String
+	Type        graphql.String       // ISSUE, PULL_REQUEST, DRAFT_ISSUE or REDACTED
+	FieldValues FieldValueConnection `graphql:"fieldValues(first: 100)"`
+	Content     ItemContent
+}
+
+// CommentNode is a comment on an issue or pull request
+type CommentNode struct {
+	Author struct {
+		Login graphql.String
+	}
+	CreatedAt graphql.String
+	Body      graphql.String
+}
+
+// CommentConnection contains the latest comment of an issue or pull request
+type CommentConnection struct {
+	Nodes []CommentNode
+}
+
+// ItemCommentQuery fetches the latest comment of the issue or pull request behind a project item
+type ItemCommentQuery struct {
+	Node struct {
+		ProjectV2Item struct {
+			Content struct {
+				Issue struct {
+					Comments CommentConnection `graphql:"comments(last: 1)"`
+				} `graphql:"... on Issue"`
+				PullRequest struct {
+					Comments CommentConnection `graphql:"comments(last: 1)"`
+				} `graphql:"... on PullRequest"`
+			}
+		} `graphql:"... on ProjectV2Item"`
+	} `graphql:"node(id: $id)"`
+}
+
+// PageInfo contains the pagination state of a connection
+type PageInfo struct {
+	HasNextPage graphql.Boolean
+	EndCursor   graphql.String
+}
+
+// WorkflowNode is a built-in workflow of a project, e.g. "Auto-archive items"
+type WorkflowNode struct {
+	Name    graphql.String
+	Enabled graphql.Boolean
+}
+
+// ProjectItemsQuery fetches one page of items of a project, together with its workflows
+type ProjectItemsQuery struct {
+	Node struct {
+		TypeName  graphql.String `graphql:"__typename"`
+		ProjectV2 struct {
+			Title     graphql.String
+			Workflows struct {
+				Nodes []WorkflowNode
+			} `graphql:"workflows(first: 20)"`
+			Items struct {
+				PageInfo PageInfo
+				Nodes    []ProjectItemNode
+			} `graphql:"items(first: $first, after: $cursor)"`
+		} `graphql:"... on ProjectV2"`
+	} `graphql:"node(id: $id)"`
+}
+
+// ItemFieldValuesQuery fetches a page of field values of a project item
+type ItemFieldValuesQuery struct {
+	Node struct {
+		ProjectV2Item struct {
+			FieldValues FieldValueConnection `graphql:"fieldValues(first: $first, after: $cursor)"`
+		} `graphql:"... on ProjectV2Item"`
+	} `graphql:"node(id: $id)"`
+}
+
+// ItemUserFieldQuery fetches a page of the users set in a user field of a project item
+type ItemUserFieldQuery struct {
+	Node struct {
+		ProjectV2Item struct {
+			FieldValueByName struct {
+				UserValue struct {
+					Users UserConnection `graphql:"users(first: $first, after: $cursor)"`
+				} `graphql:"... on ProjectV2ItemFieldUserValue"`
+			} `graphql:"fieldValueByName(name: $name)"`
+		} `graphql:"... on ProjectV2Item"`
+	} `graphql:"node(id: $id)"`
+}
+
+// AssigneesQuery fetches a page of assignees of an issue or pull request
+type AssigneesQuery struct {
+	Node struct {
+		Assignable struct {
+			Assignees UserConnection `graphql:"assignees(first: $first, after: $cursor)"`
+		} `graphql:"... on Assignable"`
+	} `graphql:"node(id: $id)"`
+}
+
+// LabelsQuery fetches a page of labels of an issue or pull request
+type LabelsQuery struct {
+	Node struct {
+		Labelable struct {
+			Labels LabelConnection `graphql:"labels(first: $first, after: $cursor)"`
+		} `graphql:"... on Labelable"`
+	} `graphql:"node(id: $id)"`
+}
+
+// BlockedByQuery fetches a page of the issues blocking an issue
+type BlockedByQuery struct {
+	Node struct {
+		Issue struct {
+			BlockedBy IssueRefConnection `graphql:"blockedBy(first: $first, after: $cursor)"`
+		} `graphql:"... on Issue"`
+	} `graphql:"node(id: $id)"`
+}
+
+// ProjectV2StatusUpdateStatus is the status of a project status update, e.g. ON_TRACK
+type ProjectV2StatusUpdateStatus string
+
+// CreateProjectV2StatusUpdateInput is the input of CreateStatusUpdateMutation
+type CreateProjectV2StatusUpdateInput struct {
+	ProjectID graphql.ID                   `json:"projectId"`
+	Status    *ProjectV2StatusUpdateStatus `json:"status,omitempty"`
+	Body      *graphql.String              `json:"body,omitempty"`
+}
+
+// CreateStatusUpdateMutation posts a status update to a project
+type CreateStatusUpdateMutation struct {
+	CreateProjectV2StatusUpdate struct {
+		StatusUpdate struct {
+			ID graphql.String
+		}
+	} `graphql:"createProjectV2StatusUpdate(input: $input)"`
+}
+
+// IssueCommentNode is a comment of an issue
+type IssueCommentNode struct {
+	ID              graphql.String
+	Body            graphql.String
+	ViewerDidAuthor graphql.Boolean
+}
+
+// IssueCommentsQuery fetches an issue with its latest comments
+type IssueCommentsQuery struct {
+	Repository struct {
+		Issue struct {
+			ID       graphql.String
+			Comments struct {
+				Nodes []IssueCommentNode
+			} `graphql:"comments(last: 100)"`
+		} `graphql:"issue(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// AddCommentInput is the input of AddCommentMutation
+type AddCommentInput struct {
+	SubjectID graphql.ID     `json:"subjectId"`
+	Body      graphql.String `json:"body"`
+}
+
+// AddCommentMutation adds a comment to an issue
+type AddCommentMutation struct {
+	AddComment struct {
+		CommentEdge struct {
+			Node struct {
+				URL graphql.String
+			}
+		}
+	} `graphql:"addComment(input: $input)"`
+}
+
+// UpdateIssueCommentInput is the input of UpdateIssueCommentMutation
+type UpdateIssueCommentInput struct {
+	ID   graphql.ID     `json:"id"`
+	Body graphql.String `json:"body"`
+}
+
+// UpdateIssueCommentMutation replaces the body of an issue comment
+type UpdateIssueCommentMutation struct {
+	UpdateIssueComment struct {
+		IssueComment struct {
+			URL graphql.String
+		}
+	} `graphql:"updateIssueComment(input: $input)"`
+}
+
+// orgProjectVariables builds the variables for OrgProjectQuery
+func orgProjectVariables(projectNumber int, organization string) map[string]interface{} {
+	return map[string]interface{}{
+		"number": graphql.Int(projectNumber),
+		"login":  graphql.String(organization),
+	}
+}
+
+// viewerProjectVariables builds the variables for ViewerProjectQuery
+func viewerProjectVariables(projectNumber int) map[string]interface{} {
+	return map[string]interface{}{
+		"number": graphql.Int(projectNumber),
+	}
+}
+
+// connectionVariables builds the variables for queries of a page of a node's
+// connection, e.g. ProjectItemsQuery or AssigneesQuery
+func connectionVariables(nodeID string, cursor *graphql.String, pageSize int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     graphql.ID(nodeID),
+		"cursor": cursor,
+		"first":  graphql.Int(pageSize),
+	}
+}
+
+// userFieldVariables builds the variables for ItemUserFieldQuery
+func userFieldVariables(itemID, field string, cursor *graphql.String, pageSize int) map[string]interface{} {
+	variables := connectionVariables(itemID, cursor, pageSize)
+	variables["name"] = graphql.String(field)
+	return variables
+}
+
+// itemCommentVariables builds the variables for ItemCommentQuery
+func itemCommentVariables(itemID string) map[string]interface{} {
+	return map[string]interface{}{
+		"id": graphql.ID(itemID),
+	}
+}
+
+// createStatusUpdateVariables builds the variables for CreateStatusUpdateMutation
+func createStatusUpdateVariables(projectNodeID, status, body string) map[string]interface{} {
+	statusValue := ProjectV2StatusUpdateStatus(status)
+	bodyValue := graphql.String(body)
+	return map[string]interface{}{
+		"input": CreateProjectV2StatusUpdateInput{
+			ProjectID: graphql.ID(projectNodeID),
+			Status:    &statusValue,
+			Body:      &bodyValue,
+		},
+	}
+}
+
+// issueCommentsVariables builds the variables for IssueCommentsQuery
+func issueCommentsVariables(ref RepoIssue) map[string]interface{} {
+	return map[string]interface{}{
+		"owner":  graphql.String(ref.Owner),
+		"name":   graphql.String(ref.Repo),
+		"number": graphql.Int(ref.Number),
+	}
+}
@@ -0,0 +1,155 @@
+package github
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secondaryRateLimitWait is the wait after a secondary rate limit without a
+// Retry-After header, as recommended by GitHub
+const secondaryRateLimitWait = time.Minute
+
+// retryTransport retries requests failing with transient errors: rate limits, 5xx
+// responses and network errors. It honors Retry-After and the reset time of the
+// primary rate limit, and otherwise backs off exponentially with jitter.
+// Mutations are only retried after rate limits, which reject them unprocessed.
+type retryTransport struct {
+	transport http.RoundTripper
+	policy    RetryPolicy
+	logger    *log.Logger // Logs retries, nil disables logging
+	now       func() time.Time
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+	mutation := isMutation(body)
+
+	backoff := t.policy.Backoff
+	for attempt := 1; ; attempt++ {
+		try := req.Clone(req.Context())
+		if body != nil {
+			try.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.transport.RoundTrip(try)
+		wait, reason, retry := t.classify(resp, err, mutation, backoff)
+		if !retry || attempt >= t.policy.MaxAttempts || (t.policy.MaxDelay > 0 && wait > t.policy.MaxDelay) {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if t.logger != nil {
+			t.logger.Printf("Retrying in %s after %s (attempt %d of %d)\n", wait.Round(time.Millisecond), reason, attempt+1, t.policy.MaxAttempts)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// classify decides whether a request is retried, how long to wait before and why
+func (t *retryTransport) classify(resp *http.Response, err error, mutation bool, backoff time.Duration) (time.Duration, string, bool) {
+	if err != nil {
+		return jitter(backoff), err.Error(), !mutation
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return t.rateLimitWait(resp, backoff), "rate limit", true
+	case resp.StatusCode == http.StatusForbidden && isSecondaryRateLimit(resp):
+		return t.rateLimitWait(resp, max(backoff, secondaryRateLimitWait)), "secondary rate limit", true
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("X-RateLimit-Remaining") == "0" && bodyContains(resp, "RATE_LIMITED"):
+		return t.rateLimitWait(resp, backoff), "rate limit", true
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		wait, ok := retryAfter(resp, t.now())
+		if !ok {
+			wait = jitter(backoff)
+		}
+		return wait, resp.Status, !mutation
+	}
+	return 0, "", false
+}
+
+// rateLimitWait returns how long to wait after a rate limit: the Retry-After header,
+// the reset time of an exhausted rate limit, or the fallback with jitter
+func (t *retryTransport) rateLimitWait(resp *http.Response, fallback time.Duration) time.Duration {
+	if wait, ok := retryAfter(resp, t.now()); ok {
+		return wait
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Unix(reset, 0).Sub(t.now()), 0)
+		}
+	}
+	return jitter(fallback)
+}
+
+// retryAfter parses the Retry-After header given in seconds or as HTTP date
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// isSecondaryRateLimit returns true if a 403 response is a secondary rate limit
+// rather than missing permissions
+func isSecondaryRateLimit(resp *http.Response) bool {
+	return resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0" ||
+		bodyContains(resp, "secondary rate limit")
+}
+
+// bodyContains returns true if the response body contains the text, leaving the
+// body readable
+func bodyContains(resp *http.Response, text string) bool {
+	if resp.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return err == nil && strings.Contains(strings.ToLower(string(body)), strings.ToLower(text))
+}
+
+// isMutation returns true if the GraphQL request body is a mutation
+func isMutation(body []byte) bool {
+	return bytes.Contains(body, []byte(`"query":"mutation`))
+}
+
+// jitter returns a random duration between half and all of the backoff, so that
+// clients failing at the same time don't retry in lockstep
+func jitter(backoff time.Duration) time.Duration {
+	if backoff <= 1 {
+		return backoff
+	}
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
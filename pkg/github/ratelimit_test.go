@@ -0,0 +1,172 @@
+package github
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRetryPolicy retries quickly so that tests don't wait
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxDelay: time.Second}
+
+// failingServer responds with the given failures before answering the viewer query
+func failingServer(t *testing.T, failures ...func(w http.ResponseWriter)) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(calls.Add(1))
+		if call <= len(failures) {
+			failures[call-1](w)
+			return
+		}
+		w.Write([]byte(`{"data": {"viewer": {"login": "octocat"}}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func respond(status int, headers map[string]string, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		for name, value := range headers {
+			w.Header().Set(name, value)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+func TestClientRetriesTransientFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure func(w http.ResponseWriter)
+	}{
+		{name: "bad gateway", failure: respond(http.StatusBadGateway, nil, "")},
+		{name: "service unavailable with Retry-After", failure: respond(http.StatusServiceUnavailable, map[string]string{"Retry-After": "0"}, "")},
+		{name: "too many requests", failure: respond(http.StatusTooManyRequests, map[string]string{"Retry-After": "0"}, "")},
+		{name: "secondary rate limit", failure: respond(http.StatusForbidden, map[string]string{"Retry-After": "0"},
+			`{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`)},
+		{name: "primary rate limit", failure: respond(http.StatusOK,
+			map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(time.Now().Unix(), 10)},
+			`{"errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}]}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := failingServer(t, tt.failure, tt.failure)
+			client := NewClient(&http.Client{}, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+			login, err := client.ViewerLogin()
+			require.NoError(t, err)
+			assert.Equal(t, "octocat", login)
+			assert.Equal(t, int32(3), calls.Load())
+		})
+	}
+}
+
+func TestClientRetryLimits(t *testing.T) {
+	t.Run("max attempts", func(t *testing.T) {
+		fail := respond(http.StatusBadGateway, nil, "")
+		server, calls := failingServer(t, fail, fail, fail, fail)
+		client := NewClient(&http.Client{}, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+		_, err := client.ViewerLogin()
+		assert.ErrorContains(t, err, "502 Bad Gateway")
+		assert.Equal(t, int32(3), calls.Load(), "the executor doesn't retry on top of the transport")
+	})
+
+	t.Run("Retry-After beyond max delay", func(t *testing.T) {
+		server, calls := failingServer(t, respond(http.StatusTooManyRequests, map[string]string{"Retry-After": "3600"}, ""))
+		client := NewClient(&http.Client{}, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+		_, err := client.ViewerLogin()
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("permission errors", func(t *testing.T) {
+		server, calls := failingServer(t, respond(http.StatusForbidden, nil, `{"message": "Resource not accessible by integration"}`))
+		client := NewClient(&http.Client{}, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+		_, err := client.ViewerLogin()
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("mutations after 5xx", func(t *testing.T) {
+		server, calls := failingServer(t, respond(http.StatusBadGateway, nil, ""))
+		client := NewClient(&http.Client{}, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy))
+
+		_, err := client.CreateStatusUpdate("PVT_123", "ON_TRACK", "All good")
+		assert.ErrorContains(t, err, "502 Bad Gateway")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestRetryTransportLogsRetries(t *testing.T) {
+	var logs bytes.Buffer
+	var calls int
+	transport := &retryTransport{
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, `{"query":"{viewer{login}}"}`, string(body), "the body is sent again")
+			if calls == 1 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		}),
+		policy: testRetryPolicy,
+		logger: log.New(&logs, "", 0),
+		now:    time.Now,
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "https://api.github.com/graphql", strings.NewReader(`{"query":"{viewer{login}}"}`))
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, logs.String(), "after connection reset by peer (attempt 2 of 3)")
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	header := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	wait, ok := retryAfter(header("30"), now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, wait)
+
+	wait, ok = retryAfter(header(now.Add(time.Minute).Format(http.TimeFormat)), now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, wait)
+
+	_, ok = retryAfter(header("soon"), now)
+	assert.False(t, ok)
+}
+
+func TestJitter(t *testing.T) {
+	for range 100 {
+		wait := jitter(time.Second)
+		assert.GreaterOrEqual(t, wait, 500*time.Millisecond)
+		assert.LessOrEqual(t, wait, time.Second)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,158 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Repository references a repository, e.g. owner/repo
+type Repository struct {
+	Owner string
+	Name  string
+}
+
+// ParseRepository parses a repository reference in owner/repo format
+func ParseRepository(s string) (Repository, error) {
+	owner, name, ok := strings.Cut(s, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return Repository{}, fmt.Errorf("invalid repository %q (must be owner/repo)", s)
+	}
+	return Repository{Owner: owner, Name: name}, nil
+}
+
+// String returns the repository in owner/repo format
+func (r Repository) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// ReleaseAsset is a file attached to a release
+type ReleaseAsset struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// release is a release as returned by the REST API
+type release struct {
+	ID        int64  `json:"id"`
+	HTMLURL   string `json:"html_url"`
+	UploadURL string `json:"upload_url"` // URL template, e.g. .../assets{?name,label}
+	Assets    []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"assets"`
+}
+
+// RESTURL returns the REST API root of a GraphQL endpoint: api.github.com for
+// github.com, /api/v3 for GitHub Enterprise Server and the api subdomain for *.ghe.com
+func RESTURL(graphQLURL string) string {
+	if base, ok := strings.CutSuffix(graphQLURL, "/api/graphql"); ok {
+		return base + "/api/v3"
+	}
+	return strings.TrimSuffix(graphQLURL, "/graphql")
+}
+
+// PublishRelease creates the release of the tag in the repository, or updates its
+// name and body if it exists, and attaches the assets, replacing assets of the same
+// name. New tags are created from the default branch. It returns the URL of the release.
+func (c *Client) PublishRelease(repo Repository, tag, name, body string, assets []ReleaseAsset) (string, error) {
+	if c.httpClient == nil {
+		return "", fmt.Errorf("failed to publish release: the client has no HTTP client")
+	}
+	ctx := context.Background()
+	base := fmt.Sprintf("%s/repos/%s/%s/releases", RESTURL(c.options.BaseURL), url.PathEscape(repo.Owner), url.PathEscape(repo.Name))
+
+	var rel release
+	status, err := c.restCall(ctx, http.MethodGet, base+"/tags/"+url.PathEscape(tag), "", nil, &rel)
+	switch {
+	case status == http.StatusNotFound:
+		input := map[string]string{"tag_name": tag, "name": name, "body": body}
+		if _, err := c.restCall(ctx, http.MethodPost, base, "", input, &rel); err != nil {
+			return "", fmt.Errorf("failed to create release %s in %s: %w", tag, repo, err)
+		}
+	case err != nil:
+		return "", fmt.Errorf("failed to query release %s in %s: %w", tag, repo, err)
+	default:
+		input := map[string]string{"name": name, "body": body}
+		if _, err := c.restCall(ctx, http.MethodPatch, fmt.Sprintf("%s/%d", base, rel.ID), "", input, &rel); err != nil {
+			return "", fmt.Errorf("failed to update release %s in %s: %w", tag, repo, err)
+		}
+	}
+
+	for _, asset := range assets {
+		for _, existing := range rel.Assets {
+			if existing.Name != asset.Name {
+				continue
+			}
+			if _, err := c.restCall(ctx, http.MethodDelete, fmt.Sprintf("%s/assets/%d", base, existing.ID), "", nil, nil); err != nil {
+				return "", fmt.Errorf("failed to replace asset %s of release %s: %w", asset.Name, tag, err)
+			}
+		}
+
+		upload, _, _ := strings.Cut(rel.UploadURL, "{")
+		upload += "?name=" + url.QueryEscape(asset.Name)
+		if _, err := c.restCall(ctx, http.MethodPost, upload, asset.ContentType, asset.Data, nil); err != nil {
+			return "", fmt.Errorf("failed to upload asset %s to release %s: %w", asset.Name, tag, err)
+		}
+	}
+	return rel.HTMLURL, nil
+}
+
+// restCall sends a REST API request and decodes the JSON response into out if not nil.
+// The input is sent as is with the content type if it is a byte slice, as JSON
+// otherwise. It returns the status code, and an error for status codes other than 2xx.
+func (c *Client) restCall(ctx context.Context, method, endpoint, contentType string, input, out interface{}) (int, error) {
+	var body io.Reader
+	switch in := input.(type) {
+	case nil:
+	case []byte:
+		body = bytes.NewReader(in)
+	default:
+		data, err := json.Marshal(in)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body, contentType = bytes.NewReader(data), "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiError struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &apiError) == nil && apiError.Message != "" {
+			return resp.StatusCode, fmt.Errorf("%s: %s", resp.Status, apiError.Message)
+		}
+		return resp.StatusCode, fmt.Errorf("%s", resp.Status)
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,133 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepository(t *testing.T) {
+	repo, err := ParseRepository("octo-org/reports")
+	require.NoError(t, err)
+	assert.Equal(t, Repository{Owner: "octo-org", Name: "reports"}, repo)
+	assert.Equal(t, "octo-org/reports", repo.String())
+
+	for _, invalid := range []string{"", "reports", "/reports", "octo-org/", "a/b/c"} {
+		_, err := ParseRepository(invalid)
+		assert.ErrorContains(t, err, "must be owner/repo", invalid)
+	}
+}
+
+func TestRESTURL(t *testing.T) {
+	assert.Equal(t, "https://api.github.com", RESTURL(DefaultBaseURL))
+	assert.Equal(t, "https://github.mycorp.com/api/v3", RESTURL("https://github.mycorp.com/api/graphql"))
+	assert.Equal(t, "https://api.octo.ghe.com", RESTURL("https://api.octo.ghe.com/graphql"))
+}
+
+// fakeReleases is a REST API serving the releases of octo-org/reports
+type fakeReleases struct {
+	mu       sync.Mutex
+	release  map[string]interface{} // Existing release, nil if none
+	requests []string
+	input    map[string]string // Body of the last create or update request
+	uploads  map[string]string
+}
+
+func (f *fakeReleases) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.RequestURI())
+	uploadURL := "http://" + r.Host + "/uploads/repos/octo-org/reports/releases/7/assets{?name,label}"
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/octo-org/reports/releases/tags/week-1":
+		if f.release == nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+			return
+		}
+		json.NewEncoder(w).Encode(f.release)
+	case r.Method == http.MethodPost && r.URL.Path == "/repos/octo-org/reports/releases",
+		r.Method == http.MethodPatch && r.URL.Path == "/repos/octo-org/reports/releases/7":
+		json.NewDecoder(r.Body).Decode(&f.input)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id": 7, "html_url": "https://github.com/octo-org/reports/releases/tag/week-1", "upload_url": uploadURL, "assets": f.release["assets"],
+		})
+	case r.Method == http.MethodDelete && r.URL.Path == "/repos/octo-org/reports/releases/assets/3":
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && r.URL.Path == "/uploads/repos/octo-org/reports/releases/7/assets":
+		data, _ := io.ReadAll(r.Body)
+		f.uploads[r.URL.Query().Get("name")] = string(data)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	default:
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message": "Validation Failed"}`)
+	}
+}
+
+func TestPublishRelease(t *testing.T) {
+	assets := []ReleaseAsset{
+		{Name: "report.md", ContentType: "text/markdown", Data: []byte("# Report")},
+		{Name: "report.csv", ContentType: "text/csv", Data: []byte("id,title")},
+	}
+
+	t.Run("creates the release", func(t *testing.T) {
+		fake := &fakeReleases{uploads: map[string]string{}}
+		srv := httptest.NewServer(fake)
+		defer srv.Close()
+
+		client := NewClient(srv.Client(), WithBaseURL(srv.URL+"/graphql"))
+		url, err := client.PublishRelease(Repository{Owner: "octo-org", Name: "reports"}, "week-1", "Week 1", "Summary", assets)
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/octo-org/reports/releases/tag/week-1", url)
+		assert.Equal(t, map[string]string{"report.md": "# Report", "report.csv": "id,title"}, fake.uploads)
+		assert.Equal(t, "POST /repos/octo-org/reports/releases", fake.requests[1])
+		assert.Equal(t, map[string]string{"tag_name": "week-1", "name": "Week 1", "body": "Summary"}, fake.input)
+	})
+
+	t.Run("updates the release and replaces assets", func(t *testing.T) {
+		fake := &fakeReleases{
+			release: map[string]interface{}{"id": 7, "assets": []map[string]interface{}{{"id": 3, "name": "report.md"}}},
+			uploads: map[string]string{},
+		}
+		srv := httptest.NewServer(fake)
+		defer srv.Close()
+
+		client := NewClient(srv.Client(), WithBaseURL(srv.URL+"/graphql"))
+		_, err := client.PublishRelease(Repository{Owner: "octo-org", Name: "reports"}, "week-1", "Week 1", "Summary", assets)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET /repos/octo-org/reports/releases/tags/week-1",
+			"PATCH /repos/octo-org/reports/releases/7",
+			"DELETE /repos/octo-org/reports/releases/assets/3",
+			"POST /uploads/repos/octo-org/reports/releases/7/assets?name=report.md",
+			"POST /uploads/repos/octo-org/reports/releases/7/assets?name=report.csv",
+		}, fake.requests)
+		assert.Equal(t, map[string]string{"name": "Week 1", "body": "Summary"}, fake.input)
+	})
+
+	t.Run("reports API errors", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "Resource not accessible by integration"}`)
+		}))
+		defer srv.Close()
+
+		client := NewClient(srv.Client(), WithBaseURL(srv.URL+"/graphql"))
+		_, err := client.PublishRelease(Repository{Owner: "octo-org", Name: "reports"}, "week-1", "Week 1", "Summary", nil)
+		assert.EqualError(t, err, "failed to query release week-1 in octo-org/reports: 403 Forbidden: Resource not accessible by integration")
+	})
+
+	t.Run("requires an HTTP client", func(t *testing.T) {
+		_, err := NewClientWithExecutor(nil).PublishRelease(Repository{Owner: "octo-org", Name: "reports"}, "week-1", "Week 1", "Summary", nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TokenKind is the type of a GitHub token, derived from its prefix
+type TokenKind string
+
+const (
+	TokenKindFineGrained TokenKind = "fine-grained personal access token"
+	TokenKindClassic     TokenKind = "classic personal access token"
+	TokenKindOAuth       TokenKind = "OAuth token"
+	TokenKindApp         TokenKind = "GitHub App token"
+	TokenKindUnknown     TokenKind = "token"
+)
+
+// tokenEnvVars lists the environment variables a token is read from, in order
+var tokenEnvVars = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+
+// enterpriseTokenEnvVars lists the environment variables a token for a host other
+// than github.com is read from, in order, as by the gh CLI
+var enterpriseTokenEnvVars = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+
+// DetectTokenKind returns the kind of a token based on its prefix
+func DetectTokenKind(token string) TokenKind {
+	switch {
+	case strings.HasPrefix(token, "github_pat_"):
+		return TokenKindFineGrained
+	case strings.HasPrefix(token, "ghp_"):
+		return TokenKindClassic
+	case strings.HasPrefix(token, "gho_"):
+		return TokenKindOAuth
+	case strings.HasPrefix(token, "ghs_"), strings.HasPrefix(token, "ghu_"):
+		return TokenKindApp
+	default:
+		return TokenKindUnknown
+	}
+}
+
+// ResolveToken returns the token to authenticate with at the host and a description
+// of where it was found. The token file takes precedence over the GITHUB_TOKEN and
+// GH_TOKEN environment variables, looked up with getenv. Without either, the token
+// the gh CLI is logged in with is used, the same way gh extensions authenticate.
+func ResolveToken(tokenFile, host string, getenv func(string) string) (string, string, error) {
+	if tokenFile != "" {
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", "", fmt.Errorf("token file %s is empty", tokenFile)
+		}
+		return token, tokenFile, nil
+	}
+
+	hostname := HostName(host)
+	names := tokenEnvVars
+	if hostname != DefaultHost {
+		names = append(append([]string{}, enterpriseTokenEnvVars...), tokenEnvVars...)
+	}
+	for _, name := range names {
+		if token := strings.TrimSpace(getenv(name)); token != "" {
+			return token, name, nil
+		}
+	}
+
+	// gh auth token also reads tokens kept in the system keyring
+	if token, err := ghAuthToken(hostname); err == nil && token != "" {
+		return token, "gh auth token", nil
+	}
+	token, path, err := ghConfigToken(hostname, getenv)
+	if err != nil {
+		return "", "", err
+	}
+	if token != "" {
+		return token, path, nil
+	}
+
+	return "", "", fmt.Errorf("a GitHub token is required: set GITHUB_TOKEN or GH_TOKEN, log in with gh auth login --hostname %s, or use --token-file", hostname)
+}
+
+// authHint returns guidance for a permission error of a token of the given kind,
+// or an empty string if the error is not a known permission problem
+func authHint(err error, kind TokenKind) string {
+	msg := err.Error()
+	switch {
+	case kind == TokenKindFineGrained && strings.Contains(msg, "Resource not accessible by personal access token"):
+		return "fine-grained tokens need the \"Projects\" organization permission, and the token's resource owner " +
+			"must be the organization that owns the project. Organizations may also require approval of fine-grained tokens"
+	case kind == TokenKindFineGrained && strings.Contains(msg, "Could not resolve to a ProjectV2"):
+		return "fine-grained tokens only see projects of their resource owner. Create the token with the project's " +
+			"organization as resource owner and grant it the \"Projects\" organization permission"
+	case strings.Contains(msg, "read:project") || strings.Contains(msg, "required scopes"):
+		return "classic tokens need the read:project scope (project to publish status updates); " +
+			"with the GitHub CLI run: gh auth refresh -s read:project"
+	}
+	return ""
+}
+
+// authHintExecutor adds guidance for the token kind to permission errors
+type authHintExecutor struct {
+	executor Executor
+	kind     TokenKind
+}
+
+// Query runs the query, explaining permission errors
+func (e *authHintExecutor) Query(ctx context.Context, q interface{}, variables map[string]interface{}) error {
+	return e.explain(e.executor.Query(ctx, q, variables))
+}
+
+// Mutate runs the mutation, explaining permission errors
+func (e *authHintExecutor) Mutate(ctx context.Context, m interface{}, variables map[string]interface{}) error {
+	return e.explain(e.executor.Mutate(ctx, m, variables))
+}
+
+// explain wraps permission errors with guidance
+func (e *authHintExecutor) explain(err error) error {
+	if err == nil {
+		return nil
+	}
+	if hint := authHint(err, e.kind); hint != "" {
+		return fmt.Errorf("%w\nhint: %s", err, hint)
+	}
+	return err
+}
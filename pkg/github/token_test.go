@@ -0,0 +1,78 @@
+package github
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/github/githubtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectTokenKind(t *testing.T) {
+	assert.Equal(t, TokenKindFineGrained, DetectTokenKind("github_pat_11ABC"))
+	assert.Equal(t, TokenKindClassic, DetectTokenKind("ghp_abc"))
+	assert.Equal(t, TokenKindOAuth, DetectTokenKind("gho_abc"))
+	assert.Equal(t, TokenKindApp, DetectTokenKind("ghs_abc"))
+	assert.Equal(t, TokenKindUnknown, DetectTokenKind("0123456789abcdef"))
+}
+
+func TestResolveToken(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+	stubGHAuthToken(t, nil)
+
+	token, source, err := ResolveToken("", "", env(map[string]string{"GITHUB_TOKEN": "ghp_env", "GH_TOKEN": "gho_cli"}))
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_env", token)
+	assert.Equal(t, "GITHUB_TOKEN", source)
+
+	token, source, err = ResolveToken("", "", env(map[string]string{"GH_TOKEN": "gho_cli\n"}))
+	require.NoError(t, err)
+	assert.Equal(t, "gho_cli", token)
+	assert.Equal(t, "GH_TOKEN", source)
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("github_pat_file\n"), 0600))
+	token, source, err = ResolveToken(path, "", env(map[string]string{"GITHUB_TOKEN": "ghp_env"}))
+	require.NoError(t, err)
+	assert.Equal(t, "github_pat_file", token)
+	assert.Equal(t, path, source)
+
+	_, _, err = ResolveToken("", "", env(nil))
+	assert.ErrorContains(t, err, "set GITHUB_TOKEN or GH_TOKEN, log in with gh auth login --hostname github.com, or use --token-file")
+
+	_, _, err = ResolveToken(filepath.Join(t.TempDir(), "missing"), "", env(nil))
+	assert.ErrorContains(t, err, "failed to read token file")
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(empty, []byte("\n"), 0600))
+	_, _, err = ResolveToken(empty, "", env(nil))
+	assert.ErrorContains(t, err, "is empty")
+}
+
+func TestAuthHint(t *testing.T) {
+	forbidden := errors.New("Resource not accessible by personal access token")
+	scopes := errors.New("Your token has not been granted the required scopes to execute this query. The 'read:project' scope is required")
+
+	assert.Contains(t, authHint(forbidden, TokenKindFineGrained), "\"Projects\" organization permission")
+	assert.Empty(t, authHint(forbidden, TokenKindClassic))
+	assert.Contains(t, authHint(scopes, TokenKindClassic), "gh auth refresh -s read:project")
+	assert.Empty(t, authHint(errors.New("502 Bad Gateway"), TokenKindFineGrained))
+}
+
+func TestClientAuthHint(t *testing.T) {
+	executor := githubtest.NewExecutor(githubtest.Fail(errors.New("Resource not accessible by personal access token")))
+	client := NewClientWithExecutor(executor, WithTokenKind(TokenKindFineGrained))
+
+	_, err := client.ViewerLogin()
+	assert.ErrorContains(t, err, "Resource not accessible by personal access token\nhint: fine-grained tokens")
+
+	// Without a token kind, errors are passed through unchanged
+	executor = githubtest.NewExecutor(githubtest.Fail(errors.New("Resource not accessible by personal access token")))
+	_, err = NewClientWithExecutor(executor).ViewerLogin()
+	assert.NotContains(t, err.Error(), "hint")
+}
@@ -0,0 +1,102 @@
+// Package hooks runs user-defined shell commands at points of the capture and report workflow.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Event is a point of the workflow at which hook commands run
+type Event string
+
+const (
+	PreCapture  Event = "pre-capture"  // Before fetching the project state
+	PostCapture Event = "post-capture" // After saving a snapshot
+	PostReport  Event = "post-report"  // After writing and delivering a report
+)
+
+// envPrefix prefixes the names of the environment variables passed to hook commands
+const envPrefix = "GH_PROJECT_REPORT_"
+
+// Context describes the run that triggered a hook, passed to hook commands as
+// environment variables
+type Context struct {
+	ProjectNumber int
+	Snapshot      string // Snapshot saved by capture or the "to" snapshot of a report
+	FromSnapshot  string // "from" snapshot of a report
+	Items         int    // Number of items in Snapshot
+	Added         int
+	Removed       int
+	Changed       int
+	Delayed       int // Changed items at or above the moderate delay threshold
+}
+
+// Env returns the environment variables describing the context for an event.
+// Snapshot details are only set once a snapshot exists, diff counts only for reports.
+func (c Context) Env(event Event) []string {
+	env := []string{
+		envPrefix + "HOOK=" + string(event),
+		envPrefix + "PROJECT=" + strconv.Itoa(c.ProjectNumber),
+	}
+	if event == PreCapture {
+		return env
+	}
+
+	env = append(env,
+		envPrefix+"SNAPSHOT="+c.Snapshot,
+		envPrefix+"ITEMS="+strconv.Itoa(c.Items),
+	)
+	if event == PostReport {
+		env = append(env,
+			envPrefix+"FROM_SNAPSHOT="+c.FromSnapshot,
+			envPrefix+"ADDED="+strconv.Itoa(c.Added),
+			envPrefix+"REMOVED="+strconv.Itoa(c.Removed),
+			envPrefix+"CHANGED="+strconv.Itoa(c.Changed),
+			envPrefix+"DELAYED="+strconv.Itoa(c.Delayed),
+		)
+	}
+	return env
+}
+
+// RunnerOption configures a Runner
+type RunnerOption func(*Runner)
+
+// WithOutput sets where the output of hook commands is written, os.Stderr by default
+// to keep stdout clean for piped reports
+func WithOutput(w io.Writer) RunnerOption {
+	return func(r *Runner) {
+		r.output = w
+	}
+}
+
+// Runner runs the configured commands of hook events
+type Runner struct {
+	commands map[Event][]string
+	output   io.Writer
+}
+
+// NewRunner creates a runner for the shell commands of each event
+func NewRunner(commands map[Event][]string, opts ...RunnerOption) *Runner {
+	r := &Runner{commands: commands, output: os.Stderr}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run runs the commands of an event in order, stopping at the first failing command
+func (r *Runner) Run(ctx context.Context, event Event, hookCtx Context) error {
+	for _, line := range r.commands[event] {
+		cmd := shellCommand(ctx, line)
+		cmd.Env = append(os.Environ(), hookCtx.Env(event)...)
+		cmd.Stdout = r.output
+		cmd.Stderr = r.output
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to run %s hook %q: %w", event, line, err)
+		}
+	}
+	return nil
+}
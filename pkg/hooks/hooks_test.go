@@ -0,0 +1,85 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextEnv(t *testing.T) {
+	hookCtx := Context{ProjectNumber: 42, Snapshot: "states/project=42/1704067200.json", FromSnapshot: "states/project=42/1703462400.json", Items: 10, Added: 1, Removed: 2, Changed: 3, Delayed: 1}
+
+	tests := []struct {
+		name  string
+		event Event
+		want  []string
+	}{
+		{
+			name:  "pre-capture",
+			event: PreCapture,
+			want:  []string{"GH_PROJECT_REPORT_HOOK=pre-capture", "GH_PROJECT_REPORT_PROJECT=42"},
+		},
+		{
+			name:  "post-capture",
+			event: PostCapture,
+			want: []string{
+				"GH_PROJECT_REPORT_HOOK=post-capture", "GH_PROJECT_REPORT_PROJECT=42",
+				"GH_PROJECT_REPORT_SNAPSHOT=states/project=42/1704067200.json", "GH_PROJECT_REPORT_ITEMS=10",
+			},
+		},
+		{
+			name:  "post-report",
+			event: PostReport,
+			want: []string{
+				"GH_PROJECT_REPORT_HOOK=post-report", "GH_PROJECT_REPORT_PROJECT=42",
+				"GH_PROJECT_REPORT_SNAPSHOT=states/project=42/1704067200.json", "GH_PROJECT_REPORT_ITEMS=10",
+				"GH_PROJECT_REPORT_FROM_SNAPSHOT=states/project=42/1703462400.json",
+				"GH_PROJECT_REPORT_ADDED=1", "GH_PROJECT_REPORT_REMOVED=2", "GH_PROJECT_REPORT_CHANGED=3", "GH_PROJECT_REPORT_DELAYED=1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hookCtx.Env(tt.event))
+		})
+	}
+}
+
+func TestRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in this test use sh syntax")
+	}
+
+	t.Run("runs commands of the event in order", func(t *testing.T) {
+		var output bytes.Buffer
+		runner := NewRunner(map[Event][]string{
+			PostCapture: {`echo "saved $GH_PROJECT_REPORT_SNAPSHOT"`, `echo "items $GH_PROJECT_REPORT_ITEMS"`},
+			PostReport:  {"echo report"},
+		}, WithOutput(&output))
+
+		err := runner.Run(context.Background(), PostCapture, Context{ProjectNumber: 42, Snapshot: "1704067200.json", Items: 3})
+		require.NoError(t, err)
+		assert.Equal(t, "saved 1704067200.json\nitems 3\n", output.String())
+	})
+
+	t.Run("no commands", func(t *testing.T) {
+		runner := NewRunner(nil)
+		assert.NoError(t, runner.Run(context.Background(), PreCapture, Context{}))
+	})
+
+	t.Run("failing command stops the event", func(t *testing.T) {
+		var output bytes.Buffer
+		runner := NewRunner(map[Event][]string{
+			PreCapture: {"exit 3", "echo unreachable"},
+		}, WithOutput(&output))
+
+		err := runner.Run(context.Background(), PreCapture, Context{ProjectNumber: 42})
+		assert.ErrorContains(t, err, `failed to run pre-capture hook "exit 3"`)
+		assert.Empty(t, output.String())
+	})
+}
@@ -0,0 +1,13 @@
+//go:build windows
+
+package hooks
+
+import (
+	"context"
+	"os/exec"
+)
+
+// shellCommand returns the command running a command line in the system shell
+func shellCommand(ctx context.Context, line string) *exec.Cmd {
+	return exec.CommandContext(ctx, "cmd", "/C", line)
+}
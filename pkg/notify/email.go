@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+)
+
+// Formats of email reports
+const (
+	EmailHTML     = "html"
+	EmailMarkdown = "markdown"
+)
+
+// emailHealth maps project health to the subject of an email
+var emailHealth = map[format.Health]string{
+	format.HealthOnTrack:  "On track",
+	format.HealthAtRisk:   "At risk",
+	format.HealthOffTrack: "Off track",
+}
+
+// EmailConfig contains the SMTP server and recipients of email reports
+type EmailConfig struct {
+	Host     string   // SMTP server
+	Port     int      // SMTP port, 587 if not set
+	Username string   // User of PLAIN authentication, no authentication if empty
+	Password string   // Password of PLAIN authentication
+	From     string   // Sender address
+	To       []string // Recipient addresses
+	Format   string   // EmailHTML (default) or EmailMarkdown
+}
+
+// sendMailFunc sends a message over SMTP, as smtp.SendMail
+type sendMailFunc func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailNotifier sends reports by email over SMTP
+type EmailNotifier struct {
+	config   EmailConfig
+	sendMail sendMailFunc
+	now      func() time.Time
+}
+
+// NewEmailNotifier creates a notifier sending reports with the given configuration
+func NewEmailNotifier(config EmailConfig) (*EmailNotifier, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("email notifications require an SMTP host")
+	}
+	if config.From == "" {
+		return nil, fmt.Errorf("email notifications require a sender address")
+	}
+	if len(config.To) == 0 {
+		return nil, fmt.Errorf("email notifications require at least one recipient")
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	switch config.Format {
+	case "":
+		config.Format = EmailHTML
+	case EmailHTML, EmailMarkdown:
+	default:
+		return nil, fmt.Errorf("invalid email format: %s (must be one of: %s, %s)", config.Format, EmailHTML, EmailMarkdown)
+	}
+	return &EmailNotifier{config: config, sendMail: smtp.SendMail, now: time.Now}, nil
+}
+
+// Notify sends the full report to all recipients
+func (n *EmailNotifier) Notify(ctx context.Context, report Report) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	message, err := n.BuildMessage(report)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+	}
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+	if err := n.sendMail(addr, auth, n.config.From, n.config.To, message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// BuildMessage builds the email of the report, with the health and number of delayed
+// items in the subject and the report in HTML or Markdown as body
+func (n *EmailNotifier) BuildMessage(report Report) ([]byte, error) {
+	health := format.ClassifyHealth(report.Diff, report.Options)
+	delayed := format.DelayedItems(report.Diff, report.Options)
+	subject := fmt.Sprintf("Project %d timeline report: %s, %d delayed", report.ProjectNumber, emailHealth[health], len(delayed))
+
+	options := func(o *format.FormatterOptions) { *o = report.Options }
+	body, contentType := format.NewHTMLFormatter(options).Format(report.Diff), "text/html"
+	if n.config.Format == EmailMarkdown {
+		body, contentType = format.NewTableFormatter(options).Format(report.Diff), "text/markdown"
+	}
+
+	var msg bytes.Buffer
+	for _, header := range [][2]string{
+		{"From", n.config.From},
+		{"To", strings.Join(n.config.To, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", n.now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", contentType + "; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	} {
+		fmt.Fprintf(&msg, "%s: %s\r\n", header[0], header[1])
+	}
+	msg.WriteString("\r\n")
+
+	// Quoted-printable keeps lines within the limits of SMTP
+	qp := quotedprintable.NewWriter(&msg)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return nil, fmt.Errorf("failed to encode email: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode email: %w", err)
+	}
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEmailNotifier(t *testing.T) {
+	valid := EmailConfig{Host: "smtp.example.com", From: "reports@example.com", To: []string{"team@example.com"}}
+
+	notifier, err := NewEmailNotifier(valid)
+	require.NoError(t, err)
+	assert.Equal(t, 587, notifier.config.Port)
+	assert.Equal(t, EmailHTML, notifier.config.Format)
+
+	tests := []struct {
+		name   string
+		modify func(*EmailConfig)
+		err    string
+	}{
+		{"missing host", func(c *EmailConfig) { c.Host = "" }, "require an SMTP host"},
+		{"missing sender", func(c *EmailConfig) { c.From = "" }, "require a sender address"},
+		{"missing recipients", func(c *EmailConfig) { c.To = nil }, "require at least one recipient"},
+		{"invalid format", func(c *EmailConfig) { c.Format = "pdf" }, "invalid email format: pdf (must be one of: html, markdown)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			_, err := NewEmailNotifier(config)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	notifier, err := NewEmailNotifier(EmailConfig{
+		Host:     "smtp.example.com",
+		Port:     2525,
+		Username: "bot",
+		Password: "secret",
+		From:     "reports@example.com",
+		To:       []string{"alice@example.com", "bob@example.com"},
+		Format:   EmailMarkdown,
+	})
+	require.NoError(t, err)
+	notifier.now = func() time.Time { return time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC) }
+
+	var addr, from string
+	var to []string
+	var message []byte
+	notifier.sendMail = func(a string, auth smtp.Auth, f string, t []string, msg []byte) error {
+		addr, from, to, message = a, f, t, msg
+		return nil
+	}
+	require.NoError(t, notifier.Notify(context.Background(), testReport(1)))
+
+	assert.Equal(t, "smtp.example.com:2525", addr)
+	assert.Equal(t, "reports@example.com", from)
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, to)
+
+	header, body, ok := strings.Cut(string(message), "\r\n\r\n")
+	require.True(t, ok)
+	assert.Contains(t, header, "To: alice@example.com, bob@example.com\r\n")
+	assert.Contains(t, header, "Subject: Project 7 timeline report: Off track, 1 delayed\r\n")
+	assert.Contains(t, header, "Date: Mon, 08 Jan 2024 09:00:00 +0000\r\n")
+	assert.Contains(t, header, "Content-Type: text/markdown; charset=utf-8\r\n")
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	require.NoError(t, err)
+	assert.Contains(t, string(decoded), "Task <0>")
+
+	notifier.sendMail = func(string, smtp.Auth, string, []string, []byte) error {
+		return errors.New("connection refused")
+	}
+	assert.EqualError(t, notifier.Notify(context.Background(), testReport(0)), "failed to send email: connection refused")
+}
@@ -0,0 +1,69 @@
+// Package notify delivers reports to chat tools and by email.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// Report is the content delivered by notifiers
+type Report struct {
+	ProjectNumber int
+	Diff          types.ProjectDiff
+	Options       format.FormatterOptions
+}
+
+// Notifier delivers a report to a notification target
+type Notifier interface {
+	Notify(ctx context.Context, report Report) error
+}
+
+// Config contains the settings of all notification targets
+type Config struct {
+	SlackWebhook string      // Incoming webhook URL of the Slack channel
+	TeamsWebhook string      // Webhook URL of the Teams channel
+	Email        EmailConfig // SMTP server and recipients of email reports
+}
+
+// constructors maps notification target names to notifier constructors
+var constructors = map[string]func(Config) (Notifier, error){
+	"email": func(cfg Config) (Notifier, error) {
+		return NewEmailNotifier(cfg.Email)
+	},
+	"slack": func(cfg Config) (Notifier, error) {
+		if cfg.SlackWebhook == "" {
+			return nil, fmt.Errorf("slack notifications require a webhook URL")
+		}
+		return NewSlackNotifier(cfg.SlackWebhook), nil
+	},
+	"teams": func(cfg Config) (Notifier, error) {
+		if cfg.TeamsWebhook == "" {
+			return nil, fmt.Errorf("teams notifications require a webhook URL")
+		}
+		return NewTeamsNotifier(cfg.TeamsWebhook), nil
+	},
+}
+
+// Names returns the names of all notification targets in sorted order
+func Names() []string {
+	names := make([]string, 0, len(constructors))
+	for name := range constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the notifier for the given target name
+func New(name string, cfg Config) (Notifier, error) {
+	constructor, ok := constructors[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid notification target: %s (must be one of: %s)", name, strings.Join(Names(), ", "))
+	}
+	return constructor(cfg)
+}
@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	notifier, err := New("slack", Config{SlackWebhook: "https://hooks.slack.com/services/T/B/X"})
+	require.NoError(t, err)
+	assert.IsType(t, &SlackNotifier{}, notifier)
+
+	_, err = New("slack", Config{})
+	assert.ErrorContains(t, err, "require a webhook URL")
+
+	notifier, err = New("email", Config{Email: EmailConfig{Host: "smtp.example.com", From: "reports@example.com", To: []string{"team@example.com"}}})
+	require.NoError(t, err)
+	assert.IsType(t, &EmailNotifier{}, notifier)
+
+	_, err = New("email", Config{})
+	assert.ErrorContains(t, err, "require an SMTP host")
+
+	notifier, err = New("teams", Config{TeamsWebhook: "https://example.webhook.office.com/webhookb2/X"})
+	require.NoError(t, err)
+	assert.IsType(t, &TeamsNotifier{}, notifier)
+
+	_, err = New("teams", Config{})
+	assert.ErrorContains(t, err, "require a webhook URL")
+
+	_, err = New("discord", Config{})
+	assert.ErrorContains(t, err, "invalid notification target: discord (must be one of: email, slack, teams)")
+}
@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+)
+
+// slackDelayedLimit is the number of delayed items listed in a Slack message
+const slackDelayedLimit = 5
+
+// slackHealth maps project health to the status line of a Slack message
+var slackHealth = map[format.Health]string{
+	format.HealthOnTrack:  ":large_green_circle: On track",
+	format.HealthAtRisk:   ":large_orange_circle: At risk",
+	format.HealthOffTrack: ":red_circle: Off track",
+}
+
+// slackEscaper escapes the characters Slack treats as control characters in text
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// SlackMessage is a Slack message built from Block Kit blocks
+type SlackMessage struct {
+	Text   string       `json:"text"` // Fallback for notifications
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit layout block
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object
+type SlackText struct {
+	Type string `json:"type"` // plain_text or mrkdwn
+	Text string `json:"text"`
+}
+
+// SlackNotifier posts reports to a Slack incoming webhook
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackNotifier creates a notifier posting to the given incoming webhook URL
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Notify posts the report as a Block Kit message
+func (n *SlackNotifier) Notify(ctx context.Context, report Report) error {
+	payload, err := json.Marshal(BuildSlackMessage(report))
+	if err != nil {
+		return fmt.Errorf("failed to encode slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post slack message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to post slack message: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// BuildSlackMessage converts a report into a Slack message with a compact
+// summary and the most delayed items, linked to GitHub where possible
+func BuildSlackMessage(report Report) SlackMessage {
+	diff := report.Diff
+	health := format.ClassifyHealth(diff, report.Options)
+	delayed := format.DelayedItems(diff, report.Options)
+
+	title := fmt.Sprintf("Project %d timeline report", report.ProjectNumber)
+	summary := fmt.Sprintf("%s\n*%d added, %d removed, %d changed, %d delayed*",
+		slackHealth[health], len(diff.AddedItems), len(diff.RemovedItems), len(diff.ChangedItems), len(delayed))
+
+	message := SlackMessage{
+		Text: fmt.Sprintf("%s: %d delayed", title, len(delayed)),
+		Blocks: []SlackBlock{
+			{Type: "header", Text: &SlackText{Type: "plain_text", Text: title}},
+			{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: summary}},
+		},
+	}
+
+	if len(delayed) == 0 {
+		return message
+	}
+
+	urls := make(map[string]string)
+	for _, change := range diff.ChangedItems {
+		urls[change.ItemID] = change.After.URL
+	}
+
+	var sb strings.Builder
+	for i, item := range delayed {
+		if i == slackDelayedLimit {
+			break
+		}
+		title := slackEscaper.Replace(item.Title)
+		if url := urls[item.ID]; url != "" {
+			title = fmt.Sprintf("<%s|%s>", url, title)
+		}
+		sb.WriteString(fmt.Sprintf("• %s: %s\n", title, item.Level))
+	}
+	message.Blocks = append(message.Blocks,
+		SlackBlock{Type: "divider"},
+		SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: strings.TrimSuffix(sb.String(), "\n")}},
+	)
+
+	if len(delayed) > slackDelayedLimit {
+		message.Blocks = append(message.Blocks, SlackBlock{
+			Type:     "context",
+			Elements: []SlackText{{Type: "mrkdwn", Text: fmt.Sprintf("… and %d more delayed items", len(delayed)-slackDelayedLimit)}},
+		})
+	}
+
+	return message
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testReport creates a report with the given number of items slipping by 45 days
+func testReport(delayed int) Report {
+	var diff types.ProjectDiff
+	for i := 0; i < delayed; i++ {
+		item := types.Item{
+			ID:         fmt.Sprint(i),
+			URL:        fmt.Sprintf("https://github.com/org/repo/issues/%d", i),
+			Attributes: map[string]interface{}{"Title": fmt.Sprintf("Task <%d>", i)},
+		}
+		diff.ChangedItems = append(diff.ChangedItems, types.ItemDiff{
+			ItemID:     item.ID,
+			Before:     item,
+			After:      item,
+			DateChange: &types.DateSpanChange{EndDaysDelta: 45, DurationDelta: 45},
+		})
+	}
+	return Report{ProjectNumber: 7, Diff: diff, Options: format.DefaultOptions()}
+}
+
+func TestBuildSlackMessage(t *testing.T) {
+	message := BuildSlackMessage(testReport(1))
+
+	assert.Equal(t, "Project 7 timeline report: 1 delayed", message.Text)
+	require.Len(t, message.Blocks, 4)
+	assert.Equal(t, "header", message.Blocks[0].Type)
+	assert.Equal(t, ":red_circle: Off track\n*0 added, 0 removed, 1 changed, 1 delayed*", message.Blocks[1].Text.Text)
+	assert.Equal(t, "divider", message.Blocks[2].Type)
+	assert.Equal(t, "• <https://github.com/org/repo/issues/0|Task &lt;0&gt;>: 🚫 Extreme delay", message.Blocks[3].Text.Text)
+
+	// Long lists are truncated
+	message = BuildSlackMessage(testReport(7))
+	require.Len(t, message.Blocks, 5)
+	assert.Equal(t, "… and 2 more delayed items", message.Blocks[4].Elements[0].Text)
+
+	// Without delays only the summary is sent
+	message = BuildSlackMessage(testReport(0))
+	assert.Len(t, message.Blocks, 2)
+	assert.Contains(t, message.Blocks[1].Text.Text, ":large_green_circle: On track")
+}
+
+func TestSlackNotifier(t *testing.T) {
+	var received SlackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	err := NewSlackNotifier(server.URL).Notify(context.Background(), testReport(1))
+	require.NoError(t, err)
+	assert.Equal(t, "Project 7 timeline report: 1 delayed", received.Text)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer failing.Close()
+
+	err = NewSlackNotifier(failing.URL).Notify(context.Background(), testReport(1))
+	assert.EqualError(t, err, "failed to post slack message: 403 Forbidden: invalid_token")
+}
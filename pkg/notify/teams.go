@@ -0,0 +1,169 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+)
+
+// teamsDelayedLimit is the number of delayed items listed in a Teams message
+const teamsDelayedLimit = 5
+
+// teamsStatus is the status line of a Teams message and its Adaptive Card color
+type teamsStatus struct {
+	text  string
+	color string
+}
+
+// teamsHealth maps project health to the status line of a Teams message
+var teamsHealth = map[format.Health]teamsStatus{
+	format.HealthOnTrack:  {"🟢 On track", "Good"},
+	format.HealthAtRisk:   {"🟠 At risk", "Warning"},
+	format.HealthOffTrack: {"🔴 Off track", "Attention"},
+}
+
+// teamsEscaper escapes the characters ending the text of Markdown links in Adaptive Cards
+var teamsEscaper = strings.NewReplacer("[", `\[`, "]", `\]`)
+
+// TeamsMessage is a message to a Teams channel webhook carrying an Adaptive Card
+type TeamsMessage struct {
+	Type        string            `json:"type"` // Always "message"
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment is an attachment of a Teams message
+type TeamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	Content     TeamsCard `json:"content"`
+}
+
+// TeamsCard is an Adaptive Card
+type TeamsCard struct {
+	Schema  string         `json:"$schema"`
+	Type    string         `json:"type"` // Always "AdaptiveCard"
+	Version string         `json:"version"`
+	Body    []TeamsElement `json:"body"`
+}
+
+// TeamsElement is an element of an Adaptive Card, a TextBlock or a FactSet
+type TeamsElement struct {
+	Type      string      `json:"type"`
+	Text      string      `json:"text,omitempty"`
+	Size      string      `json:"size,omitempty"`
+	Weight    string      `json:"weight,omitempty"`
+	Color     string      `json:"color,omitempty"`
+	IsSubtle  bool        `json:"isSubtle,omitempty"`
+	Wrap      bool        `json:"wrap,omitempty"`
+	Separator bool        `json:"separator,omitempty"`
+	Facts     []TeamsFact `json:"facts,omitempty"`
+}
+
+// TeamsFact is a title and value of a FactSet
+type TeamsFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// TeamsNotifier posts reports to a Teams channel webhook
+type TeamsNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewTeamsNotifier creates a notifier posting to the given channel webhook URL
+func NewTeamsNotifier(webhookURL string) *TeamsNotifier {
+	return &TeamsNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Notify posts the report as an Adaptive Card
+func (n *TeamsNotifier) Notify(ctx context.Context, report Report) error {
+	payload, err := json.Marshal(BuildTeamsMessage(report))
+	if err != nil {
+		return fmt.Errorf("failed to encode teams message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create teams request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post teams message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Webhooks of Teams workflows accept messages with 202 Accepted
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to post teams message: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// BuildTeamsMessage converts a report into a Teams message with an Adaptive Card of a
+// compact summary and the most delayed items, linked to GitHub where possible
+func BuildTeamsMessage(report Report) TeamsMessage {
+	diff := report.Diff
+	status := teamsHealth[format.ClassifyHealth(diff, report.Options)]
+	delayed := format.DelayedItems(diff, report.Options)
+
+	body := []TeamsElement{
+		{Type: "TextBlock", Text: fmt.Sprintf("Project %d timeline report", report.ProjectNumber), Size: "Large", Weight: "Bolder", Wrap: true},
+		{Type: "TextBlock", Text: status.text, Color: status.color, Weight: "Bolder"},
+		{Type: "FactSet", Facts: []TeamsFact{
+			{Title: "Added", Value: fmt.Sprint(len(diff.AddedItems))},
+			{Title: "Removed", Value: fmt.Sprint(len(diff.RemovedItems))},
+			{Title: "Changed", Value: fmt.Sprint(len(diff.ChangedItems))},
+			{Title: "Delayed", Value: fmt.Sprint(len(delayed))},
+		}},
+	}
+
+	if len(delayed) > 0 {
+		urls := make(map[string]string)
+		for _, change := range diff.ChangedItems {
+			urls[change.ItemID] = change.After.URL
+		}
+
+		var sb strings.Builder
+		for i, item := range delayed {
+			if i == teamsDelayedLimit {
+				break
+			}
+			title := teamsEscaper.Replace(item.Title)
+			if url := urls[item.ID]; url != "" {
+				title = fmt.Sprintf("[%s](%s)", title, url)
+			}
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", title, item.Level))
+		}
+		body = append(body, TeamsElement{Type: "TextBlock", Text: strings.TrimSuffix(sb.String(), "\n"), Wrap: true, Separator: true})
+
+		if len(delayed) > teamsDelayedLimit {
+			body = append(body, TeamsElement{Type: "TextBlock", Text: fmt.Sprintf("… and %d more delayed items", len(delayed)-teamsDelayedLimit), IsSubtle: true, Wrap: true})
+		}
+	}
+
+	return TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: TeamsCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+			},
+		}},
+	}
+}
@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTeamsMessage(t *testing.T) {
+	message := BuildTeamsMessage(testReport(1))
+
+	require.Len(t, message.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", message.Attachments[0].ContentType)
+	body := message.Attachments[0].Content.Body
+	require.Len(t, body, 4)
+	assert.Equal(t, "Project 7 timeline report", body[0].Text)
+	assert.Equal(t, TeamsElement{Type: "TextBlock", Text: "🔴 Off track", Color: "Attention", Weight: "Bolder"}, body[1])
+	assert.Equal(t, TeamsFact{Title: "Delayed", Value: "1"}, body[2].Facts[3])
+	assert.Equal(t, "- [Task <0>](https://github.com/org/repo/issues/0): 🚫 Extreme delay", body[3].Text)
+
+	// Long lists are truncated
+	body = BuildTeamsMessage(testReport(7)).Attachments[0].Content.Body
+	require.Len(t, body, 5)
+	assert.Equal(t, "… and 2 more delayed items", body[4].Text)
+
+	// Without delays only the summary is sent
+	body = BuildTeamsMessage(testReport(0)).Attachments[0].Content.Body
+	require.Len(t, body, 3)
+	assert.Equal(t, "🟢 On track", body[1].Text)
+}
+
+func TestTeamsNotifier(t *testing.T) {
+	var received TeamsMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	err := NewTeamsNotifier(server.URL).Notify(context.Background(), testReport(1))
+	require.NoError(t, err)
+	assert.Equal(t, "message", received.Type)
+	assert.Equal(t, "AdaptiveCard", received.Attachments[0].Content.Type)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Invalid webhook request", http.StatusBadRequest)
+	}))
+	defer failing.Close()
+
+	err = NewTeamsNotifier(failing.URL).Notify(context.Background(), testReport(1))
+	assert.EqualError(t, err, "failed to post teams message: 400 Bad Request: Invalid webhook request")
+}
@@ -0,0 +1,141 @@
+// Package redact removes secrets such as tokens and webhook URLs from text before
+// it is logged or printed.
+package redact
+
+import (
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask replaces redacted secrets
+const Mask = "[REDACTED]"
+
+// minSecretLength is the length below which registered values are ignored, so that
+// short values such as empty or placeholder settings don't mask unrelated text
+const minSecretLength = 6
+
+// pattern is a regular expression of a secret. Submatch 1, if present, is a prefix
+// that is kept, e.g. the name of a header.
+type pattern struct {
+	re *regexp.Regexp
+}
+
+// patterns match secrets that are recognizable without being registered
+var patterns = []pattern{
+	// GitHub tokens: classic, OAuth, user-to-server, server-to-server, refresh and fine-grained
+	{regexp.MustCompile(`()\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})`)},
+	// Authorization headers
+	{regexp.MustCompile(`(?i)(authorization:\s*(?:bearer|token|basic)\s+)[^\s"',]+`)},
+	// Slack incoming webhooks
+	{regexp.MustCompile(`(https://hooks\.slack\.com/services/)[A-Za-z0-9/]+`)},
+	// Signatures and credentials in query strings, e.g. presigned URLs
+	{regexp.MustCompile(`(?i)((?:x-amz-signature|x-amz-security-token|x-goog-signature|access_token|client_secret)=)[^&\s"']+`)},
+}
+
+// Redactor removes secrets from text
+type Redactor struct {
+	mu      sync.RWMutex
+	secrets []string // Registered values, longest first
+}
+
+// New creates a redactor that removes recognizable secrets and the given values
+func New(secrets ...string) *Redactor {
+	r := &Redactor{}
+	r.Add(secrets...)
+	return r
+}
+
+// Add registers values that are secret, e.g. a token read from a file
+func (r *Redactor) Add(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, secret := range secrets {
+		secret = strings.TrimSpace(secret)
+		if len(secret) < minSecretLength || contains(r.secrets, secret) {
+			continue
+		}
+		r.secrets = append(r.secrets, secret)
+	}
+
+	// Longer secrets first, so that a secret containing another is masked whole
+	sort.SliceStable(r.secrets, func(i, j int) bool {
+		return len(r.secrets[i]) > len(r.secrets[j])
+	})
+}
+
+// String returns the text with all secrets masked
+func (r *Redactor) String(s string) string {
+	r.mu.RLock()
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+	r.mu.RUnlock()
+
+	for _, p := range patterns {
+		s = p.re.ReplaceAllString(s, "${1}"+Mask)
+	}
+	return s
+}
+
+// Writer returns a writer masking secrets in everything written to w. Each write is
+// redacted on its own, which suits loggers writing whole lines.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	return &writer{redactor: r, w: w}
+}
+
+// writer masks secrets before writing to the underlying writer
+type writer struct {
+	redactor *Redactor
+	w        io.Writer
+}
+
+// Write writes the redacted data. It reports the length of p as written, as
+// callers can't relate a shorter write of the redacted text to their data.
+func (w *writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, w.redactor.String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// contains returns true if the value is in the list
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Default is the redactor used by the package-level functions
+var Default = New()
+
+// Add registers secret values with the default redactor
+func Add(secrets ...string) {
+	Default.Add(secrets...)
+}
+
+// String masks secrets with the default redactor
+func String(s string) string {
+	return Default.String(s)
+}
+
+// Writer returns a writer masking secrets with the default redactor
+func Writer(w io.Writer) io.Writer {
+	return Default.Writer(w)
+}
+
+// Preview describes a token for logs without revealing it, keeping only a known
+// prefix that tells the kind of token, e.g. "ghp_[REDACTED]"
+func Preview(token string) string {
+	for _, prefix := range []string{"github_pat_", "ghp_", "gho_", "ghu_", "ghs_", "ghr_"} {
+		if strings.HasPrefix(token, prefix) {
+			return prefix + Mask
+		}
+	}
+	return Mask
+}
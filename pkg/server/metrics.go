@@ -0,0 +1,227 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/storage"
+)
+
+// noStatus is the status label of items without a status
+const noStatus = "No Status"
+
+// MetricsWriter writes metrics in the Prometheus text exposition format
+type MetricsWriter interface {
+	WritePrometheus(w io.Writer) error
+}
+
+// MetricsHandler serves the metrics of the writers in the Prometheus text format
+func MetricsHandler(writers ...MetricsWriter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		for _, writer := range writers {
+			if err := writer.WritePrometheus(w); err != nil {
+				log.Printf("Failed to write metrics: %v\n", err)
+				return
+			}
+		}
+	})
+}
+
+// ProjectMetrics writes gauges of the health of projects from their latest snapshot:
+// items per status, items per delay level and total slip days. Delays are measured
+// against the latest snapshot at least Window older, or the first snapshot.
+type ProjectMetrics struct {
+	Store       storage.StateStore
+	Projects    []int         // Projects to report, all projects of the store if empty
+	Window      time.Duration // Age of the snapshot delays are measured against
+	StatusField string        // Field of the status of items, e.g. Status
+	Options     []func(*format.FormatterOptions)
+}
+
+// projectHealth is the health of a project at its latest snapshot
+type projectHealth struct {
+	number      int
+	snapshot    time.Time
+	items       int
+	statuses    map[string]int
+	delayLevels map[string]int
+	slipDays    int
+}
+
+// WritePrometheus writes the metrics of the projects, loading their snapshots
+func (m *ProjectMetrics) WritePrometheus(w io.Writer) error {
+	projects := m.Projects
+	if len(projects) == 0 {
+		var err error
+		if projects, err = m.Store.ListProjects(); err != nil {
+			return fmt.Errorf("failed to list projects: %w", err)
+		}
+	}
+
+	var healths []projectHealth
+	for _, number := range projects {
+		health, ok, err := m.health(number)
+		if err != nil {
+			return err
+		}
+		if ok {
+			healths = append(healths, health)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# HELP gh_project_report_snapshot_timestamp_seconds Time of the latest snapshot of the project.\n")
+	sb.WriteString("# TYPE gh_project_report_snapshot_timestamp_seconds gauge\n")
+	for _, h := range healths {
+		fmt.Fprintf(&sb, "gh_project_report_snapshot_timestamp_seconds{project=\"%d\"} %d\n", h.number, h.snapshot.Unix())
+	}
+	sb.WriteString("# HELP gh_project_report_items Items of the latest snapshot of the project.\n")
+	sb.WriteString("# TYPE gh_project_report_items gauge\n")
+	for _, h := range healths {
+		fmt.Fprintf(&sb, "gh_project_report_items{project=\"%d\"} %d\n", h.number, h.items)
+	}
+	sb.WriteString("# HELP gh_project_report_items_by_status Items of the latest snapshot per status.\n")
+	sb.WriteString("# TYPE gh_project_report_items_by_status gauge\n")
+	for _, h := range healths {
+		for _, status := range sortedKeys(h.statuses) {
+			fmt.Fprintf(&sb, "gh_project_report_items_by_status{project=\"%d\",status=%q} %d\n", h.number, status, h.statuses[status])
+		}
+	}
+	sb.WriteString("# HELP gh_project_report_items_by_delay_level Items with a date change within the window per delay level.\n")
+	sb.WriteString("# TYPE gh_project_report_items_by_delay_level gauge\n")
+	for _, h := range healths {
+		for _, level := range format.DelayLevelKeys() {
+			fmt.Fprintf(&sb, "gh_project_report_items_by_delay_level{project=\"%d\",level=%q} %d\n", h.number, level, h.delayLevels[level])
+		}
+	}
+	sb.WriteString("# HELP gh_project_report_slip_days Days end dates moved later within the window, summed over all items.\n")
+	sb.WriteString("# TYPE gh_project_report_slip_days gauge\n")
+	for _, h := range healths {
+		fmt.Fprintf(&sb, "gh_project_report_slip_days{project=\"%d\"} %d\n", h.number, h.slipDays)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// health loads the latest snapshot of the project and the one delays are measured
+// against. It is not ok if the project has no snapshots.
+func (m *ProjectMetrics) health(number int) (projectHealth, bool, error) {
+	timestamps, err := m.Store.ListTimestamps(number)
+	if err != nil {
+		return projectHealth{}, false, fmt.Errorf("failed to list snapshots of project %d: %w", number, err)
+	}
+	if len(timestamps) == 0 {
+		return projectHealth{}, false, nil
+	}
+	latest := timestamps[len(timestamps)-1]
+	baseline := timestamps[0]
+	for _, ts := range timestamps {
+		if ts.After(latest.Add(-m.Window)) {
+			break
+		}
+		baseline = ts
+	}
+
+	state, err := m.Store.LoadState(number, latest)
+	if err != nil {
+		return projectHealth{}, false, fmt.Errorf("failed to load state: %w", err)
+	}
+	previous, err := m.Store.LoadState(number, baseline)
+	if err != nil {
+		return projectHealth{}, false, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	health := projectHealth{
+		number:   number,
+		snapshot: state.Timestamp,
+		items:    len(state.Items),
+		statuses: make(map[string]int),
+	}
+	for _, item := range state.Items {
+		status, _ := item.Attributes[m.StatusField].(string)
+		if status == "" {
+			status = noStatus
+		}
+		health.statuses[status]++
+	}
+
+	options := format.DefaultOptions()
+	for _, opt := range m.Options {
+		opt(&options)
+	}
+	diff := previous.CompareTo(state)
+	health.delayLevels = format.DelayLevelCounts(*diff, options)
+	for _, change := range diff.ChangedItems {
+		if change.DateChange != nil && change.DateChange.EndDaysDelta > 0 {
+			health.slipDays += change.DateChange.EndDaysDelta
+		}
+	}
+	return health, true, nil
+}
+
+// CaptureMetrics records the captures of a running watch: how many succeeded and
+// failed, and the duration of the latest one. It is safe for concurrent use.
+type CaptureMetrics struct {
+	mu          sync.Mutex
+	successes   int
+	failures    int
+	duration    time.Duration // Duration of the latest capture
+	lastSuccess time.Time
+}
+
+// Record records a capture that started at start and failed with err, if not nil
+func (c *CaptureMetrics) Record(start time.Time, err error) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.duration = now.Sub(start)
+	if err != nil {
+		c.failures++
+		return
+	}
+	c.successes++
+	c.lastSuccess = now
+}
+
+// WritePrometheus writes the capture metrics
+func (c *CaptureMetrics) WritePrometheus(w io.Writer) error {
+	c.mu.Lock()
+	successes, failures, duration, lastSuccess := c.successes, c.failures, c.duration, c.lastSuccess
+	c.mu.Unlock()
+
+	var sb strings.Builder
+	sb.WriteString("# HELP gh_project_report_captures_total Captures of the project state by result.\n")
+	sb.WriteString("# TYPE gh_project_report_captures_total counter\n")
+	fmt.Fprintf(&sb, "gh_project_report_captures_total{result=\"success\"} %d\n", successes)
+	fmt.Fprintf(&sb, "gh_project_report_captures_total{result=\"failure\"} %d\n", failures)
+	sb.WriteString("# HELP gh_project_report_capture_duration_seconds Duration of the latest capture.\n")
+	sb.WriteString("# TYPE gh_project_report_capture_duration_seconds gauge\n")
+	fmt.Fprintf(&sb, "gh_project_report_capture_duration_seconds %g\n", duration.Seconds())
+	if !lastSuccess.IsZero() {
+		sb.WriteString("# HELP gh_project_report_last_capture_success_timestamp_seconds Time of the latest successful capture.\n")
+		sb.WriteString("# TYPE gh_project_report_last_capture_success_timestamp_seconds gauge\n")
+		fmt.Fprintf(&sb, "gh_project_report_last_capture_success_timestamp_seconds %d\n", lastSuccess.Unix())
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// sortedKeys returns the keys of the map in ascending order
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectMetrics(t *testing.T) {
+	store := storage.NewMemoryStore()
+	item := func(id, end, status string) types.Item {
+		return types.Item{
+			ID:         id,
+			DateSpan:   types.MustNewDateSpan("2024-01-01", end),
+			Attributes: map[string]interface{}{"Title": id, "Status": status},
+		}
+	}
+	for _, state := range []*types.ProjectState{
+		{ProjectNumber: 42, Timestamp: time.Unix(1704067200, 0), Items: []types.Item{item("1", "2024-01-10", "Todo"), item("2", "2024-01-10", "")}},
+		{ProjectNumber: 42, Timestamp: time.Unix(1704153600, 0), Items: []types.Item{item("1", "2024-01-31", "Todo"), item("2", "2024-01-10", "")}},
+	} {
+		_, err := store.SaveState(state)
+		require.NoError(t, err)
+	}
+
+	var sb strings.Builder
+	metrics := &ProjectMetrics{Store: store, Window: 24 * time.Hour, StatusField: "Status"}
+	require.NoError(t, metrics.WritePrometheus(&sb))
+	body := sb.String()
+
+	for _, line := range []string{
+		`gh_project_report_snapshot_timestamp_seconds{project="42"} 1704153600`,
+		`gh_project_report_items{project="42"} 2`,
+		`gh_project_report_items_by_status{project="42",status="No Status"} 1`,
+		`gh_project_report_items_by_status{project="42",status="Todo"} 1`,
+		`gh_project_report_items_by_delay_level{project="42",level="high"} 1`,
+		`gh_project_report_items_by_delay_level{project="42",level="extreme"} 0`,
+		`gh_project_report_slip_days{project="42"} 21`,
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+
+	// Without a snapshot older than the window, delays are measured against the first one
+	sb.Reset()
+	metrics.Window = 7 * 24 * time.Hour
+	require.NoError(t, metrics.WritePrometheus(&sb))
+	assert.Contains(t, sb.String(), `gh_project_report_slip_days{project="42"} 21`+"\n")
+}
+
+func TestCaptureMetrics(t *testing.T) {
+	var metrics CaptureMetrics
+	var sb strings.Builder
+	require.NoError(t, metrics.WritePrometheus(&sb))
+	assert.NotContains(t, sb.String(), "last_capture_success")
+
+	metrics.Record(time.Now().Add(-2*time.Second), nil)
+	metrics.Record(time.Now(), errors.New("rate limited"))
+	sb.Reset()
+	require.NoError(t, metrics.WritePrometheus(&sb))
+	body := sb.String()
+	assert.Contains(t, body, `gh_project_report_captures_total{result="success"} 1`+"\n")
+	assert.Contains(t, body, `gh_project_report_captures_total{result="failure"} 1`+"\n")
+	assert.Contains(t, body, "gh_project_report_last_capture_success_timestamp_seconds ")
+}
@@ -0,0 +1,270 @@
+// Package server serves stored snapshots and reports of projects over HTTP.
+package server
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// Server is an HTTP handler serving a browser of stored snapshots, single
+// snapshots and HTML reports of diffs between two selected snapshots
+type Server struct {
+	store    storage.StateStore
+	opts     []func(*format.FormatterOptions)
+	renderer *format.HTMLRenderer
+	mux      *http.ServeMux
+}
+
+// New creates a server for the states of the store. The formatter options are
+// applied to all rendered reports.
+func New(store storage.StateStore, opts ...func(*format.FormatterOptions)) *Server {
+	s := &Server{
+		store:    store,
+		opts:     opts,
+		renderer: &format.HTMLRenderer{},
+		mux:      http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /{$}", s.handleProjects)
+	s.mux.HandleFunc("GET /projects/{number}", s.handleSnapshots)
+	s.mux.HandleFunc("GET /projects/{number}/snapshots/{timestamp}", s.handleSnapshot)
+	s.mux.HandleFunc("GET /projects/{number}/diff", s.handleDiff)
+	return s
+}
+
+// HandleMetrics serves the metrics of the writers at /metrics in the Prometheus text format
+func (s *Server) HandleMetrics(writers ...MetricsWriter) {
+	s.mux.Handle("GET /metrics", MetricsHandler(writers...))
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleProjects lists the projects with stored snapshots
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := s.store.ListProjects()
+	if err != nil {
+		s.fail(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var body strings.Builder
+	if len(projects) == 0 {
+		body.WriteString("<p>No snapshots found. Capture a project to browse its history.</p>\n")
+	}
+	body.WriteString("<ul>\n")
+	for _, number := range projects {
+		fmt.Fprintf(&body, "<li><a href=\"/projects/%d\">Project %d</a></li>\n", number, number)
+	}
+	body.WriteString("</ul>\n")
+	s.write(w, s.renderer.RenderPage("Projects", body.String()))
+}
+
+// handleSnapshots lists the snapshots of a project with a form to compare two of them
+func (s *Server) handleSnapshots(w http.ResponseWriter, r *http.Request) {
+	number, ok := s.projectNumber(w, r)
+	if !ok {
+		return
+	}
+
+	states, err := s.store.ListStates(number)
+	if err != nil {
+		s.fail(w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(states) == 0 {
+		s.fail(w, http.StatusNotFound, fmt.Errorf("no snapshots found for project %d", number))
+		return
+	}
+
+	// Item counts are loaded from the snapshots unless the store indexes them
+	snapshots := make([]format.Snapshot, len(states))
+	for i, info := range states {
+		items, err := storage.CountItems(s.store, info)
+		if err != nil {
+			s.fail(w, http.StatusInternalServerError, err)
+			return
+		}
+		snapshots[i] = format.Snapshot{Timestamp: info.Timestamp, Filename: info.Filename, Items: items, Size: info.Size}
+	}
+
+	doc := format.BuildSnapshotsDocument(number, snapshots)
+	table := doc.Sections[0].Table
+	table.Links = make(map[string]string)
+	for i, snapshot := range snapshots {
+		table.Links[table.Rows[i][0]] = snapshotPath(number, snapshot.Timestamp)
+	}
+
+	var body strings.Builder
+	body.WriteString(compareForm(number, snapshots))
+	for _, section := range doc.Sections {
+		body.WriteString(s.renderer.RenderSection(&section))
+	}
+	s.write(w, s.renderer.RenderPage(doc.Title, body.String()))
+}
+
+// handleSnapshot shows all items of a single snapshot
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	number, ok := s.projectNumber(w, r)
+	if !ok {
+		return
+	}
+	timestamp, err := parseTimestamp(r.PathValue("timestamp"))
+	if err != nil {
+		s.fail(w, http.StatusBadRequest, err)
+		return
+	}
+
+	state, ok := s.loadState(w, number, timestamp)
+	if !ok {
+		return
+	}
+
+	doc := format.BuildStateDocument(state, format.DefaultOptions().Duration)
+	s.write(w, s.renderer.RenderDocument(&doc))
+}
+
+// handleDiff renders the HTML report of the changes between the snapshots
+// closest to the from and to query parameters
+func (s *Server) handleDiff(w http.ResponseWriter, r *http.Request) {
+	number, ok := s.projectNumber(w, r)
+	if !ok {
+		return
+	}
+	from, err := parseTimestamp(r.URL.Query().Get("from"))
+	if err != nil {
+		s.fail(w, http.StatusBadRequest, fmt.Errorf("invalid from: %w", err))
+		return
+	}
+	to, err := parseTimestamp(r.URL.Query().Get("to"))
+	if err != nil {
+		s.fail(w, http.StatusBadRequest, fmt.Errorf("invalid to: %w", err))
+		return
+	}
+	if from.After(to) {
+		from, to = to, from
+	}
+
+	fromState, ok := s.loadState(w, number, from)
+	if !ok {
+		return
+	}
+	toState, ok := s.loadState(w, number, to)
+	if !ok {
+		return
+	}
+
+	opts := append([]func(*format.FormatterOptions){}, s.opts...)
+	if palette, err := s.store.LoadPalette(number); err == nil {
+		opts = append(opts, format.WithPalette(palette))
+	}
+	if toState.Timezone != "" {
+		loc, err := toState.Location()
+		if err != nil {
+			s.fail(w, http.StatusInternalServerError, err)
+			return
+		}
+		opts = append(opts, format.WithLocation(loc))
+	}
+
+	// Freeze windows don't count as slip, as in reports of the diff command
+	options := format.DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	diff := fromState.CompareTo(toState)
+	diff.DiscountFreezes(options.FreezeWindows)
+	s.write(w, format.NewHTMLFormatter(opts...).Format(*diff))
+}
+
+// projectNumber parses the project number of the request path, failing the request if invalid
+func (s *Server) projectNumber(w http.ResponseWriter, r *http.Request) (int, bool) {
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil || number <= 0 {
+		s.fail(w, http.StatusBadRequest, fmt.Errorf("invalid project number: %s", r.PathValue("number")))
+		return 0, false
+	}
+	return number, true
+}
+
+// loadState loads the state closest to the timestamp, failing the request if there is none
+func (s *Server) loadState(w http.ResponseWriter, number int, timestamp time.Time) (*types.ProjectState, bool) {
+	state, err := s.store.LoadState(number, timestamp)
+	if err != nil {
+		s.fail(w, http.StatusNotFound, err)
+		return nil, false
+	}
+	return state, true
+}
+
+// write writes an HTML page
+func (s *Server) write(w http.ResponseWriter, page string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write([]byte(page)); err != nil {
+		log.Printf("Failed to write response: %v\n", err)
+	}
+}
+
+// fail writes an error page with the given status
+func (s *Server) fail(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	body := "<p>" + html.EscapeString(err.Error()) + "</p>\n<p><a href=\"/\">Back to projects</a></p>\n"
+	if _, err := w.Write([]byte(s.renderer.RenderPage(http.StatusText(status), body))); err != nil {
+		log.Printf("Failed to write response: %v\n", err)
+	}
+}
+
+// compareForm renders the form selecting two snapshots to compare, preselecting
+// the two most recent ones
+func compareForm(number int, snapshots []format.Snapshot) string {
+	options := func(selected int) string {
+		var sb strings.Builder
+		for i := len(snapshots) - 1; i >= 0; i-- {
+			attr := ""
+			if i == selected {
+				attr = " selected"
+			}
+			fmt.Fprintf(&sb, "<option value=\"%d\"%s>%s</option>\n", snapshots[i].Timestamp.Unix(), attr,
+				html.EscapeString(snapshots[i].Timestamp.Local().Format("2006-01-02 15:04:05")))
+		}
+		return sb.String()
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<form action=\"/projects/%d/diff\" method=\"get\">\n", number)
+	sb.WriteString("<label>From <select name=\"from\">\n" + options(max(len(snapshots)-2, 0)) + "</select></label>\n")
+	sb.WriteString("<label>To <select name=\"to\">\n" + options(len(snapshots)-1) + "</select></label>\n")
+	sb.WriteString("<button type=\"submit\">Compare</button>\n</form>\n")
+	return sb.String()
+}
+
+// snapshotPath returns the path of the page of a snapshot
+func snapshotPath(number int, timestamp time.Time) string {
+	return fmt.Sprintf("/projects/%d/snapshots/%d", number, timestamp.Unix())
+}
+
+// parseTimestamp parses a timestamp given as Unix seconds or in RFC3339 format
+func parseTimestamp(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, fmt.Errorf("missing timestamp")
+	}
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q (must be Unix seconds or RFC3339)", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	store := storage.NewMemoryStore()
+	item := func(end string) types.Item {
+		return types.Item{
+			ID:         "1",
+			DateSpan:   types.MustNewDateSpan("2024-01-01", end),
+			Attributes: map[string]interface{}{"Title": "Launch <beta>", "Status": "Todo"},
+		}
+	}
+	for _, state := range []*types.ProjectState{
+		{ProjectNumber: 42, Timestamp: time.Unix(1704067200, 0), Items: []types.Item{item("2024-01-10")}},
+		{ProjectNumber: 42, Timestamp: time.Unix(1704153600, 0), Items: []types.Item{item("2024-01-31")}},
+	} {
+		_, err := store.SaveState(state)
+		require.NoError(t, err)
+	}
+
+	srv := httptest.NewServer(New(store))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, url string) (int, string) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestServer(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains []string
+	}{
+		{
+			name:     "projects",
+			path:     "/",
+			status:   http.StatusOK,
+			contains: []string{`<a href="/projects/42">Project 42</a>`},
+		},
+		{
+			name:   "snapshots",
+			path:   "/projects/42",
+			status: http.StatusOK,
+			contains: []string{
+				`<form action="/projects/42/diff"`,
+				`<option value="1704067200" selected>`,
+				`<a href="/projects/42/snapshots/1704153600">`,
+			},
+		},
+		{
+			name:     "snapshot",
+			path:     "/projects/42/snapshots/1704067200",
+			status:   http.StatusOK,
+			contains: []string{"Launch &lt;beta&gt;", "2024-01-10"},
+		},
+		{
+			name:     "diff",
+			path:     "/projects/42/diff?from=1704067200&to=1704153600",
+			status:   http.StatusOK,
+			contains: []string{"Launch &lt;beta&gt;", "<table>"},
+		},
+		{
+			name:     "diff with reversed range and RFC3339 timestamps",
+			path:     "/projects/42/diff?from=2024-01-02T00:00:00Z&to=2024-01-01T00:00:00Z",
+			status:   http.StatusOK,
+			contains: []string{"Launch &lt;beta&gt;"},
+		},
+		{
+			name:     "diff without timestamps",
+			path:     "/projects/42/diff",
+			status:   http.StatusBadRequest,
+			contains: []string{"invalid from: missing timestamp"},
+		},
+		{
+			name:     "invalid project",
+			path:     "/projects/abc",
+			status:   http.StatusBadRequest,
+			contains: []string{"invalid project number"},
+		},
+		{
+			name:     "unknown project",
+			path:     "/projects/7",
+			status:   http.StatusNotFound,
+			contains: []string{"no snapshots found for project 7"},
+		},
+		{
+			name:   "unknown path",
+			path:   "/nope",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := get(t, srv.URL+tt.path)
+			assert.Equal(t, tt.status, status)
+			for _, s := range tt.contains {
+				assert.Contains(t, body, s)
+			}
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	ts, err := parseTimestamp("1704067200")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1704067200), ts.Unix())
+
+	ts, err = parseTimestamp("2024-01-01T00:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1704067200), ts.Unix())
+
+	_, err = parseTimestamp("yesterday")
+	assert.ErrorContains(t, err, "must be Unix seconds or RFC3339")
+}
+
+func TestServerMetrics(t *testing.T) {
+	metrics := storage.NewMetrics()
+	store, err := storage.NewStore(t.TempDir(), storage.WithMetrics(metrics))
+	require.NoError(t, err)
+	handler := New(store)
+	handler.HandleMetrics(metrics)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	status, _ := get(t, srv.URL+"/")
+	require.Equal(t, http.StatusOK, status)
+
+	status, body := get(t, srv.URL+"/metrics")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `gh_project_report_store_operations_total{operation="ListProjects"} 1`)
+}
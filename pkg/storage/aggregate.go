@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// aggregatesDir is the directory within a project directory holding monthly aggregates
+const aggregatesDir = "monthly"
+
+// SaveAggregate saves a monthly aggregate next to the raw states of its project
+func (s *Store) SaveAggregate(aggregate *types.MonthlyAggregate) (string, error) {
+	if aggregate.ProjectNumber == 0 {
+		return "", fmt.Errorf("invalid aggregate: project number is required")
+	}
+
+	dir := s.aggregateDir(aggregate.ProjectNumber)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("failed to create aggregates directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(aggregate, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal aggregate: %w", err)
+	}
+	if data, err = s.options.seal(data); err != nil {
+		return "", err
+	}
+
+	filename := filepath.Join(dir, aggregate.Month+".json")
+	err = writeFileAtomic(filename, data, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to write aggregate file: %w", err)
+	}
+
+	return filename, nil
+}
+
+// ListAggregates returns the monthly aggregates of a project sorted by month
+func (s *Store) ListAggregates(projectNumber int) ([]types.MonthlyAggregate, error) {
+	dir := s.aggregateDir(projectNumber)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read aggregates directory: %w", err)
+	}
+
+	var aggregates []types.MonthlyAggregate
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read aggregate file: %w", err)
+		}
+		if data, err = s.options.open(data); err != nil {
+			return nil, fmt.Errorf("failed to load aggregate %s: %w", file.Name(), err)
+		}
+
+		var aggregate types.MonthlyAggregate
+		if err := json.Unmarshal(data, &aggregate); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal aggregate %s: %w", file.Name(), err)
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+
+	sort.Slice(aggregates, func(i, j int) bool {
+		return aggregates[i].Month < aggregates[j].Month
+	})
+
+	return aggregates, nil
+}
+
+// Compact creates monthly aggregates for all completed months before now that
+// have raw states but no aggregate yet. Existing aggregates are never
+// overwritten, so raw states of compacted months can safely be expired.
+func (s *Store) Compact(projectNumber int, fields []string, now time.Time) ([]types.MonthlyAggregate, error) {
+	existing, err := s.ListAggregates(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+	compacted := make(map[string]bool, len(existing))
+	for _, aggregate := range existing {
+		compacted[aggregate.Month] = true
+	}
+
+	stateFiles, err := s.ListStateFiles(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group state files by month, skipping the current month and compacted months
+	currentMonth := types.MonthOf(now)
+	var months []string
+	filesByMonth := make(map[string][]string)
+	for _, file := range stateFiles {
+		month := types.MonthOf(extractTimestamp(file))
+		if month >= currentMonth || compacted[month] {
+			continue
+		}
+		if _, ok := filesByMonth[month]; !ok {
+			months = append(months, month)
+		}
+		filesByMonth[month] = append(filesByMonth[month], file)
+	}
+
+	var created []types.MonthlyAggregate
+	for _, month := range months {
+		aggregate, err := types.NewMonthlyAggregate(projectNumber, month, fields)
+		if err != nil {
+			return nil, err
+		}
+
+		// Load one state at a time to keep memory usage flat
+		for _, file := range filesByMonth[month] {
+			state, err := s.LoadStateFile(file)
+			if err != nil {
+				return nil, err
+			}
+			if err := aggregate.Add(state); err != nil {
+				return nil, fmt.Errorf("failed to aggregate %s: %w", file, err)
+			}
+		}
+
+		if _, err := s.SaveAggregate(aggregate); err != nil {
+			return nil, err
+		}
+		created = append(created, *aggregate)
+	}
+
+	return created, nil
+}
+
+// aggregateDir returns the directory holding the monthly aggregates of a project
+func (s *Store) aggregateDir(projectNumber int) string {
+	return filepath.Join(s.baseDir, "states", fmt.Sprintf("project=%d", projectNumber), aggregatesDir)
+}
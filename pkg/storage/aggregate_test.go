@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompact(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "storage_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	store, err := NewFileStore(tempDir)
+	require.NoError(t, err)
+
+	save := func(ts time.Time, statuses ...string) {
+		state := &types.ProjectState{Timestamp: ts, ProjectNumber: 123}
+		for i, status := range statuses {
+			state.Items = append(state.Items, types.Item{
+				ID:         string(rune('a' + i)),
+				DateSpan:   types.MustNewDateSpan("2024-01-01", "2024-01-10"),
+				Attributes: map[string]interface{}{"Title": "Task", "Status": status},
+			})
+		}
+		_, err := store.SaveState(state)
+		require.NoError(t, err)
+	}
+
+	save(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), "Todo")
+	save(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), "Todo", "Done", "Done")
+	save(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), "Done")
+	save(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "Done") // current month
+
+	now := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	created, err := store.Compact(123, []string{"Status"}, now)
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+
+	january := created[0]
+	assert.Equal(t, "2024-01", january.Month)
+	assert.Equal(t, 2, january.Snapshots)
+	assert.Equal(t, types.CountStats{Min: 1, Max: 3, Mean: 2}, january.Items)
+	assert.Equal(t, 30, january.TotalDurationDays)
+	assert.Equal(t, map[string]int{"Todo": 1, "Done": 2}, january.Distribution["Status"])
+	assert.FileExists(t, filepath.Join(tempDir, "states", "project=123", "monthly", "2024-01.json"))
+
+	// Raw states of compacted months can be expired without affecting aggregates
+	require.NoError(t, os.Remove(filepath.Join(tempDir, "states", "project=123", "1705708800.json")))
+	created, err = store.Compact(123, []string{"Status"}, now)
+	require.NoError(t, err)
+	assert.Empty(t, created)
+
+	aggregates, err := store.ListAggregates(123)
+	require.NoError(t, err)
+	require.Len(t, aggregates, 2)
+	assert.Equal(t, "2024-01", aggregates[0].Month)
+	assert.Equal(t, 2, aggregates[0].Snapshots)
+	assert.Equal(t, "2024-02", aggregates[1].Month)
+
+	// Aggregates don't show up as states
+	timestamps, err := store.ListTimestamps(123)
+	require.NoError(t, err)
+	assert.Len(t, timestamps, 3)
+}
+
+func TestListAggregatesWithoutAggregates(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "storage_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	store, err := NewFileStore(tempDir)
+	require.NoError(t, err)
+
+	aggregates, err := store.ListAggregates(123)
+	assert.NoError(t, err)
+	assert.Empty(t, aggregates)
+}
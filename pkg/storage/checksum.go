@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// checksumSuffix is appended to the name of a state file to name its checksum file
+const checksumSuffix = ".sha256"
+
+// ErrChecksumMismatch is returned when loading a state that doesn't match its checksum,
+// e.g. because it was truncated by a crash or modified after it was saved
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// encodeChecksum returns the SHA-256 checksum file of a state in the format of
+// sha256sum, so that snapshots can also be verified with "sha256sum -c"
+func encodeChecksum(data []byte, filename string) []byte {
+	sum := sha256.Sum256(data)
+	return []byte(hex.EncodeToString(sum[:]) + "  " + filepath.Base(filename) + "\n")
+}
+
+// verifyChecksum verifies the data of a state against its checksum file
+func verifyChecksum(data, checksum []byte, filename string) error {
+	expected, _, _ := bytes.Cut(bytes.TrimSpace(checksum), []byte(" "))
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != string(expected) {
+		return fmt.Errorf("%w for state file %s: it is truncated or was modified after it was saved", ErrChecksumMismatch, filename)
+	}
+	return nil
+}
+
+// writeFileAtomic writes a file through a temporary file in the same directory that
+// is renamed when complete, so that a crash never leaves a partially written file
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checksumTestState() *types.ProjectState {
+	return &types.ProjectState{
+		ProjectNumber: 42,
+		Timestamp:     time.Unix(1704067200, 0),
+		Items: []types.Item{{
+			ID:         "1",
+			DateSpan:   types.MustNewDateSpan("2024-01-01", "2024-01-10"),
+			Attributes: map[string]interface{}{"Title": "Launch"},
+		}},
+	}
+}
+
+func TestStoreChecksum(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	filename, err := store.SaveState(checksumTestState())
+	require.NoError(t, err)
+
+	// The checksum file is in the format of sha256sum
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	checksum, err := os.ReadFile(filename + checksumSuffix)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	assert.Equal(t, hex.EncodeToString(sum[:])+"  1704067200.json\n", string(checksum))
+
+	// No temporary files are left behind and checksums aren't listed as states
+	entries, err := os.ReadDir(filepath.Dir(filename))
+	require.NoError(t, err)
+	assert.Len(t, entries, 3) // State, checksum and metadata directory
+	states, err := store.ListStates(42)
+	require.NoError(t, err)
+	assert.Len(t, states, 1)
+
+	_, err = store.LoadStateFile(filename)
+	assert.NoError(t, err)
+
+	// Modified states are detected
+	require.NoError(t, os.WriteFile(filename, append(data, ' '), 0644))
+	_, err = store.LoadStateFile(filename)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	// States without checksum are loaded as is
+	require.NoError(t, os.Remove(filename+checksumSuffix))
+	_, err = store.LoadStateFile(filename)
+	assert.NoError(t, err)
+
+	// Deleting a state deletes its checksum
+	filename, err = store.SaveState(checksumTestState())
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteState(filename))
+	assert.NoFileExists(t, filename+checksumSuffix)
+}
+
+func TestObjectStoreChecksum(t *testing.T) {
+	store := newTestObjectStore(t, "reports")
+
+	name, err := store.SaveState(checksumTestState())
+	require.NoError(t, err)
+	_, err = store.LoadStateFile(name)
+	require.NoError(t, err)
+
+	// Truncated states are detected
+	ctx := context.Background()
+	data, err := store.client.Get(ctx, store.keyOf(name))
+	require.NoError(t, err)
+	require.NoError(t, store.client.Put(ctx, store.keyOf(name), data[:len(data)/2]))
+	_, err = store.LoadStateFile(name)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	require.NoError(t, store.DeleteState(name))
+	_, err = store.client.Get(ctx, store.keyOf(name)+checksumSuffix)
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "palette.json")
+
+	require.NoError(t, writeFileAtomic(filename, []byte("first"), 0644))
+	require.NoError(t, writeFileAtomic(filename, []byte("second"), 0644))
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
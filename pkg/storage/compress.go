@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// gzipSuffix is appended to the names of compressed state files
+const gzipSuffix = ".gz"
+
+// gzipMagic are the first bytes of gzip compressed data
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// stateFileName returns the file name of a state captured at the timestamp
+func stateFileName(timestamp time.Time, compress bool) string {
+	name := fmt.Sprintf("%d.json", timestamp.Unix())
+	if compress {
+		name += gzipSuffix
+	}
+	return name
+}
+
+// isStateFileName returns true if the name is a plain or compressed state file
+func isStateFileName(name string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(name, gzipSuffix), ".json")
+}
+
+// encodeState marshals a state to JSON, gzip compressed if requested
+func encodeState(state *types.ProjectState, compress bool) ([]byte, error) {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if !compress {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress state: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress state: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeState unmarshals a state, decompressing gzip compressed data
+func decodeState(data []byte) (*types.ProjectState, error) {
+	if bytes.HasPrefix(data, gzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress state: %w", err)
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("failed to decompress state: %w", err)
+		}
+	}
+
+	var state types.ProjectState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	state.NormalizeAttributes()
+	return &state, nil
+}
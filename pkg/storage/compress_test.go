@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeState(t *testing.T) {
+	state := &types.ProjectState{
+		Timestamp:     time.Unix(1704067200, 0).UTC(),
+		ProjectNumber: 123,
+		Items:         []types.Item{{ID: "item-1", Attributes: map[string]interface{}{"Title": "Item"}}},
+	}
+
+	plain, err := encodeState(state, false)
+	require.NoError(t, err)
+	compressed, err := encodeState(state, true)
+	require.NoError(t, err)
+	assert.Equal(t, gzipMagic, compressed[:2])
+	assert.NotEqual(t, plain, compressed)
+
+	for _, data := range [][]byte{plain, compressed} {
+		decoded, err := decodeState(data)
+		require.NoError(t, err)
+		assert.Equal(t, state.ProjectNumber, decoded.ProjectNumber)
+		assert.Equal(t, "Item", decoded.Items[0].GetTitle())
+	}
+
+	_, err = decodeState(append(append([]byte{}, gzipMagic...), "not gzip"...))
+	assert.ErrorContains(t, err, "failed to decompress state")
+}
+
+func TestStateFileNames(t *testing.T) {
+	ts := time.Unix(1704067200, 0)
+	assert.Equal(t, "1704067200.json", stateFileName(ts, false))
+	assert.Equal(t, "1704067200.json.gz", stateFileName(ts, true))
+
+	assert.True(t, isStateFileName("1704067200.json"))
+	assert.True(t, isStateFileName("1704067200.json.gz"))
+	assert.False(t, isStateFileName("1704067200.txt.gz"))
+
+	assert.True(t, ts.Equal(extractTimestamp("states/project=1/1704067200.json.gz")))
+}
+
+func TestMixedCompressedStates(t *testing.T) {
+	dir := t.TempDir()
+	plainStore, err := NewFileStore(dir)
+	require.NoError(t, err)
+	compressedStore, err := NewFileStore(dir, WithCompression(true))
+	require.NoError(t, err)
+
+	older := &types.ProjectState{Timestamp: time.Unix(1704067200, 0), ProjectNumber: 1}
+	newer := &types.ProjectState{Timestamp: time.Unix(1704153600, 0), ProjectNumber: 1}
+	_, err = plainStore.SaveState(older)
+	require.NoError(t, err)
+	filename, err := compressedStore.SaveState(newer)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "states", "project=1", "1704153600.json.gz"), filename)
+
+	// Both stores read either kind of file
+	timestamps, err := plainStore.ListTimestamps(1)
+	require.NoError(t, err)
+	assert.Len(t, timestamps, 2)
+
+	loaded, err := plainStore.LoadState(1, newer.Timestamp)
+	require.NoError(t, err)
+	assert.Equal(t, filename, loaded.Filename)
+
+	loaded, err = compressedStore.LoadState(1, older.Timestamp)
+	require.NoError(t, err)
+	assert.True(t, older.Timestamp.Equal(loaded.Timestamp))
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// KeySize is the size in bytes of the AES-256 keys encrypting states
+const KeySize = 32
+
+// encryptionMagic are the first bytes of encrypted files, followed by the nonce and
+// the AES-GCM sealed data
+var encryptionMagic = []byte("GHPRAES1")
+
+// ErrEncrypted is returned when loading an encrypted state or metadata file without a key
+var ErrEncrypted = errors.New("file is encrypted")
+
+// WithEncryption encrypts new states, palettes and monthly aggregates with AES-256-GCM
+// using the key, which must be KeySize bytes. Encrypted files are decrypted
+// transparently on load, and plain files stay readable. A nil key disables encryption.
+func WithEncryption(key []byte) StoreOption {
+	return func(o *StoreOptions) {
+		o.Key = key
+	}
+}
+
+// ParseKey decodes a base64 encoded key, e.g. generated with "openssl rand -base64 32"
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid key: must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// ReadKeyFile reads a base64 encoded key from a file
+func ReadKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	return ParseKey(string(data))
+}
+
+// encode marshals a state as configured, compressing and then encrypting it
+func (o StoreOptions) encode(state *types.ProjectState) ([]byte, error) {
+	data, err := encodeState(state, o.Compress)
+	if err != nil {
+		return nil, err
+	}
+	return o.seal(data)
+}
+
+// decode unmarshals a state, decrypting and decompressing it if needed
+func (o StoreOptions) decode(data []byte) (*types.ProjectState, error) {
+	data, err := o.open(data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeState(data)
+}
+
+// seal encrypts the data of a file if a key is set. Besides states, it protects the
+// metadata derived from their fields, such as palettes and monthly aggregates.
+func (o StoreOptions) seal(data []byte) ([]byte, error) {
+	if o.Key == nil {
+		return data, nil
+	}
+	return encryptData(data, o.Key)
+}
+
+// open decrypts the data of a file sealed by seal, and returns other data unchanged
+func (o StoreOptions) open(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, encryptionMagic) {
+		return data, nil
+	}
+	if o.Key == nil {
+		return nil, fmt.Errorf("%w: set GH_PROJECT_REPORT_KEY or --key-file to load it", ErrEncrypted)
+	}
+	return decryptData(data, o.Key)
+}
+
+// encryptData seals data with a random nonce
+func encryptData(data, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := append(append([]byte{}, encryptionMagic...), nonce...)
+	return gcm.Seal(out, nonce, data, encryptionMagic), nil
+}
+
+// decryptData opens data sealed by encryptData
+func decryptData(data, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed := data[len(encryptionMagic):]
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("failed to decrypt file: truncated data")
+	}
+	nonce, sealed := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, encryptionMagic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt file: wrong key or modified data: %w", err)
+	}
+	return plain, nil
+}
+
+// newGCM returns the AES-GCM cipher of a key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return gcm, nil
+}
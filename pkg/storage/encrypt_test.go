@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func TestStoreEncryption(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		dir := t.TempDir()
+		store, err := NewFileStore(dir, WithEncryption(testKey(1)), WithCompression(compress))
+		require.NoError(t, err)
+
+		filename, err := store.SaveState(checksumTestState())
+		require.NoError(t, err)
+
+		// Titles aren't readable in the stored file
+		data, err := os.ReadFile(filename)
+		require.NoError(t, err)
+		assert.Equal(t, encryptionMagic, data[:len(encryptionMagic)])
+		assert.NotContains(t, string(data), "Launch")
+
+		state, err := store.LoadStateFile(filename)
+		require.NoError(t, err)
+		assert.Equal(t, "Launch", state.Items[0].GetTitle())
+
+		// The index still counts the items of encrypted states
+		states, err := store.ListStates(42)
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		assert.Equal(t, 1, states[0].Items)
+	}
+}
+
+func TestStoreEncryptsMetadata(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir, WithEncryption(testKey(1)))
+	require.NoError(t, err)
+
+	palette := types.NewPalette()
+	palette.Observe("status", []string{"Secret review"})
+	require.NoError(t, store.SavePalette(42, palette))
+	data, err := os.ReadFile(store.paletteFile(42))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "Secret review")
+	loaded, err := store.LoadPalette(42)
+	require.NoError(t, err)
+	assert.Equal(t, palette, loaded)
+
+	aggregate, err := types.NewMonthlyAggregate(42, "2024-01", []string{"Status"})
+	require.NoError(t, err)
+	require.NoError(t, aggregate.Add(&types.ProjectState{ProjectNumber: 42, Timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Items: []types.Item{{ID: "1", Attributes: map[string]interface{}{"Status": "Secret review"}}}}))
+	filename, err := store.SaveAggregate(aggregate)
+	require.NoError(t, err)
+	data, err = os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "Secret review")
+	aggregates, err := store.ListAggregates(42)
+	require.NoError(t, err)
+	require.Len(t, aggregates, 1)
+	assert.Equal(t, aggregate.Distribution, aggregates[0].Distribution)
+	assert.Contains(t, aggregates[0].Distribution["Status"], "Secret review")
+
+	// Without the key, the metadata can't be loaded
+	plain, err := NewFileStore(dir)
+	require.NoError(t, err)
+	_, err = plain.LoadPalette(42)
+	assert.ErrorIs(t, err, ErrEncrypted)
+	_, err = plain.ListAggregates(42)
+	assert.ErrorIs(t, err, ErrEncrypted)
+}
+
+func TestStoreEncryptionKeys(t *testing.T) {
+	dir := t.TempDir()
+	encrypted, err := NewFileStore(dir, WithEncryption(testKey(1)))
+	require.NoError(t, err)
+	filename, err := encrypted.SaveState(checksumTestState())
+	require.NoError(t, err)
+
+	// Without a key
+	plain, err := NewFileStore(dir)
+	require.NoError(t, err)
+	_, err = plain.LoadStateFile(filename)
+	assert.ErrorIs(t, err, ErrEncrypted)
+
+	// With another key
+	other, err := NewFileStore(dir, WithEncryption(testKey(2)))
+	require.NoError(t, err)
+	_, err = other.LoadStateFile(filename)
+	assert.ErrorContains(t, err, "failed to decrypt file: wrong key or modified data")
+
+	// Plain states stay readable with a key
+	state := checksumTestState()
+	state.Timestamp = state.Timestamp.Add(1)
+	plainFile, err := plain.SaveState(state)
+	require.NoError(t, err)
+	loaded, err := encrypted.LoadStateFile(plainFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Launch", loaded.Items[0].GetTitle())
+}
+
+func TestDecryptDataTruncated(t *testing.T) {
+	_, err := decryptData(append([]byte{}, encryptionMagic...), testKey(1))
+	assert.ErrorContains(t, err, "truncated data")
+}
+
+func TestParseKey(t *testing.T) {
+	key, err := ParseKey(base64.StdEncoding.EncodeToString(testKey(3)) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, testKey(3), key)
+
+	_, err = ParseKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.ErrorContains(t, err, "invalid key: must be 32 bytes, got 5")
+
+	_, err = ParseKey("not base64!")
+	assert.ErrorContains(t, err, "failed to decode key")
+
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(testKey(4))), 0600))
+	key, err = ReadKeyFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, testKey(4), key)
+
+	_, err = ReadKeyFile(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "failed to read key file")
+}
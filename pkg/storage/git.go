@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// gitTimeout limits how long a git command may take, including pushing to a remote
+const gitTimeout = 2 * time.Minute
+
+// gitIdentity is the committer of snapshots when git has no user configured, e.g. in CI
+var gitIdentity = []string{"-c", "user.name=gh-project-report", "-c", "user.email=gh-project-report@users.noreply.github.com"}
+
+// WithGit commits each state saved or deleted by a local store to the git repository
+// of its directory, which is initialized if needed and must not be a subdirectory of
+// another repository, and pushes the commit to the remote if not empty. A remote
+// implies committing.
+func WithGit(enabled bool, remote string) StoreOption {
+	return func(o *StoreOptions) {
+		o.GitCommit = enabled || remote != ""
+		o.GitRemote = remote
+	}
+}
+
+// PushError is returned when a state was saved or deleted and committed, but pushing the
+// commit failed. The change is stored, so it must not be retried: the commit is pushed
+// with the next one.
+type PushError struct {
+	Remote string
+	Err    error
+}
+
+func (e *PushError) Error() string {
+	return fmt.Sprintf("failed to push to %s: %v", e.Remote, e.Err)
+}
+
+func (e *PushError) Unwrap() error {
+	return e.Err
+}
+
+// gitIgnored are the .gitignore patterns of files of the store that aren't committed:
+// the index and its lock, as the index is rebuilt from the states, and temporary files
+// of atomic writes
+var gitIgnored = []string{"**/" + metaDir + "/" + indexFileName, "**/" + metaDir + "/" + indexLockFileName, ".*.tmp-*"}
+
+// commitGit commits the changes in a project directory of the store and pushes the
+// commit if a remote is set. It returns a *PushError if only the push failed.
+func (s *Store) commitGit(projectDir, message string) error {
+	if !s.options.GitCommit {
+		return nil
+	}
+	if err := s.prepareGit(); err != nil {
+		return err
+	}
+
+	dir, err := filepath.Rel(s.baseDir, projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project directory: %w", err)
+	}
+	pathspec := []string{"--", filepath.ToSlash(dir), ".gitignore"}
+	if _, err := runGit(s.baseDir, append([]string{"add", "--all"}, pathspec...)...); err != nil {
+		return err
+	}
+
+	// Nothing is committed if the state was already committed, e.g. when re-imported.
+	// Changes staged outside of the project directory are left alone.
+	if _, err := runGit(s.baseDir, append([]string{"diff", "--cached", "--quiet"}, pathspec...)...); err == nil {
+		return nil
+	}
+
+	args := append([]string{"commit", "--quiet", "-m", message}, pathspec...)
+	if email, _ := runGit(s.baseDir, "config", "user.email"); email == "" {
+		args = append(gitIdentity, args...)
+	}
+	if _, err := runGit(s.baseDir, args...); err != nil {
+		return err
+	}
+
+	if s.options.GitRemote != "" {
+		if _, err := runGit(s.baseDir, "push", "--quiet", s.options.GitRemote, "HEAD"); err != nil {
+			return &PushError{Remote: s.options.GitRemote, Err: err}
+		}
+	}
+	return nil
+}
+
+// prepareGit initializes the git repository of the store if needed, and ignores the
+// files that aren't committed. The store must be the top level of its repository, so
+// that commits and pushes never include files of an enclosing repository.
+func (s *Store) prepareGit() error {
+	top, err := runGit(s.baseDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		if _, err := runGit(s.baseDir, "init"); err != nil {
+			return err
+		}
+	} else if !sameDir(top, s.baseDir) {
+		return fmt.Errorf("store directory %s is inside the git repository %s: it must be the top level of its own repository", s.baseDir, top)
+	}
+
+	filename := filepath.Join(s.baseDir, ".gitignore")
+	data, err := os.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .gitignore: %w", err)
+	}
+	lines := strings.Split(string(data), "\n")
+	content := string(data)
+	for _, pattern := range gitIgnored {
+		if !slices.Contains(lines, pattern) {
+			if content != "" && !strings.HasSuffix(content, "\n") {
+				content += "\n"
+			}
+			content += pattern + "\n"
+		}
+	}
+	if content == string(data) {
+		return nil
+	}
+	if err := writeFileAtomic(filename, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+	return nil
+}
+
+// sameDir returns true if two paths refer to the same directory
+func sameDir(a, b string) bool {
+	resolve := func(path string) string {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		return filepath.Clean(path)
+	}
+	return resolve(a) == resolve(b)
+}
+
+// runGit runs a git command in a directory, returning its trimmed output. The error
+// includes what git printed to explain the failure.
+func runGit(dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		// Name the subcommand, skipping configuration given with -c
+		name := args[0]
+		for i := 0; i+2 < len(args) && args[i] == "-c"; i += 2 {
+			name = args[i+2]
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("failed to run git %s: %w: %s", name, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("failed to run git %s: %w", name, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
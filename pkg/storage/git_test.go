@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+}
+
+func TestStoreCommitsStatesToGit(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	store, err := NewFileStore(dir, WithGit(true, ""))
+	require.NoError(t, err)
+
+	filename, err := store.SaveState(checksumTestState())
+	require.NoError(t, err)
+
+	log, err := runGit(dir, "log", "--format=%s")
+	require.NoError(t, err)
+	assert.Equal(t, "Capture project 42 at 2024-01-01T00:00:00Z", log)
+
+	// The state and its checksum are committed, the index is ignored
+	files, err := runGit(dir, "ls-files")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		".gitignore",
+		"states/project=42/1704067200.json",
+		"states/project=42/1704067200.json.sha256",
+	}, strings.Split(files, "\n"))
+	status, err := runGit(dir, "status", "--porcelain")
+	require.NoError(t, err)
+	assert.Empty(t, status)
+
+	// Saving the same state again commits nothing
+	_, err = store.SaveState(checksumTestState())
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeleteState(filename))
+	log, err = runGit(dir, "log", "--format=%s")
+	require.NoError(t, err)
+	assert.Equal(t, "Delete state 1704067200.json\nCapture project 42 at 2024-01-01T00:00:00Z", log)
+	files, err = runGit(dir, "ls-files")
+	require.NoError(t, err)
+	assert.Equal(t, ".gitignore", files)
+}
+
+func TestStoreKeepsGitignore(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log"), 0644))
+	store, err := NewFileStore(dir, WithGit(true, ""))
+	require.NoError(t, err)
+
+	_, err = store.SaveState(checksumTestState())
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "*.log\n**/meta/index.json\n**/meta/index.lock\n.*.tmp-*\n", string(data))
+}
+
+func TestStoreRejectsGitSubdirectory(t *testing.T) {
+	requireGit(t)
+	repo := t.TempDir()
+	_, err := runGit(repo, "init")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "unrelated.txt"), []byte("draft"), 0644))
+
+	// A store in a subdirectory of another repository never commits its files
+	store, err := NewFileStore(filepath.Join(repo, "snapshots"), WithGit(true, ""))
+	require.NoError(t, err)
+	_, err = store.SaveState(checksumTestState())
+	assert.ErrorContains(t, err, "must be the top level of its own repository")
+
+	_, err = runGit(repo, "rev-parse", "HEAD")
+	assert.Error(t, err)
+}
+
+func TestStorePushesStatesToGitRemote(t *testing.T) {
+	requireGit(t)
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	_, err := runGit(t.TempDir(), "init", "--bare", remote)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	store, err := NewFileStore(dir, WithGit(false, remote))
+	require.NoError(t, err)
+
+	state := checksumTestState()
+	_, err = store.SaveState(state)
+	require.NoError(t, err)
+	state.Timestamp = state.Timestamp.Add(time.Hour)
+	_, err = store.SaveState(state)
+	require.NoError(t, err)
+
+	log, err := runGit(remote, "log", "--format=%s", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "Capture project 42 at 2024-01-01T01:00:00Z\nCapture project 42 at 2024-01-01T00:00:00Z", log)
+}
+
+func TestStoreKeepsStatesWhenPushFails(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	store, err := NewFileStore(dir, WithGit(false, filepath.Join(t.TempDir(), "missing.git")))
+	require.NoError(t, err)
+
+	// The state is saved and committed, only the push failed
+	filename, err := store.SaveState(checksumTestState())
+	var pushErr *PushError
+	require.ErrorAs(t, err, &pushErr)
+	assert.FileExists(t, filename)
+	log, err := runGit(dir, "log", "--format=%s")
+	require.NoError(t, err)
+	assert.Equal(t, "Capture project 42 at 2024-01-01T00:00:00Z", log)
+
+	timestamps, err := store.ListTimestamps(42)
+	require.NoError(t, err)
+	assert.Len(t, timestamps, 1)
+
+	err = store.DeleteState(filename)
+	require.ErrorAs(t, err, &pushErr)
+	assert.NoFileExists(t, filename)
+}
+
+func TestNewStoreRejectsGitForObjectStores(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	_, err := NewStore("s3://bucket/prefix", WithGit(true, ""))
+	assert.ErrorContains(t, err, "git commits are only supported by local stores, not s3://bucket/prefix")
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// heartbeatFileName is the name of the heartbeat file in the metadata directory of a project
+const heartbeatFileName = "heartbeat.json"
+
+// LoadHeartbeat loads the heartbeat of a project, or nil if none was saved yet
+func (s *Store) LoadHeartbeat(projectNumber int) (*types.Heartbeat, error) {
+	data, err := os.ReadFile(s.heartbeatFile(projectNumber))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read heartbeat file: %w", err)
+	}
+	s.options.Metrics.addRead(len(data))
+	return decodeHeartbeat(data)
+}
+
+// SaveHeartbeat saves the heartbeat of a project, replacing the previous one
+func (s *Store) SaveHeartbeat(projectNumber int, heartbeat *types.Heartbeat) error {
+	data, err := json.MarshalIndent(heartbeat, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal heartbeat: %w", err)
+	}
+
+	filename := s.heartbeatFile(projectNumber)
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	if err := writeFileAtomic(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write heartbeat file: %w", err)
+	}
+	s.options.Metrics.addWritten(len(data))
+	return nil
+}
+
+// heartbeatFile returns the path of the heartbeat file of a project
+func (s *Store) heartbeatFile(projectNumber int) string {
+	return filepath.Join(s.baseDir, "states", fmt.Sprintf("project=%d", projectNumber), metaDir, heartbeatFileName)
+}
+
+// LoadHeartbeat loads the heartbeat of a project, or nil if none was saved yet
+func (o *ObjectStore) LoadHeartbeat(projectNumber int) (*types.Heartbeat, error) {
+	data, err := o.client.Get(context.Background(), o.heartbeatKey(projectNumber))
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read heartbeat file: %w", err)
+	}
+	o.options.Metrics.addRead(len(data))
+	return decodeHeartbeat(data)
+}
+
+// SaveHeartbeat saves the heartbeat of a project, replacing the previous one
+func (o *ObjectStore) SaveHeartbeat(projectNumber int, heartbeat *types.Heartbeat) error {
+	data, err := json.MarshalIndent(heartbeat, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal heartbeat: %w", err)
+	}
+	if err := o.client.Put(context.Background(), o.heartbeatKey(projectNumber), data); err != nil {
+		return fmt.Errorf("failed to write heartbeat file: %w", err)
+	}
+	o.options.Metrics.addWritten(len(data))
+	return nil
+}
+
+// heartbeatKey returns the object key of the heartbeat of a project
+func (o *ObjectStore) heartbeatKey(projectNumber int) string {
+	return o.key(fmt.Sprintf("states/project=%d/%s/%s", projectNumber, metaDir, heartbeatFileName))
+}
+
+// LoadHeartbeat loads the heartbeat of a project, or nil if none was saved yet
+func (m *MemoryStore) LoadHeartbeat(projectNumber int) (*types.Heartbeat, error) {
+	m.mu.RLock()
+	data, ok := m.states[memoryHeartbeatName(projectNumber)]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	return decodeHeartbeat(data)
+}
+
+// SaveHeartbeat saves the heartbeat of a project, replacing the previous one
+func (m *MemoryStore) SaveHeartbeat(projectNumber int, heartbeat *types.Heartbeat) error {
+	data, err := json.Marshal(heartbeat)
+	if err != nil {
+		return fmt.Errorf("failed to marshal heartbeat: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[memoryHeartbeatName(projectNumber)] = data
+	return nil
+}
+
+// memoryHeartbeatName returns the name of the heartbeat of a project in the memory store
+func memoryHeartbeatName(projectNumber int) string {
+	return fmt.Sprintf("project=%d/%s/%s", projectNumber, metaDir, heartbeatFileName)
+}
+
+// decodeHeartbeat unmarshals a heartbeat
+func decodeHeartbeat(data []byte) (*types.Heartbeat, error) {
+	var heartbeat types.Heartbeat
+	if err := json.Unmarshal(data, &heartbeat); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal heartbeat: %w", err)
+	}
+	return &heartbeat, nil
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeartbeat(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	stores := map[string]StateStore{
+		"file":   fileStore,
+		"memory": NewMemoryStore(),
+		"object": newTestObjectStore(t, "reports"),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			heartbeat, err := store.LoadHeartbeat(123)
+			require.NoError(t, err)
+			assert.Nil(t, heartbeat)
+
+			first := &types.Heartbeat{Timestamp: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Snapshot: "a.json"}
+			second := &types.Heartbeat{Timestamp: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), Snapshot: "a.json"}
+			require.NoError(t, store.SaveHeartbeat(123, first))
+			require.NoError(t, store.SaveHeartbeat(123, second))
+
+			loaded, err := store.LoadHeartbeat(123)
+			require.NoError(t, err)
+			assert.Equal(t, second, loaded)
+
+			other, err := store.LoadHeartbeat(456)
+			require.NoError(t, err)
+			assert.Nil(t, other)
+
+			// The heartbeat is not mistaken for a state
+			states, err := store.ListStates(123)
+			require.NoError(t, err)
+			assert.Empty(t, states)
+		})
+	}
+}
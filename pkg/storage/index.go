@@ -0,0 +1,322 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// indexFileName is the name of the index of states in the metadata directory of a project
+const indexFileName = "index.json"
+
+// StateIndex lists the states of a project, so that they are listed and looked up
+// without reading the project directory and every state file
+type StateIndex struct {
+	States []IndexEntry `json:"states"` // Sorted by timestamp
+}
+
+// IndexEntry describes a state file in the index
+type IndexEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Filename  string    `json:"filename"` // Name of the file in the project directory
+	Items     int       `json:"items"`    // -1 if the state couldn't be decoded
+	Size      int64     `json:"size_bytes"`
+	SHA256    string    `json:"sha256"`
+}
+
+// indexLockFileName is the name of the lock of the index in the metadata directory of a project
+const indexLockFileName = "index.lock"
+
+// indexLockTimeout is how long to wait for another process updating the index, after
+// which its lock is considered left behind by a crashed process
+const indexLockTimeout = 10 * time.Second
+
+// indexLockRetry is the interval of attempts to take the lock of the index
+const indexLockRetry = 10 * time.Millisecond
+
+// RebuildIndex rebuilds the index of a project from its state files, loading each
+// of them. Lookups trust the index unless files were added to or removed from the
+// project directory since it was written, so this is needed after state files were
+// edited in place.
+func (s *Store) RebuildIndex(projectNumber int) (*StateIndex, error) {
+	dir := s.projectDir(projectNumber)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to read project directory: %w", err)
+	}
+	unlock, err := s.lockIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project directory: %w", err)
+	}
+	index, _, err := s.refreshIndex(dir, files, &StateIndex{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.writeIndex(dir, index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// loadIndex loads the index of the states in a project directory. The index is
+// trusted unless the directory was modified after it was written, i.e. state files
+// were added or removed by something else than the store, so lookups cost two stats
+// instead of listing and stating every state file. An outdated index is refreshed and
+// saved if possible, but reading never fails on a read-only store or if the index is
+// missing, e.g. in a fresh clone of a git store.
+func (s *Store) loadIndex(dir string) (*StateIndex, error) {
+	index, current, err := s.readIndex(dir)
+	if err != nil || current {
+		return index, err
+	}
+
+	var refreshed *StateIndex
+	err = s.updateIndex(dir, func(index *StateIndex) error {
+		refreshed = index
+		return nil
+	})
+	return refreshed, err
+}
+
+// updateIndex updates the index of the states in a project directory while holding
+// its lock, so that concurrent updates, e.g. of captures running in parallel, don't
+// drop each other's entries. The index is refreshed first if it is outdated. The
+// index only speeds up lookups, so if it can't be locked or saved, the failure is
+// logged and the update applied to an index that isn't saved.
+func (s *Store) updateIndex(dir string, update func(index *StateIndex) error) error {
+	unlock, lockErr := s.lockIndex(dir)
+	if lockErr != nil {
+		s.options.logf("Failed to save the index of %s: %v\n", dir, lockErr)
+	} else {
+		defer unlock()
+	}
+
+	index, current, err := s.readIndex(dir)
+	if err != nil {
+		return err
+	}
+	if !current {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("failed to read project directory: %w", err)
+		}
+		if index, _, err = s.refreshIndex(dir, files, index, s.indexWritten(dir)); err != nil {
+			return err
+		}
+	}
+
+	if err := update(index); err != nil {
+		return err
+	}
+	if lockErr == nil {
+		s.saveIndex(dir, index)
+	}
+	return nil
+}
+
+// readIndex reads the index of the states in a project directory and returns whether
+// it is current, i.e. the directory wasn't modified after the index was written. A
+// missing or unreadable index is returned empty and outdated.
+func (s *Store) readIndex(dir string) (*StateIndex, bool, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read project directory: %w", err)
+	}
+
+	written := s.indexWritten(dir)
+	if written.IsZero() {
+		return &StateIndex{}, false, nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, metaDir, indexFileName))
+	index := &StateIndex{}
+	if err != nil || json.Unmarshal(data, index) != nil {
+		return &StateIndex{}, false, nil
+	}
+	return index, !info.ModTime().After(written), nil
+}
+
+// indexWritten returns when the index of a project directory was written, or the
+// zero time if there is none
+func (s *Store) indexWritten(dir string) time.Time {
+	info, err := os.Stat(filepath.Join(dir, metaDir, indexFileName))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// lockIndex takes the lock of the index of a project directory, waiting for other
+// processes holding it, and returns the function releasing it
+func (s *Store) lockIndex(dir string) (func(), error) {
+	filename := filepath.Join(dir, metaDir, indexLockFileName)
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	deadline := time.Now().Add(indexLockTimeout)
+	for {
+		file, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(filename) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock index: %w", err)
+		}
+
+		// A lock left behind by a crashed process is taken over
+		if info, err := os.Stat(filename); err == nil && time.Since(info.ModTime()) > indexLockTimeout {
+			os.Remove(filename)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to lock index: %s is held by another process", filename)
+		}
+		time.Sleep(indexLockRetry)
+	}
+}
+
+// refreshIndex lists the state files of a project directory, keeping the entries of
+// the index whose file has the same size and wasn't modified after the index was
+// written, and loading the others. It returns whether any entry changed.
+func (s *Store) refreshIndex(dir string, files []os.DirEntry, index *StateIndex, written time.Time) (*StateIndex, bool, error) {
+	known := make(map[string]IndexEntry, len(index.States))
+	for _, entry := range index.States {
+		known[entry.Filename] = entry
+	}
+
+	refreshed := &StateIndex{}
+	changed := false
+	for _, file := range files {
+		if file.IsDir() || !isStateFileName(file.Name()) || extractTimestamp(file.Name()).IsZero() {
+			continue
+		}
+		info, err := file.Info()
+		if os.IsNotExist(err) {
+			continue // Deleted while listing
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read state file: %w", err)
+		}
+
+		entry, ok := known[file.Name()]
+		delete(known, file.Name())
+		if ok && entry.Size == info.Size() && !info.ModTime().After(written) {
+			refreshed.States = append(refreshed.States, entry)
+			continue
+		}
+
+		filename := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read state file: %w", err)
+		}
+		s.options.Metrics.addRead(len(data))
+
+		// Broken states are still listed, failing only when loaded
+		items := -1
+		if state, err := s.options.decode(data); err == nil {
+			items = len(state.Items)
+		}
+		refreshed.States = append(refreshed.States, newIndexEntry(filename, data, items))
+		changed = true
+	}
+
+	// Entries of deleted files
+	if len(known) > 0 {
+		changed = true
+	}
+	refreshed.sort()
+	return refreshed, changed, nil
+}
+
+// saveIndex saves the index of the states in a project directory. The index only
+// speeds up lookups, so failures are logged rather than failing the operation.
+func (s *Store) saveIndex(dir string, index *StateIndex) {
+	if err := s.writeIndex(dir, index); err != nil {
+		s.options.logf("Failed to save the index of %s: %v\n", dir, err)
+	}
+}
+
+// writeIndex writes the index of the states in a project directory. Like the states
+// directory listing it replaces, it isn't counted in the bytes of the metrics.
+func (s *Store) writeIndex(dir string, index *StateIndex) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+
+	filename := filepath.Join(dir, metaDir, indexFileName)
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	if err := writeFileAtomic(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+	return nil
+}
+
+// CountItems returns the number of items of a listed state, loading the state if the
+// store doesn't know it from an index
+func CountItems(store StateStore, info StateInfo) (int, error) {
+	if info.Items >= 0 {
+		return info.Items, nil
+	}
+	state, err := store.LoadStateFile(info.Filename)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load state: %w", err)
+	}
+	return len(state.Items), nil
+}
+
+// projectDir returns the directory of the states of a project
+func (s *Store) projectDir(projectNumber int) string {
+	return filepath.Join(s.baseDir, "states", fmt.Sprintf("project=%d", projectNumber))
+}
+
+// newIndexEntry describes a state file with the given content and number of items
+func newIndexEntry(filename string, data []byte, items int) IndexEntry {
+	sum := sha256.Sum256(data)
+	return IndexEntry{
+		Timestamp: extractTimestamp(filename),
+		Filename:  filepath.Base(filename),
+		Items:     items,
+		Size:      int64(len(data)),
+		SHA256:    hex.EncodeToString(sum[:]),
+	}
+}
+
+// add adds an entry to the index, replacing an entry of the same file
+func (i *StateIndex) add(entry IndexEntry) {
+	i.remove(entry.Filename)
+	i.States = append(i.States, entry)
+	i.sort()
+}
+
+// sort sorts the entries of the index by timestamp
+func (i *StateIndex) sort() {
+	sort.SliceStable(i.States, func(a, b int) bool {
+		return i.States[a].Timestamp.Before(i.States[b].Timestamp)
+	})
+}
+
+// remove removes the entry of a file from the index
+func (i *StateIndex) remove(filename string) {
+	states := i.States[:0]
+	for _, entry := range i.States {
+		if entry.Filename != filename {
+			states = append(states, entry)
+		}
+	}
+	i.States = states
+}
@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func indexTestState(unix int64, items int) *types.ProjectState {
+	state := &types.ProjectState{ProjectNumber: 7, Timestamp: time.Unix(unix, 0)}
+	for i := 0; i < items; i++ {
+		state.Items = append(state.Items, types.Item{
+			ID:         string(rune('a' + i)),
+			DateSpan:   types.MustNewDateSpan("2024-01-01", "2024-01-10"),
+			Attributes: map[string]interface{}{"Title": "Item"},
+		})
+	}
+	return state
+}
+
+func readIndex(t *testing.T, dir string) StateIndex {
+	data, err := os.ReadFile(filepath.Join(dir, metaDir, indexFileName))
+	require.NoError(t, err)
+	var index StateIndex
+	require.NoError(t, json.Unmarshal(data, &index))
+	return index
+}
+
+func TestStoreIndex(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	second, err := store.SaveState(indexTestState(1704153600, 1))
+	require.NoError(t, err)
+	first, err := store.SaveState(indexTestState(1704067200, 2))
+	require.NoError(t, err)
+
+	index := readIndex(t, store.projectDir(7))
+	require.Len(t, index.States, 2)
+	assert.Equal(t, "1704067200.json", index.States[0].Filename)
+	assert.Equal(t, 2, index.States[0].Items)
+	assert.Len(t, index.States[0].SHA256, 64)
+	info, err := os.Stat(first)
+	require.NoError(t, err)
+	assert.Equal(t, info.Size(), index.States[0].Size)
+
+	states, err := store.ListStates(7)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, StateInfo{Filename: first, Timestamp: time.Unix(1704067200, 0), Size: info.Size(), Items: 2}, states[0])
+	assert.Equal(t, second, states[1].Filename)
+
+	require.NoError(t, store.DeleteState(first))
+	index = readIndex(t, store.projectDir(7))
+	require.Len(t, index.States, 1)
+	assert.Equal(t, "1704153600.json", index.States[0].Filename)
+}
+
+func TestStoreIndexRebuiltWhenOutdated(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	_, err = store.SaveState(indexTestState(1704067200, 1))
+	require.NoError(t, err)
+
+	// A state copied into the directory after the index was written
+	dir := store.projectDir(7)
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, metaDir, indexFileName), past, past))
+	data, err := encodeState(indexTestState(1704153600, 3), false)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1704153600.json"), data, 0644))
+
+	states, err := store.ListStates(7)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, 3, states[1].Items)
+	assert.Len(t, readIndex(t, dir).States, 2)
+}
+
+func TestStoreIndexTrustedForLookups(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	filename, err := store.SaveState(indexTestState(1704067200, 1))
+	require.NoError(t, err)
+	_, err = store.SaveState(indexTestState(1704153600, 1))
+	require.NoError(t, err)
+
+	// A state edited in place and one deleted, without modifying the directory
+	dir := store.projectDir(7)
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	data, err := encodeState(indexTestState(1704067200, 3), false)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filename, data, 0644))
+	require.NoError(t, os.Remove(filepath.Join(dir, "1704153600.json")))
+	require.NoError(t, os.Chtimes(dir, info.ModTime(), info.ModTime()))
+
+	// Lookups neither list nor stat the state files
+	states, err := store.ListStates(7)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, 1, states[0].Items)
+	closest, err := store.FindClosestState(7, time.Unix(1704153600, 0))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "1704153600.json"), closest)
+
+	// Rebuilding the index picks up the changes
+	index, err := store.RebuildIndex(7)
+	require.NoError(t, err)
+	require.Len(t, index.States, 1)
+	assert.Equal(t, 3, index.States[0].Items)
+	states, err = store.ListStates(7)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, 3, states[0].Items)
+}
+
+func TestStoreIndexConcurrentSaves(t *testing.T) {
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Separate stores, like captures running in parallel
+			store, err := NewFileStore(dir)
+			assert.NoError(t, err)
+			_, err = store.SaveState(indexTestState(1704067200+int64(i)*3600, 1))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, readIndex(t, filepath.Join(dir, "states", "project=7")).States, 10)
+	assert.NoFileExists(t, filepath.Join(dir, "states", "project=7", metaDir, indexLockFileName))
+}
+
+func TestStoreIndexStaleLock(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	_, err = store.SaveState(indexTestState(1704067200, 1))
+	require.NoError(t, err)
+
+	// A lock left behind by a crashed process
+	lock := filepath.Join(store.projectDir(7), metaDir, indexLockFileName)
+	require.NoError(t, os.WriteFile(lock, nil, 0644))
+	past := time.Now().Add(-2 * indexLockTimeout)
+	require.NoError(t, os.Chtimes(lock, past, past))
+
+	_, err = store.SaveState(indexTestState(1704153600, 1))
+	require.NoError(t, err)
+	assert.Len(t, readIndex(t, store.projectDir(7)).States, 2)
+}
+
+func TestStoreIndexNotWritable(t *testing.T) {
+	var logs bytes.Buffer
+	store, err := NewFileStore(t.TempDir(), WithLogger(log.New(&logs, "", 0)))
+	require.NoError(t, err)
+	filename, err := store.SaveState(indexTestState(1704067200, 1))
+	require.NoError(t, err)
+
+	// The metadata directory can't be created, e.g. in a read-only clone without an index
+	dir := store.projectDir(7)
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, metaDir)))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, metaDir), nil, 0644))
+
+	states, err := store.ListStates(7)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, filename, states[0].Filename)
+	assert.Contains(t, logs.String(), "Failed to save the index of "+dir)
+
+	state, err := store.LoadState(7, time.Unix(1704067200, 0))
+	require.NoError(t, err)
+	assert.Len(t, state.Items, 1)
+
+	// Only an explicit rebuild fails
+	_, err = store.RebuildIndex(7)
+	assert.ErrorContains(t, err, "failed to create metadata directory")
+}
+
+func TestStoreIndexBrokenState(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	dir := store.projectDir(7)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1704067200.json"), []byte("{"), 0644))
+
+	// Broken states are listed, but fail to load
+	states, err := store.ListStates(7)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, -1, states[0].Items)
+	_, err = CountItems(store, states[0])
+	assert.Error(t, err)
+}
+
+func TestRebuildIndex(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = store.RebuildIndex(7)
+	assert.ErrorContains(t, err, "failed to read project directory")
+
+	_, err = store.SaveState(indexTestState(1704067200, 1))
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(store.projectDir(7), metaDir, indexFileName)))
+
+	index, err := store.RebuildIndex(7)
+	require.NoError(t, err)
+	require.Len(t, index.States, 1)
+	assert.Equal(t, 1, index.States[0].Items)
+}
+
+func TestCountItems(t *testing.T) {
+	store := NewMemoryStore()
+	_, err := store.SaveState(indexTestState(1704067200, 2))
+	require.NoError(t, err)
+	states, err := store.ListStates(7)
+	require.NoError(t, err)
+
+	// Memory stores don't know item counts without loading the state
+	assert.Equal(t, -1, states[0].Items)
+	items, err := CountItems(store, states[0])
+	require.NoError(t, err)
+	assert.Equal(t, 2, items)
+
+	items, err = CountItems(store, StateInfo{Filename: "missing", Items: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 5, items)
+}
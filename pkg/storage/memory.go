@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// StateStore stores project states. It is implemented by the file based Store,
+// by ObjectStore and by MemoryStore.
+type StateStore interface {
+	// SaveState saves a state and returns its name, also with a *PushError if only
+	// pushing it to git failed
+	SaveState(state *types.ProjectState) (string, error)
+	LoadState(projectNumber int, timestamp time.Time) (*types.ProjectState, error)
+	LoadStateFile(filename string) (*types.ProjectState, error)
+	FindClosestState(projectNumber int, timestamp time.Time) (string, error)
+	ListTimestamps(projectNumber int) ([]time.Time, error)
+	ListProjects() ([]int, error)
+	// ListStates returns the stored states of a project sorted by timestamp
+	ListStates(projectNumber int) ([]StateInfo, error)
+	// DeleteState deletes a state by the name returned from SaveState or FindClosestState
+	DeleteState(filename string) error
+	// LoadPalette loads the palette of a project, or an empty palette if none was saved yet
+	LoadPalette(projectNumber int) (*types.Palette, error)
+	SavePalette(projectNumber int, palette *types.Palette) error
+	// LoadHeartbeat loads the latest capture that found no changes, or nil if there is none
+	LoadHeartbeat(projectNumber int) (*types.Heartbeat, error)
+	SaveHeartbeat(projectNumber int, heartbeat *types.Heartbeat) error
+}
+
+// StateInfo describes a stored state without loading it
+type StateInfo struct {
+	Filename  string // Name of the state, e.g. its path or URL
+	Timestamp time.Time
+	Size      int64 // Stored size in bytes
+	Items     int   // Number of items, -1 if unknown without loading the state
+}
+
+var (
+	_ StateStore = (*Store)(nil)
+	_ StateStore = (*MemoryStore)(nil)
+)
+
+// MemoryStore is a concurrency-safe in-memory StateStore for tests and for
+// embedding the tool where states come from elsewhere. States are stored as
+// JSON like the file based store, so loaded states look exactly as if they had
+// been read from disk.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	states map[string][]byte // Encoded states by name
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: make(map[string][]byte)}
+}
+
+// SaveState saves a project state in memory and returns its name
+func (m *MemoryStore) SaveState(state *types.ProjectState) (string, error) {
+	err := validateState(state)
+	if err != nil {
+		return "", fmt.Errorf("invalid state: %w", err)
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	name := memoryStateName(state.ProjectNumber, state.Timestamp)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[name] = data
+	return name, nil
+}
+
+// LoadState loads the project state closest to the given timestamp
+func (m *MemoryStore) LoadState(projectNumber int, timestamp time.Time) (*types.ProjectState, error) {
+	name, err := m.FindClosestState(projectNumber, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return m.LoadStateFile(name)
+}
+
+// LoadStateFile loads a project state by the name returned from SaveState
+func (m *MemoryStore) LoadStateFile(filename string) (*types.ProjectState, error) {
+	m.mu.RLock()
+	data, ok := m.states[filename]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("failed to read state file: %s not found", filename)
+	}
+
+	var state types.ProjectState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	state.NormalizeAttributes()
+
+	state.Filename = filename
+	return &state, nil
+}
+
+// DeleteState deletes a state by the name returned from SaveState
+func (m *MemoryStore) DeleteState(filename string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.states[filename]; !ok {
+		return fmt.Errorf("failed to delete state file: %s not found", filename)
+	}
+	delete(m.states, filename)
+	return nil
+}
+
+// FindClosestState finds the name of the state closest to the given timestamp
+func (m *MemoryStore) FindClosestState(projectNumber int, timestamp time.Time) (string, error) {
+	timestamps, err := m.ListTimestamps(projectNumber)
+	if err != nil {
+		return "", err
+	}
+
+	closest, ok := closestTimestamp(timestamps, timestamp)
+	if !ok {
+		return "", fmt.Errorf("no state files found for project %d", projectNumber)
+	}
+	return memoryStateName(projectNumber, closest), nil
+}
+
+// ListTimestamps returns the timestamps of all stored states of a project in ascending order
+func (m *MemoryStore) ListTimestamps(projectNumber int) ([]time.Time, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var timestamps []time.Time
+	for name := range m.states {
+		var number int
+		var unix int64
+		if _, err := fmt.Sscanf(name, "project=%d/%d.json", &number, &unix); err == nil && number == projectNumber {
+			timestamps = append(timestamps, time.Unix(unix, 0))
+		}
+	}
+
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i].Before(timestamps[j])
+	})
+	return timestamps, nil
+}
+
+// ListStates returns the stored states of a project sorted by timestamp
+func (m *MemoryStore) ListStates(projectNumber int) ([]StateInfo, error) {
+	timestamps, err := m.ListTimestamps(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	states := make([]StateInfo, len(timestamps))
+	for i, ts := range timestamps {
+		name := memoryStateName(projectNumber, ts)
+		states[i] = StateInfo{Filename: name, Timestamp: ts, Size: int64(len(m.states[name])), Items: -1}
+	}
+	return states, nil
+}
+
+// ListProjects returns the numbers of all projects with stored states in ascending order
+func (m *MemoryStore) ListProjects() ([]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	seen := make(map[int]bool)
+	var projects []int
+	for name := range m.states {
+		var number int
+		if _, err := fmt.Sscanf(name, "project=%d/", &number); err == nil && !seen[number] {
+			seen[number] = true
+			projects = append(projects, number)
+		}
+	}
+
+	sort.Ints(projects)
+	return projects, nil
+}
+
+// memoryStateName returns the name of a state in the memory store, mirroring the file layout
+func memoryStateName(projectNumber int, timestamp time.Time) string {
+	return fmt.Sprintf("project=%d/%d.json", projectNumber, timestamp.Unix())
+}
+
+// closestTimestamp returns the timestamp closest to the target. The second
+// return value is false if there are no timestamps.
+func closestTimestamp(timestamps []time.Time, target time.Time) (time.Time, bool) {
+	var closest time.Time
+	var minDiff time.Duration
+	for i, ts := range timestamps {
+		diff := target.Sub(ts)
+		if diff < 0 {
+			diff = -diff
+		}
+		if i == 0 || diff < minDiff {
+			closest = ts
+			minDiff = diff
+		}
+	}
+	return closest, len(timestamps) > 0
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStateStore checks the behavior shared by all StateStore implementations
+func testStateStore(t *testing.T, store StateStore) {
+	timestamps := []time.Time{
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	for _, projectNumber := range []int{42, 7} {
+		for _, ts := range timestamps {
+			_, err := store.SaveState(&types.ProjectState{
+				Timestamp:     ts,
+				ProjectNumber: projectNumber,
+				Items: []types.Item{
+					{ID: "test-1", Attributes: map[string]interface{}{"Title": fmt.Sprintf("Item %d", ts.Day())}},
+				},
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	_, err := store.SaveState(&types.ProjectState{Timestamp: timestamps[0]})
+	assert.Error(t, err, "invalid states are rejected")
+
+	projects, err := store.ListProjects()
+	require.NoError(t, err)
+	assert.Equal(t, []int{7, 42}, projects)
+
+	listed, err := store.ListTimestamps(42)
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	assert.True(t, listed[0].Equal(timestamps[1]))
+	assert.True(t, listed[2].Equal(timestamps[0]))
+
+	states, err := store.ListStates(42)
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	for i, info := range states {
+		assert.True(t, info.Timestamp.Equal(listed[i]))
+		assert.Positive(t, info.Size)
+		loaded, err := store.LoadStateFile(info.Filename)
+		require.NoError(t, err)
+		assert.True(t, loaded.Timestamp.Equal(info.Timestamp))
+	}
+
+	state, err := store.LoadState(42, time.Date(2024, 1, 2, 20, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "Item 3", state.Items[0].GetTitle())
+	assert.Equal(t, 42, state.ProjectNumber)
+
+	filename, err := store.FindClosestState(7, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	state, err = store.LoadStateFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "Item 1", state.Items[0].GetTitle())
+	assert.Equal(t, filename, state.Filename)
+
+	_, err = store.LoadState(999, time.Now())
+	assert.Error(t, err)
+
+	require.NoError(t, store.DeleteState(filename))
+	listed, err = store.ListTimestamps(7)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.True(t, listed[0].Equal(timestamps[2]), "the oldest state was deleted")
+}
+
+func TestStateStores(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		tempDir, err := os.MkdirTemp("", "storage_test")
+		require.NoError(t, err)
+		defer os.RemoveAll(tempDir)
+
+		store, err := NewFileStore(tempDir)
+		require.NoError(t, err)
+		testStateStore(t, store)
+	})
+
+	t.Run("compressed file", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir(), WithCompression(true))
+		require.NoError(t, err)
+		testStateStore(t, store)
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		testStateStore(t, NewMemoryStore())
+	})
+}
+
+func TestMemoryStoreIsolatesStates(t *testing.T) {
+	store := NewMemoryStore()
+	state := &types.ProjectState{
+		Timestamp:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ProjectNumber: 123,
+		Items:         []types.Item{{ID: "test-1", Attributes: map[string]interface{}{"Title": "Before"}}},
+	}
+	name, err := store.SaveState(state)
+	require.NoError(t, err)
+	assert.Equal(t, "project=123/1704067200.json", name)
+
+	// Changes after saving don't leak into the store
+	state.Items[0].Attributes["Title"] = "After"
+	loaded, err := store.LoadStateFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "Before", loaded.Items[0].GetTitle())
+
+	_, err = store.LoadStateFile("project=123/0.json")
+	assert.Error(t, err)
+}
+
+func TestMemoryStoreConcurrentAccess(t *testing.T) {
+	store := NewMemoryStore()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := store.SaveState(&types.ProjectState{
+				Timestamp:     start.Add(time.Duration(i) * time.Hour),
+				ProjectNumber: 123,
+				Items:         []types.Item{{ID: "test-1", Attributes: map[string]interface{}{"Title": "Item"}}},
+			})
+			assert.NoError(t, err)
+			_, err = store.LoadState(123, start)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	timestamps, err := store.ListTimestamps(123)
+	require.NoError(t, err)
+	assert.Len(t, timestamps, 20)
+}
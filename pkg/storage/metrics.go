@@ -0,0 +1,297 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// maxLatencySamples is the number of latest latencies per operation kept for percentiles
+const maxLatencySamples = 1000
+
+// Metrics records the operations of a store: how often each was called, how often
+// it failed, its latency and the bytes read and written. It is safe for concurrent
+// use, and a nil *Metrics records nothing.
+type Metrics struct {
+	mu           sync.Mutex
+	operations   map[string]*operationMetrics
+	bytesRead    int64
+	bytesWritten int64
+}
+
+// operationMetrics are the calls of a single store operation
+type operationMetrics struct {
+	count     int
+	errors    int
+	total     time.Duration
+	latencies []time.Duration // Latest latencies, a ring buffer of maxLatencySamples
+	next      int
+}
+
+// OperationStats summarizes the calls of a store operation
+type OperationStats struct {
+	Operation string // Method of the store, e.g. LoadState
+	Count     int
+	Errors    int
+	Total     time.Duration // Time spent in all calls
+	P50       time.Duration // Latency percentiles of the latest calls
+	P90       time.Duration
+	P99       time.Duration
+	Max       time.Duration
+}
+
+// NewMetrics creates empty store metrics
+func NewMetrics() *Metrics {
+	return &Metrics{operations: make(map[string]*operationMetrics)}
+}
+
+// WithMetrics records the operations of the store created by NewStore in the metrics
+func WithMetrics(metrics *Metrics) StoreOption {
+	return func(o *StoreOptions) {
+		o.Metrics = metrics
+	}
+}
+
+// record records a call of an operation that started at start. It is deferred with
+// a pointer to the error returned by the call.
+func (m *Metrics) record(operation string, start time.Time, err *error) {
+	if m == nil {
+		return
+	}
+	latency := time.Since(start)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	op, ok := m.operations[operation]
+	if !ok {
+		op = &operationMetrics{}
+		m.operations[operation] = op
+	}
+	op.count++
+	if *err != nil {
+		op.errors++
+	}
+	op.total += latency
+	if len(op.latencies) < maxLatencySamples {
+		op.latencies = append(op.latencies, latency)
+	} else {
+		op.latencies[op.next] = latency
+		op.next = (op.next + 1) % maxLatencySamples
+	}
+}
+
+// addRead records bytes read from the backend of a store
+func (m *Metrics) addRead(n int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bytesRead += int64(n)
+}
+
+// addWritten records bytes written to the backend of a store
+func (m *Metrics) addWritten(n int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bytesWritten += int64(n)
+}
+
+// Bytes returns the bytes of states and palettes read from and written to the backend
+func (m *Metrics) Bytes() (read, written int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.bytesRead, m.bytesWritten
+}
+
+// Operations returns the stats of all operations called so far, sorted by name
+func (m *Metrics) Operations() []OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make([]OperationStats, 0, len(m.operations))
+	for name, op := range m.operations {
+		latencies := append([]time.Duration(nil), op.latencies...)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		stats = append(stats, OperationStats{
+			Operation: name,
+			Count:     op.count,
+			Errors:    op.errors,
+			Total:     op.total,
+			P50:       percentile(latencies, 0.5),
+			P90:       percentile(latencies, 0.9),
+			P99:       percentile(latencies, 0.99),
+			Max:       percentile(latencies, 1),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Operation < stats[j].Operation })
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// Summary describes the metrics for verbose output, one line per operation, e.g.
+// "LoadState: 12 calls, 0 errors, p50 80ms, p90 210ms, p99 450ms, max 450ms"
+func (m *Metrics) Summary() string {
+	read, written := m.Bytes()
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Store: %s read, %s written\n", formatBytes(read), formatBytes(written))
+	for _, op := range m.Operations() {
+		fmt.Fprintf(&sb, "  %s: %d call%s, %d error%s, p50 %s, p90 %s, p99 %s, max %s\n",
+			op.Operation, op.Count, plural(op.Count), op.Errors, plural(op.Errors),
+			roundLatency(op.P50), roundLatency(op.P90), roundLatency(op.P99), roundLatency(op.Max))
+	}
+	return sb.String()
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	read, written := m.Bytes()
+	operations := m.Operations()
+
+	var sb strings.Builder
+	sb.WriteString("# HELP gh_project_report_store_operations_total Calls of store operations.\n")
+	sb.WriteString("# TYPE gh_project_report_store_operations_total counter\n")
+	for _, op := range operations {
+		fmt.Fprintf(&sb, "gh_project_report_store_operations_total{operation=%q} %d\n", op.Operation, op.Count)
+	}
+	sb.WriteString("# HELP gh_project_report_store_errors_total Failed calls of store operations.\n")
+	sb.WriteString("# TYPE gh_project_report_store_errors_total counter\n")
+	for _, op := range operations {
+		fmt.Fprintf(&sb, "gh_project_report_store_errors_total{operation=%q} %d\n", op.Operation, op.Errors)
+	}
+	sb.WriteString("# HELP gh_project_report_store_latency_seconds Latency of store operations.\n")
+	sb.WriteString("# TYPE gh_project_report_store_latency_seconds summary\n")
+	for _, op := range operations {
+		for _, q := range []struct {
+			quantile string
+			latency  time.Duration
+		}{{"0.5", op.P50}, {"0.9", op.P90}, {"0.99", op.P99}} {
+			fmt.Fprintf(&sb, "gh_project_report_store_latency_seconds{operation=%q,quantile=%q} %g\n", op.Operation, q.quantile, q.latency.Seconds())
+		}
+		fmt.Fprintf(&sb, "gh_project_report_store_latency_seconds_sum{operation=%q} %g\n", op.Operation, op.Total.Seconds())
+		fmt.Fprintf(&sb, "gh_project_report_store_latency_seconds_count{operation=%q} %d\n", op.Operation, op.Count)
+	}
+	sb.WriteString("# HELP gh_project_report_store_read_bytes_total Bytes of states and palettes read from the store.\n")
+	sb.WriteString("# TYPE gh_project_report_store_read_bytes_total counter\n")
+	fmt.Fprintf(&sb, "gh_project_report_store_read_bytes_total %d\n", read)
+	sb.WriteString("# HELP gh_project_report_store_written_bytes_total Bytes of states and palettes written to the store.\n")
+	sb.WriteString("# TYPE gh_project_report_store_written_bytes_total counter\n")
+	fmt.Fprintf(&sb, "gh_project_report_store_written_bytes_total %d\n", written)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// formatBytes formats a number of bytes with a decimal unit, e.g. "3.4 MB"
+func formatBytes(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "kMGTPE"[exp])
+}
+
+// roundLatency rounds a latency for display
+func roundLatency(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(10 * time.Millisecond)
+	}
+	return d.Round(100 * time.Microsecond)
+}
+
+// plural returns "s" unless n is 1
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// instrumentedStore records the operations of a store in metrics
+type instrumentedStore struct {
+	store   StateStore
+	metrics *Metrics
+}
+
+var _ StateStore = (*instrumentedStore)(nil)
+
+func (s *instrumentedStore) SaveState(state *types.ProjectState) (filename string, err error) {
+	defer s.metrics.record("SaveState", time.Now(), &err)
+	return s.store.SaveState(state)
+}
+
+func (s *instrumentedStore) LoadState(projectNumber int, timestamp time.Time) (state *types.ProjectState, err error) {
+	defer s.metrics.record("LoadState", time.Now(), &err)
+	return s.store.LoadState(projectNumber, timestamp)
+}
+
+func (s *instrumentedStore) LoadStateFile(filename string) (state *types.ProjectState, err error) {
+	defer s.metrics.record("LoadStateFile", time.Now(), &err)
+	return s.store.LoadStateFile(filename)
+}
+
+func (s *instrumentedStore) FindClosestState(projectNumber int, timestamp time.Time) (filename string, err error) {
+	defer s.metrics.record("FindClosestState", time.Now(), &err)
+	return s.store.FindClosestState(projectNumber, timestamp)
+}
+
+func (s *instrumentedStore) ListTimestamps(projectNumber int) (timestamps []time.Time, err error) {
+	defer s.metrics.record("ListTimestamps", time.Now(), &err)
+	return s.store.ListTimestamps(projectNumber)
+}
+
+func (s *instrumentedStore) ListProjects() (projects []int, err error) {
+	defer s.metrics.record("ListProjects", time.Now(), &err)
+	return s.store.ListProjects()
+}
+
+func (s *instrumentedStore) ListStates(projectNumber int) (states []StateInfo, err error) {
+	defer s.metrics.record("ListStates", time.Now(), &err)
+	return s.store.ListStates(projectNumber)
+}
+
+func (s *instrumentedStore) DeleteState(filename string) (err error) {
+	defer s.metrics.record("DeleteState", time.Now(), &err)
+	return s.store.DeleteState(filename)
+}
+
+func (s *instrumentedStore) LoadPalette(projectNumber int) (palette *types.Palette, err error) {
+	defer s.metrics.record("LoadPalette", time.Now(), &err)
+	return s.store.LoadPalette(projectNumber)
+}
+
+func (s *instrumentedStore) SavePalette(projectNumber int, palette *types.Palette) (err error) {
+	defer s.metrics.record("SavePalette", time.Now(), &err)
+	return s.store.SavePalette(projectNumber, palette)
+}
+
+func (s *instrumentedStore) LoadHeartbeat(projectNumber int) (heartbeat *types.Heartbeat, err error) {
+	defer s.metrics.record("LoadHeartbeat", time.Now(), &err)
+	return s.store.LoadHeartbeat(projectNumber)
+}
+
+func (s *instrumentedStore) SaveHeartbeat(projectNumber int, heartbeat *types.Heartbeat) (err error) {
+	defer s.metrics.record("SaveHeartbeat", time.Now(), &err)
+	return s.store.SaveHeartbeat(projectNumber, heartbeat)
+}
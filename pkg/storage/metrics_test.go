@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRecordsStoreOperations(t *testing.T) {
+	metrics := NewMetrics()
+	store, err := NewStore(t.TempDir(), WithMetrics(metrics))
+	require.NoError(t, err)
+
+	state := &types.ProjectState{ProjectNumber: 1, Timestamp: time.Unix(1704067200, 0), Items: []types.Item{{ID: "1", DateSpan: types.MustNewDateSpan("2024-01-01", "2024-01-10"), Attributes: map[string]interface{}{"Title": "Launch"}}}}
+	_, err = store.SaveState(state)
+	require.NoError(t, err)
+	_, err = store.LoadState(1, state.Timestamp)
+	require.NoError(t, err)
+	_, err = store.LoadStateFile("missing.json")
+	require.Error(t, err)
+
+	ops := make(map[string]OperationStats)
+	for _, op := range metrics.Operations() {
+		ops[op.Operation] = op
+	}
+	assert.Equal(t, 1, ops["SaveState"].Count)
+	assert.Equal(t, 1, ops["LoadState"].Count)
+	assert.Equal(t, 0, ops["LoadState"].Errors)
+	assert.Equal(t, 1, ops["LoadStateFile"].Errors)
+	assert.Positive(t, ops["LoadState"].Max)
+
+	read, written := metrics.Bytes()
+	assert.Positive(t, written)
+	assert.Equal(t, written, read)
+}
+
+func TestMetricsPercentiles(t *testing.T) {
+	metrics := NewMetrics()
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		var err error
+		metrics.record("LoadState", start, &err)
+	}
+
+	ops := metrics.Operations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, 100, ops[0].Count)
+	assert.LessOrEqual(t, ops[0].P50, ops[0].P90)
+	assert.LessOrEqual(t, ops[0].P90, ops[0].P99)
+	assert.LessOrEqual(t, ops[0].P99, ops[0].Max)
+
+	assert.Equal(t, 2*time.Millisecond, percentile([]time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond, 4 * time.Millisecond}, 0.5))
+	assert.Equal(t, 4*time.Millisecond, percentile([]time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond, 4 * time.Millisecond}, 0.99))
+	assert.Zero(t, percentile(nil, 0.5))
+}
+
+func TestMetricsOutput(t *testing.T) {
+	metrics := NewMetrics()
+	var err error
+	metrics.record("LoadState", time.Now(), &err)
+	metrics.addRead(3_400_000)
+
+	summary := metrics.Summary()
+	assert.Contains(t, summary, "Store: 3.4 MB read, 0 B written")
+	assert.Contains(t, summary, "LoadState: 1 call, 0 errors")
+
+	var sb strings.Builder
+	require.NoError(t, metrics.WritePrometheus(&sb))
+	assert.Contains(t, sb.String(), `gh_project_report_store_operations_total{operation="LoadState"} 1`)
+	assert.Contains(t, sb.String(), `gh_project_report_store_latency_seconds_count{operation="LoadState"} 1`)
+	assert.Contains(t, sb.String(), "gh_project_report_store_read_bytes_total 3400000")
+}
+
+func TestNilMetricsRecordNothing(t *testing.T) {
+	var metrics *Metrics
+	var err error
+	metrics.record("LoadState", time.Now(), &err)
+	metrics.addRead(1)
+	metrics.addWritten(1)
+}
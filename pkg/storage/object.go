@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// ErrObjectNotFound is returned by object clients for missing objects
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo describes an object in a bucket
+type ObjectInfo struct {
+	Key  string
+	Size int64 // Size in bytes
+}
+
+// ObjectClient reads and writes objects of a bucket in an object storage service
+type ObjectClient interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) error
+	// List returns all objects whose key starts with prefix
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// ObjectStore is a StateStore keeping state files in a bucket, using the same
+// layout as the file based store below a key prefix
+type ObjectStore struct {
+	client  ObjectClient
+	base    string // URL of the prefix, e.g. s3://bucket/prefix, used to name states
+	prefix  string // Key prefix without trailing slash, may be empty
+	options StoreOptions
+}
+
+var _ StateStore = (*ObjectStore)(nil)
+
+// NewObjectStore creates a store keeping states below prefix in the bucket of
+// the client. The base URL (e.g. s3://bucket) is used to name stored states.
+func NewObjectStore(client ObjectClient, base, prefix string, opts ...StoreOption) *ObjectStore {
+	prefix = strings.Trim(prefix, "/")
+	base = strings.TrimSuffix(base, "/")
+	if prefix != "" {
+		base += "/" + prefix
+	}
+	return &ObjectStore{client: client, base: base, prefix: prefix, options: buildStoreOptions(opts)}
+}
+
+// SaveState saves a project state as an object and returns its URL
+func (o *ObjectStore) SaveState(state *types.ProjectState) (string, error) {
+	err := validateState(state)
+	if err != nil {
+		return "", fmt.Errorf("invalid state: %w", err)
+	}
+
+	data, err := o.options.encode(state)
+	if err != nil {
+		return "", err
+	}
+
+	key := o.key(fmt.Sprintf("states/project=%d/%s", state.ProjectNumber, stateFileName(state.Timestamp, o.options.Compress)))
+	if err := o.client.Put(context.Background(), key, data); err != nil {
+		return "", fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := o.client.Put(context.Background(), key+checksumSuffix, encodeChecksum(data, key)); err != nil {
+		return "", fmt.Errorf("failed to write checksum file: %w", err)
+	}
+	o.options.Metrics.addWritten(len(data))
+	return o.name(key), nil
+}
+
+// LoadState loads the project state closest to the given timestamp
+func (o *ObjectStore) LoadState(projectNumber int, timestamp time.Time) (*types.ProjectState, error) {
+	name, err := o.FindClosestState(projectNumber, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return o.LoadStateFile(name)
+}
+
+// LoadStateFile loads a project state by the URL returned from SaveState, verifying it
+// against its checksum if it has one
+func (o *ObjectStore) LoadStateFile(filename string) (*types.ProjectState, error) {
+	data, err := o.client.Get(context.Background(), o.keyOf(filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	o.options.Metrics.addRead(len(data))
+
+	checksum, err := o.client.Get(context.Background(), o.keyOf(filename)+checksumSuffix)
+	if err != nil && !errors.Is(err, ErrObjectNotFound) {
+		return nil, fmt.Errorf("failed to read checksum file: %w", err)
+	}
+	if err == nil {
+		if err := verifyChecksum(data, checksum, filename); err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := o.options.decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	state.Filename = filename
+	return state, nil
+}
+
+// DeleteState deletes a state by the URL returned from SaveState, and its checksum
+func (o *ObjectStore) DeleteState(filename string) error {
+	if err := o.client.Delete(context.Background(), o.keyOf(filename)); err != nil {
+		return fmt.Errorf("failed to delete state file: %w", err)
+	}
+	if err := o.client.Delete(context.Background(), o.keyOf(filename)+checksumSuffix); err != nil && !errors.Is(err, ErrObjectNotFound) {
+		return fmt.Errorf("failed to delete checksum file: %w", err)
+	}
+	return nil
+}
+
+// FindClosestState finds the URL of the state closest to the given timestamp
+func (o *ObjectStore) FindClosestState(projectNumber int, timestamp time.Time) (string, error) {
+	states, err := o.ListStates(projectNumber)
+	if err != nil {
+		return "", err
+	}
+
+	timestamps := make([]time.Time, len(states))
+	for i, state := range states {
+		timestamps[i] = state.Timestamp
+	}
+	closest, ok := closestTimestamp(timestamps, timestamp)
+	if !ok {
+		return "", fmt.Errorf("no state files found for project %d", projectNumber)
+	}
+	for _, state := range states {
+		if state.Timestamp.Equal(closest) {
+			return state.Filename, nil
+		}
+	}
+	return "", fmt.Errorf("no state files found for project %d", projectNumber)
+}
+
+// ListTimestamps returns the timestamps of all stored states of a project in ascending order
+func (o *ObjectStore) ListTimestamps(projectNumber int) ([]time.Time, error) {
+	states, err := o.ListStates(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]time.Time, len(states))
+	for i, state := range states {
+		timestamps[i] = state.Timestamp
+	}
+	return timestamps, nil
+}
+
+// ListStates returns the stored states of a project sorted by timestamp
+func (o *ObjectStore) ListStates(projectNumber int) ([]StateInfo, error) {
+	dir := o.key(fmt.Sprintf("states/project=%d", projectNumber)) + "/"
+	objects, err := o.client.List(context.Background(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list state files: %w", err)
+	}
+
+	var states []StateInfo
+	for _, object := range objects {
+		// Skip aggregates and other objects in subdirectories
+		rest := strings.TrimPrefix(object.Key, dir)
+		if strings.Contains(rest, "/") || !isStateFileName(rest) || extractTimestamp(rest).IsZero() {
+			continue
+		}
+		states = append(states, StateInfo{Filename: o.name(object.Key), Timestamp: extractTimestamp(rest), Size: object.Size, Items: -1})
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Timestamp.Before(states[j].Timestamp)
+	})
+	return states, nil
+}
+
+// ListProjects returns the numbers of all projects with stored states in ascending order
+func (o *ObjectStore) ListProjects() ([]int, error) {
+	dir := o.key("states") + "/"
+	objects, err := o.client.List(context.Background(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list state files: %w", err)
+	}
+
+	seen := make(map[int]bool)
+	var projects []int
+	for _, object := range objects {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(object.Key, dir), "/")
+		number, err := strconv.Atoi(strings.TrimPrefix(segment, "project="))
+		if err != nil || !strings.HasPrefix(segment, "project=") || seen[number] {
+			continue
+		}
+		seen[number] = true
+		projects = append(projects, number)
+	}
+
+	sort.Ints(projects)
+	return projects, nil
+}
+
+// key returns the object key of a path relative to the prefix
+func (o *ObjectStore) key(p string) string {
+	return path.Join(o.prefix, p)
+}
+
+// keyOf returns the object key of a URL returned from name
+func (o *ObjectStore) keyOf(name string) string {
+	key := strings.TrimPrefix(strings.TrimPrefix(name, o.base), "/")
+	if o.prefix != "" {
+		key = o.prefix + "/" + key
+	}
+	return key
+}
+
+// name returns the URL of an object key
+func (o *ObjectStore) name(key string) string {
+	return o.base + "/" + strings.TrimPrefix(strings.TrimPrefix(key, o.prefix), "/")
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestObjectStore creates an object store backed by a fake S3 bucket
+func newTestObjectStore(t *testing.T, prefix string, opts ...StoreOption) *ObjectStore {
+	server := httptest.NewServer(&fakeBucket{objects: make(map[string][]byte)})
+	t.Cleanup(server.Close)
+
+	client := NewS3Client(S3Config{Endpoint: server.URL, Region: "us-east-1", Bucket: "bucket", AccessKeyID: "key", SecretAccessKey: "secret"})
+	return NewObjectStore(client, "s3://bucket", prefix, opts...)
+}
+
+func TestObjectStore(t *testing.T) {
+	t.Run("without prefix", func(t *testing.T) {
+		testStateStore(t, newTestObjectStore(t, ""))
+	})
+
+	t.Run("with prefix", func(t *testing.T) {
+		testStateStore(t, newTestObjectStore(t, "/team/reports/"))
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		store := newTestObjectStore(t, "", WithCompression(true))
+		testStateStore(t, store)
+
+		name, err := store.FindClosestState(42, time.Now())
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(name, ".json.gz"), name)
+	})
+}
+
+func TestObjectStoreNames(t *testing.T) {
+	store := newTestObjectStore(t, "ci")
+	state := &types.ProjectState{
+		Timestamp:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ProjectNumber: 123,
+		Items:         []types.Item{{ID: "test-1", Attributes: map[string]interface{}{"Title": "Test Item"}}},
+	}
+
+	name, err := store.SaveState(state)
+	require.NoError(t, err)
+	assert.Equal(t, "s3://bucket/ci/states/project=123/1704067200.json", name)
+
+	closest, err := store.FindClosestState(123, state.Timestamp.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, name, closest)
+
+	loaded, err := store.LoadStateFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, name, loaded.Filename)
+
+	// Aggregates and other projects don't show up as states
+	require.NoError(t, store.client.Put(context.Background(), "ci/states/project=123/monthly/2024-01.json", []byte("{}")))
+	timestamps, err := store.ListTimestamps(123)
+	require.NoError(t, err)
+	assert.Len(t, timestamps, 1)
+
+	_, err = store.LoadState(999, time.Now())
+	assert.ErrorContains(t, err, "no state files found for project 999")
+}
+
+func TestNewStore(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewStore(dir)
+	require.NoError(t, err)
+	assert.IsType(t, &Store{}, store)
+
+	store, err = NewStore("file://" + dir)
+	require.NoError(t, err)
+	assert.IsType(t, &Store{}, store)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	store, err = NewStore("s3://bucket/prefix")
+	require.NoError(t, err)
+	require.IsType(t, &ObjectStore{}, store)
+	objectStore := store.(*ObjectStore)
+	assert.Equal(t, "s3://bucket/prefix", objectStore.base)
+	assert.Equal(t, "https://s3.eu-west-1.amazonaws.com", objectStore.client.(*S3Client).config.Endpoint)
+
+	t.Setenv("GCS_ACCESS_KEY_ID", "")
+	_, err = NewStore("gs://bucket")
+	assert.ErrorContains(t, err, "GCS_ACCESS_KEY_ID and GCS_SECRET_ACCESS_KEY")
+
+	_, err = NewStore("s3://")
+	assert.ErrorContains(t, err, "missing bucket")
+
+	_, err = NewStore("ftp://host/dir")
+	assert.ErrorContains(t, err, "unsupported store location")
+}
@@ -0,0 +1,48 @@
+package storage
+
+import "log"
+
+// StoreOptions configures how stores write states
+type StoreOptions struct {
+	Compress bool        // Write states gzip compressed as *.json.gz
+	Key      []byte      // Encrypts new states with AES-256-GCM, nil writes them unencrypted
+	Metrics  *Metrics    // Records operations and bytes read and written, nil disables recording
+	Logger   *log.Logger // Logs failures that don't fail operations, nil disables logging
+
+	GitCommit bool   // Commit each saved and deleted state to the git repository of the store
+	GitRemote string // Remote pushed to after each commit, empty doesn't push
+}
+
+// StoreOption configures a store
+type StoreOption func(*StoreOptions)
+
+// WithCompression writes new states gzip compressed. States are always
+// decompressed transparently on load, whether compression is enabled or not.
+func WithCompression(enabled bool) StoreOption {
+	return func(o *StoreOptions) {
+		o.Compress = enabled
+	}
+}
+
+// buildStoreOptions applies store options to the defaults
+func buildStoreOptions(opts []StoreOption) StoreOptions {
+	var options StoreOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// WithLogger logs failures that don't fail store operations, such as saving the index
+func WithLogger(logger *log.Logger) StoreOption {
+	return func(o *StoreOptions) {
+		o.Logger = logger
+	}
+}
+
+// logf logs a message if a logger is set
+func (o StoreOptions) logf(format string, args ...interface{}) {
+	if o.Logger != nil {
+		o.Logger.Printf(format, args...)
+	}
+}
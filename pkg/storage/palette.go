@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/naag/gh-project-report/pkg/types"
+)
+
+// paletteFileName is the name of the palette file in the metadata directory of a project
+const paletteFileName = "palette.json"
+
+// metaDir is the directory within a project directory holding metadata shared by all states
+const metaDir = "meta"
+
+// LoadPalette loads the palette of a project, or an empty palette if none was saved yet
+func (s *Store) LoadPalette(projectNumber int) (*types.Palette, error) {
+	data, err := os.ReadFile(s.paletteFile(projectNumber))
+	if os.IsNotExist(err) {
+		return types.NewPalette(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read palette file: %w", err)
+	}
+	s.options.Metrics.addRead(len(data))
+	if data, err = s.options.open(data); err != nil {
+		return nil, err
+	}
+	return decodePalette(data)
+}
+
+// SavePalette saves the palette of a project
+func (s *Store) SavePalette(projectNumber int, palette *types.Palette) error {
+	data, err := json.MarshalIndent(palette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal palette: %w", err)
+	}
+	if data, err = s.options.seal(data); err != nil {
+		return err
+	}
+
+	filename := s.paletteFile(projectNumber)
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	if err := writeFileAtomic(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write palette file: %w", err)
+	}
+	s.options.Metrics.addWritten(len(data))
+	return nil
+}
+
+// paletteFile returns the path of the palette file of a project
+func (s *Store) paletteFile(projectNumber int) string {
+	return filepath.Join(s.baseDir, "states", fmt.Sprintf("project=%d", projectNumber), metaDir, paletteFileName)
+}
+
+// LoadPalette loads the palette of a project, or an empty palette if none was saved yet
+func (o *ObjectStore) LoadPalette(projectNumber int) (*types.Palette, error) {
+	data, err := o.client.Get(context.Background(), o.paletteKey(projectNumber))
+	if errors.Is(err, ErrObjectNotFound) {
+		return types.NewPalette(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read palette file: %w", err)
+	}
+	o.options.Metrics.addRead(len(data))
+	if data, err = o.options.open(data); err != nil {
+		return nil, err
+	}
+	return decodePalette(data)
+}
+
+// SavePalette saves the palette of a project
+func (o *ObjectStore) SavePalette(projectNumber int, palette *types.Palette) error {
+	data, err := json.MarshalIndent(palette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal palette: %w", err)
+	}
+	if data, err = o.options.seal(data); err != nil {
+		return err
+	}
+	if err := o.client.Put(context.Background(), o.paletteKey(projectNumber), data); err != nil {
+		return fmt.Errorf("failed to write palette file: %w", err)
+	}
+	o.options.Metrics.addWritten(len(data))
+	return nil
+}
+
+// paletteKey returns the object key of the palette of a project
+func (o *ObjectStore) paletteKey(projectNumber int) string {
+	return o.key(fmt.Sprintf("states/project=%d/%s/%s", projectNumber, metaDir, paletteFileName))
+}
+
+// LoadPalette loads the palette of a project, or an empty palette if none was saved yet
+func (m *MemoryStore) LoadPalette(projectNumber int) (*types.Palette, error) {
+	m.mu.RLock()
+	data, ok := m.states[memoryPaletteName(projectNumber)]
+	m.mu.RUnlock()
+	if !ok {
+		return types.NewPalette(), nil
+	}
+	return decodePalette(data)
+}
+
+// SavePalette saves the palette of a project
+func (m *MemoryStore) SavePalette(projectNumber int, palette *types.Palette) error {
+	data, err := json.Marshal(palette)
+	if err != nil {
+		return fmt.Errorf("failed to marshal palette: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[memoryPaletteName(projectNumber)] = data
+	return nil
+}
+
+// memoryPaletteName returns the name of the palette of a project in the memory store
+func memoryPaletteName(projectNumber int) string {
+	return fmt.Sprintf("project=%d/%s/%s", projectNumber, metaDir, paletteFileName)
+}
+
+// decodePalette unmarshals a palette
+func decodePalette(data []byte) (*types.Palette, error) {
+	palette := types.NewPalette()
+	if err := json.Unmarshal(data, palette); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal palette: %w", err)
+	}
+	return palette, nil
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPalette(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	stores := map[string]StateStore{
+		"file":   fileStore,
+		"memory": NewMemoryStore(),
+		"object": newTestObjectStore(t, "reports"),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			palette, err := store.LoadPalette(123)
+			require.NoError(t, err)
+			assert.Empty(t, palette.Values)
+
+			palette.Observe(types.PaletteStatus, []string{"Todo", "Done"})
+			require.NoError(t, store.SavePalette(123, palette))
+
+			loaded, err := store.LoadPalette(123)
+			require.NoError(t, err)
+			assert.Equal(t, palette, loaded)
+
+			other, err := store.LoadPalette(456)
+			require.NoError(t, err)
+			assert.Empty(t, other.Values)
+
+			// The palette is not mistaken for a state
+			_, err = store.SaveState(&types.ProjectState{
+				ProjectNumber: 123,
+				Timestamp:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Items:         []types.Item{{ID: "1", Attributes: map[string]interface{}{"Title": "Task"}}},
+			})
+			require.NoError(t, err)
+			states, err := store.ListStates(123)
+			require.NoError(t, err)
+			assert.Len(t, states, 1)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ExpandRange returns the timestamps of the nearest two adjacent snapshots for a
+// range whose ends both resolve to the same snapshot. The snapshot closest to the
+// range is paired with its neighbor closest to the range, preferring the earlier
+// one on ties. It fails if the project has fewer than two snapshots.
+func ExpandRange(store StateStore, projectNumber int, from, to time.Time) (time.Time, time.Time, error) {
+	timestamps, err := store.ListTimestamps(projectNumber)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if len(timestamps) < 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("project %d has only %d snapshot(s), capture again to compare", projectNumber, len(timestamps))
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i].Before(timestamps[j])
+	})
+
+	// The snapshot both ends resolve to
+	target, _ := closestTimestamp(timestamps, from)
+	closest := sort.Search(len(timestamps), func(i int) bool {
+		return !timestamps[i].Before(target)
+	})
+
+	switch {
+	case closest == 0:
+		return timestamps[0], timestamps[1], nil
+	case closest == len(timestamps)-1:
+		return timestamps[closest-1], timestamps[closest], nil
+	case distanceToRange(timestamps[closest+1], from, to) < distanceToRange(timestamps[closest-1], from, to):
+		return timestamps[closest], timestamps[closest+1], nil
+	default:
+		return timestamps[closest-1], timestamps[closest], nil
+	}
+}
+
+// distanceToRange returns how far the timestamp lies outside the range from..to
+func distanceToRange(ts, from, to time.Time) time.Duration {
+	switch {
+	case ts.Before(from):
+		return from.Sub(ts)
+	case ts.After(to):
+		return ts.Sub(to)
+	default:
+		return 0
+	}
+}
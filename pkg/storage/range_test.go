@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandRange(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	store := NewMemoryStore()
+	for _, d := range []int{1, 10, 19} {
+		_, err := store.SaveState(&types.ProjectState{Timestamp: day(d), ProjectNumber: 1})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		{"before first snapshot", day(1).AddDate(0, 0, -7), day(1).AddDate(0, 0, -1), day(1), day(10)},
+		{"after last snapshot", day(25), day(28), day(10), day(19)},
+		{"next snapshot is nearer", day(11), day(17), day(10), day(19)},
+		{"previous snapshot is nearer", day(8), day(12), day(1), day(10)},
+		{"tie prefers previous snapshot", day(10).Add(-time.Hour), day(10).Add(time.Hour), day(1), day(10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := ExpandRange(store, 1, tt.from, tt.to)
+			require.NoError(t, err)
+			assert.True(t, tt.wantFrom.Equal(from), "from: %s", from)
+			assert.True(t, tt.wantTo.Equal(to), "to: %s", to)
+		})
+	}
+
+	_, err := store.SaveState(&types.ProjectState{Timestamp: day(1), ProjectNumber: 2})
+	require.NoError(t, err)
+	_, _, err = ExpandRange(store, 2, day(1), day(2))
+	assert.ErrorContains(t, err, "project 2 has only 1 snapshot(s)")
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RetentionPolicy defines which snapshots to keep when pruning. For each of the
+// most recent days, weeks and months with snapshots, up to the given counts,
+// the latest snapshot of the period is kept. The latest snapshot overall is
+// always kept.
+type RetentionPolicy struct {
+	Daily   int
+	Weekly  int // Weeks start on Monday
+	Monthly int
+}
+
+// IsZero returns true if the policy keeps nothing but the latest snapshot
+func (p RetentionPolicy) IsZero() bool {
+	return p.Daily <= 0 && p.Weekly <= 0 && p.Monthly <= 0
+}
+
+// Apply splits the timestamps of snapshots into those to keep and those to
+// prune, both in ascending order. Periods are determined in the given location.
+func (p RetentionPolicy) Apply(timestamps []time.Time, loc *time.Location) (keep, prune []time.Time) {
+	sorted := append([]time.Time(nil), timestamps...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].After(sorted[j])
+	})
+
+	kept := make(map[int]bool)
+	if len(sorted) > 0 {
+		kept[0] = true
+	}
+	p.keepLatestPerPeriod(sorted, p.Daily, kept, func(t time.Time) string {
+		return t.In(loc).Format("2006-01-02")
+	})
+	p.keepLatestPerPeriod(sorted, p.Weekly, kept, func(t time.Time) string {
+		year, week := t.In(loc).ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+	p.keepLatestPerPeriod(sorted, p.Monthly, kept, func(t time.Time) string {
+		return t.In(loc).Format("2006-01")
+	})
+
+	// Return in ascending order
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if kept[i] {
+			keep = append(keep, sorted[i])
+		} else {
+			prune = append(prune, sorted[i])
+		}
+	}
+	return keep, prune
+}
+
+// keepLatestPerPeriod marks the first, i.e. latest, snapshot of each of the n
+// most recent periods of the timestamps sorted in descending order as kept
+func (p RetentionPolicy) keepLatestPerPeriod(sorted []time.Time, n int, kept map[int]bool, period func(time.Time) string) {
+	seen := make(map[string]bool)
+	for i, ts := range sorted {
+		if len(seen) >= n {
+			return
+		}
+		key := period(ts)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		kept[i] = true
+	}
+}
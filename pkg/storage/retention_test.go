@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionPolicyApply(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+	// Two snapshots per day from Monday, Jan 1 to Sunday, Jan 14
+	var timestamps []time.Time
+	for day := 1; day <= 14; day++ {
+		timestamps = append(timestamps, at(day, 9), at(day, 18))
+	}
+	// One snapshot in December
+	timestamps = append(timestamps, time.Date(2023, 12, 15, 9, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		policy   RetentionPolicy
+		wantKeep []time.Time
+	}{
+		{
+			name:     "only the latest snapshot",
+			policy:   RetentionPolicy{},
+			wantKeep: []time.Time{at(14, 18)},
+		},
+		{
+			name:     "daily",
+			policy:   RetentionPolicy{Daily: 3},
+			wantKeep: []time.Time{at(12, 18), at(13, 18), at(14, 18)},
+		},
+		{
+			name:     "weekly",
+			policy:   RetentionPolicy{Weekly: 5},
+			wantKeep: []time.Time{time.Date(2023, 12, 15, 9, 0, 0, 0, time.UTC), at(7, 18), at(14, 18)},
+		},
+		{
+			name:     "daily, weekly and monthly overlap",
+			policy:   RetentionPolicy{Daily: 2, Weekly: 2, Monthly: 2},
+			wantKeep: []time.Time{time.Date(2023, 12, 15, 9, 0, 0, 0, time.UTC), at(7, 18), at(13, 18), at(14, 18)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep, prune := tt.policy.Apply(timestamps, time.UTC)
+			assert.Equal(t, tt.wantKeep, keep)
+			assert.Len(t, prune, len(timestamps)-len(tt.wantKeep))
+			for i := 1; i < len(prune); i++ {
+				assert.True(t, prune[i-1].Before(prune[i]), "pruned snapshots are sorted")
+			}
+		})
+	}
+}
+
+func TestRetentionPolicyLocation(t *testing.T) {
+	// Both snapshots are taken on Jan 1 in UTC, but the second one on Jan 2 in Berlin
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone database not available")
+	}
+	timestamps := []time.Time{
+		time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 23, 30, 0, 0, time.UTC),
+	}
+
+	keep, _ := RetentionPolicy{Daily: 2}.Apply(timestamps, time.UTC)
+	assert.Len(t, keep, 1)
+
+	keep, _ = RetentionPolicy{Daily: 2}.Apply(timestamps, berlin)
+	assert.Len(t, keep, 2)
+}
+
+func TestRetentionPolicyIsZero(t *testing.T) {
+	assert.True(t, RetentionPolicy{}.IsZero())
+	assert.False(t, RetentionPolicy{Monthly: 1}.IsZero())
+}
@@ -1,7 +1,7 @@
 package storage
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -17,10 +17,88 @@ import (
 // Store represents a storage for project states
 type Store struct {
 	baseDir string
+	options StoreOptions
 }
 
-// NewStore creates a new store
-func NewStore(baseDir string) (*Store, error) {
+// NewStore creates the store for a location: s3://bucket/prefix keeps states
+// in S3, gs://bucket/prefix in Google Cloud Storage, and anything else is a
+// local directory, defaulting to the current directory if empty.
+//
+// S3 credentials are read from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN, the region from AWS_REGION (default us-east-1) and an
+// optional endpoint for S3 compatible services from AWS_ENDPOINT_URL. Google
+// Cloud Storage is accessed with HMAC keys from GCS_ACCESS_KEY_ID and
+// GCS_SECRET_ACCESS_KEY.
+//
+// With WithMetrics, all operations of the store and the bytes it reads and
+// writes are recorded.
+func NewStore(location string, opts ...StoreOption) (StateStore, error) {
+	store, err := newStore(location, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if metrics := buildStoreOptions(opts).Metrics; metrics != nil {
+		return &instrumentedStore{store: store, metrics: metrics}, nil
+	}
+	return store, nil
+}
+
+// newStore creates the store for a location without instrumentation
+func newStore(location string, opts ...StoreOption) (StateStore, error) {
+	scheme, rest, ok := strings.Cut(location, "://")
+	if !ok {
+		return NewFileStore(location, opts...)
+	}
+
+	bucket, prefix, _ := strings.Cut(rest, "/")
+	if scheme != "file" && bucket == "" {
+		return nil, fmt.Errorf("invalid store location %q: missing bucket", location)
+	}
+	if scheme != "file" && buildStoreOptions(opts).GitCommit {
+		return nil, fmt.Errorf("git commits are only supported by local stores, not %s", location)
+	}
+
+	switch scheme {
+	case "file":
+		return NewFileStore(rest, opts...)
+	case "s3":
+		config := S3Config{
+			Endpoint:        os.Getenv("AWS_ENDPOINT_URL"),
+			Region:          os.Getenv("AWS_REGION"),
+			Bucket:          bucket,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+		if config.Region == "" {
+			config.Region = "us-east-1"
+		}
+		if config.Endpoint == "" {
+			config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+		}
+		if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+			return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for %s", location)
+		}
+		return NewObjectStore(NewS3Client(config), "s3://"+bucket, prefix, opts...), nil
+	case "gs":
+		config := S3Config{
+			Endpoint:        "https://storage.googleapis.com",
+			Region:          "auto",
+			Bucket:          bucket,
+			AccessKeyID:     os.Getenv("GCS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("GCS_SECRET_ACCESS_KEY"),
+		}
+		if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+			return nil, fmt.Errorf("GCS_ACCESS_KEY_ID and GCS_SECRET_ACCESS_KEY (HMAC keys) are required for %s", location)
+		}
+		return NewObjectStore(NewS3Client(config), "gs://"+bucket, prefix, opts...), nil
+	default:
+		return nil, fmt.Errorf("unsupported store location %q (must be a directory, file://, s3:// or gs://)", location)
+	}
+}
+
+// NewFileStore creates a store keeping states in a local directory, defaulting to the current directory
+func NewFileStore(baseDir string, opts ...StoreOption) (*Store, error) {
 	if baseDir == "" {
 		var err error
 		baseDir, err = os.Getwd()
@@ -37,10 +115,12 @@ func NewStore(baseDir string) (*Store, error) {
 
 	return &Store{
 		baseDir: baseDir,
+		options: buildStoreOptions(opts),
 	}, nil
 }
 
-// SaveState saves a project state to disk
+// SaveState saves a project state to disk. If only pushing its commit fails, the
+// filename is returned with a *PushError.
 func (s *Store) SaveState(state *types.ProjectState) (string, error) {
 	// Validate state
 	err := validateState(state)
@@ -63,18 +143,38 @@ func (s *Store) SaveState(state *types.ProjectState) (string, error) {
 	}
 
 	// Create filename with unix timestamp
-	filename := filepath.Join(projectDir, fmt.Sprintf("%d.json", state.Timestamp.Unix()))
+	filename := filepath.Join(projectDir, stateFileName(state.Timestamp, s.options.Compress))
 
-	// Marshal state to JSON
-	data, err := json.MarshalIndent(state, "", "  ")
+	// Marshal state to JSON, compressing and encrypting it if configured
+	data, err := s.options.encode(state)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal state: %w", err)
+		return "", err
 	}
 
-	// Write to file
-	err = ioutil.WriteFile(filename, data, 0644)
+	// Write to file, followed by its checksum, and add it to the index without reading it back
+	err = s.updateIndex(projectDir, func(index *StateIndex) error {
+		if err := writeFileAtomic(filename, data, 0644); err != nil {
+			return fmt.Errorf("failed to write state file: %w", err)
+		}
+		if err := writeFileAtomic(filename+checksumSuffix, encodeChecksum(data, filename), 0644); err != nil {
+			return fmt.Errorf("failed to write checksum file: %w", err)
+		}
+		s.options.Metrics.addWritten(len(data))
+
+		index.add(newIndexEntry(filename, data, len(state.Items)))
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to write state file: %w", err)
+		return "", err
+	}
+
+	message := fmt.Sprintf("Capture project %d at %s", state.ProjectNumber, state.Timestamp.UTC().Format(time.RFC3339))
+	if err := s.commitGit(projectDir, message); err != nil {
+		var pushErr *PushError
+		if errors.As(err, &pushErr) {
+			return filename, err
+		}
+		return "", fmt.Errorf("failed to commit state: %w", err)
 	}
 
 	return filename, nil
@@ -91,32 +191,17 @@ func (s *Store) LoadState(projectNumber int, timestamp time.Time) (*types.Projec
 	return s.LoadStateFile(filename)
 }
 
-// findClosestState finds the state file closest to the given timestamp
+// FindClosestState finds the state file closest to the given timestamp
 func (s *Store) FindClosestState(projectNumber int, timestamp time.Time) (string, error) {
-	// Get list of state files
-	projectDir := filepath.Join(s.baseDir, "states", fmt.Sprintf("project=%d", projectNumber))
-	files, err := ioutil.ReadDir(projectDir)
+	stateFiles, err := s.ListStateFiles(projectNumber)
 	if err != nil {
-		return "", fmt.Errorf("failed to read project directory: %w", err)
-	}
-
-	// Filter and sort state files
-	var stateFiles []string
-	for _, file := range files {
-		if strings.HasSuffix(file.Name(), ".json") {
-			stateFiles = append(stateFiles, filepath.Join(projectDir, file.Name()))
-		}
+		return "", err
 	}
 
 	if len(stateFiles) == 0 {
 		return "", fmt.Errorf("no state files found for project %d", projectNumber)
 	}
 
-	// Sort files by timestamp
-	sort.Slice(stateFiles, func(i, j int) bool {
-		return extractTimestamp(stateFiles[i]).Before(extractTimestamp(stateFiles[j]))
-	})
-
 	// Find closest file
 	var closestFile string
 	var minDiff time.Duration
@@ -134,28 +219,141 @@ func (s *Store) FindClosestState(projectNumber int, timestamp time.Time) (string
 	return closestFile, nil
 }
 
-// LoadStateFile loads a project state from a specific file
+// ListStateFiles returns the state files of a project sorted by timestamp
+func (s *Store) ListStateFiles(projectNumber int) ([]string, error) {
+	index, err := s.loadIndex(s.projectDir(projectNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	stateFiles := make([]string, len(index.States))
+	for i, entry := range index.States {
+		stateFiles[i] = filepath.Join(s.projectDir(projectNumber), entry.Filename)
+	}
+	return stateFiles, nil
+}
+
+// ListTimestamps returns the timestamps of all stored states of a project in ascending order
+func (s *Store) ListTimestamps(projectNumber int) ([]time.Time, error) {
+	stateFiles, err := s.ListStateFiles(projectNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]time.Time, len(stateFiles))
+	for i, file := range stateFiles {
+		timestamps[i] = extractTimestamp(file)
+	}
+	return timestamps, nil
+}
+
+// ListStates returns the stored states of a project sorted by timestamp
+func (s *Store) ListStates(projectNumber int) ([]StateInfo, error) {
+	index, err := s.loadIndex(s.projectDir(projectNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]StateInfo, len(index.States))
+	for i, entry := range index.States {
+		states[i] = StateInfo{
+			Filename:  filepath.Join(s.projectDir(projectNumber), entry.Filename),
+			Timestamp: extractTimestamp(entry.Filename),
+			Size:      entry.Size,
+			Items:     entry.Items,
+		}
+	}
+	return states, nil
+}
+
+// ListProjects returns the numbers of all projects with stored states in ascending order
+func (s *Store) ListProjects() ([]int, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(s.baseDir, "states"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read states directory: %w", err)
+	}
+
+	var projects []int
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "project=") {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), "project="))
+		if err != nil {
+			continue
+		}
+		projects = append(projects, number)
+	}
+
+	sort.Ints(projects)
+	return projects, nil
+}
+
+// LoadStateFile loads a project state from a specific file, verifying it against its
+// checksum. States saved before checksums were introduced have none and are loaded as is.
 func (s *Store) LoadStateFile(filename string) (*types.ProjectState, error) {
 	// Read file
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read state file: %w", err)
 	}
+	s.options.Metrics.addRead(len(data))
+
+	checksum, err := os.ReadFile(filename + checksumSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read checksum file: %w", err)
+	}
+	if err == nil {
+		if err := verifyChecksum(data, checksum, filename); err != nil {
+			return nil, err
+		}
+	}
 
-	// Unmarshal JSON
-	var state types.ProjectState
-	err = json.Unmarshal(data, &state)
+	// Unmarshal JSON, decrypting and decompressing it if needed
+	state, err := s.options.decode(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+		return nil, err
 	}
 
 	state.Filename = filename
-	return &state, nil
+	return state, nil
+}
+
+// DeleteState deletes a state file and its checksum. If only pushing its commit fails,
+// the state is deleted and a *PushError is returned.
+func (s *Store) DeleteState(filename string) error {
+	dir := filepath.Dir(filename)
+	err := s.updateIndex(dir, func(index *StateIndex) error {
+		if err := os.Remove(filename); err != nil {
+			return fmt.Errorf("failed to delete state file: %w", err)
+		}
+		if err := os.Remove(filename + checksumSuffix); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete checksum file: %w", err)
+		}
+
+		index.remove(filepath.Base(filename))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.commitGit(dir, "Delete state "+filepath.Base(filename)); err != nil {
+		var pushErr *PushError
+		if errors.As(err, &pushErr) {
+			return err
+		}
+		return fmt.Errorf("failed to commit deleted state: %w", err)
+	}
+	return nil
 }
 
-// extractTimestamp extracts the timestamp from a state filename
+// extractTimestamp extracts the timestamp from a plain or compressed state filename
 func extractTimestamp(filename string) time.Time {
-	base := filepath.Base(filename)
+	base := strings.TrimSuffix(filepath.Base(filename), gzipSuffix)
 	if !strings.HasSuffix(base, ".json") {
 		return time.Time{}
 	}
@@ -179,7 +377,8 @@ func validateState(state *types.ProjectState) error {
 			return fmt.Errorf("item %d: ID is required", i)
 		}
 
-		if item.GetTitle() == "" {
+		// Restricted items have no title, as the token cannot read their content
+		if item.GetTitle() == "" && !item.IsRestricted() {
 			return fmt.Errorf("item %d: title is required", i)
 		}
 
@@ -18,7 +18,7 @@ func TestSaveAndLoadState(t *testing.T) {
 	defer os.RemoveAll(tempDir)
 
 	// Create store
-	store, err := NewStore(tempDir)
+	store, err := NewFileStore(tempDir)
 	assert.NoError(t, err)
 
 	// Create test state
@@ -135,6 +135,19 @@ func TestValidateState(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "restricted item without title",
+			state: &types.ProjectState{
+				ProjectNumber: 123,
+				Items: []types.Item{
+					{
+						ID:         "test-1",
+						Attributes: map[string]interface{}{types.RestrictedAttribute: true},
+					},
+				},
+			},
+			wantError: false,
+		},
 		{
 			name: "nil field value",
 			state: &types.ProjectState{
@@ -171,7 +184,7 @@ func TestFindClosestState(t *testing.T) {
 	assert.NoError(t, err)
 	defer os.RemoveAll(tempDir)
 
-	store, err := NewStore(tempDir)
+	store, err := NewFileStore(tempDir)
 	assert.NoError(t, err)
 
 	// Create test states
@@ -253,7 +266,7 @@ func TestLoadState(t *testing.T) {
 	assert.NoError(t, err)
 	defer os.RemoveAll(tempDir)
 
-	store, err := NewStore(tempDir)
+	store, err := NewFileStore(tempDir)
 	assert.NoError(t, err)
 
 	// Create test state
@@ -290,7 +303,7 @@ func TestLoadStateErrors(t *testing.T) {
 	assert.NoError(t, err)
 	defer os.RemoveAll(tempDir)
 
-	store, err := NewStore(tempDir)
+	store, err := NewFileStore(tempDir)
 	assert.NoError(t, err)
 
 	// Test loading non-existent project
@@ -317,7 +330,7 @@ func TestStoreInProjectDirectory(t *testing.T) {
 	defer os.Chdir(originalDir)
 
 	// Create store without specifying a base directory
-	store, err := NewStore("")
+	store, err := NewFileStore("")
 	assert.NoError(t, err)
 
 	// Create test state
@@ -357,3 +370,47 @@ func TestStoreInProjectDirectory(t *testing.T) {
 	assert.Equal(t, state.ProjectNumber, loadedState.ProjectNumber)
 	assert.Equal(t, state.Items[0].ID, loadedState.Items[0].ID)
 }
+
+func TestListStates(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "storage_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	store, err := NewFileStore(tempDir)
+	assert.NoError(t, err)
+
+	// No states directory yet
+	projects, err := store.ListProjects()
+	assert.NoError(t, err)
+	assert.Empty(t, projects)
+
+	timestamps := []time.Time{
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, projectNumber := range []int{42, 7} {
+		for _, ts := range timestamps {
+			_, err := store.SaveState(&types.ProjectState{
+				Timestamp:     ts,
+				ProjectNumber: projectNumber,
+				Items: []types.Item{
+					{ID: "test-1", Attributes: map[string]interface{}{"Title": "Test Item"}},
+				},
+			})
+			assert.NoError(t, err)
+		}
+	}
+
+	projects, err = store.ListProjects()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{7, 42}, projects)
+
+	listed, err := store.ListTimestamps(42)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 2)
+	assert.True(t, listed[0].Equal(timestamps[1]))
+	assert.True(t, listed[1].Equal(timestamps[0]))
+
+	_, err = store.ListTimestamps(999)
+	assert.Error(t, err)
+}
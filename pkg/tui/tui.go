@@ -0,0 +1,292 @@
+// Package tui is an interactive terminal browser of the stored snapshots of a project
+// and the changes between two of them.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/naag/gh-project-report/pkg/format"
+	"github.com/naag/gh-project-report/pkg/storage"
+)
+
+// defaultHeight is the number of lines shown until the terminal reports its size
+const defaultHeight = 24
+
+// chromeLines is the number of lines of the title and help shown around the list
+const chromeLines = 4
+
+// Model is a bubbletea model listing the snapshots of a project. Picking two
+// snapshots shows the added, removed and changed items between them, which expand
+// to all their field changes.
+type Model struct {
+	store         storage.StateStore
+	projectNumber int
+	opts          []func(*format.FormatterOptions)
+
+	snapshots []format.Snapshot
+	rows      [][]string // Timestamp, items and size of each snapshot
+	picked    []int      // Indexes of the snapshots picked for comparison, at most two
+
+	title    string // Compared snapshots, empty while picking snapshots
+	entries  []format.ExploreEntry
+	expanded map[int]bool
+
+	cursor int
+	height int
+	err    error // Last failure to load a snapshot, shown until the next key
+}
+
+// New creates a model browsing the snapshots of the project. The formatter options
+// apply to the compared snapshots.
+func New(store storage.StateStore, projectNumber int, opts ...func(*format.FormatterOptions)) (*Model, error) {
+	states, err := store.ListStates(projectNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list states: %w", err)
+	}
+	if len(states) == 0 {
+		return nil, fmt.Errorf("no state files found for project %d", projectNumber)
+	}
+
+	// Item counts are loaded from the snapshots unless the store indexes them
+	snapshots := make([]format.Snapshot, len(states))
+	for i, info := range states {
+		items, err := storage.CountItems(store, info)
+		if err != nil {
+			return nil, err
+		}
+		snapshots[i] = format.Snapshot{Timestamp: info.Timestamp, Filename: info.Filename, Items: items, Size: info.Size}
+	}
+
+	doc := format.BuildSnapshotsDocument(projectNumber, snapshots)
+	return &Model{
+		store:         store,
+		projectNumber: projectNumber,
+		opts:          opts,
+		snapshots:     snapshots,
+		rows:          doc.Sections[0].Table.Rows,
+		height:        defaultHeight,
+	}, nil
+}
+
+// Init implements tea.Model
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		m.err = nil
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "up", "k":
+			m.cursor = max(m.cursor-1, 0)
+		case "down", "j":
+			if m.cursor < m.length()-1 {
+				m.cursor++
+			}
+		case "home", "g":
+			m.cursor = 0
+		case "end", "G":
+			m.cursor = max(m.length()-1, 0)
+		case "enter", " ":
+			if m.comparing() {
+				m.expanded[m.cursor] = !m.expanded[m.cursor]
+			} else {
+				m.pick(m.cursor)
+			}
+		case "esc", "backspace":
+			if m.comparing() {
+				m.back()
+			} else {
+				m.picked = nil
+			}
+		}
+	}
+	return m, nil
+}
+
+// comparing returns true while the changes between two snapshots are shown
+func (m *Model) comparing() bool {
+	return m.title != ""
+}
+
+// length returns the number of rows the cursor moves over
+func (m *Model) length() int {
+	if m.comparing() {
+		return len(m.entries)
+	}
+	return len(m.snapshots)
+}
+
+// pick toggles whether a snapshot is picked, comparing the snapshots once two are picked
+func (m *Model) pick(i int) {
+	for j, picked := range m.picked {
+		if picked == i {
+			m.picked = append(m.picked[:j], m.picked[j+1:]...)
+			return
+		}
+	}
+	m.picked = append(m.picked, i)
+	if len(m.picked) < 2 {
+		return
+	}
+
+	if err := m.compare(min(m.picked[0], m.picked[1]), max(m.picked[0], m.picked[1])); err != nil {
+		m.err = err
+		m.picked = nil
+		return
+	}
+	m.cursor = 0
+}
+
+// compare loads two snapshots and lists the changes between them
+func (m *Model) compare(from, to int) error {
+	fromState, err := m.store.LoadStateFile(m.snapshots[from].Filename)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	toState, err := m.store.LoadStateFile(m.snapshots[to].Filename)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	opts := append([]func(*format.FormatterOptions){}, m.opts...)
+	if palette, err := m.store.LoadPalette(m.projectNumber); err == nil {
+		opts = append(opts, format.WithPalette(palette))
+	}
+	if toState.Timezone != "" {
+		loc, err := toState.Location()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, format.WithLocation(loc))
+	}
+	options := format.DefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// Freeze windows don't count as slip, as in reports of the diff command
+	diff := fromState.CompareTo(toState)
+	diff.DiscountFreezes(options.FreezeWindows)
+
+	m.entries = format.BuildExploreEntries(*diff, options)
+	m.expanded = make(map[int]bool)
+	m.title = fmt.Sprintf("Project %d: %s → %s", m.projectNumber, m.rows[from][0], m.rows[to][0])
+	return nil
+}
+
+// back returns from the compared snapshots to the list of snapshots
+func (m *Model) back() {
+	m.title = ""
+	m.entries = nil
+	m.expanded = nil
+	m.cursor = max(m.picked[0], m.picked[1])
+	m.picked = nil
+}
+
+// View implements tea.Model
+func (m *Model) View() string {
+	var title, help string
+	var lines []string
+	var first, last int // Lines of the row at the cursor, kept in view
+	if m.comparing() {
+		title = m.title
+		help = "↑/↓ move · enter expand · esc back · q quit"
+		lines, first, last = m.entryLines()
+	} else {
+		title = fmt.Sprintf("Snapshots of project %d", m.projectNumber)
+		help = "↑/↓ move · enter pick two snapshots to compare · q quit"
+		lines, first, last = m.snapshotLines()
+	}
+
+	// Scroll as little as needed to show the row at the cursor, starting with its first line
+	visible := max(m.height-chromeLines, 1)
+	offset := 0
+	if last >= visible {
+		offset = min(last-visible+1, first)
+	}
+	end := min(offset+visible, len(lines))
+
+	var sb strings.Builder
+	sb.WriteString(title + "\n\n")
+	for _, line := range lines[offset:end] {
+		sb.WriteString(line + "\n")
+	}
+	if m.err != nil {
+		sb.WriteString("\nError: " + m.err.Error() + "\n")
+	} else {
+		sb.WriteString("\n" + help + "\n")
+	}
+	return sb.String()
+}
+
+// snapshotLines returns a line per snapshot with the line of the cursor
+func (m *Model) snapshotLines() ([]string, int, int) {
+	lines := make([]string, len(m.rows))
+	for i, row := range m.rows {
+		mark := "[ ]"
+		for _, picked := range m.picked {
+			if picked == i {
+				mark = "[x]"
+			}
+		}
+		lines[i] = fmt.Sprintf("%s %s %s  %s items  %s", cursorMark(i == m.cursor), mark, row[0], row[1], row[2])
+	}
+	return lines, m.cursor, m.cursor
+}
+
+// entryLines returns a line per changed item followed by its details if expanded,
+// with the first and last line of the item at the cursor
+func (m *Model) entryLines() ([]string, int, int) {
+	if len(m.entries) == 0 {
+		return []string{"No changes between the snapshots"}, 0, 0
+	}
+
+	var lines []string
+	var first, last int
+	for i, entry := range m.entries {
+		if i == m.cursor {
+			first = len(lines)
+		}
+		toggle := "▸"
+		if m.expanded[i] {
+			toggle = "▾"
+		}
+		line := fmt.Sprintf("%s %s %s · %s", cursorMark(i == m.cursor), toggle, entry.Title, entry.Change)
+		if entry.Summary != "" {
+			line += " · " + entry.Summary
+		}
+		lines = append(lines, line)
+
+		if m.expanded[i] {
+			if entry.URL != "" {
+				lines = append(lines, "      "+entry.URL)
+			}
+			for _, detail := range entry.Details {
+				lines = append(lines, "      "+detail)
+			}
+		}
+		if i == m.cursor {
+			last = len(lines) - 1
+		}
+	}
+	return lines, first, last
+}
+
+// cursorMark returns the marker of the row at the cursor
+func cursorMark(selected bool) string {
+	if selected {
+		return ">"
+	}
+	return " "
+}
+
+var _ tea.Model = (*Model)(nil)
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/naag/gh-project-report/pkg/storage"
+	"github.com/naag/gh-project-report/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestModel(t *testing.T) *Model {
+	store := storage.NewMemoryStore()
+	item := func(end, status string) types.Item {
+		return types.Item{
+			ID:         "1",
+			DateSpan:   types.MustNewDateSpan("2024-01-01", end),
+			Attributes: map[string]interface{}{"Title": "Launch beta", "Status": status},
+		}
+	}
+	for _, state := range []*types.ProjectState{
+		{ProjectNumber: 42, Timestamp: time.Unix(1704067200, 0), Items: []types.Item{item("2024-01-10", "Todo")}},
+		{ProjectNumber: 42, Timestamp: time.Unix(1704153600, 0), Items: []types.Item{item("2024-01-10", "Todo")}},
+		{ProjectNumber: 42, Timestamp: time.Unix(1704240000, 0), Items: []types.Item{item("2024-01-31", "In Progress")}},
+	} {
+		_, err := store.SaveState(state)
+		require.NoError(t, err)
+	}
+
+	m, err := New(store, 42)
+	require.NoError(t, err)
+	return m
+}
+
+func press(m *Model, keys ...string) {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m.Update(msg)
+	}
+}
+
+func TestModel(t *testing.T) {
+	m := newTestModel(t)
+	assert.Contains(t, m.View(), "Snapshots of project 42")
+	assert.Contains(t, m.View(), "> [ ] ")
+
+	// Picking the first and last snapshot compares them
+	press(m, "enter", "down", "down")
+	assert.Contains(t, m.View(), "  [x] ")
+	press(m, "enter")
+	view := m.View()
+	assert.Contains(t, view, "Project 42: ")
+	assert.Contains(t, view, "> ▸ Launch beta · Changed · 🔴 High delay 3 weeks")
+	assert.NotContains(t, view, "Status: Todo → In Progress")
+
+	// Expanding shows all field changes
+	press(m, "enter")
+	view = m.View()
+	assert.Contains(t, view, "> ▾ Launch beta")
+	assert.Contains(t, view, "      Before: Jan 1, 2024 → Jan 10, 2024")
+	assert.Contains(t, view, "      Status: Todo → In Progress")
+
+	// Going back returns to the listing at the last picked snapshot
+	press(m, "esc")
+	assert.Contains(t, m.View(), "> [ ] ")
+	assert.Equal(t, 2, m.cursor)
+}
+
+func TestModelNoChanges(t *testing.T) {
+	m := newTestModel(t)
+	press(m, "enter", "j", "enter")
+	assert.Contains(t, m.View(), "No changes between the snapshots")
+
+	// The cursor stays on the message
+	press(m, "down", "enter")
+	assert.Equal(t, 0, m.cursor)
+}
+
+func TestModelScrolls(t *testing.T) {
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Height: chromeLines + 2})
+	press(m, "G")
+	view := m.View()
+	assert.NotContains(t, view, m.rows[0][0])
+	assert.Contains(t, view, "  [ ] "+m.rows[1][0])
+	assert.Contains(t, view, "> [ ] "+m.rows[2][0])
+}
+
+func TestModelQuits(t *testing.T) {
+	m := newTestModel(t)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.Quit(), cmd())
+}
+
+func TestNewWithoutSnapshots(t *testing.T) {
+	_, err := New(storage.NewMemoryStore(), 42)
+	assert.EqualError(t, err, "no state files found for project 42")
+}
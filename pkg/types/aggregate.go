@@ -0,0 +1,117 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// MonthFormat is the layout used to identify the month of an aggregate
+const MonthFormat = "2006-01"
+
+// CountStats describes how a count varied across the snapshots of an aggregate
+type CountStats struct {
+	Min  int     `json:"min"`
+	Max  int     `json:"max"`
+	Mean float64 `json:"mean"`
+}
+
+// MonthlyAggregate summarizes all states of a project captured in one month,
+// so long-term trends can be computed without loading every raw snapshot
+type MonthlyAggregate struct {
+	ProjectNumber     int                       `json:"project_number"`
+	Month             string                    `json:"month"` // Month in YYYY-MM format (UTC)
+	Snapshots         int                       `json:"snapshots"`
+	FirstSnapshot     time.Time                 `json:"first_snapshot"`
+	LastSnapshot      time.Time                 `json:"last_snapshot"`
+	Items             CountStats                `json:"items"`           // Item count across snapshots
+	ScheduledItems    CountStats                `json:"scheduled_items"` // Items with a start and end date across snapshots
+	TotalDurationDays int                       `json:"total_duration_days"`
+	Distribution      map[string]map[string]int `json:"distribution,omitempty"` // Field → value → item count at the last snapshot
+
+	fields []string
+	sums   [2]int
+}
+
+// MonthOf returns the month of the given time in YYYY-MM format (UTC)
+func MonthOf(t time.Time) string {
+	return t.UTC().Format(MonthFormat)
+}
+
+// NewMonthlyAggregate creates an empty aggregate for the given month. The value
+// distribution of the given fields is recorded from the last snapshot.
+func NewMonthlyAggregate(projectNumber int, month string, fields []string) (*MonthlyAggregate, error) {
+	if _, err := time.Parse(MonthFormat, month); err != nil {
+		return nil, fmt.Errorf("invalid month %q (must be YYYY-MM): %w", month, err)
+	}
+	return &MonthlyAggregate{
+		ProjectNumber: projectNumber,
+		Month:         month,
+		fields:        fields,
+	}, nil
+}
+
+// Add adds a state to the aggregate. States may be added in any order.
+func (a *MonthlyAggregate) Add(state *ProjectState) error {
+	if month := MonthOf(state.Timestamp); month != a.Month {
+		return fmt.Errorf("state from %s does not belong to month %s", month, a.Month)
+	}
+
+	items, scheduled, duration := 0, 0, 0
+	for _, item := range state.Items {
+		items++
+		if !item.DateSpan.Start.IsZero() && !item.DateSpan.End.IsZero() {
+			scheduled++
+			duration += item.DateSpan.DurationDays()
+		}
+	}
+
+	a.Snapshots++
+	a.sums[0] += items
+	a.sums[1] += scheduled
+	a.Items = addCount(a.Items, items, a.sums[0], a.Snapshots)
+	a.ScheduledItems = addCount(a.ScheduledItems, scheduled, a.sums[1], a.Snapshots)
+
+	if a.FirstSnapshot.IsZero() || state.Timestamp.Before(a.FirstSnapshot) {
+		a.FirstSnapshot = state.Timestamp
+	}
+
+	// Totals and distribution reflect the last snapshot of the month
+	if a.Snapshots == 1 || !state.Timestamp.Before(a.LastSnapshot) {
+		a.LastSnapshot = state.Timestamp
+		a.TotalDurationDays = duration
+		a.Distribution = distribution(state.Items, a.fields)
+	}
+
+	return nil
+}
+
+// addCount adds a count to the stats, given the running sum and number of counts
+func addCount(stats CountStats, count, sum, n int) CountStats {
+	if n == 1 || count < stats.Min {
+		stats.Min = count
+	}
+	if n == 1 || count > stats.Max {
+		stats.Max = count
+	}
+	stats.Mean = float64(sum) / float64(n)
+	return stats
+}
+
+// distribution counts the items per value of each field
+func distribution(items []Item, fields []string) map[string]map[string]int {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	dist := make(map[string]map[string]int, len(fields))
+	for _, field := range fields {
+		counts := make(map[string]int)
+		for _, item := range items {
+			if value, ok := item.Attributes[field]; ok {
+				counts[FormatValue(value)]++
+			}
+		}
+		dist[field] = counts
+	}
+	return dist
+}
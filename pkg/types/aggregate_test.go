@@ -0,0 +1,39 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthlyAggregate(t *testing.T) {
+	_, err := NewMonthlyAggregate(123, "January", nil)
+	assert.Error(t, err)
+
+	aggregate, err := NewMonthlyAggregate(123, "2024-01", []string{"Team"})
+	require.NoError(t, err)
+
+	later := createTestState()
+	later.Timestamp = time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	earlier := &ProjectState{
+		Timestamp: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		Items:     []Item{{ID: "1", Attributes: map[string]interface{}{"Title": "Task 1", "Team": "UI"}}},
+	}
+
+	// States are added out of order
+	require.NoError(t, aggregate.Add(later))
+	require.NoError(t, aggregate.Add(earlier))
+
+	assert.Equal(t, 2, aggregate.Snapshots)
+	assert.Equal(t, earlier.Timestamp, aggregate.FirstSnapshot)
+	assert.Equal(t, later.Timestamp, aggregate.LastSnapshot)
+	assert.Equal(t, CountStats{Min: 1, Max: 3, Mean: 2}, aggregate.Items)
+	assert.Equal(t, CountStats{Min: 0, Max: 3, Mean: 1.5}, aggregate.ScheduledItems)
+	assert.Equal(t, 31+28+31, aggregate.TotalDurationDays)
+	assert.Equal(t, map[string]map[string]int{"Team": {"UI": 2, "Backend": 1}}, aggregate.Distribution)
+
+	other := &ProjectState{Timestamp: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	assert.ErrorContains(t, aggregate.Add(other), "does not belong to month 2024-01")
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Timestamp attributes recorded for every item
+const (
+	CreatedAtAttribute = "created_at"
+	UpdatedAtAttribute = "updated_at"
+)
+
+// timestampAttributes are attributes holding timestamps, which are stored as strings in
+// canonical format so that captured and loaded states compare equal
+var timestampAttributes = map[string]bool{
+	CreatedAtAttribute: true,
+	UpdatedAtAttribute: true,
+}
+
+// FormatTimestamp formats a timestamp attribute value in canonical format, RFC3339 in UTC
+func FormatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// FormatValue formats an attribute value for display, joining lists such as the
+// assignees with commas, e.g. "@alice, @bob"
+func FormatValue(value interface{}) string {
+	if list, ok := value.([]string); ok {
+		return strings.Join(list, ", ")
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// NormalizeAttributes converts the timestamp attributes of all items to canonical
+// format and lists to string slices. States are normalized when captured and loaded,
+// as timestamps become strings and lists become []interface{} after a JSON round-trip
+// and would otherwise not compare equal.
+func (s *ProjectState) NormalizeAttributes() {
+	for _, item := range s.Items {
+		item.normalizeAttributes()
+	}
+}
+
+// normalizeAttributes converts time values and timestamp attributes in other formats,
+// e.g. with a time zone offset, to canonical format, and lists of strings to []string
+func (i Item) normalizeAttributes() {
+	for name, value := range i.Attributes {
+		switch v := value.(type) {
+		case []interface{}:
+			if list, ok := stringList(v); ok {
+				i.Attributes[name] = list
+			}
+		case time.Time:
+			i.Attributes[name] = FormatTimestamp(v)
+		case string:
+			if !timestampAttributes[name] {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				i.Attributes[name] = FormatTimestamp(t)
+			}
+		}
+	}
+}
+
+// stringList converts a list decoded from JSON to strings, returning false if an
+// entry is not a string
+func stringList(values []interface{}) ([]string, bool) {
+	list := make([]string, len(values))
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		list[i] = s
+	}
+	return list, true
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAttributes(t *testing.T) {
+	berlin := time.FixedZone("CET", 60*60)
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{name: "time", value: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), want: "2024-01-01T12:00:00Z"},
+		{name: "time with offset", value: time.Date(2024, 1, 1, 13, 0, 0, 0, berlin), want: "2024-01-01T12:00:00Z"},
+		{name: "canonical string", value: "2024-01-01T12:00:00Z", want: "2024-01-01T12:00:00Z"},
+		{name: "string with offset", value: "2024-01-01T13:00:00+01:00", want: "2024-01-01T12:00:00Z"},
+		{name: "string with fractional seconds", value: "2024-01-01T12:00:00.5Z", want: "2024-01-01T12:00:00Z"},
+		{name: "invalid string", value: "yesterday", want: "yesterday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &ProjectState{Items: []Item{{ID: "1", Attributes: map[string]interface{}{UpdatedAtAttribute: tt.value}}}}
+			state.NormalizeAttributes()
+			assert.Equal(t, tt.want, state.Items[0].Attributes[UpdatedAtAttribute])
+		})
+	}
+
+	t.Run("other attributes keep their format", func(t *testing.T) {
+		state := &ProjectState{Items: []Item{{ID: "1", Attributes: map[string]interface{}{"Due": "2024-01-01T13:00:00+01:00", "Estimate": float64(3)}}}}
+		state.NormalizeAttributes()
+		assert.Equal(t, "2024-01-01T13:00:00+01:00", state.Items[0].Attributes["Due"])
+		assert.Equal(t, float64(3), state.Items[0].Attributes["Estimate"])
+	})
+}
+
+func TestNormalizeAttributesRoundTrip(t *testing.T) {
+	captured := &ProjectState{Items: []Item{{
+		ID: "1",
+		Attributes: map[string]interface{}{
+			"Title":            "Task",
+			AssigneesAttribute: []string{"@alice", "@bob"},
+			CreatedAtAttribute: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAtAttribute: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}}}
+
+	data, err := json.Marshal(captured)
+	require.NoError(t, err)
+	loaded := &ProjectState{}
+	require.NoError(t, json.Unmarshal(data, loaded))
+
+	captured.NormalizeAttributes()
+	loaded.NormalizeAttributes()
+
+	diff := captured.CompareTo(loaded)
+	assert.Empty(t, diff.ChangedItems, "captured and loaded state should compare equal")
+	assert.Equal(t, []string{"@alice", "@bob"}, loaded.Items[0].Attributes[AssigneesAttribute])
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "@alice, @bob", FormatValue([]string{"@alice", "@bob"}))
+	assert.Equal(t, "", FormatValue([]string{}))
+	assert.Equal(t, "Todo", FormatValue("Todo"))
+	assert.Equal(t, "3", FormatValue(float64(3)))
+}
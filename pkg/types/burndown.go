@@ -0,0 +1,55 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// BurndownPoint is the open and done work of a project in one snapshot
+type BurndownPoint struct {
+	Timestamp     time.Time
+	Open          int     // Items not in a done status
+	Done          int     // Items in a done status
+	Remaining     float64 // Weight of the open items, their number if no weight field is used
+	Completed     float64 // Weight of the done items, their number if no weight field is used
+	MissingWeight int     // Items without a numeric value in the weight field, counted with weight 0
+}
+
+// Burndown counts the open and done items of the state. Items whose statusField
+// attribute matches one of doneValues, ignoring case, are done. Work is weighted
+// by the numeric weightField, or counted in items if it is empty.
+func (s *ProjectState) Burndown(statusField string, doneValues []string, weightField string) BurndownPoint {
+	p := BurndownPoint{Timestamp: s.Timestamp}
+	for _, item := range s.Items {
+		weight, ok := item.Weight(weightField)
+		if !ok {
+			p.MissingWeight++
+		}
+
+		if item.IsDone(statusField, doneValues) {
+			p.Done++
+			p.Completed += weight
+		} else {
+			p.Open++
+			p.Remaining += weight
+		}
+	}
+	return p
+}
+
+// IsDone returns true if the statusField attribute of the item matches one of
+// doneValues, ignoring case
+func (i Item) IsDone(statusField string, doneValues []string) bool {
+	status, _ := i.Attributes[statusField].(string)
+	return isDoneStatus(status, doneValues)
+}
+
+// isDoneStatus returns true if the status matches one of the done values, ignoring case
+func isDoneStatus(status string, doneValues []string) bool {
+	for _, done := range doneValues {
+		if strings.EqualFold(strings.TrimSpace(status), done) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBurndown(t *testing.T) {
+	item := func(status string, points interface{}) Item {
+		attributes := map[string]interface{}{"Status": status}
+		if points != nil {
+			attributes["Points"] = points
+		}
+		return Item{Attributes: attributes}
+	}
+	state := &ProjectState{
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Items: []Item{
+			item("Todo", float64(3)),
+			item("In Progress", float64(5)),
+			item("done", float64(2)),
+			item("Released", nil),
+			{Attributes: map[string]interface{}{}}, // No status
+		},
+	}
+
+	t.Run("items", func(t *testing.T) {
+		got := state.Burndown("Status", []string{"Done", "Released"}, "")
+		assert.Equal(t, BurndownPoint{Timestamp: state.Timestamp, Open: 3, Done: 2, Remaining: 3, Completed: 2}, got)
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		got := state.Burndown("Status", []string{"Done", "Released"}, "Points")
+		assert.Equal(t, BurndownPoint{Timestamp: state.Timestamp, Open: 3, Done: 2, Remaining: 8, Completed: 2, MissingWeight: 2}, got)
+	})
+}
+
+func TestItemIsDone(t *testing.T) {
+	item := Item{Attributes: map[string]interface{}{"Status": " done "}}
+	assert.True(t, item.IsDone("Status", []string{"Done"}))
+	assert.False(t, item.IsDone("Status", []string{"Shipped"}))
+	assert.False(t, Item{}.IsDone("Status", []string{"Done"}))
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// CauseSlip is the slip of items attributed to a cause between two snapshots
+type CauseSlip struct {
+	Cause string // Cause label, empty for items without any cause label
+	Items int    // Number of slipped items with the cause
+	Days  int    // Sum of the days their end dates moved later
+}
+
+// SlipByCause attributes the slip of changed items whose end date moved later to
+// the cause labels set on the items. Items with several cause labels count towards
+// each of them, items without one towards a cause with an empty name, which is
+// sorted last. Labels are matched case-insensitively and causes without slip are
+// omitted. The result is sorted by slip days in descending order.
+func (d ProjectDiff) SlipByCause(causes []string) []CauseSlip {
+	if len(causes) == 0 {
+		return nil
+	}
+
+	slip := make(map[string]*CauseSlip)
+	add := func(cause string, days int) {
+		if slip[cause] == nil {
+			slip[cause] = &CauseSlip{Cause: cause}
+		}
+		slip[cause].Items++
+		slip[cause].Days += days
+	}
+
+	for _, change := range d.ChangedItems {
+		if change.DateChange == nil || change.DateChange.EndDaysDelta <= 0 {
+			continue
+		}
+		matched := false
+		for _, cause := range causes {
+			if change.After.HasLabel(cause) {
+				add(cause, change.DateChange.EndDaysDelta)
+				matched = true
+			}
+		}
+		if !matched {
+			add("", change.DateChange.EndDaysDelta)
+		}
+	}
+
+	result := make([]CauseSlip, 0, len(slip))
+	for _, s := range slip {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Cause == "") != (result[j].Cause == "") {
+			return result[j].Cause == ""
+		}
+		if result[i].Days != result[j].Days {
+			return result[i].Days > result[j].Days
+		}
+		return result[i].Cause < result[j].Cause
+	})
+	return result
+}
+
+// HasLabel returns true if the issue or pull request of the item has the label, ignoring case
+func (i Item) HasLabel(name string) bool {
+	for _, label := range i.Labels {
+		if strings.EqualFold(label, name) {
+			return true
+		}
+	}
+	return false
+}
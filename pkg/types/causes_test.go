@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlipByCause(t *testing.T) {
+	slipped := func(id string, endDelta int, labels ...string) ItemDiff {
+		return ItemDiff{
+			ItemID:     id,
+			After:      Item{ID: id, Labels: labels},
+			DateChange: &DateSpanChange{EndDaysDelta: endDelta},
+		}
+	}
+
+	diff := ProjectDiff{
+		ChangedItems: []ItemDiff{
+			slipped("1", 10, "Scope-Change"),
+			slipped("2", 4, "scope-change", "underestimated"),
+			slipped("3", 3, "bug"),
+			slipped("4", 7, "underestimated"),
+			slipped("5", -5, "scope-change"),                               // Pulled in
+			{ItemID: "6", After: Item{Labels: []string{"underestimated"}}}, // No date change
+		},
+	}
+
+	t.Run("attributes slip to causes", func(t *testing.T) {
+		got := diff.SlipByCause([]string{"scope-change", "underestimated", "blocked-external"})
+		assert.Equal(t, []CauseSlip{
+			{Cause: "scope-change", Items: 2, Days: 14},
+			{Cause: "underestimated", Items: 2, Days: 11},
+			{Cause: "", Items: 1, Days: 3},
+		}, got)
+	})
+
+	t.Run("no causes configured", func(t *testing.T) {
+		assert.Nil(t, diff.SlipByCause(nil))
+	})
+
+	t.Run("no slip", func(t *testing.T) {
+		assert.Empty(t, ProjectDiff{}.SlipByCause([]string{"scope-change"}))
+	})
+}
@@ -0,0 +1,23 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// Comment is a comment on an issue or pull request
+type Comment struct {
+	Author    string
+	CreatedAt time.Time
+	Body      string
+}
+
+// FirstLine returns the first non-empty line of the comment body
+func (c Comment) FirstLine() string {
+	for _, line := range strings.Split(c.Body, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
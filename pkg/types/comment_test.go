@@ -0,0 +1,12 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentFirstLine(t *testing.T) {
+	assert.Equal(t, "Blocked on the API review", Comment{Body: "\n  Blocked on the API review \r\nDetails follow"}.FirstLine())
+	assert.Equal(t, "", Comment{Body: " \n "}.FirstLine())
+}
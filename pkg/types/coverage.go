@@ -0,0 +1,44 @@
+package types
+
+import "time"
+
+// Coverage describes how many items of a state have planning attributes set
+type Coverage struct {
+	Timestamp    time.Time
+	Items        int
+	WithDates    int // Items with both a start and an end date
+	WithEstimate int // Items with a numeric estimate
+	WithOwner    int // Items with an owner
+}
+
+// Coverage counts the items with dates, a numeric estimate in estimateField and
+// an owner in ownerField. An empty ownerField uses the assignees.
+func (s *ProjectState) Coverage(estimateField, ownerField string) Coverage {
+	if ownerField == "" {
+		ownerField = AssigneesAttribute
+	}
+
+	c := Coverage{Timestamp: s.Timestamp, Items: len(s.Items)}
+	for _, item := range s.Items {
+		if !item.DateSpan.Start.IsZero() && !item.DateSpan.End.IsZero() {
+			c.WithDates++
+		}
+		if estimateField != "" {
+			if _, ok := item.Weight(estimateField); ok {
+				c.WithEstimate++
+			}
+		}
+		if owner, ok := item.Attributes[ownerField]; ok && FormatValue(owner) != "" {
+			c.WithOwner++
+		}
+	}
+	return c
+}
+
+// Ratio returns n as a fraction of all items, or 0 if there are no items
+func (c Coverage) Ratio(n int) float64 {
+	if c.Items == 0 {
+		return 0
+	}
+	return float64(n) / float64(c.Items)
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectStateCoverage(t *testing.T) {
+	state := createTestState()
+	state.Items[0].Attributes["Estimate"] = float64(3)
+	state.Items[0].Attributes[AssigneesAttribute] = "@alice"
+	state.Items[1].Attributes["Owner"] = "@bob"
+	state.Items[2].DateSpan = DateSpan{}
+
+	c := state.Coverage("Estimate", "")
+	assert.Equal(t, Coverage{Timestamp: state.Timestamp, Items: 3, WithDates: 2, WithEstimate: 1, WithOwner: 1}, c)
+	assert.InDelta(t, 2.0/3, c.Ratio(c.WithDates), 0.001)
+
+	c = state.Coverage("", "Owner")
+	assert.Equal(t, 0, c.WithEstimate)
+	assert.Equal(t, 1, c.WithOwner)
+
+	assert.Equal(t, 0.0, Coverage{}.Ratio(0))
+}
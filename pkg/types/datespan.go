@@ -63,3 +63,26 @@ func (ds DateSpan) CompareTo(other DateSpan) DateSpanChange {
 func (ds DateSpan) Equal(other DateSpan) bool {
 	return ds.Start.Equal(other.Start) && ds.End.Equal(other.End)
 }
+
+// EndInstant returns the instant at which the end date is over in the given
+// location, i.e. midnight at the beginning of the following day
+func (ds DateSpan) EndInstant(loc *time.Location) time.Time {
+	y, m, d := ds.End.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+}
+
+// IsOverdue returns true if the end date has passed at the given instant,
+// interpreting the end date in the given location
+func (ds DateSpan) IsOverdue(now time.Time, loc *time.Location) bool {
+	return !ds.End.IsZero() && !now.Before(ds.EndInstant(loc))
+}
+
+// DaysUntilEnd returns the number of calendar days from the given instant to
+// the end date in the given location. Negative values mean the end date has passed.
+func (ds DateSpan) DaysUntilEnd(now time.Time, loc *time.Location) int {
+	y, m, d := now.In(loc).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = ds.End.Date()
+	end := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(today).Hours() / 24)
+}
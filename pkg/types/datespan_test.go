@@ -0,0 +1,39 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateSpanDeadlines(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	span := MustNewDateSpan("2024-01-01", "2024-01-10")
+
+	// 2024-01-10 20:00 UTC is already Jan 11 in Tokyo but still Jan 10 in Los Angeles
+	now := time.Date(2024, 1, 10, 20, 0, 0, 0, time.UTC)
+
+	t.Run("EndInstant", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 1, 11, 0, 0, 0, 0, tokyo), span.EndInstant(tokyo))
+		assert.Equal(t, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), span.EndInstant(time.UTC))
+	})
+
+	t.Run("IsOverdue", func(t *testing.T) {
+		assert.True(t, span.IsOverdue(now, tokyo))
+		assert.False(t, span.IsOverdue(now, losAngeles))
+		assert.False(t, span.IsOverdue(now, time.UTC))
+		assert.False(t, DateSpan{}.IsOverdue(now, time.UTC))
+	})
+
+	t.Run("DaysUntilEnd", func(t *testing.T) {
+		assert.Equal(t, -1, span.DaysUntilEnd(now, tokyo))
+		assert.Equal(t, 0, span.DaysUntilEnd(now, losAngeles))
+		assert.Equal(t, 9, span.DaysUntilEnd(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), time.UTC))
+	})
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// FreezeWindow is a period of expected inactivity, e.g. a holiday shutdown.
+// Days in a freeze window don't count as slip or towards staleness.
+type FreezeWindow struct {
+	Name  string
+	Start time.Time // First day of the freeze
+	End   time.Time // Last day of the freeze, inclusive
+}
+
+// NewFreezeWindow creates a FreezeWindow from dates in YYYY-MM-DD format
+func NewFreezeWindow(name, start, end string) (FreezeWindow, error) {
+	span, err := NewDateSpan(start, end)
+	if err != nil {
+		return FreezeWindow{}, fmt.Errorf("invalid freeze window %q: %w", name, err)
+	}
+	return FreezeWindow{Name: name, Start: span.Start, End: span.End}, nil
+}
+
+// FreezeDays returns the number of calendar days between from (inclusive) and
+// to (exclusive) that fall into any of the freeze windows. Overlapping windows
+// count each day once.
+func FreezeDays(windows []FreezeWindow, from, to time.Time) int {
+	if len(windows) == 0 || !to.After(from) {
+		return 0
+	}
+
+	days := 0
+	for day := truncateDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, w := range windows {
+			if !day.Before(truncateDay(w.Start)) && !day.After(truncateDay(w.End)) {
+				days++
+				break
+			}
+		}
+	}
+	return days
+}
+
+// DiscountFreezes removes days in freeze windows from the slip of all timeline
+// changes, so dates moved across a freeze only count the working days they slipped
+func (d *ProjectDiff) DiscountFreezes(windows []FreezeWindow) {
+	if len(windows) == 0 {
+		return
+	}
+
+	for i, change := range d.ChangedItems {
+		if change.DateChange == nil {
+			continue
+		}
+		discounted := *change.DateChange
+		discounted.StartDaysDelta -= FreezeDays(windows, change.Before.DateSpan.Start, change.After.DateSpan.Start)
+		discounted.EndDaysDelta -= FreezeDays(windows, change.Before.DateSpan.End, change.After.DateSpan.End)
+		discounted.DurationDelta = discounted.EndDaysDelta - discounted.StartDaysDelta
+		d.ChangedItems[i].DateChange = &discounted
+	}
+}
+
+// truncateDay returns midnight at the beginning of the day in the time's location
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
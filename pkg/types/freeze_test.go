@@ -0,0 +1,69 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFreezeWindow(t *testing.T) {
+	window, err := NewFreezeWindow("Winter break", "2024-12-23", "2025-01-03")
+	require.NoError(t, err)
+	assert.Equal(t, "Winter break", window.Name)
+	assert.Equal(t, time.Date(2024, 12, 23, 0, 0, 0, 0, time.UTC), window.Start)
+	assert.Equal(t, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), window.End)
+
+	_, err = NewFreezeWindow("Backwards", "2025-01-03", "2024-12-23")
+	assert.ErrorContains(t, err, `invalid freeze window "Backwards"`)
+}
+
+func TestFreezeDays(t *testing.T) {
+	windows := []FreezeWindow{
+		{Name: "Winter", Start: date("2024-12-23"), End: date("2024-12-31")},
+		{Name: "Overlap", Start: date("2024-12-30"), End: date("2025-01-01")},
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		expected int
+	}{
+		{"across whole freeze", "2024-12-20", "2025-01-10", 10},
+		{"ends inside freeze", "2024-12-20", "2024-12-25", 2},
+		{"starts inside freeze", "2024-12-31", "2025-01-05", 2},
+		{"outside freeze", "2025-02-01", "2025-02-10", 0},
+		{"backwards", "2025-01-10", "2024-12-20", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FreezeDays(windows, date(tt.from), date(tt.to)))
+		})
+	}
+}
+
+func TestDiscountFreezes(t *testing.T) {
+	windows := []FreezeWindow{{Name: "Winter", Start: date("2024-12-23"), End: date("2025-01-03")}}
+	before := Item{ID: "1", DateSpan: MustNewDateSpan("2024-12-01", "2024-12-20")}
+	after := Item{ID: "1", DateSpan: MustNewDateSpan("2024-12-01", "2025-01-10")}
+
+	diff := ProjectDiff{ChangedItems: []ItemDiff{before.CompareTo(after)}}
+	require.NotNil(t, diff.ChangedItems[0].DateChange)
+	assert.Equal(t, 21, diff.ChangedItems[0].DateChange.EndDaysDelta)
+
+	diff.DiscountFreezes(windows)
+	change := diff.ChangedItems[0].DateChange
+	assert.Equal(t, 0, change.StartDaysDelta)
+	assert.Equal(t, 9, change.EndDaysDelta)
+	assert.Equal(t, 9, change.DurationDelta)
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
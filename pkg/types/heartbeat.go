@@ -0,0 +1,10 @@
+package types
+
+import "time"
+
+// Heartbeat records the latest capture of a project that found no changes and
+// therefore saved no snapshot
+type Heartbeat struct {
+	Timestamp time.Time `json:"timestamp"` // Time of the capture
+	Snapshot  string    `json:"snapshot"`  // Latest snapshot, which the capture matched
+}
@@ -1,15 +1,50 @@
 package types
 
 import (
+	"fmt"
+	"reflect"
 	"sort"
 	"time"
 )
 
+// Content types of project items
+const (
+	ContentTypeIssue       = "Issue"
+	ContentTypePullRequest = "PullRequest"
+	ContentTypeDraftIssue  = "DraftIssue"
+)
+
+// RestrictedAttribute marks items whose issue or pull request lives in a repository
+// the token cannot read, so that only the project fields of the item are known
+const RestrictedAttribute = "restricted"
+
+// RestrictedTitle is the title shown for restricted items
+const RestrictedTitle = "🔒 Restricted item"
+
+// StateAttribute is the attribute holding whether the issue or pull request of an
+// item is open, closed or merged. Draft issues and older snapshots have no state.
+const StateAttribute = "state"
+
+// Values of StateAttribute
+const (
+	StateOpen   = "Open"
+	StateClosed = "Closed"
+	StateMerged = "Merged"
+)
+
 // Item represents a single item at a point in time
 type Item struct {
-	ID         string
-	DateSpan   DateSpan
-	Attributes map[string]interface{}
+	ID          string
+	ContentType string   `json:"ContentType,omitempty"` // Issue, PullRequest or DraftIssue, empty for older snapshots
+	ContentID   string   `json:"ContentID,omitempty"`   // Node ID of the issue or pull request
+	URL         string   `json:"URL,omitempty"`         // Web URL of the issue or pull request
+	Number      int      `json:"Number,omitempty"`      // Number of the issue or pull request in its repository
+	Repository  string   `json:"Repository,omitempty"`  // Repository of the issue or pull request, e.g. org/repo
+	BlockedBy   []string `json:"BlockedBy,omitempty"`   // Content IDs of the issues blocking this item
+	Labels      []string `json:"Labels,omitempty"`      // Names of the labels of the issue or pull request
+	DateSpan    DateSpan
+	Attributes  map[string]interface{}
+	Provenance  map[string]Provenance `json:"Provenance,omitempty"` // Where attribute values come from, empty for older snapshots
 }
 
 // FieldChange represents what changed in a specific field
@@ -26,7 +61,8 @@ type ItemDiff struct {
 	Before       Item
 	After        Item
 	DateChange   *DateSpanChange // Dedicated field for date changes
-	FieldChanges []FieldChange   // Only for attribute changes
+	FieldChanges []FieldChange   // Attribute and label changes
+	PreviousID   string          // ID of the removed item this item replaced, e.g. a re-created draft issue
 }
 
 // CompareTo compares this item to another and returns an ItemDiff
@@ -49,13 +85,37 @@ func (i Item) CompareTo(other Item) ItemDiff {
 	// Check attribute changes and additions
 	for key, newVal := range other.Attributes {
 		oldVal, exists := i.Attributes[key]
-		if !exists || oldVal != newVal {
-			changes = append(changes, FieldChange{
-				Field:    key,
-				OldValue: oldVal,
-				NewValue: newVal,
-			})
+		// Lists such as the assignees can't be compared with ==
+		if exists && reflect.DeepEqual(oldVal, newVal) {
+			continue
+		}
+		// Lists of users that were only reordered didn't change
+		if added, removed, ok := MentionChanges(oldVal, newVal); ok && len(added) == 0 && len(removed) == 0 {
+			continue
 		}
+		changes = append(changes, FieldChange{
+			Field:    key,
+			OldValue: oldVal,
+			NewValue: newVal,
+		})
+	}
+
+	// Converting a draft issue to an issue changes the content of the item
+	if i.ContentType != other.ContentType && i.ContentType != "" && other.ContentType != "" {
+		changes = append(changes, FieldChange{
+			Field:    ContentTypeField,
+			OldValue: i.ContentType,
+			NewValue: other.ContentType,
+		})
+	}
+
+	// Labels are compared as sets, so that only labels added or removed are reported
+	if added, removed := LabelChanges(i.Labels, other.Labels); len(added) > 0 || len(removed) > 0 {
+		changes = append(changes, FieldChange{
+			Field:    LabelsField,
+			OldValue: i.Labels,
+			NewValue: other.Labels,
+		})
 	}
 
 	// Check for deleted attributes
@@ -78,9 +138,9 @@ func (i Item) CompareTo(other Item) ItemDiff {
 	return diff
 }
 
-// HasChanges returns true if any field changed
+// HasChanges returns true if any field changed or the item was re-created
 func (d ItemDiff) HasChanges() bool {
-	return d.DateChange != nil || len(d.FieldChanges) > 0
+	return d.DateChange != nil || len(d.FieldChanges) > 0 || d.IsRecreated()
 }
 
 // HasDateChange returns true if the DateSpan changed
@@ -122,9 +182,40 @@ func (i Item) GetTitle() string {
 	if title, ok := i.Attributes["Title"].(string); ok {
 		return title
 	}
+	if i.IsRestricted() {
+		return RestrictedTitle
+	}
 	return ""
 }
 
+// IsRestricted returns true if the token could not read the issue or pull request of the item
+func (i Item) IsRestricted() bool {
+	restricted, _ := i.Attributes[RestrictedAttribute].(bool)
+	return restricted
+}
+
+// GetState returns whether the issue or pull request is open, closed or merged,
+// empty if unknown
+func (i Item) GetState() string {
+	state, _ := i.Attributes[StateAttribute].(string)
+	return state
+}
+
+// IsClosed returns true if the issue was closed or the pull request closed or merged
+func (i Item) IsClosed() bool {
+	state := i.GetState()
+	return state == StateClosed || state == StateMerged
+}
+
+// Reference returns the short reference of the issue or pull request, e.g. org/repo#123,
+// or an empty string for draft issues and snapshots without repository and number
+func (i Item) Reference() string {
+	if i.Repository == "" || i.Number == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s#%d", i.Repository, i.Number)
+}
+
 func (i Item) GetStatus() string {
 	if status, ok := i.Attributes["status"].(string); ok {
 		return status
@@ -133,15 +224,22 @@ func (i Item) GetStatus() string {
 }
 
 func (i Item) GetCreatedAt() time.Time {
-	if createdAt, ok := i.Attributes["created_at"].(time.Time); ok {
-		return createdAt
-	}
-	return time.Time{}
+	return i.getTime(CreatedAtAttribute)
 }
 
 func (i Item) GetUpdatedAt() time.Time {
-	if updatedAt, ok := i.Attributes["updated_at"].(time.Time); ok {
-		return updatedAt
+	return i.getTime(UpdatedAtAttribute)
+}
+
+// getTime returns a timestamp attribute, which is a string in RFC3339 format after loading from JSON
+func (i Item) getTime(name string) time.Time {
+	switch v := i.Attributes[name].(type) {
+	case time.Time:
+		return v
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t
+		}
 	}
 	return time.Time{}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestItemHelpers(t *testing.T) {
@@ -66,6 +67,17 @@ func TestItemHelpers(t *testing.T) {
 	})
 }
 
+func TestItemRestricted(t *testing.T) {
+	item := Item{ID: "1", Attributes: map[string]interface{}{RestrictedAttribute: true}}
+	assert.True(t, item.IsRestricted())
+	assert.Equal(t, RestrictedTitle, item.GetTitle())
+
+	item.Attributes["Title"] = "Known title"
+	assert.Equal(t, "Known title", item.GetTitle())
+
+	assert.False(t, Item{ID: "2"}.IsRestricted())
+}
+
 func TestItemComparison(t *testing.T) {
 	// Create base item
 	baseItem := Item{
@@ -398,3 +410,44 @@ func TestItemDiffHelpers(t *testing.T) {
 		assert.Empty(t, noDiff.GetChangedFieldNames())
 	})
 }
+
+func TestItemComparisonAssignees(t *testing.T) {
+	before := Item{ID: "1", Attributes: map[string]interface{}{AssigneesAttribute: "@bob, @alice"}}
+
+	// Snapshots captured before assignees were sorted list them in API order
+	diff := before.CompareTo(Item{ID: "1", Attributes: map[string]interface{}{AssigneesAttribute: "@alice, @bob"}})
+	assert.False(t, diff.HasChanges())
+
+	diff = before.CompareTo(Item{ID: "1", Attributes: map[string]interface{}{AssigneesAttribute: "@alice, @carol"}})
+	require.Len(t, diff.FieldChanges, 1)
+	assert.Equal(t, AssigneesAttribute, diff.FieldChanges[0].Field)
+
+	// Assignees are stored as lists, which can't be compared with ==
+	list := Item{ID: "1", Attributes: map[string]interface{}{AssigneesAttribute: []string{"@alice", "@bob"}}}
+	assert.False(t, list.CompareTo(list).HasChanges())
+	assert.False(t, before.CompareTo(list).HasChanges(), "snapshots of earlier versions store a string of mentions")
+
+	diff = list.CompareTo(Item{ID: "1", Attributes: map[string]interface{}{AssigneesAttribute: []string{"@alice", "@carol"}}})
+	require.Len(t, diff.FieldChanges, 1)
+	added, removed, ok := ListChanges(diff.FieldChanges[0].OldValue, diff.FieldChanges[0].NewValue)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"@carol"}, added)
+	assert.Equal(t, []string{"@bob"}, removed)
+}
+
+func TestItemComparisonLabels(t *testing.T) {
+	before := Item{ID: "1", Labels: []string{"bug", "p1"}}
+
+	diff := before.CompareTo(Item{ID: "1", Labels: []string{"p1", "bug"}})
+	assert.False(t, diff.HasChanges())
+
+	diff = before.CompareTo(Item{ID: "1", Labels: []string{"p1", "scope-change"}})
+	require.Len(t, diff.FieldChanges, 1)
+	assert.Equal(t, FieldChange{Field: LabelsField, OldValue: []string{"bug", "p1"}, NewValue: []string{"p1", "scope-change"}}, diff.FieldChanges[0])
+}
+
+func TestItemReference(t *testing.T) {
+	assert.Equal(t, "org/repo#123", Item{Repository: "org/repo", Number: 123}.Reference())
+	assert.Empty(t, Item{Number: 123}.Reference())
+	assert.Empty(t, Item{Repository: "org/repo"}.Reference())
+}
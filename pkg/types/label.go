@@ -0,0 +1,58 @@
+package types
+
+import "strings"
+
+// LabelsField is the field of changes to the labels of an item
+const LabelsField = "Labels"
+
+// LabelChanges returns the labels added to and removed from an item, in the order of
+// the lists. Labels are compared case-insensitively, as GitHub does.
+func LabelChanges(before, after []string) (added, removed []string) {
+	return listChanges(before, after)
+}
+
+// ListChanges returns the entries added to and removed from a list value of a field
+// change: labels or a list of users such as the assignees. It returns false if the
+// values are not lists.
+func ListChanges(oldValue, newValue interface{}) (added, removed []string, ok bool) {
+	before, beforeList := oldValue.([]string)
+	after, afterList := newValue.([]string)
+	if beforeList || afterList {
+		// Users were stored as a string of mentions by earlier versions
+		if !beforeList {
+			if before, ok = MentionList(oldValue); !ok {
+				return nil, nil, false
+			}
+		}
+		if !afterList {
+			if after, ok = MentionList(newValue); !ok {
+				return nil, nil, false
+			}
+		}
+		added, removed = LabelChanges(before, after)
+		return added, removed, true
+	}
+	return MentionChanges(oldValue, newValue)
+}
+
+// listChanges returns the entries of after missing in before and the entries of
+// before missing in after, compared case-insensitively
+func listChanges(before, after []string) (added, removed []string) {
+	had := make(map[string]bool)
+	for _, entry := range before {
+		had[strings.ToLower(entry)] = true
+	}
+	has := make(map[string]bool)
+	for _, entry := range after {
+		has[strings.ToLower(entry)] = true
+		if !had[strings.ToLower(entry)] {
+			added = append(added, entry)
+		}
+	}
+	for _, entry := range before {
+		if !has[strings.ToLower(entry)] {
+			removed = append(removed, entry)
+		}
+	}
+	return added, removed
+}
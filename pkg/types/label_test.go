@@ -0,0 +1,43 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelChanges(t *testing.T) {
+	added, removed := LabelChanges([]string{"bug", "p1"}, []string{"P1", "scope-change"})
+	assert.Equal(t, []string{"scope-change"}, added)
+	assert.Equal(t, []string{"bug"}, removed)
+
+	added, removed = LabelChanges(nil, nil)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+func TestListChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new interface{}
+		added    []string
+		removed  []string
+		ok       bool
+	}{
+		{name: "labels", old: []string{"bug"}, new: []string{"bug", "p1"}, added: []string{"p1"}, ok: true},
+		{name: "first labels", old: nil, new: []string{"bug"}, added: []string{"bug"}, ok: true},
+		{name: "labels removed", old: []string{"bug"}, new: []string(nil), removed: []string{"bug"}, ok: true},
+		{name: "users", old: "@alice", new: "@bob", added: []string{"@bob"}, removed: []string{"@alice"}, ok: true},
+		{name: "labels and text", old: []string{"bug"}, new: "bug"},
+		{name: "text", old: "Todo", new: "Done"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed, ok := ListChanges(tt.old, tt.new)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.added, added)
+			assert.Equal(t, tt.removed, removed)
+		})
+	}
+}
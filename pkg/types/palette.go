@@ -0,0 +1,125 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// Palette categories
+const (
+	PaletteStatus   = "status"   // Values of the status field
+	PaletteAssignee = "assignee" // Assignees, e.g. "@alice"
+	PaletteLabel    = "label"    // Issue and pull request labels
+)
+
+// PaletteColors are assigned to the values of a category in palette order. They
+// are distinguishable on a light background and repeat for long categories.
+var PaletteColors = []string{
+	"#0969da", "#1a7f37", "#9a6700", "#cf222e", "#8250df",
+	"#bf3989", "#1b7c83", "#953800", "#57606a", "#6639ba",
+}
+
+// Palette records the values of categories such as statuses and assignees in the
+// order they were first seen. Persisting it next to the snapshots keeps the order
+// and colors of values stable between reports of different weeks.
+type Palette struct {
+	Values map[string][]string `json:"values"` // Values by category
+}
+
+// NewPalette creates an empty palette
+func NewPalette() *Palette {
+	return &Palette{Values: make(map[string][]string)}
+}
+
+// Observe appends the values not yet in the category, in alphabetical order so
+// that values first seen together get the same position on every machine. It
+// returns true if the palette changed.
+func (p *Palette) Observe(category string, values []string) bool {
+	if p.Values == nil {
+		p.Values = make(map[string][]string)
+	}
+
+	known := make(map[string]bool, len(p.Values[category]))
+	for _, value := range p.Values[category] {
+		known[value] = true
+	}
+
+	var added []string
+	for _, value := range values {
+		if value != "" && !known[value] {
+			known[value] = true
+			added = append(added, value)
+		}
+	}
+	sort.Strings(added)
+	p.Values[category] = append(p.Values[category], added...)
+	return len(added) > 0
+}
+
+// ObserveState observes the statuses, assignees and labels of all items of a state.
+// It returns true if the palette changed.
+func (p *Palette) ObserveState(state *ProjectState, statusField string) bool {
+	var statuses, assignees, labels []string
+	for _, item := range state.Items {
+		if status, ok := item.Attributes[statusField].(string); ok {
+			statuses = append(statuses, status)
+		}
+		assignees = append(assignees, item.Assignees()...)
+		labels = append(labels, item.Labels...)
+	}
+
+	changed := p.Observe(PaletteStatus, statuses)
+	changed = p.Observe(PaletteAssignee, assignees) || changed
+	changed = p.Observe(PaletteLabel, labels) || changed
+	return changed
+}
+
+// Index returns the position of a value in its category. The second return value
+// is false for unknown values and a nil palette.
+func (p *Palette) Index(category, value string) (int, bool) {
+	if p == nil {
+		return 0, false
+	}
+	for i, v := range p.Values[category] {
+		if v == value {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Color returns the color of a value, or an empty string if it is unknown
+func (p *Palette) Color(category, value string) string {
+	i, ok := p.Index(category, value)
+	if !ok {
+		return ""
+	}
+	return PaletteColors[i%len(PaletteColors)]
+}
+
+// Colors returns the colors of all values of a category by value
+func (p *Palette) Colors(category string) map[string]string {
+	if p == nil {
+		return nil
+	}
+	colors := make(map[string]string, len(p.Values[category]))
+	for i, value := range p.Values[category] {
+		colors[value] = PaletteColors[i%len(PaletteColors)]
+	}
+	return colors
+}
+
+// Less orders values of a category by palette position. Unknown values follow the
+// known ones in alphabetical order, ignoring case.
+func (p *Palette) Less(category, a, b string) bool {
+	i, knownA := p.Index(category, a)
+	j, knownB := p.Index(category, b)
+	switch {
+	case knownA && knownB:
+		return i < j
+	case knownA != knownB:
+		return knownA
+	default:
+		return strings.ToLower(a) < strings.ToLower(b)
+	}
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaletteObserve(t *testing.T) {
+	p := NewPalette()
+
+	assert.True(t, p.Observe(PaletteStatus, []string{"Todo", "Done", "Todo", ""}))
+	assert.False(t, p.Observe(PaletteStatus, []string{"Done"}))
+	assert.True(t, p.Observe(PaletteStatus, []string{"Blocked", "Todo"}))
+
+	// New values are appended, so earlier values keep their position
+	assert.Equal(t, []string{"Done", "Todo", "Blocked"}, p.Values[PaletteStatus])
+	assert.Equal(t, PaletteColors[0], p.Color(PaletteStatus, "Done"))
+	assert.Equal(t, PaletteColors[2], p.Color(PaletteStatus, "Blocked"))
+	assert.Equal(t, "", p.Color(PaletteStatus, "Unknown"))
+	assert.Equal(t, map[string]string{"Done": PaletteColors[0], "Todo": PaletteColors[1], "Blocked": PaletteColors[2]}, p.Colors(PaletteStatus))
+}
+
+func TestPaletteObserveState(t *testing.T) {
+	p := &Palette{}
+	state := &ProjectState{Items: []Item{
+		{ID: "1", Attributes: map[string]interface{}{"Status": "Todo", AssigneesAttribute: "@bob, @alice"}, Labels: []string{"bug"}},
+		{ID: "2", Attributes: map[string]interface{}{"Status": "Done"}},
+	}}
+
+	assert.True(t, p.ObserveState(state, "Status"))
+	assert.False(t, p.ObserveState(state, "Status"))
+	assert.Equal(t, []string{"Done", "Todo"}, p.Values[PaletteStatus])
+	assert.Equal(t, []string{"@alice", "@bob"}, p.Values[PaletteAssignee])
+	assert.Equal(t, []string{"bug"}, p.Values[PaletteLabel])
+}
+
+func TestPaletteLess(t *testing.T) {
+	p := &Palette{Values: map[string][]string{PaletteAssignee: {"@zoe", "@adam"}}}
+
+	assert.True(t, p.Less(PaletteAssignee, "@zoe", "@adam"))
+	assert.True(t, p.Less(PaletteAssignee, "@adam", "@bob"))
+	assert.True(t, p.Less(PaletteAssignee, "@Bob", "@carl"))
+	assert.False(t, p.Less(PaletteAssignee, "@carl", "@zoe"))
+
+	var empty *Palette
+	assert.True(t, empty.Less(PaletteAssignee, "@adam", "@zoe"))
+	assert.Equal(t, "", empty.Color(PaletteAssignee, "@adam"))
+	assert.Nil(t, empty.Colors(PaletteAssignee))
+}
@@ -0,0 +1,86 @@
+package types
+
+import "strings"
+
+// AssigneesAttribute is the attribute holding the assignees of issues and pull requests
+const AssigneesAttribute = "Assignees"
+
+// Mention formats a login as a mention, which is how users are stored in attributes
+func Mention(login string) string {
+	return "@" + login
+}
+
+// MentionList returns the users of an attribute value holding users, e.g. ["@alice", "@bob"]
+// as stored for assignees and user fields, or "@alice, @bob" as stored by earlier versions.
+// It returns false if the value is not a list of users. Missing and empty values are
+// empty lists.
+func MentionList(value interface{}) ([]string, bool) {
+	var users []string
+	switch v := value.(type) {
+	case nil:
+		return nil, true
+	case []string:
+		users = v
+	case string:
+		users = strings.Split(v, ",")
+	default:
+		return nil, false
+	}
+
+	var mentions []string
+	for _, user := range users {
+		user = strings.TrimSpace(user)
+		if user == "" {
+			continue
+		}
+		if !strings.HasPrefix(user, "@") {
+			return nil, false
+		}
+		mentions = append(mentions, user)
+	}
+	return mentions, true
+}
+
+// MentionChanges returns the users added to and removed from a list of users, in
+// the order of the lists. It returns false unless both values are lists of users
+// and at least one of them is not empty.
+func MentionChanges(oldValue, newValue interface{}) (added, removed []string, ok bool) {
+	before, ok := MentionList(oldValue)
+	if !ok {
+		return nil, nil, false
+	}
+	after, ok := MentionList(newValue)
+	if !ok || (len(before) == 0 && len(after) == 0) {
+		return nil, nil, false
+	}
+
+	added, removed = listChanges(before, after)
+	return added, removed, true
+}
+
+// InvolvesUser returns true if the user is mentioned in any attribute of the
+// item, i.e. is an assignee or set in a user field such as an owner field
+func (i Item) InvolvesUser(login string) bool {
+	mention := Mention(login)
+	for _, value := range i.Attributes {
+		users, _ := MentionList(value)
+		for _, user := range users {
+			if strings.EqualFold(user, mention) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FilterByUser returns a new ProjectState containing only items that involve the given user
+func (s *ProjectState) FilterByUser(login string) *ProjectState {
+	filtered := *s
+	filtered.Items = make([]Item, 0)
+	for _, item := range s.Items {
+		if item.InvolvesUser(login) {
+			filtered.Items = append(filtered.Items, item)
+		}
+	}
+	return &filtered
+}
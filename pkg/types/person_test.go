@@ -0,0 +1,71 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvolvesUser(t *testing.T) {
+	item := Item{
+		ID: "1",
+		Attributes: map[string]interface{}{
+			"Title":            "Task 1",
+			AssigneesAttribute: []string{"@alice", "@bob"},
+			"Owner":            "@Carol",
+			"Notes":            "dave",
+			"Estimate":         float64(3),
+		},
+	}
+
+	assert.True(t, item.InvolvesUser("alice"))
+	assert.True(t, item.InvolvesUser("bob"))
+	assert.True(t, item.InvolvesUser("carol"))
+	assert.False(t, item.InvolvesUser("dave"))
+	assert.False(t, item.InvolvesUser("ali"))
+}
+
+func TestFilterByUser(t *testing.T) {
+	state := createTestState()
+	state.Items[0].Attributes[AssigneesAttribute] = "@alice"
+	state.Items[2].Attributes["Owner"] = "@alice, @bob"
+
+	filtered := state.FilterByUser("alice")
+	assert.Len(t, filtered.Items, 2)
+	assert.Equal(t, "1", filtered.Items[0].ID)
+	assert.Equal(t, "3", filtered.Items[1].ID)
+	assert.Equal(t, state.ProjectNumber, filtered.ProjectNumber)
+	assert.Len(t, state.Items, 3)
+
+	assert.Empty(t, state.FilterByUser("nobody").Items)
+}
+
+func TestMentionChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new interface{}
+		added    []string
+		removed  []string
+		ok       bool
+	}{
+		{name: "added and removed", old: "@alice, @bob", new: "@bob, @carol", added: []string{"@carol"}, removed: []string{"@alice"}, ok: true},
+		{name: "reordered", old: "@alice, @bob", new: "@Bob, @alice", ok: true},
+		{name: "first assignee", old: nil, new: "@alice", added: []string{"@alice"}, ok: true},
+		{name: "unassigned", old: "@alice", new: "", removed: []string{"@alice"}, ok: true},
+		{name: "not users", old: "Todo", new: "Done"},
+		{name: "numbers", old: 3.0, new: "@alice"},
+		{name: "both empty", old: "", new: nil},
+		{name: "lists", old: []string{"@alice", "@bob"}, new: []string{"@bob", "@carol"}, added: []string{"@carol"}, removed: []string{"@alice"}, ok: true},
+		{name: "string to list", old: "@bob, @alice", new: []string{"@alice", "@bob"}, ok: true},
+		{name: "list of labels", old: []string{"bug"}, new: []string{"feature"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed, ok := MentionChanges(tt.old, tt.new)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.added, added)
+			assert.Equal(t, tt.removed, removed)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -8,12 +10,15 @@ import (
 
 // ProjectState represents the state of a project at a specific point in time
 type ProjectState struct {
-	Filename      string    `json:"filename"`
-	Timestamp     time.Time `json:"timestamp"`
-	ProjectNumber int       `json:"project_number,omitempty"`
-	ProjectID     string    `json:"project_id,omitempty"`
-	Organization  string    `json:"organization,omitempty"`
-	Items         []Item    `json:"items"`
+	Filename      string     `json:"filename"`
+	Timestamp     time.Time  `json:"timestamp"`
+	ProjectNumber int        `json:"project_number,omitempty"`
+	ProjectID     string     `json:"project_id,omitempty"`
+	Organization  string     `json:"organization,omitempty"`
+	Timezone      string     `json:"timezone,omitempty"` // IANA timezone used to interpret date fields
+	Items         []Item     `json:"items"`
+	Workflows     []Workflow `json:"workflows,omitempty"` // Built-in automations of the project
+	Warnings      []string   `json:"warnings,omitempty"`  // Problems noticed while capturing the state
 }
 
 // ProjectDiff represents all changes between two project states
@@ -21,6 +26,40 @@ type ProjectDiff struct {
 	AddedItems   []Item     // Items that are new in the target state
 	RemovedItems []Item     // Items that were in source but not in target
 	ChangedItems []ItemDiff // Items that exist in both states but changed
+	CurrentItems []Item     // All items of the target state, for reporting on the current state
+	EmptyProject bool       // Both states contain no items at all
+
+	WorkflowChanges []WorkflowChange // Built-in automations that were enabled, disabled, added or removed
+}
+
+// Location returns the timezone used to interpret the date fields of the project, defaulting to UTC
+func (s *ProjectState) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+	}
+	return loc, nil
+}
+
+// SameContent returns true if both states hold the same items and workflows, regardless
+// of when they were captured, where they are stored and the warnings noticed on capture
+func (s *ProjectState) SameContent(other *ProjectState) bool {
+	a, errA := s.contentJSON()
+	b, errB := other.contentJSON()
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// contentJSON marshals the state without the properties ignored by SameContent. Values
+// are compared as marshaled, so a state loaded from a snapshot matches a fresh one.
+func (s *ProjectState) contentJSON() ([]byte, error) {
+	content := *s
+	content.Filename = ""
+	content.Timestamp = time.Time{}
+	content.Warnings = nil
+	return json.Marshal(content)
 }
 
 // FilterState returns a new ProjectState containing only items that match the filter
@@ -36,6 +75,15 @@ func (s *ProjectState) FilterState(filter string) (*ProjectState, error) {
 	}
 	attribute, value := parts[0], parts[1]
 
+	// The attribute may be prefixed with a provenance, e.g. "field:Status", to only
+	// match attributes from that source
+	var provenance Provenance
+	if kind, name, ok := strings.Cut(attribute, ":"); ok {
+		if p, err := ParseProvenance(kind); err == nil {
+			provenance, attribute = p, name
+		}
+	}
+
 	// Create new state with filtered items
 	filtered := &ProjectState{
 		Filename:      s.Filename,
@@ -43,13 +91,19 @@ func (s *ProjectState) FilterState(filter string) (*ProjectState, error) {
 		ProjectNumber: s.ProjectNumber,
 		ProjectID:     s.ProjectID,
 		Organization:  s.Organization,
+		Timezone:      s.Timezone,
 		Items:         make([]Item, 0),
+		Workflows:     s.Workflows,
+		Warnings:      s.Warnings,
 	}
 
 	// Add items that match the filter
 	for _, item := range s.Items {
 		if itemValue, ok := item.Attributes[attribute]; ok {
-			if fmt.Sprintf("%v", itemValue) == value {
+			if provenance != "" && item.AttributeProvenance(attribute) != provenance {
+				continue
+			}
+			if FormatValue(itemValue) == value {
 				filtered.Items = append(filtered.Items, item)
 			}
 		}
@@ -59,7 +113,12 @@ func (s *ProjectState) FilterState(filter string) (*ProjectState, error) {
 }
 
 func (p *ProjectState) CompareTo(other *ProjectState) *ProjectDiff {
-	diff := ProjectDiff{}
+	diff := ProjectDiff{
+		CurrentItems: other.Items,
+		EmptyProject: len(p.Items) == 0 && len(other.Items) == 0,
+
+		WorkflowChanges: CompareWorkflows(p.Workflows, other.Workflows),
+	}
 
 	// Find removed and changed items
 	for _, oldItem := range p.Items {
@@ -93,5 +152,6 @@ func (p *ProjectState) CompareTo(other *ProjectState) *ProjectDiff {
 		}
 	}
 
+	diff.matchRecreated()
 	return &diff
 }
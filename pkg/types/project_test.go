@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -88,6 +89,20 @@ func TestFilterState(t *testing.T) {
 			expectedCount: 1,
 			expectedIDs:   []string{"1"},
 		},
+		{
+			name:          "filter by project field",
+			filter:        "field:Team=UI",
+			wantErr:       false,
+			expectedCount: 2,
+			expectedIDs:   []string{"1", "3"},
+		},
+		{
+			name:          "filter by content attribute of a field",
+			filter:        "content:Team=UI",
+			wantErr:       false,
+			expectedCount: 0,
+			expectedIDs:   []string{},
+		},
 		{
 			name:          "filter with no matches",
 			filter:        "Team=DevOps",
@@ -175,3 +190,71 @@ func TestFilterState_Integration(t *testing.T) {
 	assert.Equal(t, 1, len(diff.AddedItems))
 	assert.Equal(t, "2", diff.AddedItems[0].ID)
 }
+
+func TestProjectStateLocation(t *testing.T) {
+	state := createTestState()
+
+	loc, err := state.Location()
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	state.Timezone = "Europe/Berlin"
+	loc, err = state.Location()
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", loc.String())
+
+	filtered, err := state.FilterState("Team=UI")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", filtered.Timezone)
+
+	state.Timezone = "Mars/Olympus"
+	_, err = state.Location()
+	assert.ErrorContains(t, err, "invalid timezone")
+}
+
+func TestCompareToEmptyProject(t *testing.T) {
+	empty := &ProjectState{Items: []Item{}}
+
+	diff := empty.CompareTo(&ProjectState{})
+	assert.True(t, diff.EmptyProject)
+
+	diff = empty.CompareTo(createTestState())
+	assert.False(t, diff.EmptyProject)
+	assert.Len(t, diff.AddedItems, 3)
+}
+
+func TestProjectStateSameContent(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fresh := &ProjectState{
+		Filename:      "",
+		Timestamp:     time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+		ProjectNumber: 1,
+		Items: []Item{
+			{ID: "1", DateSpan: DateSpan{Start: start, End: start.AddDate(0, 0, 7)}, Attributes: map[string]interface{}{"Title": "Task", "Points": 3.0}},
+		},
+		Warnings: []string{"1 item is restricted"},
+	}
+
+	// A snapshot loaded from disk went through JSON
+	data, err := json.Marshal(fresh)
+	require.NoError(t, err)
+	var stored ProjectState
+	require.NoError(t, json.Unmarshal(data, &stored))
+	stored.Filename = "states/project=1/2024-01-01T10:00:00Z.json"
+	stored.Timestamp = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	stored.Warnings = nil
+
+	assert.True(t, fresh.SameContent(&stored))
+
+	changed := stored
+	changed.Items = []Item{{ID: "1", DateSpan: stored.Items[0].DateSpan, Attributes: map[string]interface{}{"Title": "Task", "Points": 5.0}}}
+	assert.False(t, fresh.SameContent(&changed))
+
+	moved := stored
+	moved.Items = []Item{{ID: "1", DateSpan: DateSpan{Start: start, End: start.AddDate(0, 0, 8)}, Attributes: stored.Items[0].Attributes}}
+	assert.False(t, fresh.SameContent(&moved))
+
+	workflows := stored
+	workflows.Workflows = []Workflow{{Name: "Auto-close", Enabled: true}}
+	assert.False(t, fresh.SameContent(&workflows))
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Provenance tells where the value of an attribute comes from
+type Provenance string
+
+const (
+	// ProvenanceField marks values of custom fields of the project
+	ProvenanceField Provenance = "field"
+	// ProvenanceContent marks values of the issue, pull request or draft issue itself,
+	// e.g. its title, assignees, labels and timestamps
+	ProvenanceContent Provenance = "content"
+	// ProvenanceDerived marks values computed while capturing, e.g. the start date of an
+	// iteration or the due date of a milestone
+	ProvenanceDerived Provenance = "derived"
+)
+
+// contentAttributes are the attributes taken from the content of items, used for
+// snapshots captured before provenance was recorded
+var contentAttributes = map[string]bool{
+	"Title":             true,
+	CreatedAtAttribute:  true,
+	UpdatedAtAttribute:  true,
+	AssigneesAttribute:  true,
+	StateAttribute:      true,
+	RestrictedAttribute: true,
+	LabelsField:         true,
+	ContentTypeField:    true,
+}
+
+// ParseProvenance parses the name of a provenance
+func ParseProvenance(s string) (Provenance, error) {
+	switch p := Provenance(strings.ToLower(strings.TrimSpace(s))); p {
+	case ProvenanceField, ProvenanceContent, ProvenanceDerived:
+		return p, nil
+	}
+	return "", fmt.Errorf("invalid provenance: %s (must be one of: %s, %s, %s)", s, ProvenanceField, ProvenanceContent, ProvenanceDerived)
+}
+
+// SetAttribute sets an attribute of the item and records where its value comes from
+func (i *Item) SetAttribute(name string, value interface{}, provenance Provenance) {
+	if i.Attributes == nil {
+		i.Attributes = map[string]interface{}{}
+	}
+	if i.Provenance == nil {
+		i.Provenance = map[string]Provenance{}
+	}
+	i.Attributes[name] = value
+	i.Provenance[name] = provenance
+}
+
+// AttributeProvenance returns where the value of an attribute comes from. Attributes of
+// snapshots without recorded provenance are project fields unless they are well-known
+// content attributes.
+func (i Item) AttributeProvenance(name string) Provenance {
+	if p, ok := i.Provenance[name]; ok {
+		return p
+	}
+	if contentAttributes[name] {
+		return ProvenanceContent
+	}
+	return ProvenanceField
+}
+
+// FieldProvenance returns where the value of a changed field comes from, as recorded
+// in the state after the change, or before it for deleted attributes
+func (d ItemDiff) FieldProvenance(field string) Provenance {
+	if _, ok := d.After.Attributes[field]; !ok {
+		return d.Before.AttributeProvenance(field)
+	}
+	return d.After.AttributeProvenance(field)
+}
+
+// KeepProvenance drops the field changes of attributes with other provenances, and
+// changed items left without changes. Date changes are always kept, as dates are the
+// subject of the report.
+func (d *ProjectDiff) KeepProvenance(kinds ...Provenance) {
+	if len(kinds) == 0 {
+		return
+	}
+	keep := map[Provenance]bool{}
+	for _, kind := range kinds {
+		keep[kind] = true
+	}
+
+	changed := d.ChangedItems[:0]
+	for _, change := range d.ChangedItems {
+		var fields []FieldChange
+		for _, field := range change.FieldChanges {
+			if keep[change.FieldProvenance(field.Field)] {
+				fields = append(fields, field)
+			}
+		}
+		change.FieldChanges = fields
+		if change.HasChanges() {
+			changed = append(changed, change)
+		}
+	}
+	d.ChangedItems = changed
+}
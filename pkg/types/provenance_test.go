@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProvenance(t *testing.T) {
+	p, err := ParseProvenance("Field")
+	require.NoError(t, err)
+	assert.Equal(t, ProvenanceField, p)
+
+	_, err = ParseProvenance("custom")
+	assert.EqualError(t, err, "invalid provenance: custom (must be one of: field, content, derived)")
+}
+
+func TestAttributeProvenance(t *testing.T) {
+	var item Item
+	item.SetAttribute("Status", "Todo", ProvenanceField)
+	item.SetAttribute("Sprint Start", "2024-01-01", ProvenanceDerived)
+	assert.Equal(t, "Todo", item.Attributes["Status"])
+	assert.Equal(t, ProvenanceField, item.AttributeProvenance("Status"))
+	assert.Equal(t, ProvenanceDerived, item.AttributeProvenance("Sprint Start"))
+
+	// Snapshots without provenance fall back to well-known content attributes
+	older := Item{Attributes: map[string]interface{}{"Title": "Task", "Status": "Todo"}}
+	assert.Equal(t, ProvenanceContent, older.AttributeProvenance("Title"))
+	assert.Equal(t, ProvenanceContent, older.AttributeProvenance(CreatedAtAttribute))
+	assert.Equal(t, ProvenanceField, older.AttributeProvenance("Status"))
+}
+
+func TestKeepProvenance(t *testing.T) {
+	before := Item{ID: "1", DateSpan: MustNewDateSpan("2024-01-01", "2024-01-10"), Attributes: map[string]interface{}{}}
+	before.SetAttribute("Title", "Task", ProvenanceContent)
+	before.SetAttribute("Status", "Todo", ProvenanceField)
+	before.SetAttribute("Milestone Due", "2024-02-01", ProvenanceDerived)
+
+	after := Item{ID: "1", DateSpan: before.DateSpan, Attributes: map[string]interface{}{}}
+	after.SetAttribute("Title", "Renamed task", ProvenanceContent)
+	after.SetAttribute("Status", "Done", ProvenanceField)
+
+	titleOnly := Item{ID: "2", Attributes: map[string]interface{}{"Title": "Old"}}
+	moved := Item{ID: "3", DateSpan: MustNewDateSpan("2024-01-01", "2024-01-10"), Attributes: map[string]interface{}{"Title": "Old"}}
+	movedAfter := Item{ID: "3", DateSpan: MustNewDateSpan("2024-01-01", "2024-01-12"), Attributes: map[string]interface{}{"Title": "New"}}
+
+	diff := ProjectDiff{ChangedItems: []ItemDiff{
+		before.CompareTo(after),
+		titleOnly.CompareTo(Item{ID: "2", Attributes: map[string]interface{}{"Title": "New"}}),
+		moved.CompareTo(movedAfter),
+	}}
+	assert.Equal(t, ProvenanceDerived, diff.ChangedItems[0].FieldProvenance("Milestone Due"))
+
+	diff.KeepProvenance(ProvenanceField)
+	require.Len(t, diff.ChangedItems, 2)
+	assert.Equal(t, []FieldChange{{Field: "Status", OldValue: "Todo", NewValue: "Done"}}, diff.ChangedItems[0].FieldChanges)
+	// Date changes are kept even without field changes
+	assert.Equal(t, "3", diff.ChangedItems[1].ItemID)
+	assert.Empty(t, diff.ChangedItems[1].FieldChanges)
+}
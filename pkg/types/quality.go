@@ -0,0 +1,32 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// StaleDrafts returns the draft issues of the current state that were created
+// longer than maxAge before now and never converted to real issues, oldest first.
+// Days in freeze windows don't count towards the age.
+func (d ProjectDiff) StaleDrafts(now time.Time, maxAge time.Duration, freezes []FreezeWindow) []Item {
+	var stale []Item
+	for _, item := range d.CurrentItems {
+		if item.ContentType != ContentTypeDraftIssue {
+			continue
+		}
+		createdAt := item.GetCreatedAt()
+		if createdAt.IsZero() {
+			continue
+		}
+		age := now.Sub(createdAt) - time.Duration(FreezeDays(freezes, createdAt, now))*24*time.Hour
+		if age < maxAge {
+			continue
+		}
+		stale = append(stale, item)
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		return stale[i].GetCreatedAt().Before(stale[j].GetCreatedAt())
+	})
+	return stale
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleDrafts(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	item := func(id, contentType string, createdAt interface{}) Item {
+		return Item{
+			ID:          id,
+			ContentType: contentType,
+			Attributes:  map[string]interface{}{"Title": "Task " + id, "created_at": createdAt},
+		}
+	}
+
+	diff := ProjectDiff{CurrentItems: []Item{
+		item("fresh-draft", ContentTypeDraftIssue, now.AddDate(0, 0, -3)),
+		item("old-draft", ContentTypeDraftIssue, now.AddDate(0, -1, 0)),
+		item("older-draft", ContentTypeDraftIssue, "2023-12-01T00:00:00Z"),
+		item("old-issue", ContentTypeIssue, now.AddDate(0, -2, 0)),
+		item("unknown-age", ContentTypeDraftIssue, nil),
+	}}
+
+	stale := diff.StaleDrafts(now, 14*24*time.Hour, nil)
+
+	ids := make([]string, len(stale))
+	for i, item := range stale {
+		ids[i] = item.ID
+	}
+	assert.Equal(t, []string{"older-draft", "old-draft"}, ids)
+}
+
+func TestStaleDraftsExcludesFreezes(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	diff := ProjectDiff{CurrentItems: []Item{{
+		ID:          "draft",
+		ContentType: ContentTypeDraftIssue,
+		Attributes:  map[string]interface{}{"Title": "Task", "created_at": now.AddDate(0, 0, -20)},
+	}}}
+	freezes := []FreezeWindow{{Name: "Offsite", Start: now.AddDate(0, 0, -10), End: now.AddDate(0, 0, -4)}}
+
+	assert.Len(t, diff.StaleDrafts(now, 14*24*time.Hour, nil), 1)
+	assert.Empty(t, diff.StaleDrafts(now, 14*24*time.Hour, freezes))
+}
@@ -0,0 +1,81 @@
+package types
+
+import "time"
+
+// ContentTypeField is the field of the changes of the content type of an item, e.g.
+// from DraftIssue to Issue when a draft issue is converted
+const ContentTypeField = "content_type"
+
+// IsRecreated returns true if the item replaced a removed item under a new ID
+func (d ItemDiff) IsRecreated() bool {
+	return d.PreviousID != ""
+}
+
+// matchRecreated reports removed items that reappear as added items under a new ID as
+// changes of the added item instead: items of the same issue or pull request that were
+// removed from the project and added again, and items with the same title and dates,
+// e.g. draft issues deleted and re-created as issues. Removed and added items without
+// a unique counterpart stay as they are.
+func (d *ProjectDiff) matchRecreated() {
+	matchedRemoved := make([]bool, len(d.RemovedItems))
+	matchedAdded := make([]bool, len(d.AddedItems))
+
+	match := func(key func(Item) string) {
+		removedByKey := map[string][]int{}
+		for i, item := range d.RemovedItems {
+			if k := key(item); k != "" && !matchedRemoved[i] {
+				removedByKey[k] = append(removedByKey[k], i)
+			}
+		}
+		addedByKey := map[string][]int{}
+		for i, item := range d.AddedItems {
+			if k := key(item); k != "" && !matchedAdded[i] {
+				addedByKey[k] = append(addedByKey[k], i)
+			}
+		}
+
+		for i, item := range d.RemovedItems {
+			k := key(item)
+			if k == "" || matchedRemoved[i] || len(removedByKey[k]) != 1 || len(addedByKey[k]) != 1 {
+				continue
+			}
+			added := addedByKey[k][0]
+			matchedRemoved[i], matchedAdded[added] = true, true
+
+			change := item.CompareTo(d.AddedItems[added])
+			change.ItemID = d.AddedItems[added].ID
+			change.PreviousID = item.ID
+			d.ChangedItems = append(d.ChangedItems, change)
+		}
+	}
+	match(func(item Item) string { return item.ContentID })
+	match(func(item Item) string {
+		title := item.GetTitle()
+		if title == "" || item.IsRestricted() {
+			return ""
+		}
+		return title + "\x00" + formatKeyDate(item.DateSpan.Start) + "\x00" + formatKeyDate(item.DateSpan.End)
+	})
+
+	d.RemovedItems = unmatchedItems(d.RemovedItems, matchedRemoved)
+	d.AddedItems = unmatchedItems(d.AddedItems, matchedAdded)
+}
+
+// formatKeyDate formats the day of a date for matching, empty for no date
+func formatKeyDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// unmatchedItems returns the items that were not matched, in their order
+func unmatchedItems(items []Item, matched []bool) []Item {
+	var kept []Item
+	for i, item := range items {
+		if !matched[i] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
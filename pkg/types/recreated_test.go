@@ -0,0 +1,79 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareToRecreatedItems(t *testing.T) {
+	start := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	span := DateSpan{Start: start, End: start.AddDate(0, 0, 5)}
+	item := func(id, contentType, contentID, title string) Item {
+		return Item{ID: id, ContentType: contentType, ContentID: contentID, DateSpan: span, Attributes: map[string]interface{}{"Title": title}}
+	}
+
+	t.Run("issue removed and added again", func(t *testing.T) {
+		before := &ProjectState{Items: []Item{item("PVTI_1", ContentTypeIssue, "I_1", "Login")}}
+		after := &ProjectState{Items: []Item{item("PVTI_2", ContentTypeIssue, "I_1", "Login page")}}
+
+		diff := before.CompareTo(after)
+		assert.Empty(t, diff.AddedItems)
+		assert.Empty(t, diff.RemovedItems)
+		require.Len(t, diff.ChangedItems, 1)
+		change := diff.ChangedItems[0]
+		assert.Equal(t, "PVTI_2", change.ItemID)
+		assert.Equal(t, "PVTI_1", change.PreviousID)
+		assert.True(t, change.IsRecreated())
+		assert.Equal(t, []FieldChange{{Field: "Title", OldValue: "Login", NewValue: "Login page"}}, change.FieldChanges)
+	})
+
+	t.Run("draft re-created as issue", func(t *testing.T) {
+		before := &ProjectState{Items: []Item{item("PVTI_1", ContentTypeDraftIssue, "DI_1", "Login")}}
+		after := &ProjectState{Items: []Item{item("PVTI_2", ContentTypeIssue, "I_1", "Login")}}
+
+		diff := before.CompareTo(after)
+		assert.Empty(t, diff.AddedItems)
+		assert.Empty(t, diff.RemovedItems)
+		require.Len(t, diff.ChangedItems, 1)
+		assert.Equal(t, []FieldChange{{Field: ContentTypeField, OldValue: ContentTypeDraftIssue, NewValue: ContentTypeIssue}}, diff.ChangedItems[0].FieldChanges)
+		assert.Equal(t, ProvenanceContent, diff.ChangedItems[0].FieldProvenance(ContentTypeField))
+	})
+
+	t.Run("same title with other dates", func(t *testing.T) {
+		moved := item("PVTI_2", ContentTypeIssue, "I_2", "Login")
+		moved.DateSpan.End = moved.DateSpan.End.AddDate(0, 0, 1)
+		before := &ProjectState{Items: []Item{item("PVTI_1", ContentTypeDraftIssue, "DI_1", "Login")}}
+		after := &ProjectState{Items: []Item{moved}}
+
+		diff := before.CompareTo(after)
+		assert.Len(t, diff.AddedItems, 1)
+		assert.Len(t, diff.RemovedItems, 1)
+		assert.Empty(t, diff.ChangedItems)
+	})
+
+	t.Run("ambiguous titles", func(t *testing.T) {
+		before := &ProjectState{Items: []Item{
+			item("PVTI_1", ContentTypeDraftIssue, "DI_1", "Follow-up"),
+			item("PVTI_2", ContentTypeDraftIssue, "DI_2", "Follow-up"),
+		}}
+		after := &ProjectState{Items: []Item{item("PVTI_3", ContentTypeIssue, "I_3", "Follow-up")}}
+
+		diff := before.CompareTo(after)
+		assert.Len(t, diff.AddedItems, 1)
+		assert.Len(t, diff.RemovedItems, 2)
+		assert.Empty(t, diff.ChangedItems)
+	})
+
+	t.Run("converted in place", func(t *testing.T) {
+		before := &ProjectState{Items: []Item{item("PVTI_1", ContentTypeDraftIssue, "DI_1", "Login")}}
+		after := &ProjectState{Items: []Item{item("PVTI_1", ContentTypeIssue, "I_1", "Login")}}
+
+		diff := before.CompareTo(after)
+		require.Len(t, diff.ChangedItems, 1)
+		assert.False(t, diff.ChangedItems[0].IsRecreated())
+		assert.Equal(t, ContentTypeField, diff.ChangedItems[0].FieldChanges[0].Field)
+	})
+}
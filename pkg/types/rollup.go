@@ -0,0 +1,74 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Rollup aggregates the items of a diff, weighted by a numeric attribute
+type Rollup struct {
+	WeightField   string  // Attribute used as weight, empty when counting items
+	Added         float64 // Weight of added items
+	Removed       float64 // Weight of removed items
+	Changed       float64 // Weight of changed items
+	Delayed       float64 // Weight of changed items whose end date moved later
+	SlipDays      float64 // End date slip in days multiplied by the item weight
+	MissingWeight int     // Number of items without a numeric weight
+	Restricted    int     // Number of current items the token could not read
+}
+
+// Weight returns the numeric value of the given attribute. Without a field,
+// every item weighs 1. The second return value is false if the attribute is
+// missing or not numeric, in which case the weight is 0.
+func (i Item) Weight(field string) (float64, bool) {
+	if field == "" {
+		return 1, true
+	}
+
+	switch v := i.Attributes[field].(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// Rollup aggregates the diff weighted by the given attribute
+func (d ProjectDiff) Rollup(weightField string) Rollup {
+	r := Rollup{WeightField: weightField}
+
+	weigh := func(item Item) float64 {
+		w, ok := item.Weight(weightField)
+		if !ok {
+			r.MissingWeight++
+		}
+		return w
+	}
+
+	for _, item := range d.AddedItems {
+		r.Added += weigh(item)
+	}
+	for _, item := range d.RemovedItems {
+		r.Removed += weigh(item)
+	}
+	for _, change := range d.ChangedItems {
+		w := weigh(change.After)
+		r.Changed += w
+		if change.DateChange != nil && change.DateChange.EndDaysDelta > 0 {
+			r.Delayed += w
+			r.SlipDays += w * float64(change.DateChange.EndDaysDelta)
+		}
+	}
+	for _, item := range d.CurrentItems {
+		if item.IsRestricted() {
+			r.Restricted++
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemWeight(t *testing.T) {
+	item := Item{
+		ID: "1",
+		Attributes: map[string]interface{}{
+			"Estimate": float64(5),
+			"Points":   "3",
+			"Size":     2,
+			"Team":     "UI",
+		},
+	}
+
+	tests := []struct {
+		field  string
+		want   float64
+		wantOK bool
+	}{
+		{field: "", want: 1, wantOK: true},
+		{field: "Estimate", want: 5, wantOK: true},
+		{field: "Points", want: 3, wantOK: true},
+		{field: "Size", want: 2, wantOK: true},
+		{field: "Team", want: 0, wantOK: false},
+		{field: "Missing", want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, ok := item.Weight(tt.field)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestProjectDiffRollup(t *testing.T) {
+	weighted := func(id string, estimate interface{}) Item {
+		attrs := map[string]interface{}{"Title": "Task " + id}
+		if estimate != nil {
+			attrs["Estimate"] = estimate
+		}
+		return Item{ID: id, Attributes: attrs}
+	}
+
+	diff := ProjectDiff{
+		AddedItems:   []Item{weighted("1", float64(1)), weighted("2", float64(1))},
+		RemovedItems: []Item{weighted("3", nil)},
+		ChangedItems: []ItemDiff{
+			{
+				ItemID:     "4",
+				After:      weighted("4", float64(13)),
+				DateChange: &DateSpanChange{EndDaysDelta: 2},
+			},
+			{
+				ItemID:     "5",
+				After:      weighted("5", float64(3)),
+				DateChange: &DateSpanChange{EndDaysDelta: -4},
+			},
+		},
+	}
+
+	t.Run("counts without weight field", func(t *testing.T) {
+		r := diff.Rollup("")
+		assert.Equal(t, Rollup{Added: 2, Removed: 1, Changed: 2, Delayed: 1, SlipDays: 2}, r)
+	})
+
+	t.Run("weighted by estimate", func(t *testing.T) {
+		r := diff.Rollup("Estimate")
+		assert.Equal(t, Rollup{
+			WeightField:   "Estimate",
+			Added:         2,
+			Removed:       0,
+			Changed:       16,
+			Delayed:       13,
+			SlipDays:      26,
+			MissingWeight: 1,
+		}, r)
+	})
+}
+
+func TestProjectDiffRollupRestricted(t *testing.T) {
+	diff := ProjectDiff{
+		CurrentItems: []Item{
+			{ID: "1", Attributes: map[string]interface{}{"Title": "Visible"}},
+			{ID: "2", Attributes: map[string]interface{}{RestrictedAttribute: true}},
+		},
+	}
+
+	assert.Equal(t, 1, diff.Rollup("").Restricted)
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// SLAViolation is an item that has been in a status for longer than its SLA allows
+type SLAViolation struct {
+	Item         Item
+	Status       string
+	Since        time.Time // First snapshot showing the item in the status
+	BusinessDays int       // Business days in the status up to the report
+	Limit        int       // Business days allowed by the SLA
+}
+
+// Overage returns the business days by which the SLA was exceeded
+func (v SLAViolation) Overage() int {
+	return v.BusinessDays - v.Limit
+}
+
+// statusRun is the status of an item and when it was first seen in it
+type statusRun struct {
+	status string
+	since  time.Time
+}
+
+// StatusTracker follows the status of items through consecutive snapshots to
+// find out since when each item is in its current status
+type StatusTracker struct {
+	field string
+	runs  map[string]statusRun // By item ID
+}
+
+// NewStatusTracker creates a tracker of the given status field
+func NewStatusTracker(field string) *StatusTracker {
+	return &StatusTracker{field: field, runs: make(map[string]statusRun)}
+}
+
+// Observe records the statuses of the next snapshot. States must be observed in
+// chronological order. Items missing from the snapshot are forgotten, so an item
+// removed and added again starts over.
+func (t *StatusTracker) Observe(state *ProjectState) {
+	runs := make(map[string]statusRun, len(state.Items))
+	for _, item := range state.Items {
+		status, _ := item.Attributes[t.field].(string)
+		if run, ok := t.runs[item.ID]; ok && run.status == status {
+			runs[item.ID] = run
+			continue
+		}
+		runs[item.ID] = statusRun{status: status, since: state.Timestamp}
+	}
+	t.runs = runs
+}
+
+// Since returns the current status of an item and the first snapshot showing it in
+// that status. The second return value is false for items not seen in the last snapshot.
+func (t *StatusTracker) Since(itemID string) (string, time.Time, bool) {
+	run, ok := t.runs[itemID]
+	return run.status, run.since, ok
+}
+
+// Violations returns the items of the state that have been in a status for more
+// business days than allowed by slas, which maps statuses to business days and is
+// matched ignoring case. The time in a status is counted up to now from the first
+// snapshot showing the item in it. Violations are sorted by overage, largest first.
+func (t *StatusTracker) Violations(state *ProjectState, slas map[string]int, now time.Time) []SLAViolation {
+	var violations []SLAViolation
+	for _, item := range state.Items {
+		status, since, ok := t.Since(item.ID)
+		if !ok || status == "" {
+			continue
+		}
+		limit, ok := lookupSLA(slas, status)
+		if !ok {
+			continue
+		}
+		days := BusinessDaysBetween(since, now)
+		if days > limit {
+			violations = append(violations, SLAViolation{Item: item, Status: status, Since: since, BusinessDays: days, Limit: limit})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Overage() != violations[j].Overage() {
+			return violations[i].Overage() > violations[j].Overage()
+		}
+		return violations[i].Item.GetTitle() < violations[j].Item.GetTitle()
+	})
+	return violations
+}
+
+// lookupSLA returns the SLA of a status, matching statuses ignoring case
+func lookupSLA(slas map[string]int, status string) (int, bool) {
+	for s, limit := range slas {
+		if strings.EqualFold(strings.TrimSpace(s), strings.TrimSpace(status)) {
+			return limit, true
+		}
+	}
+	return 0, false
+}
+
+// BusinessDaysBetween returns the number of weekdays after the calendar day of from
+// up to and including the calendar day of to, e.g. 1 from Friday to Monday
+func BusinessDaysBetween(from, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := 0
+	for day := start.AddDate(0, 0, 1); !day.After(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			days++
+		}
+	}
+	return days
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessDaysBetween(t *testing.T) {
+	friday := time.Date(2024, 1, 5, 17, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, BusinessDaysBetween(friday, friday))
+	assert.Equal(t, 0, BusinessDaysBetween(friday, friday.AddDate(0, 0, 2)))
+	assert.Equal(t, 1, BusinessDaysBetween(friday, friday.AddDate(0, 0, 3)))
+	assert.Equal(t, 5, BusinessDaysBetween(friday, friday.AddDate(0, 0, 7)))
+	assert.Equal(t, 0, BusinessDaysBetween(friday, friday.AddDate(0, 0, -3)))
+}
+
+func TestStatusTracker(t *testing.T) {
+	day := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC) // Monday
+	snapshot := func(offset int, statuses map[string]string) *ProjectState {
+		state := &ProjectState{Timestamp: day.AddDate(0, 0, offset)}
+		for _, id := range []string{"1", "2", "3", "4"} {
+			if status, ok := statuses[id]; ok {
+				state.Items = append(state.Items, Item{ID: id, Attributes: map[string]interface{}{"Title": "Task " + id, "Status": status}})
+			}
+		}
+		return state
+	}
+
+	tracker := NewStatusTracker("Status")
+	tracker.Observe(snapshot(0, map[string]string{"1": "Blocked", "2": "In Review", "3": "Blocked", "4": "Blocked"}))
+	tracker.Observe(snapshot(2, map[string]string{"1": "Blocked", "2": "In Review", "3": "Todo", "4": "Blocked"}))
+	tracker.Observe(snapshot(7, map[string]string{"1": "Blocked", "2": "In Review", "3": "Blocked"}))
+	latest := snapshot(9, map[string]string{"1": "Blocked", "2": "In Review", "3": "Blocked", "4": "Blocked"})
+	tracker.Observe(latest)
+
+	status, since, ok := tracker.Since("3")
+	require.True(t, ok)
+	assert.Equal(t, "Blocked", status)
+	assert.Equal(t, day.AddDate(0, 0, 7), since)
+
+	violations := tracker.Violations(latest, map[string]int{"blocked": 5, "In Review": 6}, latest.Timestamp)
+	require.Len(t, violations, 2)
+	assert.Equal(t, "1", violations[0].Item.ID)
+	assert.Equal(t, 7, violations[0].BusinessDays)
+	assert.Equal(t, 2, violations[0].Overage())
+	assert.Equal(t, "2", violations[1].Item.ID)
+	assert.Equal(t, 1, violations[1].Overage())
+
+	_, _, ok = tracker.Since("missing")
+	assert.False(t, ok)
+}
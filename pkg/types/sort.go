@@ -0,0 +1,126 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortKey is the property the items of a diff are ordered by in reports
+type SortKey string
+
+const (
+	SortDelay  SortKey = "delay"  // Days the end date moved, 0 for items without a date change
+	SortStart  SortKey = "start"  // Start date
+	SortEnd    SortKey = "end"    // End date
+	SortTitle  SortKey = "title"  // Title, ignoring case
+	SortStatus SortKey = "status" // Value of the status field, ignoring case
+)
+
+// SortKeys lists the valid sort keys
+var SortKeys = []SortKey{SortDelay, SortStart, SortEnd, SortTitle, SortStatus}
+
+// ParseSortKey parses the name of a sort key
+func ParseSortKey(s string) (SortKey, error) {
+	key := SortKey(strings.ToLower(strings.TrimSpace(s)))
+	for _, valid := range SortKeys {
+		if key == valid {
+			return key, nil
+		}
+	}
+	names := make([]string, len(SortKeys))
+	for i, valid := range SortKeys {
+		names[i] = string(valid)
+	}
+	return "", fmt.Errorf("invalid sort key: %s (must be one of: %s)", s, strings.Join(names, ", "))
+}
+
+// sortValue is the value of an item compared when sorting
+type sortValue struct {
+	missing bool // The item has no value, e.g. no start date
+	number  int64
+	text    string
+}
+
+// value returns the value of the key for an item, with the date change of changed items
+func (k SortKey) value(item Item, dateChange *DateSpanChange, statusField string) sortValue {
+	switch k {
+	case SortDelay:
+		if dateChange == nil {
+			return sortValue{}
+		}
+		return sortValue{number: int64(dateChange.EndDaysDelta)}
+	case SortStart:
+		if item.DateSpan.Start.IsZero() {
+			return sortValue{missing: true}
+		}
+		return sortValue{number: item.DateSpan.Start.Unix()}
+	case SortEnd:
+		if item.DateSpan.End.IsZero() {
+			return sortValue{missing: true}
+		}
+		return sortValue{number: item.DateSpan.End.Unix()}
+	case SortTitle:
+		return sortValue{text: strings.ToLower(item.GetTitle())}
+	case SortStatus:
+		return attributeValue(item, statusField)
+	}
+	return sortValue{}
+}
+
+// attributeValue returns the value of an attribute of an item, ignoring case
+func attributeValue(item Item, field string) sortValue {
+	value, ok := item.Attributes[field]
+	if !ok || value == nil || FormatValue(value) == "" {
+		return sortValue{missing: true}
+	}
+	return sortValue{text: strings.ToLower(FormatValue(value))}
+}
+
+// less reports whether a sorts before b. Missing values sort last in both directions.
+func (a sortValue) less(b sortValue, desc bool) bool {
+	if a.missing || b.missing {
+		return !a.missing && b.missing
+	}
+	if a.number != b.number {
+		return (a.number < b.number) != desc
+	}
+	if a.text != b.text {
+		return (a.text < b.text) != desc
+	}
+	return false
+}
+
+// Sort orders the added, removed and changed items of the diff by the key, ascending
+// unless desc. Changed items are compared by their state after the change. Items
+// without a value, e.g. undated items when sorting by start date, come last in both
+// directions, and items with equal values keep their order.
+func (d *ProjectDiff) Sort(key SortKey, statusField string, desc bool) {
+	for _, items := range [][]Item{d.AddedItems, d.RemovedItems} {
+		sort.SliceStable(items, func(i, j int) bool {
+			return key.value(items[i], nil, statusField).less(key.value(items[j], nil, statusField), desc)
+		})
+	}
+	changes := d.ChangedItems
+	sort.SliceStable(changes, func(i, j int) bool {
+		a := key.value(changes[i].After, changes[i].DateChange, statusField)
+		b := key.value(changes[j].After, changes[j].DateChange, statusField)
+		return a.less(b, desc)
+	})
+}
+
+// GroupBy orders the added, removed and changed items of the diff by the value of a
+// field, ignoring case, so that items with the same value are listed together. Items
+// keep their order within a group, e.g. the order of Sort, and items without a value
+// come last.
+func (d *ProjectDiff) GroupBy(field string) {
+	for _, items := range [][]Item{d.AddedItems, d.RemovedItems} {
+		sort.SliceStable(items, func(i, j int) bool {
+			return attributeValue(items[i], field).less(attributeValue(items[j], field), false)
+		})
+	}
+	changes := d.ChangedItems
+	sort.SliceStable(changes, func(i, j int) bool {
+		return attributeValue(changes[i].After, field).less(attributeValue(changes[j].After, field), false)
+	})
+}
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSortKey(t *testing.T) {
+	key, err := ParseSortKey(" Delay ")
+	require.NoError(t, err)
+	assert.Equal(t, SortDelay, key)
+
+	_, err = ParseSortKey("priority")
+	assert.EqualError(t, err, "invalid sort key: priority (must be one of: delay, start, end, title, status)")
+}
+
+func TestProjectDiffSort(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	item := func(id, title, status string, start int) Item {
+		i := Item{ID: id, Attributes: map[string]interface{}{"Title": title}}
+		if status != "" {
+			i.Attributes["Status"] = status
+		}
+		if start > 0 {
+			i.DateSpan = DateSpan{Start: day(start), End: day(start + 5)}
+		}
+		return i
+	}
+	change := func(id string, endDelta int) ItemDiff {
+		c := ItemDiff{ItemID: id, After: item(id, id, "", 0)}
+		if endDelta != 0 {
+			c.DateChange = &DateSpanChange{EndDaysDelta: endDelta}
+		}
+		return c
+	}
+	newDiff := func() *ProjectDiff {
+		return &ProjectDiff{
+			AddedItems: []Item{
+				item("a", "beta", "Todo", 10),
+				item("b", "Alpha", "", 0),
+				item("c", "gamma", "done", 3),
+			},
+			ChangedItems: []ItemDiff{change("x", 3), change("y", 0), change("z", 14), change("w", -2)},
+		}
+	}
+	ids := func(items []Item) []string {
+		var out []string
+		for _, i := range items {
+			out = append(out, i.ID)
+		}
+		return out
+	}
+	changeIDs := func(changes []ItemDiff) []string {
+		var out []string
+		for _, c := range changes {
+			out = append(out, c.ItemID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name        string
+		key         SortKey
+		desc        bool
+		wantAdded   []string
+		wantChanged []string
+	}{
+		{name: "delay", key: SortDelay, wantAdded: []string{"a", "b", "c"}, wantChanged: []string{"w", "y", "x", "z"}},
+		{name: "most delayed first", key: SortDelay, desc: true, wantAdded: []string{"a", "b", "c"}, wantChanged: []string{"z", "x", "y", "w"}},
+		{name: "start, undated last", key: SortStart, wantAdded: []string{"c", "a", "b"}, wantChanged: []string{"x", "y", "z", "w"}},
+		{name: "start descending, undated last", key: SortStart, desc: true, wantAdded: []string{"a", "c", "b"}, wantChanged: []string{"x", "y", "z", "w"}},
+		{name: "end", key: SortEnd, wantAdded: []string{"c", "a", "b"}, wantChanged: []string{"x", "y", "z", "w"}},
+		{name: "title ignoring case", key: SortTitle, wantAdded: []string{"b", "a", "c"}, wantChanged: []string{"w", "x", "y", "z"}},
+		{name: "status, missing last", key: SortStatus, wantAdded: []string{"c", "a", "b"}, wantChanged: []string{"x", "y", "z", "w"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := newDiff()
+			diff.Sort(tt.key, "Status", tt.desc)
+			assert.Equal(t, tt.wantAdded, ids(diff.AddedItems))
+			assert.Equal(t, tt.wantChanged, changeIDs(diff.ChangedItems))
+		})
+	}
+}
+
+func TestProjectDiffGroupBy(t *testing.T) {
+	item := func(id, team string) Item {
+		i := Item{ID: id, Attributes: map[string]interface{}{"Title": id}}
+		if team != "" {
+			i.Attributes["Team"] = team
+		}
+		return i
+	}
+	diff := &ProjectDiff{
+		AddedItems:   []Item{item("a", "UI"), item("b", ""), item("c", "api"), item("d", "ui")},
+		ChangedItems: []ItemDiff{{ItemID: "e", After: item("e", "UI")}, {ItemID: "f", After: item("f", "API")}},
+	}
+
+	diff.GroupBy("Team")
+
+	ids := func(items []Item) []string {
+		var out []string
+		for _, i := range items {
+			out = append(out, i.ID)
+		}
+		return out
+	}
+	// Items of a group keep their order, items without a value come last
+	assert.Equal(t, []string{"c", "a", "d", "b"}, ids(diff.AddedItems))
+	assert.Equal(t, "f", diff.ChangedItems[0].ItemID)
+}
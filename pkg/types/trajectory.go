@@ -0,0 +1,96 @@
+package types
+
+import "time"
+
+// Date fields of DateMove
+const (
+	DateFieldStart = "start"
+	DateFieldEnd   = "end"
+)
+
+// DateMove is a change of the start or end date of an item between two consecutive
+// snapshots
+type DateMove struct {
+	At    time.Time // Timestamp of the snapshot first showing the new date
+	Field string    // DateFieldStart or DateFieldEnd
+	From  time.Time
+	To    time.Time
+}
+
+// Days returns the number of days the date moved, positive if it moved later
+func (m DateMove) Days() int {
+	return int(m.To.Sub(m.From).Hours() / 24)
+}
+
+// ItemTrajectory lists when the dates of an item moved across a sequence of snapshots
+type ItemTrajectory struct {
+	Item  Item // Latest state of the item
+	Moves []DateMove
+}
+
+// EndDays returns the net number of days the end date moved across all moves
+func (t ItemTrajectory) EndDays() int {
+	days := 0
+	for _, move := range t.Moves {
+		if move.Field == DateFieldEnd {
+			days += move.Days()
+		}
+	}
+	return days
+}
+
+// Trajectories compares each state with the next one, in the given order, and returns
+// the items whose start or end date moved in any step, ordered by their first move.
+// Dates that were set or cleared are not moves. Re-created items continue the
+// trajectory of the item they replace.
+func Trajectories(states []*ProjectState) []ItemTrajectory {
+	var trajectories []*ItemTrajectory
+	byID := map[string]*ItemTrajectory{}
+
+	for i := 1; i < len(states); i++ {
+		diff := states[i-1].CompareTo(states[i])
+		for _, change := range diff.ChangedItems {
+			trajectory, ok := byID[change.ItemID]
+			if previous, recreated := byID[change.PreviousID]; !ok && recreated {
+				trajectory, ok = previous, true
+				delete(byID, change.PreviousID)
+				byID[change.ItemID] = trajectory
+			}
+			if ok {
+				trajectory.Item = change.After
+			}
+			if change.DateChange == nil {
+				continue
+			}
+
+			moves := dateMoves(change.Before.DateSpan, change.After.DateSpan, states[i].Timestamp)
+			if len(moves) == 0 {
+				continue
+			}
+			if !ok {
+				trajectory = &ItemTrajectory{Item: change.After}
+				trajectories = append(trajectories, trajectory)
+				byID[change.ItemID] = trajectory
+			}
+			trajectory.Moves = append(trajectory.Moves, moves...)
+		}
+	}
+
+	result := make([]ItemTrajectory, len(trajectories))
+	for i, trajectory := range trajectories {
+		result[i] = *trajectory
+	}
+	return result
+}
+
+// dateMoves returns the moves of the start and end date between two spans
+func dateMoves(before, after DateSpan, at time.Time) []DateMove {
+	var moves []DateMove
+	if !before.Start.IsZero() && !after.Start.IsZero() && !before.Start.Equal(after.Start) {
+		moves = append(moves, DateMove{At: at, Field: DateFieldStart, From: before.Start, To: after.Start})
+	}
+	if !before.End.IsZero() && !after.End.IsZero() && !before.End.Equal(after.End) {
+		moves = append(moves, DateMove{At: at, Field: DateFieldEnd, From: before.End, To: after.End})
+	}
+	return moves
+}
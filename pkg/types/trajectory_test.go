@@ -0,0 +1,76 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrajectories(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	state := func(at int, items ...Item) *ProjectState {
+		return &ProjectState{Timestamp: day(at), Items: items}
+	}
+	item := func(id, title string, start, end int) Item {
+		i := Item{ID: id, Attributes: map[string]interface{}{"Title": title}}
+		if start > 0 {
+			i.DateSpan.Start = day(start)
+		}
+		if end > 0 {
+			i.DateSpan.End = day(end)
+		}
+		return i
+	}
+
+	states := []*ProjectState{
+		state(1, item("a", "Login", 2, 10), item("b", "Search", 3, 12), item("c", "Undated", 0, 0)),
+		state(8, item("a", "Login", 2, 15), item("b", "Search", 3, 12), item("c", "Undated", 0, 20)),
+		state(15, item("a", "Login v2", 2, 15), item("b", "Search", 5, 12), item("c", "Undated", 0, 20)),
+		state(22, item("a", "Login v2", 2, 22), item("b", "Search", 5, 12), item("c", "Undated", 0, 18)),
+	}
+
+	trajectories := Trajectories(states)
+	require.Len(t, trajectories, 3)
+
+	login := trajectories[0]
+	assert.Equal(t, "Login v2", login.Item.GetTitle())
+	assert.Equal(t, []DateMove{
+		{At: day(8), Field: DateFieldEnd, From: day(10), To: day(15)},
+		{At: day(22), Field: DateFieldEnd, From: day(15), To: day(22)},
+	}, login.Moves)
+	assert.Equal(t, 12, login.EndDays())
+
+	search := trajectories[1]
+	assert.Equal(t, []DateMove{{At: day(15), Field: DateFieldStart, From: day(3), To: day(5)}}, search.Moves)
+	assert.Equal(t, 0, search.EndDays())
+
+	// Setting a date is not a move, changing it is
+	undated := trajectories[2]
+	assert.Equal(t, []DateMove{{At: day(22), Field: DateFieldEnd, From: day(20), To: day(18)}}, undated.Moves)
+	assert.Equal(t, -2, undated.Moves[0].Days())
+}
+
+func TestTrajectoriesRecreatedItem(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	item := func(id, contentID string, end int) Item {
+		return Item{ID: id, ContentID: contentID, DateSpan: DateSpan{Start: day(1), End: day(end)}, Attributes: map[string]interface{}{"Title": "Login"}}
+	}
+	states := []*ProjectState{
+		{Timestamp: day(1), Items: []Item{item("PVTI_1", "I_1", 10)}},
+		{Timestamp: day(8), Items: []Item{item("PVTI_1", "I_1", 12)}},
+		{Timestamp: day(15), Items: []Item{item("PVTI_2", "I_1", 14)}},
+	}
+
+	trajectories := Trajectories(states)
+	require.Len(t, trajectories, 1)
+	assert.Equal(t, "PVTI_2", trajectories[0].Item.ID)
+	assert.Len(t, trajectories[0].Moves, 2)
+	assert.Equal(t, 4, trajectories[0].EndDays())
+}
+
+func TestTrajectoriesSingleState(t *testing.T) {
+	assert.Empty(t, Trajectories([]*ProjectState{{}}))
+	assert.Empty(t, Trajectories(nil))
+}
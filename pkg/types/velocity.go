@@ -0,0 +1,79 @@
+package types
+
+import "time"
+
+// VelocitySample is the work completed between two consecutive snapshots
+type VelocitySample struct {
+	Since         time.Time // Timestamp of the earlier snapshot
+	Timestamp     time.Time // Timestamp of the later snapshot
+	Items         int       // Items that moved to a done status
+	Completed     float64   // Weight of those items, their number if no weight field is used
+	MissingWeight int       // Completed items without a numeric value in the weight field
+}
+
+// VelocityPeriod is the work completed in one period, e.g. a week or an iteration
+type VelocityPeriod struct {
+	Start         time.Time
+	End           time.Time // Exclusive
+	Items         int
+	Completed     float64
+	MissingWeight int
+}
+
+// Velocity returns the items of the state that are done but were not done in the
+// previous state, including items added in a done status. Items are done if their
+// statusField attribute matches one of doneValues, ignoring case. Work is weighted
+// by the numeric weightField, or counted in items if it is empty.
+func (s *ProjectState) Velocity(prev *ProjectState, statusField string, doneValues []string, weightField string) VelocitySample {
+	wasDone := make(map[string]bool, len(prev.Items))
+	for _, item := range prev.Items {
+		status, _ := item.Attributes[statusField].(string)
+		wasDone[item.ID] = isDoneStatus(status, doneValues)
+	}
+
+	sample := VelocitySample{Since: prev.Timestamp, Timestamp: s.Timestamp}
+	for _, item := range s.Items {
+		status, _ := item.Attributes[statusField].(string)
+		if !isDoneStatus(status, doneValues) || wasDone[item.ID] {
+			continue
+		}
+		weight, ok := item.Weight(weightField)
+		if !ok {
+			sample.MissingWeight++
+		}
+		sample.Items++
+		sample.Completed += weight
+	}
+	return sample
+}
+
+// BucketVelocity sums samples into consecutive periods of the given length,
+// starting at start. Periods without samples are included up to the last sample,
+// so that gaps in delivery show as zero. Samples before start are ignored.
+func BucketVelocity(samples []VelocitySample, start time.Time, period time.Duration) []VelocityPeriod {
+	if period <= 0 {
+		return nil
+	}
+
+	var periods []VelocityPeriod
+	for _, sample := range samples {
+		if sample.Timestamp.Before(start) {
+			continue
+		}
+		index := int(sample.Timestamp.Sub(start) / period)
+		for len(periods) <= index {
+			periodStart := start.Add(time.Duration(len(periods)) * period)
+			periods = append(periods, VelocityPeriod{Start: periodStart, End: periodStart.Add(period)})
+		}
+		periods[index].Items += sample.Items
+		periods[index].Completed += sample.Completed
+		periods[index].MissingWeight += sample.MissingWeight
+	}
+	return periods
+}
+
+// StartOfWeek returns midnight of the Monday on or before t, in the location of t
+func StartOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7 // Days since Monday
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVelocity(t *testing.T) {
+	item := func(id, status string, points interface{}) Item {
+		attributes := map[string]interface{}{"Status": status}
+		if points != nil {
+			attributes["Points"] = points
+		}
+		return Item{ID: id, Attributes: attributes}
+	}
+	prev := &ProjectState{
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Items: []Item{
+			item("1", "Todo", float64(3)),
+			item("2", "Done", float64(5)),
+			item("3", "In Progress", nil),
+		},
+	}
+	curr := &ProjectState{
+		Timestamp: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		Items: []Item{
+			item("1", "done", float64(3)), // Completed
+			item("2", "Done", float64(5)), // Already done
+			item("3", "Released", nil),    // Completed without points
+			item("4", "Done", float64(2)), // Added as done
+		},
+	}
+
+	t.Run("items", func(t *testing.T) {
+		got := curr.Velocity(prev, "Status", []string{"Done", "Released"}, "")
+		assert.Equal(t, VelocitySample{Since: prev.Timestamp, Timestamp: curr.Timestamp, Items: 3, Completed: 3}, got)
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		got := curr.Velocity(prev, "Status", []string{"Done", "Released"}, "Points")
+		assert.Equal(t, VelocitySample{Since: prev.Timestamp, Timestamp: curr.Timestamp, Items: 3, Completed: 5, MissingWeight: 1}, got)
+	})
+}
+
+func TestBucketVelocity(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	samples := []VelocitySample{
+		{Timestamp: start.Add(-time.Hour), Items: 9, Completed: 9},
+		{Timestamp: start.AddDate(0, 0, 2), Items: 1, Completed: 3},
+		{Timestamp: start.AddDate(0, 0, 6), Items: 2, Completed: 2, MissingWeight: 1},
+		{Timestamp: start.AddDate(0, 0, 15), Items: 1, Completed: 8},
+	}
+
+	got := BucketVelocity(samples, start, week)
+
+	assert.Equal(t, []VelocityPeriod{
+		{Start: start, End: start.AddDate(0, 0, 7), Items: 3, Completed: 5, MissingWeight: 1},
+		{Start: start.AddDate(0, 0, 7), End: start.AddDate(0, 0, 14)},
+		{Start: start.AddDate(0, 0, 14), End: start.AddDate(0, 0, 21), Items: 1, Completed: 8},
+	}, got)
+	assert.Nil(t, BucketVelocity(samples, start, 0))
+}
+
+func TestStartOfWeek(t *testing.T) {
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), StartOfWeek(time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), StartOfWeek(time.Date(2024, 1, 7, 23, 0, 0, 0, time.UTC)))
+}
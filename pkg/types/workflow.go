@@ -0,0 +1,65 @@
+package types
+
+import "sort"
+
+// Workflow is a built-in automation of a project, e.g. "Auto-archive items"
+type Workflow struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// WorkflowChangeKind describes how a workflow changed between two states
+type WorkflowChangeKind string
+
+const (
+	WorkflowEnabled  WorkflowChangeKind = "enabled"
+	WorkflowDisabled WorkflowChangeKind = "disabled"
+	WorkflowAdded    WorkflowChangeKind = "added"
+	WorkflowRemoved  WorkflowChangeKind = "removed"
+)
+
+// WorkflowChange describes the change of a single workflow
+type WorkflowChange struct {
+	Name    string
+	Kind    WorkflowChangeKind
+	Enabled bool // Whether the workflow is enabled in the target state, false for removed workflows
+}
+
+// CompareWorkflows returns the workflows that were enabled, disabled, added or
+// removed between two states, sorted by name. States captured before workflows
+// were recorded have none, so nothing is reported if either side is empty.
+func CompareWorkflows(before, after []Workflow) []WorkflowChange {
+	if len(before) == 0 || len(after) == 0 {
+		return nil
+	}
+
+	old := make(map[string]Workflow)
+	for _, w := range before {
+		old[w.Name] = w
+	}
+
+	var changes []WorkflowChange
+	seen := make(map[string]bool)
+	for _, w := range after {
+		seen[w.Name] = true
+		previous, ok := old[w.Name]
+		switch {
+		case !ok:
+			changes = append(changes, WorkflowChange{Name: w.Name, Kind: WorkflowAdded, Enabled: w.Enabled})
+		case !previous.Enabled && w.Enabled:
+			changes = append(changes, WorkflowChange{Name: w.Name, Kind: WorkflowEnabled, Enabled: true})
+		case previous.Enabled && !w.Enabled:
+			changes = append(changes, WorkflowChange{Name: w.Name, Kind: WorkflowDisabled})
+		}
+	}
+	for _, w := range before {
+		if !seen[w.Name] {
+			changes = append(changes, WorkflowChange{Name: w.Name, Kind: WorkflowRemoved})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
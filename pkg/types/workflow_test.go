@@ -0,0 +1,33 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareWorkflows(t *testing.T) {
+	before := []Workflow{
+		{Name: "Auto-archive items", Enabled: false},
+		{Name: "Item closed", Enabled: true},
+		{Name: "Pull request merged", Enabled: true},
+		{Name: "Item added to project", Enabled: true},
+	}
+	after := []Workflow{
+		{Name: "Auto-archive items", Enabled: true},
+		{Name: "Item closed", Enabled: false},
+		{Name: "Item added to project", Enabled: true},
+		{Name: "Auto-add to project", Enabled: true},
+	}
+
+	assert.Equal(t, []WorkflowChange{
+		{Name: "Auto-add to project", Kind: WorkflowAdded, Enabled: true},
+		{Name: "Auto-archive items", Kind: WorkflowEnabled, Enabled: true},
+		{Name: "Item closed", Kind: WorkflowDisabled},
+		{Name: "Pull request merged", Kind: WorkflowRemoved},
+	}, CompareWorkflows(before, after))
+
+	// States captured without workflows don't report changes
+	assert.Nil(t, CompareWorkflows(nil, after))
+	assert.Nil(t, CompareWorkflows(before, nil))
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// Workload describes the work scheduled for one assignee in the upcoming weeks
+type Workload struct {
+	Assignee string
+	Weekly   []int // Maximum number of items scheduled on the same day, per week
+	Days     int   // Scheduled days summed over all items
+	Peak     int   // Maximum number of items scheduled on the same day
+}
+
+// Workloads aggregates the dated items of the current state per assignee over
+// the given number of weeks starting on the calendar day of from. Items with
+// several assignees count fully for each of them. Workloads are sorted by peak
+// concurrency and scheduled days, busiest first.
+func (d ProjectDiff) Workloads(from time.Time, weeks int) []Workload {
+	if weeks <= 0 {
+		return nil
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	days := weeks * 7
+
+	// Number of items scheduled per assignee and day of the window
+	perDay := make(map[string][]int)
+	for _, item := range d.CurrentItems {
+		if item.DateSpan.Start.IsZero() || item.DateSpan.End.IsZero() {
+			continue
+		}
+		first := daysBetween(start, item.DateSpan.Start)
+		last := daysBetween(start, item.DateSpan.End)
+		if last < 0 || first >= days {
+			continue
+		}
+		first = max(first, 0)
+		last = min(last, days-1)
+
+		for _, assignee := range item.Assignees() {
+			counts, ok := perDay[assignee]
+			if !ok {
+				counts = make([]int, days)
+				perDay[assignee] = counts
+			}
+			for day := first; day <= last; day++ {
+				counts[day]++
+			}
+		}
+	}
+
+	var workloads []Workload
+	for assignee, counts := range perDay {
+		w := Workload{Assignee: assignee, Weekly: make([]int, weeks)}
+		for day, n := range counts {
+			w.Days += n
+			w.Peak = max(w.Peak, n)
+			w.Weekly[day/7] = max(w.Weekly[day/7], n)
+		}
+		workloads = append(workloads, w)
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].Peak != workloads[j].Peak {
+			return workloads[i].Peak > workloads[j].Peak
+		}
+		if workloads[i].Days != workloads[j].Days {
+			return workloads[i].Days > workloads[j].Days
+		}
+		return workloads[i].Assignee < workloads[j].Assignee
+	})
+	return workloads
+}
+
+// Assignees returns the mentions of the item's assignees, e.g. "@alice"
+func (i Item) Assignees() []string {
+	assignees, _ := MentionList(i.Attributes[AssigneesAttribute])
+	return assignees
+}
+
+// daysBetween returns the number of calendar days from a to b
+func daysBetween(a, b time.Time) int {
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
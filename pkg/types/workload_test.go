@@ -0,0 +1,40 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkloads(t *testing.T) {
+	// Monday, Jan 1 2024
+	from := time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)
+	item := func(assignees, start, end string) Item {
+		return Item{
+			DateSpan:   MustNewDateSpan(start, end),
+			Attributes: map[string]interface{}{AssigneesAttribute: assignees},
+		}
+	}
+	diff := ProjectDiff{CurrentItems: []Item{
+		item("@alice", "2024-01-01", "2024-01-05"),
+		item("@alice, @bob", "2024-01-03", "2024-01-10"),
+		item("@alice", "2024-01-04", "2024-01-04"),
+		item("@bob", "2023-12-01", "2024-01-02"),                          // Started before the window
+		item("@carol", "2024-01-15", "2024-01-20"),                        // After the window
+		item("", "2024-01-01", "2024-01-05"),                              // Unassigned
+		{Attributes: map[string]interface{}{AssigneesAttribute: "@dave"}}, // Undated
+	}}
+
+	assert.Equal(t, []Workload{
+		{Assignee: "@alice", Weekly: []int{3, 1}, Days: 5 + 8 + 1, Peak: 3},
+		{Assignee: "@bob", Weekly: []int{1, 1}, Days: 2 + 8, Peak: 1},
+	}, diff.Workloads(from, 2))
+
+	assert.Nil(t, diff.Workloads(from, 0))
+}
+
+func TestItemAssignees(t *testing.T) {
+	assert.Equal(t, []string{"@alice", "@bob"}, Item{Attributes: map[string]interface{}{AssigneesAttribute: "@alice, @bob"}}.Assignees())
+	assert.Nil(t, Item{Attributes: map[string]interface{}{}}.Assignees())
+}
@@ -0,0 +1,230 @@
+// Package update implements updating the binary from GitHub releases.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ChecksumsAsset is the name of the release asset containing SHA-256 checksums of all
+// binaries, optionally prefixed like the binaries, e.g. "gh-project-report_v1.2.0_checksums.txt"
+const ChecksumsAsset = "checksums.txt"
+
+// Timeout limits how long fetching a release and downloading its assets may take, so
+// that an update never hangs, e.g. in CI
+const Timeout = 5 * time.Minute
+
+// Release represents a GitHub release
+type Release struct {
+	TagName string  `json:"tag_name"`
+	HTMLURL string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset represents a file attached to a GitHub release
+type Asset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// FindAsset returns the asset with the given name
+func (r *Release) FindAsset(name string) (*Asset, bool) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// FindBinary returns the binary for the given platform, matching asset names by their
+// "<os>-<arch>" suffix like gh does for precompiled extensions, e.g.
+// "gh-project-report-linux-amd64" or "gh-project-report_v1.2.0_windows-arm64.exe"
+func (r *Release) FindBinary(goos, goarch string) (*Asset, bool) {
+	platform := goos + "-" + goarch
+	for i := range r.Assets {
+		name, exe := strings.CutSuffix(r.Assets[i].Name, ".exe")
+		if exe != (goos == "windows") {
+			continue
+		}
+		if name == platform || strings.HasSuffix(name, "-"+platform) || strings.HasSuffix(name, "_"+platform) {
+			return &r.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// FindChecksums returns the checksums of the binaries of the release
+func (r *Release) FindChecksums() (*Asset, bool) {
+	for i := range r.Assets {
+		name := r.Assets[i].Name
+		if name == ChecksumsAsset || strings.HasSuffix(name, "-"+ChecksumsAsset) || strings.HasSuffix(name, "_"+ChecksumsAsset) {
+			return &r.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// Updater fetches releases of a repository
+type Updater struct {
+	httpClient *http.Client
+	baseURL    string
+	repository string
+}
+
+// NewUpdater creates a new updater for the given repository in owner/name format. A
+// nil client uses one limited to Timeout.
+func NewUpdater(httpClient *http.Client, repository string) *Updater {
+	return NewUpdaterWithBaseURL(httpClient, "https://api.github.com", repository)
+}
+
+// NewUpdaterWithBaseURL creates a new updater with a custom API base URL
+func NewUpdaterWithBaseURL(httpClient *http.Client, baseURL, repository string) *Updater {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: Timeout}
+	}
+	return &Updater{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		repository: repository,
+	}
+}
+
+// LatestRelease fetches the latest release of the repository
+func (u *Updater) LatestRelease() (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", u.baseURL, u.repository)
+	data, err := u.get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+
+	var release Release
+	err = json.Unmarshal(data, &release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+
+	return &release, nil
+}
+
+// Download downloads the given release asset
+func (u *Updater) Download(asset *Asset) ([]byte, error) {
+	data, err := u.get(asset.BrowserDownloadURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	}
+	return data, nil
+}
+
+// DownloadVerified downloads the binary of a release for the given platform and verifies
+// it against the release checksums. The checksums aren't signed, so this only detects
+// corrupted downloads.
+func (u *Updater) DownloadVerified(release *Release, goos, goarch string) ([]byte, error) {
+	asset, ok := release.FindBinary(goos, goarch)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no binary for %s-%s", release.TagName, goos, goarch)
+	}
+
+	checksumsAsset, ok := release.FindChecksums()
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s, refusing to install an unverified binary", release.TagName, ChecksumsAsset)
+	}
+
+	checksums, err := u.Download(checksumsAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := u.Download(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	err = VerifyChecksum(data, checksums, asset.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// get performs a GET request and returns the response body
+func (u *Updater) get(url string) ([]byte, error) {
+	resp, err := u.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// VerifyChecksum verifies data against the entry for name in a sha256sum-style checksums file
+func VerifyChecksum(data, checksums []byte, name string) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), fields[0]) {
+			return fmt.Errorf("checksum mismatch for %s", name)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no checksum found for %s", name)
+}
+
+// ReplaceExecutable atomically replaces the file at path with data, keeping its permissions
+func ReplaceExecutable(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat executable: %w", err)
+	}
+
+	// Write to a temp file in the same directory so the rename is atomic
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write new executable: %w", err)
+	}
+
+	err = os.Chmod(tmp.Name(), info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed to replace executable: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package update
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindBinary(t *testing.T) {
+	release := &Release{Assets: []Asset{
+		{Name: "gh-project-report_v1.2.0_checksums.txt"},
+		{Name: "gh-project-report_v1.2.0_linux-arm64"},
+		{Name: "gh-project-report_v1.2.0_linux-amd64"},
+		{Name: "gh-project-report-darwin-arm64"},
+		{Name: "gh-project-report_v1.2.0_windows-amd64.exe"},
+	}}
+
+	for _, tt := range []struct{ goos, goarch, want string }{
+		{"linux", "amd64", "gh-project-report_v1.2.0_linux-amd64"},
+		{"darwin", "arm64", "gh-project-report-darwin-arm64"},
+		{"windows", "amd64", "gh-project-report_v1.2.0_windows-amd64.exe"},
+	} {
+		asset, ok := release.FindBinary(tt.goos, tt.goarch)
+		require.True(t, ok, tt.want)
+		assert.Equal(t, tt.want, asset.Name)
+	}
+	_, ok := release.FindBinary("linux", "arm")
+	assert.False(t, ok)
+	_, ok = release.FindBinary("windows", "arm64")
+	assert.False(t, ok)
+
+	asset, ok := release.FindChecksums()
+	require.True(t, ok)
+	assert.Equal(t, "gh-project-report_v1.2.0_checksums.txt", asset.Name)
+}
+
+func TestNewUpdaterTimeout(t *testing.T) {
+	assert.Equal(t, Timeout, NewUpdater(nil, "naag/gh-project-report").httpClient.Timeout)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("binary")
+	sum := sha256.Sum256(data)
+	checksums := []byte(fmt.Sprintf("deadbeef  other-file\n%s  gh-project-report-linux-amd64\n", hex.EncodeToString(sum[:])))
+
+	assert.NoError(t, VerifyChecksum(data, checksums, "gh-project-report-linux-amd64"))
+	assert.ErrorContains(t, VerifyChecksum([]byte("tampered"), checksums, "gh-project-report-linux-amd64"), "checksum mismatch")
+	assert.ErrorContains(t, VerifyChecksum(data, checksums, "missing"), "no checksum found")
+}
+
+func TestDownloadVerified(t *testing.T) {
+	binary := []byte("new binary")
+	sum := sha256.Sum256(binary)
+	name := "gh-project-report-linux-amd64"
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/naag/gh-project-report/releases/latest":
+			fmt.Fprintf(w, `{
+				"tag_name": "v1.2.0",
+				"assets": [
+					{"name": %q, "browser_download_url": "%s/download/bin"},
+					{"name": "checksums.txt", "browser_download_url": "%s/download/checksums"}
+				]
+			}`, name, server.URL, server.URL)
+		case "/download/bin":
+			w.Write(binary)
+		case "/download/checksums":
+			fmt.Fprintf(w, "%s  %s\n", hex.EncodeToString(sum[:]), name)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	updater := NewUpdaterWithBaseURL(server.Client(), server.URL, "naag/gh-project-report")
+
+	release, err := updater.LatestRelease()
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", release.TagName)
+
+	data, err := updater.DownloadVerified(release, "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, binary, data)
+
+	_, err = updater.DownloadVerified(release, "plan9", "386")
+	assert.ErrorContains(t, err, "has no binary for plan9-386")
+}
+
+func TestLatestReleaseNotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	updater := NewUpdaterWithBaseURL(server.Client(), server.URL, "naag/gh-project-report")
+	_, err := updater.LatestRelease()
+	assert.ErrorContains(t, err, "failed to fetch latest release")
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gh-project-report")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0755))
+
+	require.NoError(t, ReplaceExecutable(path, []byte("new")))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temp file should be cleaned up")
+}